@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,14 +18,21 @@ import (
 	"volunteer-scheduler/graph/volunteer"
 	volGen "volunteer-scheduler/graph/volunteer/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/publicfeed"
 	"volunteer-scheduler/services"
+	"volunteer-scheduler/sso"
+	"volunteer-scheduler/webhooks"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/gorilla/websocket"
 	_ "github.com/lib/pq"
 	"github.com/rs/cors"
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 func getEnvWithDefault(key, fallback string) string {
@@ -47,6 +55,52 @@ func readSecret(path string) string {
 	return strings.TrimSpace(string(data))
 }
 
+// newServer builds a GraphQL server with handler.NewDefaultServer's
+// transports and extensions, plus the upload limits on multipart forms,
+// complexity and depth limits (list fields costed by listSizes), and the
+// persisted query allowlist in place of automatic persisted queries when
+// allowlist is set. ws, if set, carries subscriptions. Errors are reported
+// with codes by middleware.ErrorPresenter.
+func newServer(es graphql.ExecutableSchema, listSizes map[string]int, limits middleware.QueryLimits, allowlist *middleware.PersistedQueryAllowlist, ws *transport.Websocket) *handler.Server {
+	srv := handler.New(middleware.WithListCosts(es, listSizes))
+	if ws != nil {
+		srv.AddTransport(*ws)
+	}
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxMemory:     32 << 20, // 32 MB RAM buffer per request
+		MaxUploadSize: 10 << 20, // 10 MB hard limit (service adds the 5 MB app limit)
+	})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	if allowlist != nil {
+		srv.Use(allowlist)
+	} else {
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New[string](100),
+		})
+	}
+	srv.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	srv.Use(middleware.DepthLimit{Max: limits.MaxDepth})
+	srv.SetErrorPresenter(middleware.ErrorPresenter)
+	return srv
+}
+
+// websocketTransport carries subscriptions over a websocket that only the
+// frontend origin may open and that closes when the caller's session ends.
+func websocketTransport(frontendURL string, magicLinkService *services.MagicLinkService, apiTokenService *services.APITokenService) *transport.Websocket {
+	return &transport.Websocket{
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: middleware.WebsocketOriginAllowed(frontendURL),
+		},
+		InitFunc:              middleware.WebsocketAuth(magicLinkService, apiTokenService),
+		KeepAlivePingInterval: 10 * time.Second,
+	}
+}
+
 func main() {
 	// -------------------------------------------------------------------------
 	// Database connection
@@ -112,6 +166,8 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to initialize mailer:", err)
 	}
+	suppressionList := services.NewSuppressionList(db)
+	mailer.UseSuppressionList(suppressionList)
 
 	magicLinkService := services.NewMagicLinkService(db, mailer)
 	volunteerService := services.NewVolunteerService(db, mailer)
@@ -120,7 +176,23 @@ func main() {
 	feedbackService := services.NewFeedbackService(db, mailer)
 	staffService := services.NewStaffService(db)
 	fundingEntityService := services.NewFundingEntityService(db)
+	sessionService := services.NewSessionService(db)
+	scopeService := services.NewScopeService(db)
+	impersonationService := services.NewImpersonationService(db, mailer)
+	apiTokenService := services.NewAPITokenService(db)
+	auditService := services.NewAuditService(db)
+	accountRequestService := services.NewAccountRequestService(db, mailer, volunteerService)
+	privacyService := services.NewPrivacyService(db, mailer)
+	webhookService := services.NewWebhookService(db)
+	rateLimiter := services.NewRateLimiter(db)
 	reminderScheduler := services.NewReminderScheduler(db, mailer)
+	shiftCapacityFeed := services.NewShiftCapacityFeed(db_url)
+
+	// Single sign-on is optional; it is enabled by setting OIDC_ISSUER.
+	var oidcService *services.OIDCService
+	if oidcConfig := services.OIDCConfigFromEnv(readSecret("/run/secrets/secret_oidc_client")); oidcConfig != nil {
+		oidcService = services.NewOIDCService(db, *oidcConfig)
+	}
 
 	eventService, err := services.NewEventService(db, mailer, shiftService)
 	if err != nil {
@@ -134,74 +206,144 @@ func main() {
 	// Run the reminder scheduler at startup. It will run "forever".
 	go reminderScheduler.RunReminderScheduler(context.Background())
 
+	// Relay shift capacity changes (Postgres NOTIFY) to subscribers.
+	go shiftCapacityFeed.RunShiftCapacityFeed(context.Background())
+
+	// Send queued outbound webhooks, retrying failures with backoff.
+	go webhookService.RunWebhookDispatcher(context.Background())
+
+	// Publish scheduled drafts and mark ended events completed.
+	go eventService.RunEventStatusScheduler(context.Background())
+
 	// Run token cleanup once at startup, then every 24 hours.
 	go func() {
 		if err := magicLinkService.CleanupExpiredTokens(context.Background()); err != nil {
 			log.Printf("Initial token cleanup error: %v", err)
 		}
+		if err := rateLimiter.Cleanup(context.Background()); err != nil {
+			log.Printf("Initial rate limit cleanup error: %v", err)
+		}
+		if err := accountRequestService.Cleanup(context.Background()); err != nil {
+			log.Printf("Initial account request cleanup error: %v", err)
+		}
+		if oidcService != nil {
+			if err := oidcService.Cleanup(context.Background()); err != nil {
+				log.Printf("Initial SSO state cleanup error: %v", err)
+			}
+		}
 		ticker := time.NewTicker(24 * time.Hour)
 		defer ticker.Stop()
 		for range ticker.C {
 			if err := magicLinkService.CleanupExpiredTokens(context.Background()); err != nil {
 				log.Printf("Token cleanup error: %v", err)
 			}
+			if err := rateLimiter.Cleanup(context.Background()); err != nil {
+				log.Printf("Rate limit cleanup error: %v", err)
+			}
+			if err := accountRequestService.Cleanup(context.Background()); err != nil {
+				log.Printf("Account request cleanup error: %v", err)
+			}
+			if oidcService != nil {
+				if err := oidcService.Cleanup(context.Background()); err != nil {
+					log.Printf("SSO state cleanup error: %v", err)
+				}
+			}
 		}
 	}()
 
 	isProd := os.Getenv("APP_ENV") == "production"
+	if isProd && os.Getenv("SIGN_IN_CODE_SECRET") == "" {
+		log.Fatal("SIGN_IN_CODE_SECRET must be set in production")
+	}
 
 	// -------------------------------------------------------------------------
 	// Resolvers
 	// -------------------------------------------------------------------------
 
 	authResolver := &auth.Resolver{
-		MagicLinkService: magicLinkService,
-		IsProd:           isProd,
+		MagicLinkService:      magicLinkService,
+		AccountRequestService: accountRequestService,
+		IsProd:                isProd,
 	}
 
 	volunteerResolver := &volunteer.Resolver{
-		DB:               db,
-		EventService:     eventService,
-		VolunteerService: volunteerService,
-		ShiftService:     shiftService,
-		VenueService:     venueService,
-		FeedbackService:  feedbackService,
+		DB:                db,
+		EventService:      eventService,
+		VolunteerService:  volunteerService,
+		ShiftService:      shiftService,
+		VenueService:      venueService,
+		FeedbackService:   feedbackService,
+		SessionService:    sessionService,
+		PrivacyService:    privacyService,
+		ShiftCapacityFeed: shiftCapacityFeed,
 	}
 
 	adminResolver := &admin.Resolver{
-		DB:                   db,
-		EventService:         eventService,
-		VolunteerService:     volunteerService,
-		ShiftService:         shiftService,
-		VenueService:         venueService,
-		FeedbackService:      feedbackService,
-		StaffService:         staffService,
-		FundingEntityService: fundingEntityService,
+		DB:                    db,
+		EventService:          eventService,
+		VolunteerService:      volunteerService,
+		ShiftService:          shiftService,
+		VenueService:          venueService,
+		FeedbackService:       feedbackService,
+		StaffService:          staffService,
+		FundingEntityService:  fundingEntityService,
+		SessionService:        sessionService,
+		ScopeService:          scopeService,
+		ImpersonationService:  impersonationService,
+		APITokenService:       apiTokenService,
+		AuditService:          auditService,
+		AccountRequestService: accountRequestService,
+		PrivacyService:        privacyService,
+		WebhookService:        webhookService,
+		ShiftCapacityFeed:     shiftCapacityFeed,
 	}
 
 	// -------------------------------------------------------------------------
 	// GraphQL servers
 	// -------------------------------------------------------------------------
 
-	authSrv := handler.NewDefaultServer(authGen.NewExecutableSchema(authGen.Config{
+	// CORS middleware and websocket handshakes — allow the frontend origin.
+	// ALLOWED_ORIGIN must be set explicitly to the frontend's public URL.
+	// Falls back to localhost for local development.
+	frontendURL := getEnvWithDefault("ALLOWED_ORIGIN", "http://localhost:3000")
+	log.Printf("CORS allowed origin (ALLOWED_ORIGIN): %s", frontendURL)
+	ws := websocketTransport(frontendURL, magicLinkService, apiTokenService)
+
+	// Only the operations the frontend was built with may run, if a manifest
+	// of them is configured (API tokens are exempt).
+	var allowlist *middleware.PersistedQueryAllowlist
+	if path := os.Getenv("PERSISTED_QUERIES_PATH"); path != "" {
+		allowlist, err = middleware.LoadPersistedQueryAllowlist(path)
+		if err != nil {
+			log.Fatalf("Failed to load persisted queries: %v", err)
+		}
+		log.Printf("GraphQL persisted query allowlist loaded from %s", path)
+	}
+
+	authSrv := newServer(authGen.NewExecutableSchema(authGen.Config{
 		Resolvers: authResolver,
-	}))
+	}), nil, middleware.QueryLimitsFromEnv("AUTH", middleware.QueryLimits{MaxComplexity: 100, MaxDepth: 6}), allowlist, nil)
 
-	volunteerSrv := handler.NewDefaultServer(volGen.NewExecutableSchema(volGen.Config{
+	volunteerSrv := newServer(volGen.NewExecutableSchema(volGen.Config{
 		Resolvers: volunteerResolver,
-	}))
-	volunteerSrv.AddTransport(transport.MultipartForm{
-		MaxMemory:     32 << 20, // 32 MB RAM buffer per request
-		MaxUploadSize: 10 << 20, // 10 MB hard limit (service adds the 5 MB app limit)
-	})
-
-	adminSrv := handler.NewDefaultServer(adminGen.NewExecutableSchema(adminGen.Config{
+	}), volunteer.ListSizes, middleware.QueryLimitsFromEnv("VOLUNTEER", middleware.QueryLimits{MaxComplexity: 5000, MaxDepth: 8}), allowlist, ws)
+	// Admins viewing as a volunteer can look but not touch.
+	volunteerSrv.AroundOperations(volunteer.ReadOnlyWhenImpersonating)
+	// API tokens reach only the fields their scope lists (@tokenScope).
+	volunteerSrv.AroundRootFields(middleware.AuthorizeTokenScope)
+	volunteerSrv.AroundFields(middleware.SubscriptionRootFields(middleware.AuthorizeTokenScope))
+
+	adminSrv := newServer(adminGen.NewExecutableSchema(adminGen.Config{
 		Resolvers: adminResolver,
-	}))
-	adminSrv.AddTransport(transport.MultipartForm{
-		MaxMemory:     32 << 20, // 32 MB RAM buffer per request
-		MaxUploadSize: 10 << 20, // 10 MB hard limit (service adds the 5 MB app limit)
-	})
+	}), admin.ListSizes, middleware.QueryLimitsFromEnv("ADMIN", middleware.QueryLimits{MaxComplexity: 20000, MaxDepth: 10}), allowlist, ws)
+	// Per-field role checks (@hasRole) for coordinators, and per-field scope
+	// checks (@tokenScope) for API tokens.
+	adminSrv.AroundRootFields(admin.AuthorizeRootField)
+	adminSrv.AroundRootFields(middleware.AuthorizeTokenScope)
+	adminSrv.AroundFields(middleware.SubscriptionRootFields(admin.AuthorizeRootField))
+	adminSrv.AroundFields(middleware.SubscriptionRootFields(middleware.AuthorizeTokenScope))
+	// Every admin mutation lands in the audit log.
+	adminSrv.AroundFields(admin.AuditMutations(auditService))
 
 	// Disable introspection and playground in production.
 	// AroundOperations runs after the Introspection extension sets DisableIntrospection=false,
@@ -221,15 +363,11 @@ func main() {
 	// HTTP routing
 	// -------------------------------------------------------------------------
 
-	// CORS middleware — allow the frontend origin.
-	// ALLOWED_ORIGIN must be set explicitly to the frontend's public URL.
-	// Falls back to localhost for local development.
-	frontendURL := getEnvWithDefault("ALLOWED_ORIGIN", "http://localhost:3000")
-	log.Printf("CORS allowed origin (ALLOWED_ORIGIN): %s", frontendURL)
 	c := cors.New(cors.Options{
 		AllowedOrigins:   []string{frontendURL},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", middleware.ImpersonationHeader, middleware.CSRFHeader},
+		ExposedHeaders:   []string{"Retry-After"},
 		AllowCredentials: true,
 	})
 
@@ -240,17 +378,98 @@ func main() {
 		http.Handle("/volunteer", playground.Handler("Volunteer GraphQL", "/graphql/volunteer"))
 	}
 
+	// Client addresses. Behind a load balancer every request arrives from the
+	// proxy, so its X-Forwarded-For must be trusted to rate limit per client.
+	if err := middleware.SetTrustedProxies(os.Getenv("TRUSTED_PROXIES")); err != nil {
+		log.Fatalf("TRUSTED_PROXIES: %v", err)
+	}
+	// Empty (as docker-compose passes it when unset) means the defaults; use
+	// "off" to disable.
+	authRateLimitSpec := os.Getenv("AUTH_RATE_LIMITS")
+	if authRateLimitSpec == "" {
+		authRateLimitSpec = middleware.DefaultAuthRateLimits
+	}
+	authRateLimits, err := middleware.ParseRateLimits(authRateLimitSpec)
+	if err != nil {
+		log.Fatalf("AUTH_RATE_LIMITS: %v", err)
+	}
+
 	// GraphQL API endpoints.
 	// The auth endpoint has no token, so we wrap it manually to inject the
 	// ResponseWriter and Request into the context — the login resolver needs
-	// them to set the HttpOnly session cookie.
-	http.Handle("/graphql/auth", c.Handler(middleware.WithHTTPContext(authSrv)))
-	http.Handle("/graphql/volunteer", c.Handler(middleware.RequireAuth(magicLinkService, volunteerSrv)))
-	http.Handle("/graphql/admin", c.Handler(middleware.RequireAdmin(magicLinkService, adminSrv)))
+	// them to set the HttpOnly session cookie. It is also public, so each
+	// operation is rate limited per client IP.
+	//
+	// All three refuse state-changing requests from other origins, and cookie
+	// requests without the X-Requested-With header (CSRF).
+	http.Handle("/graphql/auth", c.Handler(middleware.CSRFProtect(frontendURL,
+		middleware.RateLimitOperations(rateLimiter, "auth", authRateLimits,
+			middleware.WithHTTPContext(authSrv)))))
+	// Both accept a login session or an API token (Bearer vsk_...), and
+	// upgrade to a websocket for subscriptions.
+	// Admins may view the volunteer endpoint as a volunteer (X-Impersonate).
+	http.Handle("/graphql/volunteer", c.Handler(middleware.CSRFProtect(frontendURL,
+		middleware.RequireAuth(magicLinkService, apiTokenService,
+			middleware.Impersonate(impersonationService, volunteerSrv)))))
+	http.Handle("/graphql/admin", c.Handler(middleware.CSRFProtect(frontendURL,
+		middleware.RequireAdmin(magicLinkService, apiTokenService, adminSrv))))
+
+	// Single sign-on. These are top-level browser navigations, not XHR, so no
+	// CORS; the login page links to /auth/oidc/login on this API's origin.
+	if oidcService != nil {
+		ssoHandlers := &sso.Handlers{
+			OIDC:        oidcService,
+			MagicLink:   magicLinkService,
+			FrontendURL: getEnvWithDefault("FRONTEND_BASE_URL", "http://localhost:3000"),
+			IsProd:      isProd,
+		}
+		http.Handle("/auth/oidc/login", ssoHandlers.Login())
+		http.Handle("/auth/oidc/callback", ssoHandlers.Callback())
+		log.Println("Single sign-on enabled: /auth/oidc/login")
+	}
+
+	// Public events feed for the organisation's website. Unauthenticated and
+	// read from any origin, so it is cached and rate limited per client IP.
+	publicFeedRateLimitSpec := os.Getenv("PUBLIC_FEED_RATE_LIMIT")
+	if publicFeedRateLimitSpec == "" {
+		publicFeedRateLimitSpec = "60/1m"
+	}
+	publicFeedRateLimit, err := middleware.ParseRateLimit(publicFeedRateLimitSpec)
+	if err != nil {
+		log.Fatalf("PUBLIC_FEED_RATE_LIMIT: %v", err)
+	}
+	publicFeedCacheTTL := 60 * time.Second
+	if val, err := strconv.Atoi(os.Getenv("PUBLIC_FEED_CACHE_SECONDS")); err == nil && val >= 0 {
+		publicFeedCacheTTL = time.Duration(val) * time.Second
+	}
+	publicFeed := &publicfeed.Handlers{
+		Events:      eventService,
+		FrontendURL: getEnvWithDefault("FRONTEND_BASE_URL", "http://localhost:3000"),
+		CacheTTL:    publicFeedCacheTTL,
+	}
+	http.Handle("/public/events.json",
+		middleware.RateLimitRequests(rateLimiter, "public-feed", publicFeedRateLimit, publicFeed.JSON()))
+	http.Handle("/public/events/widget",
+		middleware.RateLimitRequests(rateLimiter, "public-feed", publicFeedRateLimit, publicFeed.Widget()))
+
+	// Mail-provider callbacks. Server-to-server, so no CORS; each request is
+	// authenticated by an HMAC signature instead of a session.
+	inboundWebhookSecret := os.Getenv("INBOUND_WEBHOOK_SECRET")
+	if inboundWebhookSecret == "" {
+		inboundWebhookSecret = readSecret("/run/secrets/secret_inbound_webhook")
+	}
+	http.Handle("/webhooks/inbound-email", webhooks.InboundEmail(feedbackService, inboundWebhookSecret))
+
+	resendWebhookSecret := os.Getenv("RESEND_WEBHOOK_SECRET")
+	if resendWebhookSecret == "" {
+		resendWebhookSecret = readSecret("/run/secrets/secret_resend_webhook")
+	}
+	http.Handle("/webhooks/email-events", webhooks.EmailEvents(suppressionList, resendWebhookSecret))
 
 	log.Println("Server running on :8080")
 	log.Println("Auth endpoint: /graphql/auth")
 	log.Println("Volunteer endpoint: /graphql/volunteer")
 	log.Println("Admin endpoint: /graphql/admin")
+	log.Println("Public events feed: /public/events.json, /public/events/widget")
 	log.Fatal(http.ListenAndServe(":8080", nil))
 }
@@ -0,0 +1,220 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/services"
+)
+
+// auditTarget says which record an admin mutation changes: the entity (a key
+// of the audit service's snapshots) and where its ID is found, either in the
+// arguments (idArg) or, for creates, in the result (resultID). Both are
+// dotted paths over the GraphQL names, e.g. "event.id".
+type auditTarget struct {
+	entity   string
+	idArg    string
+	resultID string
+}
+
+// auditTargets covers every mutation on /graphql/admin. A mutation missing
+// here is still logged, just without a target or diff.
+var auditTargets = map[string]auditTarget{
+	// Lookups
+	"createFundingEntity": {entity: "FundingEntity", resultID: "id"},
+	"updateFundingEntity": {entity: "FundingEntity", idArg: "input.id"},
+	"deleteFundingEntity": {entity: "FundingEntity", idArg: "id"},
+	"createJobType":       {entity: "JobType", resultID: "id"},
+	"updateJobType":       {entity: "JobType", idArg: "job.id"},
+	"deleteJobType":       {entity: "JobType", idArg: "JobId"},
+
+	// Events/Opportunities/Shifts
+	"createEvent":       {entity: "Event", resultID: "id"},
+	"updateEvent":       {entity: "Event", idArg: "event.id"},
+	"deleteEvent":       {entity: "Event", idArg: "eventId"},
+	"createEventDate":   {entity: "EventDate", resultID: "id"},
+	"updateEventDate":   {entity: "EventDate", idArg: "date.id"},
+	"deleteEventDate":   {entity: "EventDate", idArg: "eventDateId"},
+	"createOpportunity": {entity: "Opportunity", resultID: "id"},
+	"updateOpportunity": {entity: "Opportunity", idArg: "opp.id"},
+	"deleteOpportunity": {entity: "Opportunity", idArg: "oppId"},
+	"createShift":       {entity: "Shift", resultID: "id"},
+	"updateShift":       {entity: "Shift", idArg: "shift.id"},
+	"deleteShift":       {entity: "Shift", idArg: "shiftId"},
+	"publishEvent":      {entity: "Event", idArg: "eventId"},
+	"cancelEvent":       {entity: "Event", idArg: "eventId"},
+
+	// Volunteer Shifts
+	"assignVolunteerToShift": {entity: "Shift", idArg: "shiftId"},
+	"cancelShift":            {entity: "Shift", idArg: "shiftId"},
+
+	// Feedback
+	"giveFeedback":           {entity: "Feedback", resultID: "id"},
+	"attachFileToFeedback":   {entity: "Feedback", idArg: "feedbackId"},
+	"updateFeedbackStatus":   {entity: "Feedback", idArg: "su.feedbackId"},
+	"addFeedbackNote":        {entity: "Feedback", idArg: "note.feedbackId"},
+	"emailFeedbackSubmitter": {entity: "Feedback", idArg: "input.feedbackId"},
+
+	// Staff
+	"createStaff": {entity: "Staff", resultID: "id"},
+	"updateStaff": {entity: "Staff", idArg: "staff.id"},
+	"deleteStaff": {entity: "Staff", idArg: "staffId"},
+
+	// Venues
+	"createVenue": {entity: "Venue", resultID: "id"},
+	"updateVenue": {entity: "Venue", idArg: "venue.id"},
+	"deleteVenue": {entity: "Venue", idArg: "venueId"},
+
+	// Volunteers
+	"createVolunteer":       {entity: "Volunteer", resultID: "id"},
+	"updateVolunteer":       {entity: "Volunteer", idArg: "profile.id"},
+	"deleteVolunteer":       {entity: "Volunteer", idArg: "volunteerId"},
+	"clearEmailSuppression": {entity: "Volunteer", idArg: "volunteerId"},
+	"revokeAllSessions":     {entity: "Volunteer", idArg: "volunteerId"},
+
+	// Volunteer segments
+	"createVolunteerSegment": {entity: "VolunteerSegment", resultID: "id"},
+	"updateVolunteerSegment": {entity: "VolunteerSegment", idArg: "input.id"},
+	"deleteVolunteerSegment": {entity: "VolunteerSegment", idArg: "segmentId"},
+
+	// Impersonation
+	"startImpersonation": {entity: "Impersonation", resultID: "impersonation.id"},
+	"endImpersonation":   {entity: "Impersonation", idArg: "impersonationId"},
+
+	// API tokens
+	"createApiToken": {entity: "APIToken", resultID: "apiToken.id"},
+	"revokeApiToken": {entity: "APIToken", idArg: "tokenId"},
+
+	// Outbound webhooks
+	"createWebhookSubscription": {entity: "WebhookSubscription", resultID: "subscription.id"},
+	"updateWebhookSubscription": {entity: "WebhookSubscription", idArg: "input.id"},
+	"deleteWebhookSubscription": {entity: "WebhookSubscription", idArg: "subscriptionId"},
+	"rotateWebhookSecret":       {entity: "WebhookSubscription", idArg: "subscriptionId"},
+	"retryWebhookDelivery":      {entity: "WebhookDelivery", idArg: "deliveryId"},
+
+	// Account requests
+	"approveAccountRequest": {entity: "AccountRequest", idArg: "requestId"},
+	"denyAccountRequest":    {entity: "AccountRequest", idArg: "requestId"},
+
+	// Erasure requests. The target is the request, not the volunteer, so the
+	// audit log never records the personal data an approval erases.
+	"approveErasureRequest": {entity: "ErasureRequest", idArg: "requestId"},
+	"denyErasureRequest":    {entity: "ErasureRequest", idArg: "requestId"},
+}
+
+// AuditMutations records every root mutation in the audit log: the actor,
+// the target record and its fields before and after, and the client IP.
+// Failed mutations are logged too, without a diff; calls refused by
+// AuthorizeRootField never reach it. Writing the log never fails the
+// mutation. Register it with handler.Server.AroundFields.
+func AuditMutations(audit *services.AuditService) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (any, error) {
+		fc := graphql.GetFieldContext(ctx)
+		if fc == nil || fc.Object != "Mutation" || fc.Field.Field == nil {
+			return next(ctx)
+		}
+
+		rec := services.AuditRecord{Operation: fc.Field.Name}
+		if id, ok := middleware.VolunteerIdFromContext(ctx); ok {
+			rec.ActorID = &id
+		}
+		_, rec.ViaAPIToken = middleware.APITokenScopeFromContext(ctx)
+		if r, ok := middleware.RequestFromContext(ctx); ok {
+			rec.IPAddress = middleware.ClientIP(r)
+		}
+
+		target, known := auditTargets[fc.Field.Name]
+		if known {
+			rec.Entity = target.entity
+			if target.idArg != "" {
+				rec.EntityID = lookupPath(fc.Args, target.idArg)
+				before, err := audit.Snapshot(ctx, rec.Entity, rec.EntityID)
+				if err != nil {
+					log.Printf("audit: %s: %v", rec.Operation, err)
+				}
+				rec.Before = before
+			}
+		}
+
+		res, err := next(ctx)
+
+		rec.Success = err == nil && resultSucceeded(res)
+		if err != nil {
+			rec.Error = err.Error()
+		}
+		if known && rec.Success {
+			if target.resultID != "" {
+				rec.EntityID = lookupPath(res, target.resultID)
+			}
+			after, snapErr := audit.Snapshot(ctx, rec.Entity, rec.EntityID)
+			if snapErr != nil {
+				log.Printf("audit: %s: %v", rec.Operation, snapErr)
+			}
+			rec.After = after
+		} else {
+			// Nothing changed; keep the target but no diff.
+			rec.Before = nil
+		}
+
+		// Use a context the client cannot cancel: the change has happened.
+		if logErr := audit.Record(context.WithoutCancel(ctx), rec); logErr != nil {
+			log.Printf("audit: %s: %v", rec.Operation, logErr)
+		}
+		return res, err
+	}
+}
+
+// resultSucceeded reads the success flag every admin mutation result carries.
+// A result without one counts as success.
+func resultSucceeded(res any) bool {
+	var r struct {
+		Success *bool `json:"success"`
+	}
+	b, err := json.Marshal(res)
+	if err != nil || json.Unmarshal(b, &r) != nil || r.Success == nil {
+		return true
+	}
+	return *r.Success
+}
+
+// lookupPath follows a dotted path through v (an argument map or a result)
+// as it would serialise to JSON, and returns the value there as a string.
+// Only the first segment's value is serialised, so upload arguments elsewhere
+// are never read.
+func lookupPath(v any, path string) string {
+	parts := strings.Split(path, ".")
+	if args, ok := v.(map[string]any); ok {
+		v = args[parts[0]]
+		parts = parts[1:]
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	var cur any
+	if err := json.Unmarshal(b, &cur); err != nil {
+		return ""
+	}
+	for _, p := range parts {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return ""
+		}
+		cur = m[p]
+	}
+
+	switch c := cur.(type) {
+	case string:
+		return c
+	case float64:
+		return fmt.Sprintf("%.0f", c)
+	}
+	return ""
+}
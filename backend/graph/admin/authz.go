@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
+)
+
+// AuthorizeRootField enforces the @hasRole directive on every root field of
+// the admin schema. A field without @hasRole is open to ADMINISTRATOR only,
+// so new fields are locked down until someone decides otherwise. Register it
+// with handler.Server.AroundRootFields, and for subscriptions wrapped in
+// middleware.SubscriptionRootFields.
+//
+// Record-level checks (which funding entity a coordinator may touch) are made
+// by the resolvers themselves; see Resolver.fundingScope.
+func AuthorizeRootField(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	fc := graphql.GetRootFieldContext(ctx)
+	if fc == nil || fc.Field.Field == nil || fc.Field.Definition == nil {
+		return next(ctx)
+	}
+	// Introspection (__schema, __type, __typename) carries no data.
+	if strings.HasPrefix(fc.Field.Name, "__") {
+		return next(ctx)
+	}
+
+	roles, _ := middleware.VolunteerRolesFromContext(ctx)
+	for _, allowed := range allowedRoles(fc.Field.Definition.Directives.ForName("hasRole")) {
+		for _, r := range roles {
+			if r == allowed {
+				return next(ctx)
+			}
+		}
+	}
+
+	graphql.AddError(ctx, &gqlerror.Error{
+		Message:    "forbidden",
+		Path:       graphql.GetPath(ctx),
+		Extensions: map[string]any{"code": "FORBIDDEN"},
+	})
+	return graphql.Null
+}
+
+// allowedRoles reads the roles listed in a @hasRole directive, defaulting to
+// ADMINISTRATOR when the field has none.
+func allowedRoles(d *ast.Directive) []string {
+	if d == nil {
+		return []string{string(models.RoleAdministrator)}
+	}
+	arg := d.Arguments.ForName("roles")
+	if arg == nil || arg.Value == nil {
+		return []string{string(models.RoleAdministrator)}
+	}
+	var roles []string
+	for _, child := range arg.Value.Children {
+		roles = append(roles, child.Value.Raw)
+	}
+	return roles
+}
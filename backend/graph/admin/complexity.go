@@ -0,0 +1,31 @@
+package admin
+
+// ListSizes is how many items each list field of the admin schema is
+// expected to return, for middleware.WithListCosts. Lists not named here
+// are assumed to hold middleware.DefaultListSize items; connection fields
+// are sized by their `first` argument instead.
+var ListSizes = map[string]int{
+	"Query.events":                100,
+	"Query.volunteers":            200,
+	"Query.assignableVolunteers":  200,
+	"Query.feedback":              100,
+	"Query.auditLog":              100,
+	"Query.volunteerShifts":       50,
+	"Query.opportunitiesForEvent": 20,
+	"Query.staff":                 50,
+	"Query.venues":                50,
+	"Query.fundingEntities":       20,
+	"Query.impersonations":        50,
+	"Query.apiTokens":             20,
+	"Query.webhookSubscriptions":  20,
+	"Query.volunteerSegments":     20,
+	"Query.accountRequests":       50,
+	"Query.erasureRequests":       20,
+	"Event.eventDates":            5,
+	"Event.shiftSummaries":        10,
+	"Opportunity.shifts":          10,
+	"Feedback.notes":              5,
+	"Feedback.attachments":        3,
+	"AuditEntry.changes":          10,
+	"LookupValues.cities":         50,
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"context"
 	"volunteer-scheduler/graph/admin/generated"
 	"volunteer-scheduler/models"
 )
@@ -83,6 +84,32 @@ func toGenEventShiftSummary(m *models.EventShiftSummary) *generated.EventShiftSu
 	}
 }
 
+func toGenShiftCapacity(m *models.ShiftCapacity) *generated.ShiftCapacity {
+	return &generated.ShiftCapacity{
+		EventID:            m.EventId,
+		ShiftID:            m.ShiftId,
+		AssignedVolunteers: m.AssignedVolunteers,
+		MaxVolunteers:      m.MaxVolunteers,
+	}
+}
+
+// toGenShiftCapacityStream converts a subscription's changes as they arrive,
+// until ctx is done or ms is closed.
+func toGenShiftCapacityStream(ctx context.Context, ms <-chan *models.ShiftCapacity) <-chan *generated.ShiftCapacity {
+	out := make(chan *generated.ShiftCapacity)
+	go func() {
+		defer close(out)
+		for m := range ms {
+			select {
+			case out <- toGenShiftCapacity(m):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
 // Feedback
 
 func toGenFeedbackMetaAttachments(ms []*models.FeedbackMetaAttachment) []*generated.FeedbackMetaAttachment {
@@ -126,7 +153,18 @@ func toGenMutationResult(m *models.MutationResult) *generated.MutationResult {
 		Success: m.Success,
 		Message: m.Message,
 		ID:      m.ID,
+		Code:    toGenErrorCode(m.Code),
+	}
+}
+
+// toGenErrorCode is nil for results that did not fail, or failed for a reason
+// with no code.
+func toGenErrorCode(c models.ErrorCode) *generated.ErrorCode {
+	if c == "" {
+		return nil
 	}
+	g := generated.ErrorCode(c)
+	return &g
 }
 
 // Roles
@@ -194,6 +232,11 @@ func toGenEvent(m *models.Event) *generated.Event {
 		ShiftSummaries:  toGenEventShiftSummaries(m.ShiftSummaries),
 		RecurrenceGroup: toGenRecurrenceGroup(m.RecurrenceGroup),
 		RecurrenceOrder: m.RecurrenceOrder,
+		Status:          generated.EventStatus(m.Status),
+		PublishAt:       m.PublishAt,
+		CancelledAt:     m.CancelledAt,
+		SearchRank:      m.SearchRank,
+		SearchSnippet:   m.SearchSnippet,
 	}
 }
 
@@ -381,6 +424,14 @@ func toGenVenue(m *models.Venue) *generated.Venue {
 
 // Volunteers
 
+func toGenAssignableVolunteers(ms []*models.Volunteer) []*generated.AssignableVolunteer {
+	result := make([]*generated.AssignableVolunteer, len(ms))
+	for i, m := range ms {
+		result[i] = &generated.AssignableVolunteer{ID: m.ID, FirstName: m.FirstName, LastName: m.LastName}
+	}
+	return result
+}
+
 func toGenVolunteers(ms []*models.Volunteer) []*generated.Volunteer {
 	result := make([]*generated.Volunteer, len(ms))
 	for i, m := range ms {
@@ -402,7 +453,65 @@ func toGenVolunteer(m *models.Volunteer) *generated.Volunteer {
 		ZipCode:   m.ZipCode,
 		Distance:  m.Distance,
 		Roles:     toGenRoles(m.Roles),
+
+		FundingEntityIds:   m.FundingEntityIDs,
+		EmailUndeliverable: (*generated.EmailSuppressionReason)(m.EmailUndeliverable),
+		HoursServed:        m.HoursServed,
+		SignupCount:        m.SignupCount,
+		LastServedAt:       m.LastServedAt,
+	}
+}
+
+// Volunteer segments
+
+func toGenVolunteerSegments(ms []*models.VolunteerSegment) []*generated.VolunteerSegment {
+	result := make([]*generated.VolunteerSegment, len(ms))
+	for i, m := range ms {
+		result[i] = toGenVolunteerSegment(m)
+	}
+	return result
+}
+
+func toGenVolunteerSegment(m *models.VolunteerSegment) *generated.VolunteerSegment {
+	if m == nil {
+		return nil
+	}
+	return &generated.VolunteerSegment{
+		ID:            m.ID,
+		Name:          m.Name,
+		Filter:        toGenVolunteerFilter(m.Filter),
+		CreatedByID:   m.CreatedByID,
+		CreatedByName: m.CreatedByName,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func toGenVolunteerFilter(m models.VolunteerFilterInput) *generated.VolunteerFilter {
+	f := &generated.VolunteerFilter{
+		FirstName:      m.FirstName,
+		LastName:       m.LastName,
+		Email:          m.Email,
+		IsActive:       m.IsActive,
+		LastServedFrom: m.LastServedFrom,
+		LastServedTo:   m.LastServedTo,
+		JobTypeIds:     m.JobTypeIDs,
+		MinHours:       m.MinHours,
+		MaxHours:       m.MaxHours,
+		MinSignups:     m.MinSignups,
+		MaxSignups:     m.MaxSignups,
+	}
+	if m.Roles != nil {
+		f.Roles = toGenRoles(m.Roles)
+	}
+	if m.Near != nil {
+		f.Near = &generated.VolunteerDistance{
+			VenueID: m.Near.VenueID,
+			ZipCode: m.Near.ZipCode,
+			Miles:   m.Near.Miles,
+		}
 	}
+	return f
 }
 
 func toGenVolunteerShifts(ms []*models.VolunteerShift) []*generated.VolunteerShift {
@@ -434,6 +543,308 @@ func toGenVolunteerShift(m *models.VolunteerShift) *generated.VolunteerShift {
 	}
 }
 
+// Impersonation
+
+func toGenImpersonations(ms []*models.Impersonation) []*generated.Impersonation {
+	result := make([]*generated.Impersonation, len(ms))
+	for i, m := range ms {
+		result[i] = toGenImpersonation(m)
+	}
+	return result
+}
+
+func toGenImpersonation(m *models.Impersonation) *generated.Impersonation {
+	if m == nil {
+		return nil
+	}
+	return &generated.Impersonation{
+		ID:                m.ID,
+		AdminID:           m.AdminID,
+		AdminName:         m.AdminName,
+		VolunteerID:       m.VolunteerID,
+		VolunteerName:     m.VolunteerName,
+		Reason:            m.Reason,
+		VolunteerNotified: m.VolunteerNotified,
+		StartedAt:         m.StartedAt,
+		ExpiresAt:         m.ExpiresAt,
+		EndedAt:           m.EndedAt,
+		RequestCount:      m.RequestCount,
+	}
+}
+
+func toGenImpersonationResult(m *models.ImpersonationResult) *generated.ImpersonationResult {
+	if m == nil {
+		return nil
+	}
+	return &generated.ImpersonationResult{
+		Success:       m.Success,
+		Message:       m.Message,
+		Impersonation: toGenImpersonation(m.Impersonation),
+		Token:         m.Token,
+		Code:          toGenErrorCode(m.Code),
+	}
+}
+
+// API tokens
+
+func toGenAPITokens(ms []*models.APIToken) []*generated.APIToken {
+	result := make([]*generated.APIToken, len(ms))
+	for i, m := range ms {
+		result[i] = toGenAPIToken(m)
+	}
+	return result
+}
+
+func toGenAPIToken(m *models.APIToken) *generated.APIToken {
+	if m == nil {
+		return nil
+	}
+	return &generated.APIToken{
+		ID:            m.ID,
+		Name:          m.Name,
+		Prefix:        m.Prefix,
+		OwnerID:       m.OwnerID,
+		OwnerName:     m.OwnerName,
+		Scope:         generated.APITokenScope(m.Scope),
+		CreatedByID:   m.CreatedByID,
+		CreatedByName: m.CreatedByName,
+		CreatedAt:     m.CreatedAt,
+		ExpiresAt:     m.ExpiresAt,
+		LastUsedAt:    m.LastUsedAt,
+		LastUsedIP:    m.LastUsedIP,
+		RevokedAt:     m.RevokedAt,
+	}
+}
+
+func toGenAPITokenResult(m *models.APITokenResult) *generated.APITokenResult {
+	if m == nil {
+		return nil
+	}
+	return &generated.APITokenResult{
+		Success:  m.Success,
+		Message:  m.Message,
+		APIToken: toGenAPIToken(m.APIToken),
+		Token:    m.Token,
+		Code:     toGenErrorCode(m.Code),
+	}
+}
+
+// Outbound webhooks
+
+func toGenWebhookSubscriptions(ms []*models.WebhookSubscription) []*generated.WebhookSubscription {
+	result := make([]*generated.WebhookSubscription, len(ms))
+	for i, m := range ms {
+		result[i] = toGenWebhookSubscription(m)
+	}
+	return result
+}
+
+func toGenWebhookSubscription(m *models.WebhookSubscription) *generated.WebhookSubscription {
+	if m == nil {
+		return nil
+	}
+	eventTypes := make([]generated.WebhookEventType, len(m.EventTypes))
+	for i, t := range m.EventTypes {
+		eventTypes[i] = generated.WebhookEventType(t)
+	}
+	return &generated.WebhookSubscription{
+		ID:            m.ID,
+		URL:           m.URL,
+		Description:   m.Description,
+		EventTypes:    eventTypes,
+		IsActive:      m.IsActive,
+		CreatedByID:   m.CreatedByID,
+		CreatedByName: m.CreatedByName,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func toGenWebhookSubscriptionResult(m *models.WebhookSubscriptionResult) *generated.WebhookSubscriptionResult {
+	if m == nil {
+		return nil
+	}
+	return &generated.WebhookSubscriptionResult{
+		Success:      m.Success,
+		Message:      m.Message,
+		Subscription: toGenWebhookSubscription(m.Subscription),
+		Secret:       m.Secret,
+		Code:         toGenErrorCode(m.Code),
+	}
+}
+
+func toGenWebhookDelivery(m *models.WebhookDelivery) *generated.WebhookDelivery {
+	return &generated.WebhookDelivery{
+		ID:             m.ID,
+		SubscriptionID: m.SubscriptionID,
+		EventType:      generated.WebhookEventType(m.EventType),
+		Payload:        m.Payload,
+		Status:         generated.WebhookDeliveryStatus(m.Status),
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		LastAttemptAt:  m.LastAttemptAt,
+		LastStatusCode: m.LastStatusCode,
+		LastError:      m.LastError,
+		CreatedAt:      m.CreatedAt,
+		DeliveredAt:    m.DeliveredAt,
+	}
+}
+
+// Audit log
+
+func toGenAuditEntries(ms []*models.AuditEntry) []*generated.AuditEntry {
+	result := make([]*generated.AuditEntry, len(ms))
+	for i, m := range ms {
+		result[i] = toGenAuditEntry(m)
+	}
+	return result
+}
+
+func toGenAuditEntry(m *models.AuditEntry) *generated.AuditEntry {
+	changes := make([]*generated.AuditChange, len(m.Changes))
+	for i, c := range m.Changes {
+		changes[i] = &generated.AuditChange{Field: c.Field, Before: c.Before, After: c.After}
+	}
+	return &generated.AuditEntry{
+		ID:          m.ID,
+		ActorID:     m.ActorID,
+		ActorName:   m.ActorName,
+		ViaAPIToken: m.ViaAPIToken,
+		Operation:   m.Operation,
+		Entity:      m.Entity,
+		EntityID:    m.EntityID,
+		Changes:     changes,
+		Success:     m.Success,
+		Error:       m.Error,
+		IPAddress:   m.IPAddress,
+		CreatedAt:   m.CreatedAt,
+	}
+}
+
+// Account requests
+
+func toGenAccountRequests(ms []*models.AccountRequest) []*generated.AccountRequest {
+	result := make([]*generated.AccountRequest, len(ms))
+	for i, m := range ms {
+		result[i] = toGenAccountRequest(m)
+	}
+	return result
+}
+
+func toGenAccountRequest(m *models.AccountRequest) *generated.AccountRequest {
+	return &generated.AccountRequest{
+		ID:                  m.ID,
+		Email:               m.Email,
+		FirstName:           m.FirstName,
+		LastName:            m.LastName,
+		Status:              generated.AccountRequestStatus(m.Status),
+		ExistingVolunteerID: m.ExistingVolunteerID,
+		CreatedAt:           m.CreatedAt,
+		VerifiedAt:          m.VerifiedAt,
+		DecidedByID:         m.DecidedByID,
+		DecidedByName:       m.DecidedByName,
+		DecidedAt:           m.DecidedAt,
+		DecisionMessage:     m.DecisionMessage,
+		VolunteerID:         m.VolunteerID,
+	}
+}
+
+// Erasure requests
+
+func toGenErasureRequests(ms []*models.ErasureRequest) []*generated.ErasureRequest {
+	result := make([]*generated.ErasureRequest, len(ms))
+	for i, m := range ms {
+		result[i] = toGenErasureRequest(m)
+	}
+	return result
+}
+
+func toGenErasureRequest(m *models.ErasureRequest) *generated.ErasureRequest {
+	return &generated.ErasureRequest{
+		ID:              m.ID,
+		VolunteerID:     m.VolunteerID,
+		VolunteerName:   m.VolunteerName,
+		Reason:          m.Reason,
+		Status:          generated.ErasureRequestStatus(m.Status),
+		CreatedAt:       m.CreatedAt,
+		DecidedByID:     m.DecidedByID,
+		DecidedByName:   m.DecidedByName,
+		DecidedAt:       m.DecidedAt,
+		DecisionMessage: m.DecisionMessage,
+	}
+}
+
+// Paging
+
+func toGenPageInfo(m models.PageInfo) *generated.PageInfo {
+	return &generated.PageInfo{
+		HasNextPage:     m.HasNextPage,
+		HasPreviousPage: m.HasPreviousPage,
+		StartCursor:     m.StartCursor,
+		EndCursor:       m.EndCursor,
+	}
+}
+
+func toGenEventConnection(m *models.EventConnection) *generated.EventConnection {
+	edges := make([]*generated.EventEdge, len(m.Edges))
+	for i, e := range m.Edges {
+		edges[i] = &generated.EventEdge{Cursor: e.Cursor, Node: toGenEvent(e.Node)}
+	}
+	return &generated.EventConnection{
+		Edges:      edges,
+		PageInfo:   toGenPageInfo(m.PageInfo),
+		TotalCount: m.TotalCount,
+	}
+}
+
+func toGenFeedbackConnection(m *models.FeedbackConnection) *generated.FeedbackConnection {
+	edges := make([]*generated.FeedbackEdge, len(m.Edges))
+	for i, e := range m.Edges {
+		edges[i] = &generated.FeedbackEdge{Cursor: e.Cursor, Node: toGenFeedback(e.Node)}
+	}
+	return &generated.FeedbackConnection{
+		Edges:      edges,
+		PageInfo:   toGenPageInfo(m.PageInfo),
+		TotalCount: m.TotalCount,
+	}
+}
+
+func toGenWebhookDeliveryConnection(m *models.WebhookDeliveryConnection) *generated.WebhookDeliveryConnection {
+	edges := make([]*generated.WebhookDeliveryEdge, len(m.Edges))
+	for i, e := range m.Edges {
+		edges[i] = &generated.WebhookDeliveryEdge{Cursor: e.Cursor, Node: toGenWebhookDelivery(e.Node)}
+	}
+	return &generated.WebhookDeliveryConnection{
+		Edges:      edges,
+		PageInfo:   toGenPageInfo(m.PageInfo),
+		TotalCount: m.TotalCount,
+	}
+}
+
+func toGenVolunteerConnection(m *models.VolunteerConnection) *generated.VolunteerConnection {
+	edges := make([]*generated.VolunteerEdge, len(m.Edges))
+	for i, e := range m.Edges {
+		edges[i] = &generated.VolunteerEdge{Cursor: e.Cursor, Node: toGenVolunteer(e.Node)}
+	}
+	return &generated.VolunteerConnection{
+		Edges:      edges,
+		PageInfo:   toGenPageInfo(m.PageInfo),
+		TotalCount: m.TotalCount,
+	}
+}
+
+func toGenVolunteerShiftConnection(m *models.VolunteerShiftConnection) *generated.VolunteerShiftConnection {
+	edges := make([]*generated.VolunteerShiftEdge, len(m.Edges))
+	for i, e := range m.Edges {
+		edges[i] = &generated.VolunteerShiftEdge{Cursor: e.Cursor, Node: toGenVolunteerShift(e.Node)}
+	}
+	return &generated.VolunteerShiftConnection{
+		Edges:      edges,
+		PageInfo:   toGenPageInfo(m.PageInfo),
+		TotalCount: m.TotalCount,
+	}
+}
+
 // Convert generated (graphql) types to models. (Input from API to services.)
 
 // Generic
@@ -486,11 +897,17 @@ func toModelEventFilterInput(g *generated.EventFilterInput) *models.EventFilterI
 		tf := models.ShiftsTimeFilter(*g.TimeFrame)
 		timeframe = &tf
 	}
+	var statuses []models.EventStatus
+	for _, st := range g.Statuses {
+		statuses = append(statuses, models.EventStatus(st))
+	}
 	return &models.EventFilterInput{
 		Cities:    g.Cities,
 		EventType: eventType,
 		Jobs:      g.Jobs,
 		TimeFrame: timeframe,
+		Search:    g.Search,
+		Statuses:  statuses,
 	}
 }
 
@@ -509,6 +926,7 @@ func toModelNewEventInput(g generated.NewEventInput) models.NewEventInput {
 		ServiceTypes:    g.ServiceTypes,
 		EventDates:      toModelNewEventDates(g.EventDates),
 		Recurrence:      toModelRecurrenceInput(g.Recurrence),
+		PublishAt:       g.PublishAt,
 	}
 }
 
@@ -660,19 +1078,16 @@ func toModelFeedbackFilterInput(g *generated.FeedbackFilterInput) *models.Feedba
 		return nil
 	}
 
-	var fs models.FeedbackStatus
+	var f models.FeedbackFilterInput
 	if g.Status != nil {
-		fs = models.FeedbackStatus(*g.Status)
+		fs := models.FeedbackStatus(*g.Status)
+		f.Status = &fs
 	}
-	var ft models.FeedbackType
 	if g.Type != nil {
-		ft = models.FeedbackType(*g.Type)
-	}
-
-	return &models.FeedbackFilterInput{
-		Status: &fs,
-		Type:   &ft,
+		ft := models.FeedbackType(*g.Type)
+		f.Type = &ft
 	}
+	return &f
 }
 
 func toModelFeedbackStatusUpdateInput(g generated.FeedbackStatusUpdateInput) models.FeedbackStatusUpdateInput {
@@ -751,11 +1166,33 @@ func toModelVolunteeFilterInput(g *generated.VolunteerFilterInput) *models.Volun
 	if g == nil {
 		return nil
 	}
-	return &models.VolunteerFilterInput{
-		FirstName: g.FirstName,
-		LastName:  g.LastName,
-		Email:     g.Email,
-	}
+	f := &models.VolunteerFilterInput{
+		FirstName:      g.FirstName,
+		LastName:       g.LastName,
+		Email:          g.Email,
+		IsActive:       g.IsActive,
+		LastServedFrom: g.LastServedFrom,
+		LastServedTo:   g.LastServedTo,
+		JobTypeIDs:     g.JobTypeIds,
+		MinHours:       g.MinHours,
+		MaxHours:       g.MaxHours,
+		MinSignups:     g.MinSignups,
+		MaxSignups:     g.MaxSignups,
+	}
+	if g.Roles != nil {
+		f.Roles = make([]models.Role, len(g.Roles))
+		for i, r := range g.Roles {
+			f.Roles[i] = models.Role(r)
+		}
+	}
+	if g.Near != nil {
+		f.Near = &models.VolunteerDistanceInput{
+			VenueID: g.Near.VenueID,
+			ZipCode: g.Near.ZipCode,
+			Miles:   g.Near.Miles,
+		}
+	}
+	return f
 }
 
 func toModelNewVolunteerInput(g generated.NewVolunteerInput) models.NewVolunteerInput {
@@ -768,6 +1205,8 @@ func toModelNewVolunteerInput(g generated.NewVolunteerInput) models.NewVolunteer
 		ZipCode:   g.ZipCode,
 		Distance:  g.Distance,
 		Role:      models.Role(g.Role),
+
+		FundingEntityIDs: g.FundingEntityIds,
 	}
 }
 
@@ -781,5 +1220,192 @@ func toModelUpdateVolunteerInput(g generated.UpdateVolunteerInput) models.Update
 		ZipCode:   g.ZipCode,
 		Distance:  g.Distance,
 		Role:      models.Role(g.Role),
+
+		FundingEntityIDs: g.FundingEntityIds,
+	}
+}
+
+// Volunteer segments
+
+func toModelNewVolunteerSegmentInput(g generated.NewVolunteerSegmentInput) models.NewVolunteerSegmentInput {
+	input := models.NewVolunteerSegmentInput{Name: g.Name}
+	if f := toModelVolunteeFilterInput(g.Filter); f != nil {
+		input.Filter = *f
+	}
+	return input
+}
+
+func toModelUpdateVolunteerSegmentInput(g generated.UpdateVolunteerSegmentInput) models.UpdateVolunteerSegmentInput {
+	return models.UpdateVolunteerSegmentInput{
+		ID:     g.ID,
+		Name:   g.Name,
+		Filter: toModelVolunteeFilterInput(g.Filter),
+	}
+}
+
+// Impersonation
+
+func toModelStartImpersonationInput(g generated.StartImpersonationInput) models.StartImpersonationInput {
+	return models.StartImpersonationInput{
+		VolunteerID:     g.VolunteerID,
+		Reason:          g.Reason,
+		NotifyVolunteer: g.NotifyVolunteer != nil && *g.NotifyVolunteer,
+	}
+}
+
+// API tokens
+
+func toModelNewAPITokenInput(g generated.NewAPITokenInput) models.NewAPITokenInput {
+	return models.NewAPITokenInput{
+		Name:          g.Name,
+		OwnerID:       g.OwnerID,
+		Scope:         models.APITokenScope(g.Scope),
+		ExpiresInDays: g.ExpiresInDays,
+	}
+}
+
+// Outbound webhooks
+
+func toModelWebhookEventTypes(gs []generated.WebhookEventType) []models.WebhookEventType {
+	if gs == nil {
+		return nil
+	}
+	result := make([]models.WebhookEventType, len(gs))
+	for i, g := range gs {
+		result[i] = models.WebhookEventType(g)
+	}
+	return result
+}
+
+func toModelNewWebhookSubscriptionInput(g generated.NewWebhookSubscriptionInput) models.NewWebhookSubscriptionInput {
+	return models.NewWebhookSubscriptionInput{
+		URL:         g.URL,
+		Description: g.Description,
+		EventTypes:  toModelWebhookEventTypes(g.EventTypes),
+	}
+}
+
+func toModelUpdateWebhookSubscriptionInput(g generated.UpdateWebhookSubscriptionInput) models.UpdateWebhookSubscriptionInput {
+	return models.UpdateWebhookSubscriptionInput{
+		ID:          g.ID,
+		URL:         g.URL,
+		Description: g.Description,
+		EventTypes:  toModelWebhookEventTypes(g.EventTypes),
+		IsActive:    g.IsActive,
+	}
+}
+
+func toModelWebhookDeliveryFilterInput(g *generated.WebhookDeliveryFilterInput) *models.WebhookDeliveryFilterInput {
+	if g == nil {
+		return nil
+	}
+	f := &models.WebhookDeliveryFilterInput{SubscriptionID: g.SubscriptionID}
+	if g.Status != nil {
+		status := models.WebhookDeliveryStatus(*g.Status)
+		f.Status = &status
+	}
+	if g.EventType != nil {
+		eventType := models.WebhookEventType(*g.EventType)
+		f.EventType = &eventType
+	}
+	return f
+}
+
+// Audit log
+
+func toModelAuditLogFilter(g *generated.AuditLogFilterInput) *models.AuditLogFilter {
+	if g == nil {
+		return nil
+	}
+	return &models.AuditLogFilter{
+		ActorID:  g.ActorID,
+		Entity:   g.Entity,
+		EntityID: g.EntityID,
+		From:     g.From,
+		To:       g.To,
+		Limit:    g.Limit,
+	}
+}
+
+// Account requests
+
+func toModelAccountRequestStatus(g *generated.AccountRequestStatus) models.AccountRequestStatus {
+	if g == nil {
+		return models.AccountRequestStatusPending
+	}
+	return models.AccountRequestStatus(*g)
+}
+
+// A missing input approves as a plain volunteer.
+func toModelApproveAccountRequestInput(g *generated.ApproveAccountRequestInput) models.ApproveAccountRequestInput {
+	if g == nil {
+		return models.ApproveAccountRequestInput{Role: models.RoleVolunteer}
+	}
+	return models.ApproveAccountRequestInput{
+		Role:             models.Role(g.Role),
+		FundingEntityIDs: g.FundingEntityIds,
+	}
+}
+
+// Erasure requests
+
+func toModelErasureRequestStatus(g *generated.ErasureRequestStatus) models.ErasureRequestStatus {
+	if g == nil {
+		return models.ErasureRequestStatusPending
+	}
+	return models.ErasureRequestStatus(*g)
+}
+
+// Paging
+
+func toModelPageInput(first *int, after *string) *models.PageInput {
+	return &models.PageInput{First: first, After: after}
+}
+
+func toModelSortDirection(g *generated.SortDirection) *models.SortDirection {
+	if g == nil {
+		return nil
+	}
+	d := models.SortDirection(*g)
+	return &d
+}
+
+func toModelEventSort(g *generated.EventSort) *models.EventSort {
+	if g == nil {
+		return nil
+	}
+	return &models.EventSort{
+		Field:     models.EventSortField(g.Field),
+		Direction: toModelSortDirection(g.Direction),
+	}
+}
+
+func toModelFeedbackSort(g *generated.FeedbackSort) *models.FeedbackSort {
+	if g == nil {
+		return nil
+	}
+	return &models.FeedbackSort{
+		Field:     models.FeedbackSortField(g.Field),
+		Direction: toModelSortDirection(g.Direction),
+	}
+}
+
+func toModelVolunteerSort(g *generated.VolunteerSort) *models.VolunteerSort {
+	if g == nil {
+		return nil
+	}
+	return &models.VolunteerSort{
+		Field:     models.VolunteerSortField(g.Field),
+		Direction: toModelSortDirection(g.Direction),
+	}
+}
+
+func toModelVolunteerShiftSort(g *generated.VolunteerShiftSort) *models.VolunteerShiftSort {
+	if g == nil {
+		return nil
+	}
+	return &models.VolunteerShiftSort{
+		Field:     models.VolunteerShiftSortField(g.Field),
+		Direction: toModelSortDirection(g.Direction),
 	}
 }
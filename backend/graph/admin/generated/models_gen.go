@@ -9,6 +9,22 @@ import (
 	"strconv"
 )
 
+type AccountRequest struct {
+	ID                  string               `json:"id"`
+	Email               string               `json:"email"`
+	FirstName           string               `json:"firstName"`
+	LastName            string               `json:"lastName"`
+	Status              AccountRequestStatus `json:"status"`
+	ExistingVolunteerID *string              `json:"existingVolunteerId,omitempty"`
+	CreatedAt           string               `json:"createdAt"`
+	VerifiedAt          string               `json:"verifiedAt"`
+	DecidedByID         *string              `json:"decidedById,omitempty"`
+	DecidedByName       *string              `json:"decidedByName,omitempty"`
+	DecidedAt           *string              `json:"decidedAt,omitempty"`
+	DecisionMessage     *string              `json:"decisionMessage,omitempty"`
+	VolunteerID         *string              `json:"volunteerId,omitempty"`
+}
+
 type AddEventDateInput struct {
 	EventID       string `json:"eventId"`
 	StartDateTime string `json:"startDateTime"`
@@ -22,6 +38,84 @@ type AddShiftInput struct {
 	MaxVolunteers *int   `json:"maxVolunteers,omitempty"`
 }
 
+type APIToken struct {
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	Prefix        string        `json:"prefix"`
+	OwnerID       string        `json:"ownerId"`
+	OwnerName     string        `json:"ownerName"`
+	Scope         APITokenScope `json:"scope"`
+	CreatedByID   *string       `json:"createdById,omitempty"`
+	CreatedByName *string       `json:"createdByName,omitempty"`
+	CreatedAt     string        `json:"createdAt"`
+	ExpiresAt     string        `json:"expiresAt"`
+	LastUsedAt    *string       `json:"lastUsedAt,omitempty"`
+	LastUsedIP    *string       `json:"lastUsedIp,omitempty"`
+	RevokedAt     *string       `json:"revokedAt,omitempty"`
+}
+
+type APITokenResult struct {
+	Success  bool       `json:"success"`
+	Message  *string    `json:"message,omitempty"`
+	APIToken *APIToken  `json:"apiToken,omitempty"`
+	Token    *string    `json:"token,omitempty"`
+	Code     *ErrorCode `json:"code,omitempty"`
+}
+
+type ApproveAccountRequestInput struct {
+	Role             Role  `json:"role"`
+	FundingEntityIds []int `json:"fundingEntityIds,omitempty"`
+}
+
+type AssignableVolunteer struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+}
+
+type AuditChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before,omitempty"`
+	After  *string `json:"after,omitempty"`
+}
+
+type AuditEntry struct {
+	ID          string         `json:"id"`
+	ActorID     *string        `json:"actorId,omitempty"`
+	ActorName   *string        `json:"actorName,omitempty"`
+	ViaAPIToken bool           `json:"viaApiToken"`
+	Operation   string         `json:"operation"`
+	Entity      *string        `json:"entity,omitempty"`
+	EntityID    *string        `json:"entityId,omitempty"`
+	Changes     []*AuditChange `json:"changes"`
+	Success     bool           `json:"success"`
+	Error       *string        `json:"error,omitempty"`
+	IPAddress   *string        `json:"ipAddress,omitempty"`
+	CreatedAt   string         `json:"createdAt"`
+}
+
+type AuditLogFilterInput struct {
+	ActorID  *string `json:"actorId,omitempty"`
+	Entity   *string `json:"entity,omitempty"`
+	EntityID *string `json:"entityId,omitempty"`
+	From     *string `json:"from,omitempty"`
+	To       *string `json:"to,omitempty"`
+	Limit    *int    `json:"limit,omitempty"`
+}
+
+type ErasureRequest struct {
+	ID              string               `json:"id"`
+	VolunteerID     string               `json:"volunteerId"`
+	VolunteerName   string               `json:"volunteerName"`
+	Reason          *string              `json:"reason,omitempty"`
+	Status          ErasureRequestStatus `json:"status"`
+	CreatedAt       string               `json:"createdAt"`
+	DecidedByID     *string              `json:"decidedById,omitempty"`
+	DecidedByName   *string              `json:"decidedByName,omitempty"`
+	DecidedAt       *string              `json:"decidedAt,omitempty"`
+	DecisionMessage *string              `json:"decisionMessage,omitempty"`
+}
+
 type Event struct {
 	ID              string               `json:"id"`
 	Name            string               `json:"name"`
@@ -36,6 +130,17 @@ type Event struct {
 	ShiftSummaries  []*EventShiftSummary `json:"shiftSummaries"`
 	RecurrenceGroup *RecurrenceGroup     `json:"recurrenceGroup,omitempty"`
 	RecurrenceOrder *int                 `json:"recurrenceOrder,omitempty"`
+	Status          EventStatus          `json:"status"`
+	PublishAt       *string              `json:"publishAt,omitempty"`
+	CancelledAt     *string              `json:"cancelledAt,omitempty"`
+	SearchRank      *float64             `json:"searchRank,omitempty"`
+	SearchSnippet   *string              `json:"searchSnippet,omitempty"`
+}
+
+type EventConnection struct {
+	Edges      []*EventEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount int          `json:"totalCount"`
 }
 
 type EventDate struct {
@@ -44,11 +149,18 @@ type EventDate struct {
 	EndDateTime   string `json:"endDateTime"`
 }
 
+type EventEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Event `json:"node"`
+}
+
 type EventFilterInput struct {
 	Cities    []string         `json:"cities,omitempty"`
 	EventType *EventType       `json:"eventType,omitempty"`
 	Jobs      []int            `json:"jobs,omitempty"`
 	TimeFrame *ShiftTimeFilter `json:"timeFrame,omitempty"`
+	Search    *string          `json:"search,omitempty"`
+	Statuses  []EventStatus    `json:"statuses,omitempty"`
 }
 
 type EventShiftSummary struct {
@@ -57,6 +169,11 @@ type EventShiftSummary struct {
 	MaxVolunteers      int    `json:"maxVolunteers"`
 }
 
+type EventSort struct {
+	Field     EventSortField `json:"field"`
+	Direction *SortDirection `json:"direction,omitempty"`
+}
+
 type Feedback struct {
 	ID            string                    `json:"id"`
 	VolunteerName string                    `json:"volunteerName"`
@@ -78,6 +195,17 @@ type FeedbackAttachment struct {
 	Data     string `json:"data"`
 }
 
+type FeedbackConnection struct {
+	Edges      []*FeedbackEdge `json:"edges"`
+	PageInfo   *PageInfo       `json:"pageInfo"`
+	TotalCount int             `json:"totalCount"`
+}
+
+type FeedbackEdge struct {
+	Cursor string    `json:"cursor"`
+	Node   *Feedback `json:"node"`
+}
+
 type FeedbackEmailInput struct {
 	FeedbackID   int    `json:"feedbackId"`
 	EmailText    string `json:"emailText"`
@@ -110,6 +238,11 @@ type FeedbackNoteInput struct {
 	Note       string `json:"note"`
 }
 
+type FeedbackSort struct {
+	Field     FeedbackSortField `json:"field"`
+	Direction *SortDirection    `json:"direction,omitempty"`
+}
+
 type FeedbackStatusUpdateInput struct {
 	FeedbackID int            `json:"feedbackId"`
 	Status     FeedbackStatus `json:"status"`
@@ -122,6 +255,28 @@ type FundingEntity struct {
 	Description *string `json:"description,omitempty"`
 }
 
+type Impersonation struct {
+	ID                string  `json:"id"`
+	AdminID           string  `json:"adminId"`
+	AdminName         string  `json:"adminName"`
+	VolunteerID       string  `json:"volunteerId"`
+	VolunteerName     string  `json:"volunteerName"`
+	Reason            string  `json:"reason"`
+	VolunteerNotified bool    `json:"volunteerNotified"`
+	StartedAt         string  `json:"startedAt"`
+	ExpiresAt         string  `json:"expiresAt"`
+	EndedAt           *string `json:"endedAt,omitempty"`
+	RequestCount      int     `json:"requestCount"`
+}
+
+type ImpersonationResult struct {
+	Success       bool           `json:"success"`
+	Message       *string        `json:"message,omitempty"`
+	Impersonation *Impersonation `json:"impersonation,omitempty"`
+	Token         *string        `json:"token,omitempty"`
+	Code          *ErrorCode     `json:"code,omitempty"`
+}
+
 type JobType struct {
 	ID        int    `json:"id"`
 	Code      string `json:"code"`
@@ -140,9 +295,17 @@ type Mutation struct {
 }
 
 type MutationResult struct {
-	Success bool    `json:"success"`
-	Message *string `json:"message,omitempty"`
-	ID      *string `json:"id,omitempty"`
+	Success bool       `json:"success"`
+	Message *string    `json:"message,omitempty"`
+	ID      *string    `json:"id,omitempty"`
+	Code    *ErrorCode `json:"code,omitempty"`
+}
+
+type NewAPITokenInput struct {
+	Name          string        `json:"name"`
+	OwnerID       *string       `json:"ownerId,omitempty"`
+	Scope         APITokenScope `json:"scope"`
+	ExpiresInDays *int          `json:"expiresInDays,omitempty"`
 }
 
 type NewEventDateInput struct {
@@ -161,6 +324,7 @@ type NewEventInput struct {
 	FundingEntityID int                  `json:"fundingEntityId"`
 	ServiceTypes    []int                `json:"serviceTypes"`
 	Recurrence      *RecurrenceInput     `json:"recurrence,omitempty"`
+	PublishAt       *string              `json:"publishAt,omitempty"`
 }
 
 type NewFeedbackInput struct {
@@ -212,13 +376,25 @@ type NewVenueInput struct {
 }
 
 type NewVolunteerInput struct {
-	FirstName string  `json:"firstName"`
-	LastName  string  `json:"lastName"`
-	Email     string  `json:"email"`
-	Phone     *string `json:"phone,omitempty"`
-	ZipCode   *string `json:"zipCode,omitempty"`
-	Distance  *int    `json:"distance,omitempty"`
-	Role      Role    `json:"role"`
+	FirstName        string  `json:"firstName"`
+	LastName         string  `json:"lastName"`
+	Email            string  `json:"email"`
+	Phone            *string `json:"phone,omitempty"`
+	ZipCode          *string `json:"zipCode,omitempty"`
+	Distance         *int    `json:"distance,omitempty"`
+	Role             Role    `json:"role"`
+	FundingEntityIds []int   `json:"fundingEntityIds,omitempty"`
+}
+
+type NewVolunteerSegmentInput struct {
+	Name   string                `json:"name"`
+	Filter *VolunteerFilterInput `json:"filter"`
+}
+
+type NewWebhookSubscriptionInput struct {
+	URL         string             `json:"url"`
+	Description *string            `json:"description,omitempty"`
+	EventTypes  []WebhookEventType `json:"eventTypes"`
 }
 
 type Opportunity struct {
@@ -229,6 +405,13 @@ type Opportunity struct {
 	Shifts               []*Shift `json:"shifts"`
 }
 
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type Query struct {
 }
 
@@ -258,6 +441,13 @@ type Shift struct {
 	MaxVolunteers *int   `json:"maxVolunteers,omitempty"`
 }
 
+type ShiftCapacity struct {
+	EventID            string `json:"eventId"`
+	ShiftID            string `json:"shiftId"`
+	AssignedVolunteers int    `json:"assignedVolunteers"`
+	MaxVolunteers      *int   `json:"maxVolunteers,omitempty"`
+}
+
 type Staff struct {
 	ID        string  `json:"id"`
 	FirstName string  `json:"firstName"`
@@ -267,6 +457,15 @@ type Staff struct {
 	Position  *string `json:"position,omitempty"`
 }
 
+type StartImpersonationInput struct {
+	VolunteerID     string `json:"volunteerId"`
+	Reason          string `json:"reason"`
+	NotifyVolunteer *bool  `json:"notifyVolunteer,omitempty"`
+}
+
+type Subscription struct {
+}
+
 type UpdateEventDateInput struct {
 	ID            string `json:"id"`
 	StartDateTime string `json:"startDateTime"`
@@ -332,14 +531,29 @@ type UpdateVenueInput struct {
 }
 
 type UpdateVolunteerInput struct {
-	ID        string  `json:"id"`
-	FirstName string  `json:"firstName"`
-	LastName  string  `json:"lastName"`
-	Email     string  `json:"email"`
-	Phone     *string `json:"phone,omitempty"`
-	ZipCode   *string `json:"zipCode,omitempty"`
-	Distance  *int    `json:"distance,omitempty"`
-	Role      Role    `json:"role"`
+	ID               string  `json:"id"`
+	FirstName        string  `json:"firstName"`
+	LastName         string  `json:"lastName"`
+	Email            string  `json:"email"`
+	Phone            *string `json:"phone,omitempty"`
+	ZipCode          *string `json:"zipCode,omitempty"`
+	Distance         *int    `json:"distance,omitempty"`
+	Role             Role    `json:"role"`
+	FundingEntityIds []int   `json:"fundingEntityIds,omitempty"`
+}
+
+type UpdateVolunteerSegmentInput struct {
+	ID     string                `json:"id"`
+	Name   *string               `json:"name,omitempty"`
+	Filter *VolunteerFilterInput `json:"filter,omitempty"`
+}
+
+type UpdateWebhookSubscriptionInput struct {
+	ID          string             `json:"id"`
+	URL         *string            `json:"url,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	EventTypes  []WebhookEventType `json:"eventTypes,omitempty"`
+	IsActive    *bool              `json:"isActive,omitempty"`
 }
 
 type Venue struct {
@@ -352,20 +566,84 @@ type Venue struct {
 }
 
 type Volunteer struct {
-	ID        string  `json:"id"`
-	FirstName string  `json:"firstName"`
-	LastName  string  `json:"lastName"`
-	Email     string  `json:"email"`
-	Phone     *string `json:"phone,omitempty"`
-	ZipCode   *string `json:"zipCode,omitempty"`
-	Distance  *int    `json:"distance,omitempty"`
-	Roles     []Role  `json:"roles"`
+	ID                 string                  `json:"id"`
+	FirstName          string                  `json:"firstName"`
+	LastName           string                  `json:"lastName"`
+	Email              string                  `json:"email"`
+	Phone              *string                 `json:"phone,omitempty"`
+	ZipCode            *string                 `json:"zipCode,omitempty"`
+	Distance           *int                    `json:"distance,omitempty"`
+	Roles              []Role                  `json:"roles"`
+	FundingEntityIds   []int                   `json:"fundingEntityIds"`
+	EmailUndeliverable *EmailSuppressionReason `json:"emailUndeliverable,omitempty"`
+	HoursServed        *float64                `json:"hoursServed,omitempty"`
+	SignupCount        *int                    `json:"signupCount,omitempty"`
+	LastServedAt       *string                 `json:"lastServedAt,omitempty"`
+}
+
+type VolunteerConnection struct {
+	Edges      []*VolunteerEdge `json:"edges"`
+	PageInfo   *PageInfo        `json:"pageInfo"`
+	TotalCount int              `json:"totalCount"`
+}
+
+type VolunteerDistance struct {
+	VenueID *string `json:"venueId,omitempty"`
+	ZipCode *string `json:"zipCode,omitempty"`
+	Miles   int     `json:"miles"`
+}
+
+type VolunteerDistanceInput struct {
+	VenueID *string `json:"venueId,omitempty"`
+	ZipCode *string `json:"zipCode,omitempty"`
+	Miles   int     `json:"miles"`
+}
+
+type VolunteerEdge struct {
+	Cursor string     `json:"cursor"`
+	Node   *Volunteer `json:"node"`
+}
+
+type VolunteerFilter struct {
+	FirstName      *string            `json:"firstName,omitempty"`
+	LastName       *string            `json:"lastName,omitempty"`
+	Email          *string            `json:"email,omitempty"`
+	IsActive       *bool              `json:"isActive,omitempty"`
+	Roles          []Role             `json:"roles,omitempty"`
+	Near           *VolunteerDistance `json:"near,omitempty"`
+	LastServedFrom *string            `json:"lastServedFrom,omitempty"`
+	LastServedTo   *string            `json:"lastServedTo,omitempty"`
+	JobTypeIds     []int              `json:"jobTypeIds,omitempty"`
+	MinHours       *float64           `json:"minHours,omitempty"`
+	MaxHours       *float64           `json:"maxHours,omitempty"`
+	MinSignups     *int               `json:"minSignups,omitempty"`
+	MaxSignups     *int               `json:"maxSignups,omitempty"`
 }
 
 type VolunteerFilterInput struct {
-	FirstName *string `json:"firstName,omitempty"`
-	LastName  *string `json:"lastName,omitempty"`
-	Email     *string `json:"email,omitempty"`
+	FirstName      *string                 `json:"firstName,omitempty"`
+	LastName       *string                 `json:"lastName,omitempty"`
+	Email          *string                 `json:"email,omitempty"`
+	IsActive       *bool                   `json:"isActive,omitempty"`
+	Roles          []Role                  `json:"roles,omitempty"`
+	Near           *VolunteerDistanceInput `json:"near,omitempty"`
+	LastServedFrom *string                 `json:"lastServedFrom,omitempty"`
+	LastServedTo   *string                 `json:"lastServedTo,omitempty"`
+	JobTypeIds     []int                   `json:"jobTypeIds,omitempty"`
+	MinHours       *float64                `json:"minHours,omitempty"`
+	MaxHours       *float64                `json:"maxHours,omitempty"`
+	MinSignups     *int                    `json:"minSignups,omitempty"`
+	MaxSignups     *int                    `json:"maxSignups,omitempty"`
+}
+
+type VolunteerSegment struct {
+	ID            string           `json:"id"`
+	Name          string           `json:"name"`
+	Filter        *VolunteerFilter `json:"filter"`
+	CreatedByID   *string          `json:"createdById,omitempty"`
+	CreatedByName *string          `json:"createdByName,omitempty"`
+	CreatedAt     string           `json:"createdAt"`
+	UpdatedAt     *string          `json:"updatedAt,omitempty"`
 }
 
 type VolunteerShift struct {
@@ -384,50 +662,123 @@ type VolunteerShift struct {
 	Venue                *Venue  `json:"venue,omitempty"`
 }
 
-type EventType string
+type VolunteerShiftConnection struct {
+	Edges      []*VolunteerShiftEdge `json:"edges"`
+	PageInfo   *PageInfo             `json:"pageInfo"`
+	TotalCount int                   `json:"totalCount"`
+}
+
+type VolunteerShiftEdge struct {
+	Cursor string          `json:"cursor"`
+	Node   *VolunteerShift `json:"node"`
+}
+
+type VolunteerShiftSort struct {
+	Field     VolunteerShiftSortField `json:"field"`
+	Direction *SortDirection          `json:"direction,omitempty"`
+}
+
+type VolunteerSort struct {
+	Field     VolunteerSortField `json:"field"`
+	Direction *SortDirection     `json:"direction,omitempty"`
+}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscriptionId"`
+	EventType      WebhookEventType      `json:"eventType"`
+	Payload        string                `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *string               `json:"nextAttemptAt,omitempty"`
+	LastAttemptAt  *string               `json:"lastAttemptAt,omitempty"`
+	LastStatusCode *int                  `json:"lastStatusCode,omitempty"`
+	LastError      *string               `json:"lastError,omitempty"`
+	CreatedAt      string                `json:"createdAt"`
+	DeliveredAt    *string               `json:"deliveredAt,omitempty"`
+}
+
+type WebhookDeliveryConnection struct {
+	Edges      []*WebhookDeliveryEdge `json:"edges"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount int                    `json:"totalCount"`
+}
+
+type WebhookDeliveryEdge struct {
+	Cursor string           `json:"cursor"`
+	Node   *WebhookDelivery `json:"node"`
+}
+
+type WebhookDeliveryFilterInput struct {
+	SubscriptionID *string                `json:"subscriptionId,omitempty"`
+	Status         *WebhookDeliveryStatus `json:"status,omitempty"`
+	EventType      *WebhookEventType      `json:"eventType,omitempty"`
+}
+
+type WebhookSubscription struct {
+	ID            string             `json:"id"`
+	URL           string             `json:"url"`
+	Description   *string            `json:"description,omitempty"`
+	EventTypes    []WebhookEventType `json:"eventTypes"`
+	IsActive      bool               `json:"isActive"`
+	CreatedByID   *string            `json:"createdById,omitempty"`
+	CreatedByName *string            `json:"createdByName,omitempty"`
+	CreatedAt     string             `json:"createdAt"`
+	UpdatedAt     *string            `json:"updatedAt,omitempty"`
+}
+
+type WebhookSubscriptionResult struct {
+	Success      bool                 `json:"success"`
+	Message      *string              `json:"message,omitempty"`
+	Subscription *WebhookSubscription `json:"subscription,omitempty"`
+	Secret       *string              `json:"secret,omitempty"`
+	Code         *ErrorCode           `json:"code,omitempty"`
+}
+
+type AccountRequestStatus string
 
 const (
-	EventTypeVirtual  EventType = "VIRTUAL"
-	EventTypeInPerson EventType = "IN_PERSON"
-	EventTypeHybrid   EventType = "HYBRID"
+	AccountRequestStatusPending  AccountRequestStatus = "PENDING"
+	AccountRequestStatusApproved AccountRequestStatus = "APPROVED"
+	AccountRequestStatusDenied   AccountRequestStatus = "DENIED"
 )
 
-var AllEventType = []EventType{
-	EventTypeVirtual,
-	EventTypeInPerson,
-	EventTypeHybrid,
+var AllAccountRequestStatus = []AccountRequestStatus{
+	AccountRequestStatusPending,
+	AccountRequestStatusApproved,
+	AccountRequestStatusDenied,
 }
 
-func (e EventType) IsValid() bool {
+func (e AccountRequestStatus) IsValid() bool {
 	switch e {
-	case EventTypeVirtual, EventTypeInPerson, EventTypeHybrid:
+	case AccountRequestStatusPending, AccountRequestStatusApproved, AccountRequestStatusDenied:
 		return true
 	}
 	return false
 }
 
-func (e EventType) String() string {
+func (e AccountRequestStatus) String() string {
 	return string(e)
 }
 
-func (e *EventType) UnmarshalGQL(v any) error {
+func (e *AccountRequestStatus) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = EventType(str)
+	*e = AccountRequestStatus(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid EventType", str)
+		return fmt.Errorf("%s is not a valid AccountRequestStatus", str)
 	}
 	return nil
 }
 
-func (e EventType) MarshalGQL(w io.Writer) {
+func (e AccountRequestStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *EventType) UnmarshalJSON(b []byte) error {
+func (e *AccountRequestStatus) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -435,58 +786,56 @@ func (e *EventType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e EventType) MarshalJSON() ([]byte, error) {
+func (e AccountRequestStatus) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type FeedbackNoteType string
+type APITokenScope string
 
 const (
-	FeedbackNoteTypeAdminNote        FeedbackNoteType = "ADMIN_NOTE"
-	FeedbackNoteTypeQuestion         FeedbackNoteType = "QUESTION"
-	FeedbackNoteTypeVolunteerNote    FeedbackNoteType = "VOLUNTEER_NOTE"
-	FeedbackNoteTypeEmailToVolunteer FeedbackNoteType = "EMAIL_TO_VOLUNTEER"
+	APITokenScopeAdminReadOnly   APITokenScope = "ADMIN_READ_ONLY"
+	APITokenScopeReporting       APITokenScope = "REPORTING"
+	APITokenScopeVolunteerSignup APITokenScope = "VOLUNTEER_SIGNUP"
 )
 
-var AllFeedbackNoteType = []FeedbackNoteType{
-	FeedbackNoteTypeAdminNote,
-	FeedbackNoteTypeQuestion,
-	FeedbackNoteTypeVolunteerNote,
-	FeedbackNoteTypeEmailToVolunteer,
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopeAdminReadOnly,
+	APITokenScopeReporting,
+	APITokenScopeVolunteerSignup,
 }
 
-func (e FeedbackNoteType) IsValid() bool {
+func (e APITokenScope) IsValid() bool {
 	switch e {
-	case FeedbackNoteTypeAdminNote, FeedbackNoteTypeQuestion, FeedbackNoteTypeVolunteerNote, FeedbackNoteTypeEmailToVolunteer:
+	case APITokenScopeAdminReadOnly, APITokenScopeReporting, APITokenScopeVolunteerSignup:
 		return true
 	}
 	return false
 }
 
-func (e FeedbackNoteType) String() string {
+func (e APITokenScope) String() string {
 	return string(e)
 }
 
-func (e *FeedbackNoteType) UnmarshalGQL(v any) error {
+func (e *APITokenScope) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FeedbackNoteType(str)
+	*e = APITokenScope(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid FeedbackNoteType", str)
+		return fmt.Errorf("%s is not a valid ApiTokenScope", str)
 	}
 	return nil
 }
 
-func (e FeedbackNoteType) MarshalGQL(w io.Writer) {
+func (e APITokenScope) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *FeedbackNoteType) UnmarshalJSON(b []byte) error {
+func (e *APITokenScope) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -494,58 +843,54 @@ func (e *FeedbackNoteType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e FeedbackNoteType) MarshalJSON() ([]byte, error) {
+func (e APITokenScope) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type FeedbackStatus string
+type EmailSuppressionReason string
 
 const (
-	FeedbackStatusOpen                FeedbackStatus = "OPEN"
-	FeedbackStatusQuestionSent        FeedbackStatus = "QUESTION_SENT"
-	FeedbackStatusResolvedImplemented FeedbackStatus = "RESOLVED_IMPLEMENTED"
-	FeedbackStatusResolvedRejected    FeedbackStatus = "RESOLVED_REJECTED"
+	EmailSuppressionReasonBounce    EmailSuppressionReason = "BOUNCE"
+	EmailSuppressionReasonComplaint EmailSuppressionReason = "COMPLAINT"
 )
 
-var AllFeedbackStatus = []FeedbackStatus{
-	FeedbackStatusOpen,
-	FeedbackStatusQuestionSent,
-	FeedbackStatusResolvedImplemented,
-	FeedbackStatusResolvedRejected,
+var AllEmailSuppressionReason = []EmailSuppressionReason{
+	EmailSuppressionReasonBounce,
+	EmailSuppressionReasonComplaint,
 }
 
-func (e FeedbackStatus) IsValid() bool {
+func (e EmailSuppressionReason) IsValid() bool {
 	switch e {
-	case FeedbackStatusOpen, FeedbackStatusQuestionSent, FeedbackStatusResolvedImplemented, FeedbackStatusResolvedRejected:
+	case EmailSuppressionReasonBounce, EmailSuppressionReasonComplaint:
 		return true
 	}
 	return false
 }
 
-func (e FeedbackStatus) String() string {
+func (e EmailSuppressionReason) String() string {
 	return string(e)
 }
 
-func (e *FeedbackStatus) UnmarshalGQL(v any) error {
+func (e *EmailSuppressionReason) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FeedbackStatus(str)
+	*e = EmailSuppressionReason(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid FeedbackStatus", str)
+		return fmt.Errorf("%s is not a valid EmailSuppressionReason", str)
 	}
 	return nil
 }
 
-func (e FeedbackStatus) MarshalGQL(w io.Writer) {
+func (e EmailSuppressionReason) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *FeedbackStatus) UnmarshalJSON(b []byte) error {
+func (e *EmailSuppressionReason) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -553,56 +898,56 @@ func (e *FeedbackStatus) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e FeedbackStatus) MarshalJSON() ([]byte, error) {
+func (e EmailSuppressionReason) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type FeedbackType string
+type ErasureRequestStatus string
 
 const (
-	FeedbackTypeBug         FeedbackType = "BUG"
-	FeedbackTypeEnhancement FeedbackType = "ENHANCEMENT"
-	FeedbackTypeGeneral     FeedbackType = "GENERAL"
+	ErasureRequestStatusPending  ErasureRequestStatus = "PENDING"
+	ErasureRequestStatusApproved ErasureRequestStatus = "APPROVED"
+	ErasureRequestStatusDenied   ErasureRequestStatus = "DENIED"
 )
 
-var AllFeedbackType = []FeedbackType{
-	FeedbackTypeBug,
-	FeedbackTypeEnhancement,
-	FeedbackTypeGeneral,
+var AllErasureRequestStatus = []ErasureRequestStatus{
+	ErasureRequestStatusPending,
+	ErasureRequestStatusApproved,
+	ErasureRequestStatusDenied,
 }
 
-func (e FeedbackType) IsValid() bool {
+func (e ErasureRequestStatus) IsValid() bool {
 	switch e {
-	case FeedbackTypeBug, FeedbackTypeEnhancement, FeedbackTypeGeneral:
+	case ErasureRequestStatusPending, ErasureRequestStatusApproved, ErasureRequestStatusDenied:
 		return true
 	}
 	return false
 }
 
-func (e FeedbackType) String() string {
+func (e ErasureRequestStatus) String() string {
 	return string(e)
 }
 
-func (e *FeedbackType) UnmarshalGQL(v any) error {
+func (e *ErasureRequestStatus) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = FeedbackType(str)
+	*e = ErasureRequestStatus(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid FeedbackType", str)
+		return fmt.Errorf("%s is not a valid ErasureRequestStatus", str)
 	}
 	return nil
 }
 
-func (e FeedbackType) MarshalGQL(w io.Writer) {
+func (e ErasureRequestStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *FeedbackType) UnmarshalJSON(b []byte) error {
+func (e *ErasureRequestStatus) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -610,60 +955,68 @@ func (e *FeedbackType) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e FeedbackType) MarshalJSON() ([]byte, error) {
+func (e ErasureRequestStatus) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type RecurrencePattern string
+type ErrorCode string
 
 const (
-	RecurrencePatternDaily    RecurrencePattern = "DAILY"
-	RecurrencePatternWeekly   RecurrencePattern = "WEEKLY"
-	RecurrencePatternBiweekly RecurrencePattern = "BIWEEKLY"
-	RecurrencePatternMonthly  RecurrencePattern = "MONTHLY"
-	RecurrencePatternYearly   RecurrencePattern = "YEARLY"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation      ErrorCode = "VALIDATION"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeCapacityFull    ErrorCode = "CAPACITY_FULL"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	ErrorCodeRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrorCodeReadOnly        ErrorCode = "READ_ONLY"
+	ErrorCodeInternal        ErrorCode = "INTERNAL"
 )
 
-var AllRecurrencePattern = []RecurrencePattern{
-	RecurrencePatternDaily,
-	RecurrencePatternWeekly,
-	RecurrencePatternBiweekly,
-	RecurrencePatternMonthly,
-	RecurrencePatternYearly,
+var AllErrorCode = []ErrorCode{
+	ErrorCodeNotFound,
+	ErrorCodeValidation,
+	ErrorCodeConflict,
+	ErrorCodeCapacityFull,
+	ErrorCodeForbidden,
+	ErrorCodeUnauthenticated,
+	ErrorCodeRateLimited,
+	ErrorCodeReadOnly,
+	ErrorCodeInternal,
 }
 
-func (e RecurrencePattern) IsValid() bool {
+func (e ErrorCode) IsValid() bool {
 	switch e {
-	case RecurrencePatternDaily, RecurrencePatternWeekly, RecurrencePatternBiweekly, RecurrencePatternMonthly, RecurrencePatternYearly:
+	case ErrorCodeNotFound, ErrorCodeValidation, ErrorCodeConflict, ErrorCodeCapacityFull, ErrorCodeForbidden, ErrorCodeUnauthenticated, ErrorCodeRateLimited, ErrorCodeReadOnly, ErrorCodeInternal:
 		return true
 	}
 	return false
 }
 
-func (e RecurrencePattern) String() string {
+func (e ErrorCode) String() string {
 	return string(e)
 }
 
-func (e *RecurrencePattern) UnmarshalGQL(v any) error {
+func (e *ErrorCode) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = RecurrencePattern(str)
+	*e = ErrorCode(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid RecurrencePattern", str)
+		return fmt.Errorf("%s is not a valid ErrorCode", str)
 	}
 	return nil
 }
 
-func (e RecurrencePattern) MarshalGQL(w io.Writer) {
+func (e ErrorCode) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *RecurrencePattern) UnmarshalJSON(b []byte) error {
+func (e *ErrorCode) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -671,54 +1024,54 @@ func (e *RecurrencePattern) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e RecurrencePattern) MarshalJSON() ([]byte, error) {
+func (e ErrorCode) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type RecurrenceUpdateScope string
+type EventSortField string
 
 const (
-	RecurrenceUpdateScopeThisOnly      RecurrenceUpdateScope = "THIS_ONLY"
-	RecurrenceUpdateScopeThisAndFuture RecurrenceUpdateScope = "THIS_AND_FUTURE"
+	EventSortFieldStartDate EventSortField = "START_DATE"
+	EventSortFieldName      EventSortField = "NAME"
 )
 
-var AllRecurrenceUpdateScope = []RecurrenceUpdateScope{
-	RecurrenceUpdateScopeThisOnly,
-	RecurrenceUpdateScopeThisAndFuture,
+var AllEventSortField = []EventSortField{
+	EventSortFieldStartDate,
+	EventSortFieldName,
 }
 
-func (e RecurrenceUpdateScope) IsValid() bool {
+func (e EventSortField) IsValid() bool {
 	switch e {
-	case RecurrenceUpdateScopeThisOnly, RecurrenceUpdateScopeThisAndFuture:
+	case EventSortFieldStartDate, EventSortFieldName:
 		return true
 	}
 	return false
 }
 
-func (e RecurrenceUpdateScope) String() string {
+func (e EventSortField) String() string {
 	return string(e)
 }
 
-func (e *RecurrenceUpdateScope) UnmarshalGQL(v any) error {
+func (e *EventSortField) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = RecurrenceUpdateScope(str)
+	*e = EventSortField(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid RecurrenceUpdateScope", str)
+		return fmt.Errorf("%s is not a valid EventSortField", str)
 	}
 	return nil
 }
 
-func (e RecurrenceUpdateScope) MarshalGQL(w io.Writer) {
+func (e EventSortField) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *RecurrenceUpdateScope) UnmarshalJSON(b []byte) error {
+func (e *EventSortField) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -726,54 +1079,58 @@ func (e *RecurrenceUpdateScope) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e RecurrenceUpdateScope) MarshalJSON() ([]byte, error) {
+func (e EventSortField) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type Role string
+type EventStatus string
 
 const (
-	RoleVolunteer     Role = "VOLUNTEER"
-	RoleAdministrator Role = "ADMINISTRATOR"
+	EventStatusDraft     EventStatus = "DRAFT"
+	EventStatusPublished EventStatus = "PUBLISHED"
+	EventStatusCancelled EventStatus = "CANCELLED"
+	EventStatusCompleted EventStatus = "COMPLETED"
 )
 
-var AllRole = []Role{
-	RoleVolunteer,
-	RoleAdministrator,
+var AllEventStatus = []EventStatus{
+	EventStatusDraft,
+	EventStatusPublished,
+	EventStatusCancelled,
+	EventStatusCompleted,
 }
 
-func (e Role) IsValid() bool {
+func (e EventStatus) IsValid() bool {
 	switch e {
-	case RoleVolunteer, RoleAdministrator:
+	case EventStatusDraft, EventStatusPublished, EventStatusCancelled, EventStatusCompleted:
 		return true
 	}
 	return false
 }
 
-func (e Role) String() string {
+func (e EventStatus) String() string {
 	return string(e)
 }
 
-func (e *Role) UnmarshalGQL(v any) error {
+func (e *EventStatus) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = Role(str)
+	*e = EventStatus(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid Role", str)
+		return fmt.Errorf("%s is not a valid EventStatus", str)
 	}
 	return nil
 }
 
-func (e Role) MarshalGQL(w io.Writer) {
+func (e EventStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *Role) UnmarshalJSON(b []byte) error {
+func (e *EventStatus) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -781,56 +1138,56 @@ func (e *Role) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e Role) MarshalJSON() ([]byte, error) {
+func (e EventStatus) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
 }
 
-type ShiftTimeFilter string
+type EventType string
 
 const (
-	ShiftTimeFilterUpcoming ShiftTimeFilter = "UPCOMING"
-	ShiftTimeFilterPast     ShiftTimeFilter = "PAST"
-	ShiftTimeFilterAll      ShiftTimeFilter = "ALL"
+	EventTypeVirtual  EventType = "VIRTUAL"
+	EventTypeInPerson EventType = "IN_PERSON"
+	EventTypeHybrid   EventType = "HYBRID"
 )
 
-var AllShiftTimeFilter = []ShiftTimeFilter{
-	ShiftTimeFilterUpcoming,
-	ShiftTimeFilterPast,
-	ShiftTimeFilterAll,
+var AllEventType = []EventType{
+	EventTypeVirtual,
+	EventTypeInPerson,
+	EventTypeHybrid,
 }
 
-func (e ShiftTimeFilter) IsValid() bool {
+func (e EventType) IsValid() bool {
 	switch e {
-	case ShiftTimeFilterUpcoming, ShiftTimeFilterPast, ShiftTimeFilterAll:
+	case EventTypeVirtual, EventTypeInPerson, EventTypeHybrid:
 		return true
 	}
 	return false
 }
 
-func (e ShiftTimeFilter) String() string {
+func (e EventType) String() string {
 	return string(e)
 }
 
-func (e *ShiftTimeFilter) UnmarshalGQL(v any) error {
+func (e *EventType) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = ShiftTimeFilter(str)
+	*e = EventType(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid ShiftTimeFilter", str)
+		return fmt.Errorf("%s is not a valid EventType", str)
 	}
 	return nil
 }
 
-func (e ShiftTimeFilter) MarshalGQL(w io.Writer) {
+func (e EventType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-func (e *ShiftTimeFilter) UnmarshalJSON(b []byte) error {
+func (e *EventType) UnmarshalJSON(b []byte) error {
 	s, err := strconv.Unquote(string(b))
 	if err != nil {
 		return err
@@ -838,7 +1195,756 @@ func (e *ShiftTimeFilter) UnmarshalJSON(b []byte) error {
 	return e.UnmarshalGQL(s)
 }
 
-func (e ShiftTimeFilter) MarshalJSON() ([]byte, error) {
+func (e EventType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeedbackNoteType string
+
+const (
+	FeedbackNoteTypeAdminNote        FeedbackNoteType = "ADMIN_NOTE"
+	FeedbackNoteTypeQuestion         FeedbackNoteType = "QUESTION"
+	FeedbackNoteTypeVolunteerNote    FeedbackNoteType = "VOLUNTEER_NOTE"
+	FeedbackNoteTypeEmailToVolunteer FeedbackNoteType = "EMAIL_TO_VOLUNTEER"
+)
+
+var AllFeedbackNoteType = []FeedbackNoteType{
+	FeedbackNoteTypeAdminNote,
+	FeedbackNoteTypeQuestion,
+	FeedbackNoteTypeVolunteerNote,
+	FeedbackNoteTypeEmailToVolunteer,
+}
+
+func (e FeedbackNoteType) IsValid() bool {
+	switch e {
+	case FeedbackNoteTypeAdminNote, FeedbackNoteTypeQuestion, FeedbackNoteTypeVolunteerNote, FeedbackNoteTypeEmailToVolunteer:
+		return true
+	}
+	return false
+}
+
+func (e FeedbackNoteType) String() string {
+	return string(e)
+}
+
+func (e *FeedbackNoteType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedbackNoteType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedbackNoteType", str)
+	}
+	return nil
+}
+
+func (e FeedbackNoteType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeedbackNoteType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeedbackNoteType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeedbackSortField string
+
+const (
+	FeedbackSortFieldCreatedAt     FeedbackSortField = "CREATED_AT"
+	FeedbackSortFieldLastUpdatedAt FeedbackSortField = "LAST_UPDATED_AT"
+)
+
+var AllFeedbackSortField = []FeedbackSortField{
+	FeedbackSortFieldCreatedAt,
+	FeedbackSortFieldLastUpdatedAt,
+}
+
+func (e FeedbackSortField) IsValid() bool {
+	switch e {
+	case FeedbackSortFieldCreatedAt, FeedbackSortFieldLastUpdatedAt:
+		return true
+	}
+	return false
+}
+
+func (e FeedbackSortField) String() string {
+	return string(e)
+}
+
+func (e *FeedbackSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedbackSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedbackSortField", str)
+	}
+	return nil
+}
+
+func (e FeedbackSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeedbackSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeedbackSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeedbackStatus string
+
+const (
+	FeedbackStatusOpen                FeedbackStatus = "OPEN"
+	FeedbackStatusQuestionSent        FeedbackStatus = "QUESTION_SENT"
+	FeedbackStatusResolvedImplemented FeedbackStatus = "RESOLVED_IMPLEMENTED"
+	FeedbackStatusResolvedRejected    FeedbackStatus = "RESOLVED_REJECTED"
+)
+
+var AllFeedbackStatus = []FeedbackStatus{
+	FeedbackStatusOpen,
+	FeedbackStatusQuestionSent,
+	FeedbackStatusResolvedImplemented,
+	FeedbackStatusResolvedRejected,
+}
+
+func (e FeedbackStatus) IsValid() bool {
+	switch e {
+	case FeedbackStatusOpen, FeedbackStatusQuestionSent, FeedbackStatusResolvedImplemented, FeedbackStatusResolvedRejected:
+		return true
+	}
+	return false
+}
+
+func (e FeedbackStatus) String() string {
+	return string(e)
+}
+
+func (e *FeedbackStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedbackStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedbackStatus", str)
+	}
+	return nil
+}
+
+func (e FeedbackStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeedbackStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeedbackStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type FeedbackType string
+
+const (
+	FeedbackTypeBug         FeedbackType = "BUG"
+	FeedbackTypeEnhancement FeedbackType = "ENHANCEMENT"
+	FeedbackTypeGeneral     FeedbackType = "GENERAL"
+)
+
+var AllFeedbackType = []FeedbackType{
+	FeedbackTypeBug,
+	FeedbackTypeEnhancement,
+	FeedbackTypeGeneral,
+}
+
+func (e FeedbackType) IsValid() bool {
+	switch e {
+	case FeedbackTypeBug, FeedbackTypeEnhancement, FeedbackTypeGeneral:
+		return true
+	}
+	return false
+}
+
+func (e FeedbackType) String() string {
+	return string(e)
+}
+
+func (e *FeedbackType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FeedbackType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FeedbackType", str)
+	}
+	return nil
+}
+
+func (e FeedbackType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *FeedbackType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e FeedbackType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RecurrencePattern string
+
+const (
+	RecurrencePatternDaily    RecurrencePattern = "DAILY"
+	RecurrencePatternWeekly   RecurrencePattern = "WEEKLY"
+	RecurrencePatternBiweekly RecurrencePattern = "BIWEEKLY"
+	RecurrencePatternMonthly  RecurrencePattern = "MONTHLY"
+	RecurrencePatternYearly   RecurrencePattern = "YEARLY"
+)
+
+var AllRecurrencePattern = []RecurrencePattern{
+	RecurrencePatternDaily,
+	RecurrencePatternWeekly,
+	RecurrencePatternBiweekly,
+	RecurrencePatternMonthly,
+	RecurrencePatternYearly,
+}
+
+func (e RecurrencePattern) IsValid() bool {
+	switch e {
+	case RecurrencePatternDaily, RecurrencePatternWeekly, RecurrencePatternBiweekly, RecurrencePatternMonthly, RecurrencePatternYearly:
+		return true
+	}
+	return false
+}
+
+func (e RecurrencePattern) String() string {
+	return string(e)
+}
+
+func (e *RecurrencePattern) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RecurrencePattern(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RecurrencePattern", str)
+	}
+	return nil
+}
+
+func (e RecurrencePattern) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RecurrencePattern) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RecurrencePattern) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type RecurrenceUpdateScope string
+
+const (
+	RecurrenceUpdateScopeThisOnly      RecurrenceUpdateScope = "THIS_ONLY"
+	RecurrenceUpdateScopeThisAndFuture RecurrenceUpdateScope = "THIS_AND_FUTURE"
+)
+
+var AllRecurrenceUpdateScope = []RecurrenceUpdateScope{
+	RecurrenceUpdateScopeThisOnly,
+	RecurrenceUpdateScopeThisAndFuture,
+}
+
+func (e RecurrenceUpdateScope) IsValid() bool {
+	switch e {
+	case RecurrenceUpdateScopeThisOnly, RecurrenceUpdateScopeThisAndFuture:
+		return true
+	}
+	return false
+}
+
+func (e RecurrenceUpdateScope) String() string {
+	return string(e)
+}
+
+func (e *RecurrenceUpdateScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = RecurrenceUpdateScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid RecurrenceUpdateScope", str)
+	}
+	return nil
+}
+
+func (e RecurrenceUpdateScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *RecurrenceUpdateScope) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e RecurrenceUpdateScope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleVolunteer     Role = "VOLUNTEER"
+	RoleAdministrator Role = "ADMINISTRATOR"
+	RoleCoordinator   Role = "COORDINATOR"
+)
+
+var AllRole = []Role{
+	RoleVolunteer,
+	RoleAdministrator,
+	RoleCoordinator,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleVolunteer, RoleAdministrator, RoleCoordinator:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ShiftTimeFilter string
+
+const (
+	ShiftTimeFilterUpcoming ShiftTimeFilter = "UPCOMING"
+	ShiftTimeFilterPast     ShiftTimeFilter = "PAST"
+	ShiftTimeFilterAll      ShiftTimeFilter = "ALL"
+)
+
+var AllShiftTimeFilter = []ShiftTimeFilter{
+	ShiftTimeFilterUpcoming,
+	ShiftTimeFilterPast,
+	ShiftTimeFilterAll,
+}
+
+func (e ShiftTimeFilter) IsValid() bool {
+	switch e {
+	case ShiftTimeFilterUpcoming, ShiftTimeFilterPast, ShiftTimeFilterAll:
+		return true
+	}
+	return false
+}
+
+func (e ShiftTimeFilter) String() string {
+	return string(e)
+}
+
+func (e *ShiftTimeFilter) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ShiftTimeFilter(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ShiftTimeFilter", str)
+	}
+	return nil
+}
+
+func (e ShiftTimeFilter) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ShiftTimeFilter) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ShiftTimeFilter) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SortDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SortDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type VolunteerShiftSortField string
+
+const (
+	VolunteerShiftSortFieldStart      VolunteerShiftSortField = "START"
+	VolunteerShiftSortFieldAssignedAt VolunteerShiftSortField = "ASSIGNED_AT"
+)
+
+var AllVolunteerShiftSortField = []VolunteerShiftSortField{
+	VolunteerShiftSortFieldStart,
+	VolunteerShiftSortFieldAssignedAt,
+}
+
+func (e VolunteerShiftSortField) IsValid() bool {
+	switch e {
+	case VolunteerShiftSortFieldStart, VolunteerShiftSortFieldAssignedAt:
+		return true
+	}
+	return false
+}
+
+func (e VolunteerShiftSortField) String() string {
+	return string(e)
+}
+
+func (e *VolunteerShiftSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VolunteerShiftSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VolunteerShiftSortField", str)
+	}
+	return nil
+}
+
+func (e VolunteerShiftSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *VolunteerShiftSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e VolunteerShiftSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type VolunteerSortField string
+
+const (
+	VolunteerSortFieldLastName  VolunteerSortField = "LAST_NAME"
+	VolunteerSortFieldFirstName VolunteerSortField = "FIRST_NAME"
+	VolunteerSortFieldEmail     VolunteerSortField = "EMAIL"
+	VolunteerSortFieldCreatedAt VolunteerSortField = "CREATED_AT"
+)
+
+var AllVolunteerSortField = []VolunteerSortField{
+	VolunteerSortFieldLastName,
+	VolunteerSortFieldFirstName,
+	VolunteerSortFieldEmail,
+	VolunteerSortFieldCreatedAt,
+}
+
+func (e VolunteerSortField) IsValid() bool {
+	switch e {
+	case VolunteerSortFieldLastName, VolunteerSortFieldFirstName, VolunteerSortFieldEmail, VolunteerSortFieldCreatedAt:
+		return true
+	}
+	return false
+}
+
+func (e VolunteerSortField) String() string {
+	return string(e)
+}
+
+func (e *VolunteerSortField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VolunteerSortField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VolunteerSortField", str)
+	}
+	return nil
+}
+
+func (e VolunteerSortField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *VolunteerSortField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e VolunteerSortField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusDelivered,
+	WebhookDeliveryStatusFailed,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookDeliveryStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeShiftAssigned  WebhookEventType = "SHIFT_ASSIGNED"
+	WebhookEventTypeShiftCancelled WebhookEventType = "SHIFT_CANCELLED"
+	WebhookEventTypeEventCreated   WebhookEventType = "EVENT_CREATED"
+	WebhookEventTypeEventUpdated   WebhookEventType = "EVENT_UPDATED"
+	WebhookEventTypeEventDeleted   WebhookEventType = "EVENT_DELETED"
+	WebhookEventTypeEventCancelled WebhookEventType = "EVENT_CANCELLED"
+)
+
+var AllWebhookEventType = []WebhookEventType{
+	WebhookEventTypeShiftAssigned,
+	WebhookEventTypeShiftCancelled,
+	WebhookEventTypeEventCreated,
+	WebhookEventTypeEventUpdated,
+	WebhookEventTypeEventDeleted,
+	WebhookEventTypeEventCancelled,
+}
+
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventTypeShiftAssigned, WebhookEventTypeShiftCancelled, WebhookEventTypeEventCreated, WebhookEventTypeEventUpdated, WebhookEventTypeEventDeleted, WebhookEventTypeEventCancelled:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventType) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEventType", str)
+	}
+	return nil
+}
+
+func (e WebhookEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *WebhookEventType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e WebhookEventType) MarshalJSON() ([]byte, error) {
 	var buf bytes.Buffer
 	e.MarshalGQL(&buf)
 	return buf.Bytes(), nil
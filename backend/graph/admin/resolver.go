@@ -1,7 +1,10 @@
 package admin
 
 import (
+	"context"
 	"database/sql"
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
 	"volunteer-scheduler/services"
 )
 
@@ -15,4 +18,77 @@ type Resolver struct {
 	FeedbackService       *services.FeedbackService
 	StaffService          *services.StaffService
 	FundingEntityService  *services.FundingEntityService
+	SessionService        *services.SessionService
+	ScopeService          *services.ScopeService
+	ImpersonationService  *services.ImpersonationService
+	APITokenService       *services.APITokenService
+	AuditService          *services.AuditService
+	AccountRequestService *services.AccountRequestService
+	PrivacyService        *services.PrivacyService
+	WebhookService        *services.WebhookService
+	ShiftCapacityFeed     *services.ShiftCapacityFeed
+}
+
+// Coordinator scope
+//
+// AuthorizeRootField decides which roles may call a field at all; these
+// helpers decide which records a coordinator may touch through it.
+// Administrators pass every check.
+
+// fundingScope returns the funding entities the caller may manage.
+func (r *Resolver) fundingScope(ctx context.Context) (services.FundingScope, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return services.FundingScope{}, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+	roles, _ := middleware.VolunteerRolesFromContext(ctx)
+	return r.ScopeService.FundingScopeFor(ctx, volId, roles)
+}
+
+func (r *Resolver) checkFundingEntity(ctx context.Context, fundingEntityId int) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckFundingEntity(scope, fundingEntityId)
+}
+
+func (r *Resolver) checkEvent(ctx context.Context, eventId string) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckEvent(ctx, scope, eventId)
+}
+
+func (r *Resolver) checkEventDate(ctx context.Context, eventDateId string) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckEventDate(ctx, scope, eventDateId)
+}
+
+func (r *Resolver) checkOpportunity(ctx context.Context, oppId string) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckOpportunity(ctx, scope, oppId)
+}
+
+func (r *Resolver) checkShift(ctx context.Context, shiftId string) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckShift(ctx, scope, shiftId)
+}
+
+func (r *Resolver) checkVolunteer(ctx context.Context, volunteerId string) error {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return err
+	}
+	return r.ScopeService.CheckVolunteer(ctx, scope, volunteerId)
 }
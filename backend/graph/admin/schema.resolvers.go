@@ -7,10 +7,10 @@ package admin
 
 import (
 	"context"
-	"fmt"
 	"strconv"
 	"volunteer-scheduler/graph/admin/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
 )
 
 // CreateFundingEntity is the resolver for the createFundingEntity field.
@@ -72,6 +72,10 @@ func (r *mutationResolver) UpdateJobType(ctx context.Context, job generated.Upda
 
 // CreateEvent is the resolver for the createEvent field.
 func (r *mutationResolver) CreateEvent(ctx context.Context, newEvent generated.NewEventInput) (*generated.MutationResult, error) {
+	if err := r.checkFundingEntity(ctx, newEvent.FundingEntityID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.CreateEvent(ctx, toModelNewEventInput(newEvent))
 	if err != nil {
 		return nil, err
@@ -81,6 +85,10 @@ func (r *mutationResolver) CreateEvent(ctx context.Context, newEvent generated.N
 
 // CreateEventDate is the resolver for the createEventDate field.
 func (r *mutationResolver) CreateEventDate(ctx context.Context, newDate generated.AddEventDateInput) (*generated.MutationResult, error) {
+	if err := r.checkEvent(ctx, newDate.EventID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.CreateEventDate(ctx, toModelAddEventDate(newDate))
 	if err != nil {
 		return nil, err
@@ -90,6 +98,10 @@ func (r *mutationResolver) CreateEventDate(ctx context.Context, newDate generate
 
 // CreateOpportunity is the resolver for the createOpportunity field.
 func (r *mutationResolver) CreateOpportunity(ctx context.Context, newOpp generated.NewOpportunityInput) (*generated.MutationResult, error) {
+	if err := r.checkEvent(ctx, newOpp.EventID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.CreateOpportunity(ctx, toModelNewOpportunity(newOpp))
 	if err != nil {
 		return nil, err
@@ -99,6 +111,10 @@ func (r *mutationResolver) CreateOpportunity(ctx context.Context, newOpp generat
 
 // CreateShift is the resolver for the createShift field.
 func (r *mutationResolver) CreateShift(ctx context.Context, newShift generated.AddShiftInput) (*generated.MutationResult, error) {
+	if err := r.checkOpportunity(ctx, newShift.OpportunityID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.CreateShift(ctx, toModelAddShiftInput(newShift))
 	if err != nil {
 		return nil, err
@@ -108,6 +124,10 @@ func (r *mutationResolver) CreateShift(ctx context.Context, newShift generated.A
 
 // DeleteEvent is the resolver for the deleteEvent field.
 func (r *mutationResolver) DeleteEvent(ctx context.Context, eventID string, scope *generated.RecurrenceUpdateScope) (*generated.MutationResult, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.DeleteEvent(ctx, eventID, toModelScope(scope))
 	if err != nil {
 		return nil, err
@@ -117,6 +137,10 @@ func (r *mutationResolver) DeleteEvent(ctx context.Context, eventID string, scop
 
 // DeleteEventDate is the resolver for the deleteEventDate field.
 func (r *mutationResolver) DeleteEventDate(ctx context.Context, eventDateID string) (*generated.MutationResult, error) {
+	if err := r.checkEventDate(ctx, eventDateID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.DeleteEventDate(ctx, eventDateID)
 	if err != nil {
 		return nil, err
@@ -126,6 +150,10 @@ func (r *mutationResolver) DeleteEventDate(ctx context.Context, eventDateID stri
 
 // DeleteOpportunity is the resolver for the deleteOpportunity field.
 func (r *mutationResolver) DeleteOpportunity(ctx context.Context, oppID string) (*generated.MutationResult, error) {
+	if err := r.checkOpportunity(ctx, oppID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.DeleteOpportunity(ctx, oppID)
 	if err != nil {
 		return nil, err
@@ -135,6 +163,10 @@ func (r *mutationResolver) DeleteOpportunity(ctx context.Context, oppID string)
 
 // DeleteShift is the resolver for the deleteShift field.
 func (r *mutationResolver) DeleteShift(ctx context.Context, shiftID string) (*generated.MutationResult, error) {
+	if err := r.checkShift(ctx, shiftID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.DeleteShift(ctx, shiftID)
 	if err != nil {
 		return nil, err
@@ -144,6 +176,14 @@ func (r *mutationResolver) DeleteShift(ctx context.Context, shiftID string) (*ge
 
 // UpdateEvent is the resolver for the updateEvent field.
 func (r *mutationResolver) UpdateEvent(ctx context.Context, event generated.UpdateEventInput) (*generated.MutationResult, error) {
+	// Both the event and the funding entity it moves to must be in scope.
+	if err := r.checkEvent(ctx, event.ID); err != nil {
+		return nil, err
+	}
+	if err := r.checkFundingEntity(ctx, event.FundingEntityID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.UpdateEvent(ctx, toModelUpdateEventInput(event))
 	if err != nil {
 		return nil, err
@@ -153,6 +193,10 @@ func (r *mutationResolver) UpdateEvent(ctx context.Context, event generated.Upda
 
 // UpdateEventDate is the resolver for the updateEventDate field.
 func (r *mutationResolver) UpdateEventDate(ctx context.Context, date generated.UpdateEventDateInput) (*generated.MutationResult, error) {
+	if err := r.checkEventDate(ctx, date.ID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.EventService.UpdateEventDate(ctx, toModelUpdateEventDateInput(date))
 	if err != nil {
 		return nil, err
@@ -162,6 +206,10 @@ func (r *mutationResolver) UpdateEventDate(ctx context.Context, date generated.U
 
 // UpdateOpportunity is the resolver for the updateOpportunity field.
 func (r *mutationResolver) UpdateOpportunity(ctx context.Context, opp generated.UpdateOpportunityInput) (*generated.MutationResult, error) {
+	if err := r.checkOpportunity(ctx, opp.ID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.UpdateOpportunity(ctx, toModelUpdateOpportunity(opp))
 	if err != nil {
 		return nil, err
@@ -171,6 +219,10 @@ func (r *mutationResolver) UpdateOpportunity(ctx context.Context, opp generated.
 
 // UpdateShift is the resolver for the updateShift field.
 func (r *mutationResolver) UpdateShift(ctx context.Context, shift generated.UpdateShiftInput) (*generated.MutationResult, error) {
+	if err := r.checkShift(ctx, shift.ID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.UpdateShift(ctx, toModelUpdateShift(shift))
 	if err != nil {
 		return nil, err
@@ -178,11 +230,37 @@ func (r *mutationResolver) UpdateShift(ctx context.Context, shift generated.Upda
 	return toGenMutationResult(result), nil
 }
 
+// PublishEvent is the resolver for the publishEvent field.
+func (r *mutationResolver) PublishEvent(ctx context.Context, eventID string, publishAt *string, scope *generated.RecurrenceUpdateScope) (*generated.MutationResult, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	result, err := r.EventService.PublishEvent(ctx, eventID, publishAt, toModelScope(scope))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// CancelEvent is the resolver for the cancelEvent field.
+func (r *mutationResolver) CancelEvent(ctx context.Context, eventID string, scope *generated.RecurrenceUpdateScope) (*generated.MutationResult, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	result, err := r.EventService.CancelEvent(ctx, eventID, toModelScope(scope))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
 // UpdateFeedbackStatus is the resolver for the updateFeedbackStatus field.
 func (r *mutationResolver) UpdateFeedbackStatus(ctx context.Context, su generated.FeedbackStatusUpdateInput) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.FeedbackService.UpdateFeedbackStatus(ctx, volId, toModelFeedbackStatusUpdateInput(su))
@@ -197,7 +275,7 @@ func (r *mutationResolver) UpdateFeedbackStatus(ctx context.Context, su generate
 func (r *mutationResolver) AddFeedbackNote(ctx context.Context, note generated.FeedbackNoteInput) (*generated.MutationResult, error) {
 	adminId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.FeedbackService.AddFeedbackNote(ctx, adminId, toModelFeedbackNoteInput(note))
@@ -211,7 +289,7 @@ func (r *mutationResolver) AddFeedbackNote(ctx context.Context, note generated.F
 func (r *mutationResolver) EmailFeedbackSubmitter(ctx context.Context, input generated.FeedbackEmailInput) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.FeedbackService.EmailFeedbackSubmitter(ctx, volId, toModelFeedbackEmailInput(input))
@@ -280,7 +358,15 @@ func (r *mutationResolver) UpdateVenue(ctx context.Context, venue generated.Upda
 func (r *mutationResolver) CreateVolunteer(ctx context.Context, newVol generated.NewVolunteerInput) (*generated.MutationResult, error) {
 	creatorId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	// A VOLUNTEER_SIGNUP token stands in for an intake form, not for its
+	// owner: it may only create plain volunteers, whoever issued it.
+	if _, viaAPIToken := middleware.APITokenScopeFromContext(ctx); viaAPIToken {
+		if newVol.Role != generated.RoleVolunteer || len(newVol.FundingEntityIds) > 0 {
+			return nil, models.NewError(models.ErrorCodeForbidden, "forbidden: API tokens may only create volunteers")
+		}
 	}
 
 	result, err := r.VolunteerService.CreateVolunteer(ctx, creatorId, toModelNewVolunteerInput(newVol))
@@ -308,8 +394,224 @@ func (r *mutationResolver) UpdateVolunteer(ctx context.Context, profile generate
 	return toGenMutationResult(result), nil
 }
 
+// ClearEmailSuppression is the resolver for the clearEmailSuppression field.
+func (r *mutationResolver) ClearEmailSuppression(ctx context.Context, volunteerID string) (*generated.MutationResult, error) {
+	result, err := r.VolunteerService.ClearEmailSuppression(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// RevokeAllSessions is the resolver for the revokeAllSessions field.
+func (r *mutationResolver) RevokeAllSessions(ctx context.Context, volunteerID string) (*generated.MutationResult, error) {
+	result, err := r.SessionService.RevokeAllSessions(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// CreateVolunteerSegment is the resolver for the createVolunteerSegment field.
+func (r *mutationResolver) CreateVolunteerSegment(ctx context.Context, input generated.NewVolunteerSegmentInput) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.VolunteerService.CreateVolunteerSegment(ctx, adminId, toModelNewVolunteerSegmentInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// UpdateVolunteerSegment is the resolver for the updateVolunteerSegment field.
+func (r *mutationResolver) UpdateVolunteerSegment(ctx context.Context, input generated.UpdateVolunteerSegmentInput) (*generated.MutationResult, error) {
+	result, err := r.VolunteerService.UpdateVolunteerSegment(ctx, toModelUpdateVolunteerSegmentInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// DeleteVolunteerSegment is the resolver for the deleteVolunteerSegment field.
+func (r *mutationResolver) DeleteVolunteerSegment(ctx context.Context, segmentID string) (*generated.MutationResult, error) {
+	result, err := r.VolunteerService.DeleteVolunteerSegment(ctx, segmentID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// StartImpersonation is the resolver for the startImpersonation field.
+func (r *mutationResolver) StartImpersonation(ctx context.Context, input generated.StartImpersonationInput) (*generated.ImpersonationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.ImpersonationService.StartImpersonation(ctx, adminId, toModelStartImpersonationInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenImpersonationResult(result), nil
+}
+
+// EndImpersonation is the resolver for the endImpersonation field.
+func (r *mutationResolver) EndImpersonation(ctx context.Context, impersonationID string) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.ImpersonationService.EndImpersonation(ctx, adminId, impersonationID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// CreateAPIToken is the resolver for the createApiToken field.
+func (r *mutationResolver) CreateAPIToken(ctx context.Context, input generated.NewAPITokenInput) (*generated.APITokenResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.APITokenService.CreateAPIToken(ctx, adminId, toModelNewAPITokenInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenAPITokenResult(result), nil
+}
+
+// RevokeAPIToken is the resolver for the revokeApiToken field.
+func (r *mutationResolver) RevokeAPIToken(ctx context.Context, tokenID string) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.APITokenService.RevokeAPIToken(ctx, adminId, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// CreateWebhookSubscription is the resolver for the createWebhookSubscription field.
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, input generated.NewWebhookSubscriptionInput) (*generated.WebhookSubscriptionResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.WebhookService.CreateWebhookSubscription(ctx, adminId, toModelNewWebhookSubscriptionInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenWebhookSubscriptionResult(result), nil
+}
+
+// UpdateWebhookSubscription is the resolver for the updateWebhookSubscription field.
+func (r *mutationResolver) UpdateWebhookSubscription(ctx context.Context, input generated.UpdateWebhookSubscriptionInput) (*generated.MutationResult, error) {
+	result, err := r.WebhookService.UpdateWebhookSubscription(ctx, toModelUpdateWebhookSubscriptionInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// DeleteWebhookSubscription is the resolver for the deleteWebhookSubscription field.
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, subscriptionID string) (*generated.MutationResult, error) {
+	result, err := r.WebhookService.DeleteWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// RotateWebhookSecret is the resolver for the rotateWebhookSecret field.
+func (r *mutationResolver) RotateWebhookSecret(ctx context.Context, subscriptionID string) (*generated.WebhookSubscriptionResult, error) {
+	result, err := r.WebhookService.RotateWebhookSecret(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenWebhookSubscriptionResult(result), nil
+}
+
+// RetryWebhookDelivery is the resolver for the retryWebhookDelivery field.
+func (r *mutationResolver) RetryWebhookDelivery(ctx context.Context, deliveryID string) (*generated.MutationResult, error) {
+	result, err := r.WebhookService.RetryWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// ApproveAccountRequest is the resolver for the approveAccountRequest field.
+func (r *mutationResolver) ApproveAccountRequest(ctx context.Context, requestID string, input *generated.ApproveAccountRequestInput) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.AccountRequestService.ApproveAccountRequest(ctx, adminId, requestID, toModelApproveAccountRequestInput(input))
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// DenyAccountRequest is the resolver for the denyAccountRequest field.
+func (r *mutationResolver) DenyAccountRequest(ctx context.Context, requestID string, message *string) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.AccountRequestService.DenyAccountRequest(ctx, adminId, requestID, message)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// ApproveErasureRequest is the resolver for the approveErasureRequest field.
+func (r *mutationResolver) ApproveErasureRequest(ctx context.Context, requestID string) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.PrivacyService.ApproveErasureRequest(ctx, adminId, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
+// DenyErasureRequest is the resolver for the denyErasureRequest field.
+func (r *mutationResolver) DenyErasureRequest(ctx context.Context, requestID string, message *string) (*generated.MutationResult, error) {
+	adminId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.PrivacyService.DenyErasureRequest(ctx, adminId, requestID, message)
+	if err != nil {
+		return nil, err
+	}
+	return toGenMutationResult(result), nil
+}
+
 // AssignVolunteerToShift is the resolver for the assignVolunteerToShift field.
 func (r *mutationResolver) AssignVolunteerToShift(ctx context.Context, shiftID string, volunteerID string) (*generated.MutationResult, error) {
+	if err := r.checkShift(ctx, shiftID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.AssignVolunteerToShift(ctx, shiftID, volunteerID)
 	if err != nil {
 		return nil, err
@@ -319,6 +621,10 @@ func (r *mutationResolver) AssignVolunteerToShift(ctx context.Context, shiftID s
 
 // CancelShift is the resolver for the cancelShift field.
 func (r *mutationResolver) CancelShift(ctx context.Context, shiftID string, volunteerID string) (*generated.MutationResult, error) {
+	if err := r.checkShift(ctx, shiftID); err != nil {
+		return nil, err
+	}
+
 	result, err := r.ShiftService.CancelShiftAssignment(ctx, shiftID, volunteerID)
 	if err != nil {
 		return nil, err
@@ -328,15 +634,36 @@ func (r *mutationResolver) CancelShift(ctx context.Context, shiftID string, volu
 
 // Events is the resolver for the filteredEvents field.
 func (r *queryResolver) Events(ctx context.Context, filter *generated.EventFilterInput) ([]*generated.Event, error) {
-	events, err := r.EventService.FetchEvents(ctx, toModelEventFilterInput(filter))
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.EventService.FetchEvents(ctx, toModelEventFilterInput(filter), scope)
 	if err != nil {
 		return nil, err
 	}
 	return toGenEvents(events), nil
 }
 
+// EventsConnection is the resolver for the eventsConnection field.
+func (r *queryResolver) EventsConnection(ctx context.Context, filter *generated.EventFilterInput, sort *generated.EventSort, first *int, after *string) (*generated.EventConnection, error) {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.EventService.FetchEventsPage(ctx, toModelEventFilterInput(filter), scope, toModelEventSort(sort), toModelPageInput(first, after))
+	if err != nil {
+		return nil, err
+	}
+	return toGenEventConnection(conn), nil
+}
+
 // Event is the resolver for the EventById field.
 func (r *queryResolver) Event(ctx context.Context, eventID string) (*generated.Event, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
 	e, err := r.EventService.FetchEvent(ctx, eventID)
 	if err != nil {
 		return nil, err
@@ -346,15 +673,29 @@ func (r *queryResolver) Event(ctx context.Context, eventID string) (*generated.E
 
 // FundingEntities is the resolver for the fundingEntities field.
 func (r *queryResolver) FundingEntities(ctx context.Context) ([]*generated.FundingEntity, error) {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
 	fes, err := r.EventService.FetchFundingEntities(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return toGenFundingEntities(fes), nil
+	inScope := fes[:0]
+	for _, fe := range fes {
+		if scope.Allows(fe.ID) {
+			inScope = append(inScope, fe)
+		}
+	}
+	return toGenFundingEntities(inScope), nil
 }
 
 // OpportunitiesForEvent is the resolver for the opportunitiesForEvent field.
 func (r *queryResolver) OpportunitiesForEvent(ctx context.Context, eventID string) ([]*generated.Opportunity, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
 	opps, err := r.ShiftService.FetchOpportunitiesForEvent(ctx, eventID)
 	if err != nil {
 		return nil, err
@@ -371,6 +712,15 @@ func (r *queryResolver) Feedback(ctx context.Context, filter *generated.Feedback
 	return toGenFeedbacks(fbs), nil
 }
 
+// FeedbackConnection is the resolver for the feedbackConnection field.
+func (r *queryResolver) FeedbackConnection(ctx context.Context, filter *generated.FeedbackFilterInput, sort *generated.FeedbackSort, first *int, after *string) (*generated.FeedbackConnection, error) {
+	conn, err := r.FeedbackService.FetchFeedbackPage(ctx, toModelFeedbackFilterInput(filter), toModelFeedbackSort(sort), toModelPageInput(first, after))
+	if err != nil {
+		return nil, err
+	}
+	return toGenFeedbackConnection(conn), nil
+}
+
 // FeedbackDetail is the resolver for the feedbackDetail field.
 func (r *queryResolver) FeedbackDetail(ctx context.Context, feedbackID string) (*generated.Feedback, error) {
 	fb, err := r.FeedbackService.FetchFeedbackDetail(ctx, feedbackID)
@@ -409,15 +759,36 @@ func (r *queryResolver) Venues(ctx context.Context) ([]*generated.Venue, error)
 
 // AllVolunteers is the resolver for the allVolunteers field.
 func (r *queryResolver) Volunteers(ctx context.Context, filter *generated.VolunteerFilterInput) ([]*generated.Volunteer, error) {
-	vols, err := r.VolunteerService.FetchVolunteers(ctx, toModelVolunteeFilterInput(filter))
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vols, err := r.VolunteerService.FetchVolunteers(ctx, toModelVolunteeFilterInput(filter), scope)
 	if err != nil {
 		return nil, err
 	}
 	return toGenVolunteers(vols), nil
 }
 
+// VolunteersConnection is the resolver for the volunteersConnection field.
+func (r *queryResolver) VolunteersConnection(ctx context.Context, filter *generated.VolunteerFilterInput, sort *generated.VolunteerSort, first *int, after *string) (*generated.VolunteerConnection, error) {
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.VolunteerService.FetchVolunteersPage(ctx, toModelVolunteeFilterInput(filter), scope, toModelVolunteerSort(sort), toModelPageInput(first, after))
+	if err != nil {
+		return nil, err
+	}
+	return toGenVolunteerConnection(conn), nil
+}
+
 // Volunteer is the resolver for the volunteer field.
 func (r *queryResolver) Volunteer(ctx context.Context, volID int) (*generated.Volunteer, error) {
+	if err := r.checkVolunteer(ctx, strconv.Itoa(volID)); err != nil {
+		return nil, err
+	}
+
 	vol, err := r.VolunteerService.FetchVolunteer(ctx, volID)
 	if err != nil {
 		return nil, err
@@ -427,9 +798,137 @@ func (r *queryResolver) Volunteer(ctx context.Context, volID int) (*generated.Vo
 
 // VolunteerShifts is the resolver for the volunteerShifts field.
 func (r *queryResolver) VolunteerShifts(ctx context.Context, volunteerID string, filter generated.ShiftTimeFilter) ([]*generated.VolunteerShift, error) {
-	shifts, err := r.VolunteerService.FetchVolunteerShifts(ctx, volunteerID, toModelShiftTimeFilter(filter))
+	if err := r.checkVolunteer(ctx, volunteerID); err != nil {
+		return nil, err
+	}
+
+	// Coordinators see only the shifts this volunteer has in their regions.
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := r.VolunteerService.FetchVolunteerShifts(ctx, volunteerID, toModelShiftTimeFilter(filter), scope)
 	if err != nil {
 		return nil, err
 	}
 	return toGenVolunteerShifts(shifts), nil
 }
+
+// VolunteerShiftsConnection is the resolver for the volunteerShiftsConnection field.
+func (r *queryResolver) VolunteerShiftsConnection(ctx context.Context, volunteerID string, filter generated.ShiftTimeFilter, sort *generated.VolunteerShiftSort, first *int, after *string) (*generated.VolunteerShiftConnection, error) {
+	if err := r.checkVolunteer(ctx, volunteerID); err != nil {
+		return nil, err
+	}
+	scope, err := r.fundingScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := r.VolunteerService.FetchVolunteerShiftsPage(ctx, volunteerID, toModelShiftTimeFilter(filter), scope, toModelVolunteerShiftSort(sort), toModelPageInput(first, after))
+	if err != nil {
+		return nil, err
+	}
+	return toGenVolunteerShiftConnection(conn), nil
+}
+
+// AssignableVolunteers is the resolver for the assignableVolunteers field.
+func (r *queryResolver) AssignableVolunteers(ctx context.Context, search *string) ([]*generated.AssignableVolunteer, error) {
+	var term string
+	if search != nil {
+		term = *search
+	}
+	vols, err := r.VolunteerService.FetchAssignableVolunteers(ctx, term)
+	if err != nil {
+		return nil, err
+	}
+	return toGenAssignableVolunteers(vols), nil
+}
+
+// VolunteerSegments is the resolver for the volunteerSegments field.
+func (r *queryResolver) VolunteerSegments(ctx context.Context) ([]*generated.VolunteerSegment, error) {
+	segments, err := r.VolunteerService.FetchVolunteerSegments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toGenVolunteerSegments(segments), nil
+}
+
+// Impersonations is the resolver for the impersonations field.
+func (r *queryResolver) Impersonations(ctx context.Context, volunteerID *string) ([]*generated.Impersonation, error) {
+	imps, err := r.ImpersonationService.FetchImpersonations(ctx, volunteerID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenImpersonations(imps), nil
+}
+
+// APITokens is the resolver for the apiTokens field.
+func (r *queryResolver) APITokens(ctx context.Context) ([]*generated.APIToken, error) {
+	tokens, err := r.APITokenService.FetchAPITokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toGenAPITokens(tokens), nil
+}
+
+// WebhookSubscriptions is the resolver for the webhookSubscriptions field.
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context) ([]*generated.WebhookSubscription, error) {
+	subs, err := r.WebhookService.FetchWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return toGenWebhookSubscriptions(subs), nil
+}
+
+// WebhookDeliveries is the resolver for the webhookDeliveries field.
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, filter *generated.WebhookDeliveryFilterInput, first *int, after *string) (*generated.WebhookDeliveryConnection, error) {
+	conn, err := r.WebhookService.FetchWebhookDeliveriesPage(ctx, toModelWebhookDeliveryFilterInput(filter), toModelPageInput(first, after))
+	if err != nil {
+		return nil, err
+	}
+	return toGenWebhookDeliveryConnection(conn), nil
+}
+
+// AuditLog is the resolver for the auditLog field.
+func (r *queryResolver) AuditLog(ctx context.Context, filter *generated.AuditLogFilterInput) ([]*generated.AuditEntry, error) {
+	entries, err := r.AuditService.FetchAuditLog(ctx, toModelAuditLogFilter(filter))
+	if err != nil {
+		return nil, err
+	}
+	return toGenAuditEntries(entries), nil
+}
+
+// AccountRequests is the resolver for the accountRequests field.
+func (r *queryResolver) AccountRequests(ctx context.Context, status *generated.AccountRequestStatus) ([]*generated.AccountRequest, error) {
+	requests, err := r.AccountRequestService.FetchAccountRequests(ctx, toModelAccountRequestStatus(status))
+	if err != nil {
+		return nil, err
+	}
+	return toGenAccountRequests(requests), nil
+}
+
+// ErasureRequests is the resolver for the erasureRequests field.
+func (r *queryResolver) ErasureRequests(ctx context.Context, status *generated.ErasureRequestStatus) ([]*generated.ErasureRequest, error) {
+	requests, err := r.PrivacyService.FetchErasureRequests(ctx, toModelErasureRequestStatus(status))
+	if err != nil {
+		return nil, err
+	}
+	return toGenErasureRequests(requests), nil
+}
+
+// ShiftCapacityChanged is the resolver for the shiftCapacityChanged field.
+func (r *subscriptionResolver) ShiftCapacityChanged(ctx context.Context, eventID string) (<-chan *generated.ShiftCapacity, error) {
+	if err := r.checkEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	changes, err := r.ShiftCapacityFeed.SubscribeToEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenShiftCapacityStream(ctx, changes), nil
+}
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
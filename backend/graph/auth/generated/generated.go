@@ -46,9 +46,11 @@ type DirectiveRoot struct {
 
 type ComplexityRoot struct {
 	AuthResult struct {
-		Email   func(childComplexity int) int
-		Message func(childComplexity int) int
-		Success func(childComplexity int) int
+		Code                 func(childComplexity int) int
+		ConfirmationRequired func(childComplexity int) int
+		Email                func(childComplexity int) int
+		Message              func(childComplexity int) int
+		Success              func(childComplexity int) int
 	}
 
 	LogoutResult struct {
@@ -56,16 +58,19 @@ type ComplexityRoot struct {
 	}
 
 	MagicLinkResult struct {
+		Code    func(childComplexity int) int
 		Email   func(childComplexity int) int
 		Message func(childComplexity int) int
 		Success func(childComplexity int) int
 	}
 
 	Mutation struct {
-		ConsumeMagicLink func(childComplexity int, token string) int
-		Logout           func(childComplexity int) int
-		RequestAccount   func(childComplexity int, email string, firstName string, lastName string) int
-		RequestMagicLink func(childComplexity int, email string) int
+		ConsumeMagicLink     func(childComplexity int, token string, confirmDevice *bool, rememberDevice *bool) int
+		ConsumeSignInCode    func(childComplexity int, email string, code string, rememberDevice *bool) int
+		Logout               func(childComplexity int) int
+		RequestAccount       func(childComplexity int, email string, firstName string, lastName string) int
+		RequestMagicLink     func(childComplexity int, email string) int
+		VerifyAccountRequest func(childComplexity int, token string) int
 	}
 
 	Query struct {
@@ -73,6 +78,7 @@ type ComplexityRoot struct {
 	}
 
 	RequestResult struct {
+		Code    func(childComplexity int) int
 		Message func(childComplexity int) int
 		Success func(childComplexity int) int
 	}
@@ -80,8 +86,10 @@ type ComplexityRoot struct {
 
 type MutationResolver interface {
 	RequestMagicLink(ctx context.Context, email string) (*MagicLinkResult, error)
-	ConsumeMagicLink(ctx context.Context, token string) (*AuthResult, error)
+	ConsumeMagicLink(ctx context.Context, token string, confirmDevice *bool, rememberDevice *bool) (*AuthResult, error)
+	ConsumeSignInCode(ctx context.Context, email string, code string, rememberDevice *bool) (*AuthResult, error)
 	RequestAccount(ctx context.Context, email string, firstName string, lastName string) (*RequestResult, error)
+	VerifyAccountRequest(ctx context.Context, token string) (*RequestResult, error)
 	Logout(ctx context.Context) (*LogoutResult, error)
 }
 type QueryResolver interface {
@@ -107,6 +115,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 	_ = ec
 	switch typeName + "." + field {
 
+	case "AuthResult.code":
+		if e.complexity.AuthResult.Code == nil {
+			break
+		}
+
+		return e.complexity.AuthResult.Code(childComplexity), true
+	case "AuthResult.confirmationRequired":
+		if e.complexity.AuthResult.ConfirmationRequired == nil {
+			break
+		}
+
+		return e.complexity.AuthResult.ConfirmationRequired(childComplexity), true
 	case "AuthResult.email":
 		if e.complexity.AuthResult.Email == nil {
 			break
@@ -133,6 +153,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.LogoutResult.Success(childComplexity), true
 
+	case "MagicLinkResult.code":
+		if e.complexity.MagicLinkResult.Code == nil {
+			break
+		}
+
+		return e.complexity.MagicLinkResult.Code(childComplexity), true
 	case "MagicLinkResult.email":
 		if e.complexity.MagicLinkResult.Email == nil {
 			break
@@ -162,7 +188,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 			return 0, false
 		}
 
-		return e.complexity.Mutation.ConsumeMagicLink(childComplexity, args["token"].(string)), true
+		return e.complexity.Mutation.ConsumeMagicLink(childComplexity, args["token"].(string), args["confirmDevice"].(*bool), args["rememberDevice"].(*bool)), true
+	case "Mutation.consumeSignInCode":
+		if e.complexity.Mutation.ConsumeSignInCode == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_consumeSignInCode_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ConsumeSignInCode(childComplexity, args["email"].(string), args["code"].(string), args["rememberDevice"].(*bool)), true
 	case "Mutation.logout":
 		if e.complexity.Mutation.Logout == nil {
 			break
@@ -191,6 +228,17 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.RequestMagicLink(childComplexity, args["email"].(string)), true
+	case "Mutation.verifyAccountRequest":
+		if e.complexity.Mutation.VerifyAccountRequest == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_verifyAccountRequest_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.VerifyAccountRequest(childComplexity, args["token"].(string)), true
 
 	case "Query._authHealthCheck":
 		if e.complexity.Query.AuthHealthCheck == nil {
@@ -199,6 +247,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Query.AuthHealthCheck(childComplexity), true
 
+	case "RequestResult.code":
+		if e.complexity.RequestResult.Code == nil {
+			break
+		}
+
+		return e.complexity.RequestResult.Code(childComplexity), true
 	case "RequestResult.message":
 		if e.complexity.RequestResult.Message == nil {
 			break
@@ -325,26 +379,54 @@ type Query {
 
 type Mutation {
   requestMagicLink(email: String!): MagicLinkResult!
-  consumeMagicLink(token: String!): AuthResult!
+  # confirmDevice acknowledges opening the link in a browser other than the
+  # one that requested it; see AuthResult.confirmationRequired.
+  # rememberDevice asks for a longer-lived session (not for admins).
+  consumeMagicLink(token: String!, confirmDevice: Boolean = false, rememberDevice: Boolean = false): AuthResult!
+  # Alternative to the link for when it opens in a different browser: the
+  # 6-digit code from the same email.
+  consumeSignInCode(email: String!, code: String!, rememberDevice: Boolean = false): AuthResult!
+  # Queues a request for an account and emails the requester a link to confirm
+  # their address; admins see the request once it is confirmed.
   requestAccount(email: String!, firstName: String!, lastName: String!): RequestResult!
+  verifyAccountRequest(token: String!): RequestResult!
   logout: LogoutResult!
 }
 
+# Why a request failed; the same values as on the other endpoints.
+enum ErrorCode {
+  NOT_FOUND
+  VALIDATION
+  CONFLICT
+  CAPACITY_FULL
+  FORBIDDEN
+  UNAUTHENTICATED
+  RATE_LIMITED
+  READ_ONLY
+  INTERNAL
+}
+
 type MagicLinkResult {
   success: Boolean!
   message: String!
   email: String
+  code: ErrorCode
 }
 
 type AuthResult {
   success: Boolean!
   message: String!
   email: String
+  # True when the link was opened on a different browser than the one that
+  # requested it. Ask the volunteer to confirm, then retry with confirmDevice.
+  confirmationRequired: Boolean!
+  code: ErrorCode
 }
 
 type RequestResult {
   success: Boolean!
   message: String!
+  code: ErrorCode
 }
 
 type LogoutResult {
@@ -366,6 +448,37 @@ func (ec *executionContext) field_Mutation_consumeMagicLink_args(ctx context.Con
 		return nil, err
 	}
 	args["token"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "confirmDevice", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["confirmDevice"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "rememberDevice", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["rememberDevice"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_consumeSignInCode_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "email", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["email"] = arg0
+	arg1, err := graphql.ProcessArgField(ctx, rawArgs, "code", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["code"] = arg1
+	arg2, err := graphql.ProcessArgField(ctx, rawArgs, "rememberDevice", ec.unmarshalOBoolean2ᚖbool)
+	if err != nil {
+		return nil, err
+	}
+	args["rememberDevice"] = arg2
 	return args, nil
 }
 
@@ -401,6 +514,17 @@ func (ec *executionContext) field_Mutation_requestMagicLink_args(ctx context.Con
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_verifyAccountRequest_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "token", ec.unmarshalNString2string)
+	if err != nil {
+		return nil, err
+	}
+	args["token"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -551,6 +675,64 @@ func (ec *executionContext) fieldContext_AuthResult_email(_ context.Context, fie
 	return fc, nil
 }
 
+func (ec *executionContext) _AuthResult_confirmationRequired(ctx context.Context, field graphql.CollectedField, obj *AuthResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_AuthResult_confirmationRequired,
+		func(ctx context.Context) (any, error) {
+			return obj.ConfirmationRequired, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_AuthResult_confirmationRequired(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuthResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuthResult_code(ctx context.Context, field graphql.CollectedField, obj *AuthResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_AuthResult_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐErrorCode,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_AuthResult_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuthResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErrorCode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _LogoutResult_success(ctx context.Context, field graphql.CollectedField, obj *LogoutResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -667,6 +849,35 @@ func (ec *executionContext) fieldContext_MagicLinkResult_email(_ context.Context
 	return fc, nil
 }
 
+func (ec *executionContext) _MagicLinkResult_code(ctx context.Context, field graphql.CollectedField, obj *MagicLinkResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MagicLinkResult_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐErrorCode,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_MagicLinkResult_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MagicLinkResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErrorCode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_requestMagicLink(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -698,6 +909,8 @@ func (ec *executionContext) fieldContext_Mutation_requestMagicLink(ctx context.C
 				return ec.fieldContext_MagicLinkResult_message(ctx, field)
 			case "email":
 				return ec.fieldContext_MagicLinkResult_email(ctx, field)
+			case "code":
+				return ec.fieldContext_MagicLinkResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MagicLinkResult", field.Name)
 		},
@@ -724,7 +937,7 @@ func (ec *executionContext) _Mutation_consumeMagicLink(ctx context.Context, fiel
 		ec.fieldContext_Mutation_consumeMagicLink,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().ConsumeMagicLink(ctx, fc.Args["token"].(string))
+			return ec.resolvers.Mutation().ConsumeMagicLink(ctx, fc.Args["token"].(string), fc.Args["confirmDevice"].(*bool), fc.Args["rememberDevice"].(*bool))
 		},
 		nil,
 		ec.marshalNAuthResult2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐAuthResult,
@@ -747,6 +960,10 @@ func (ec *executionContext) fieldContext_Mutation_consumeMagicLink(ctx context.C
 				return ec.fieldContext_AuthResult_message(ctx, field)
 			case "email":
 				return ec.fieldContext_AuthResult_email(ctx, field)
+			case "confirmationRequired":
+				return ec.fieldContext_AuthResult_confirmationRequired(ctx, field)
+			case "code":
+				return ec.fieldContext_AuthResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type AuthResult", field.Name)
 		},
@@ -765,6 +982,59 @@ func (ec *executionContext) fieldContext_Mutation_consumeMagicLink(ctx context.C
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_consumeSignInCode(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_consumeSignInCode,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().ConsumeSignInCode(ctx, fc.Args["email"].(string), fc.Args["code"].(string), fc.Args["rememberDevice"].(*bool))
+		},
+		nil,
+		ec.marshalNAuthResult2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐAuthResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_consumeSignInCode(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_AuthResult_success(ctx, field)
+			case "message":
+				return ec.fieldContext_AuthResult_message(ctx, field)
+			case "email":
+				return ec.fieldContext_AuthResult_email(ctx, field)
+			case "confirmationRequired":
+				return ec.fieldContext_AuthResult_confirmationRequired(ctx, field)
+			case "code":
+				return ec.fieldContext_AuthResult_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AuthResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_consumeSignInCode_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_requestAccount(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -794,6 +1064,8 @@ func (ec *executionContext) fieldContext_Mutation_requestAccount(ctx context.Con
 				return ec.fieldContext_RequestResult_success(ctx, field)
 			case "message":
 				return ec.fieldContext_RequestResult_message(ctx, field)
+			case "code":
+				return ec.fieldContext_RequestResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type RequestResult", field.Name)
 		},
@@ -812,6 +1084,55 @@ func (ec *executionContext) fieldContext_Mutation_requestAccount(ctx context.Con
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_verifyAccountRequest(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_verifyAccountRequest,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().VerifyAccountRequest(ctx, fc.Args["token"].(string))
+		},
+		nil,
+		ec.marshalNRequestResult2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐRequestResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_verifyAccountRequest(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_RequestResult_success(ctx, field)
+			case "message":
+				return ec.fieldContext_RequestResult_message(ctx, field)
+			case "code":
+				return ec.fieldContext_RequestResult_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RequestResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_verifyAccountRequest_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_logout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -1040,6 +1361,35 @@ func (ec *executionContext) fieldContext_RequestResult_message(_ context.Context
 	return fc, nil
 }
 
+func (ec *executionContext) _RequestResult_code(ctx context.Context, field graphql.CollectedField, obj *RequestResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_RequestResult_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐErrorCode,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_RequestResult_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "RequestResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErrorCode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -2517,6 +2867,13 @@ func (ec *executionContext) _AuthResult(ctx context.Context, sel ast.SelectionSe
 			}
 		case "email":
 			out.Values[i] = ec._AuthResult_email(ctx, field, obj)
+		case "confirmationRequired":
+			out.Values[i] = ec._AuthResult_confirmationRequired(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "code":
+			out.Values[i] = ec._AuthResult_code(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2602,6 +2959,8 @@ func (ec *executionContext) _MagicLinkResult(ctx context.Context, sel ast.Select
 			}
 		case "email":
 			out.Values[i] = ec._MagicLinkResult_email(ctx, field, obj)
+		case "code":
+			out.Values[i] = ec._MagicLinkResult_code(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -2658,6 +3017,13 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "consumeSignInCode":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_consumeSignInCode(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "requestAccount":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_requestAccount(ctx, field)
@@ -2665,6 +3031,13 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "verifyAccountRequest":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_verifyAccountRequest(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "logout":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_logout(ctx, field)
@@ -2785,6 +3158,8 @@ func (ec *executionContext) _RequestResult(ctx context.Context, sel ast.Selectio
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "code":
+			out.Values[i] = ec._RequestResult_code(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -3514,6 +3889,22 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) unmarshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐErrorCode(ctx context.Context, v any) (*ErrorCode, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(ErrorCode)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋauthᚋgeneratedᚐErrorCode(ctx context.Context, sel ast.SelectionSet, v *ErrorCode) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOString2ᚖstring(ctx context.Context, v any) (*string, error) {
 	if v == nil {
 		return nil, nil
@@ -2,10 +2,19 @@
 
 package generated
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
 type AuthResult struct {
-	Success bool    `json:"success"`
-	Message string  `json:"message"`
-	Email   *string `json:"email,omitempty"`
+	Success              bool       `json:"success"`
+	Message              string     `json:"message"`
+	Email                *string    `json:"email,omitempty"`
+	ConfirmationRequired bool       `json:"confirmationRequired"`
+	Code                 *ErrorCode `json:"code,omitempty"`
 }
 
 type LogoutResult struct {
@@ -13,9 +22,10 @@ type LogoutResult struct {
 }
 
 type MagicLinkResult struct {
-	Success bool    `json:"success"`
-	Message string  `json:"message"`
-	Email   *string `json:"email,omitempty"`
+	Success bool       `json:"success"`
+	Message string     `json:"message"`
+	Email   *string    `json:"email,omitempty"`
+	Code    *ErrorCode `json:"code,omitempty"`
 }
 
 type Mutation struct {
@@ -25,6 +35,76 @@ type Query struct {
 }
 
 type RequestResult struct {
-	Success bool   `json:"success"`
-	Message string `json:"message"`
+	Success bool       `json:"success"`
+	Message string     `json:"message"`
+	Code    *ErrorCode `json:"code,omitempty"`
+}
+
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation      ErrorCode = "VALIDATION"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeCapacityFull    ErrorCode = "CAPACITY_FULL"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	ErrorCodeRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrorCodeReadOnly        ErrorCode = "READ_ONLY"
+	ErrorCodeInternal        ErrorCode = "INTERNAL"
+)
+
+var AllErrorCode = []ErrorCode{
+	ErrorCodeNotFound,
+	ErrorCodeValidation,
+	ErrorCodeConflict,
+	ErrorCodeCapacityFull,
+	ErrorCodeForbidden,
+	ErrorCodeUnauthenticated,
+	ErrorCodeRateLimited,
+	ErrorCodeReadOnly,
+	ErrorCodeInternal,
+}
+
+func (e ErrorCode) IsValid() bool {
+	switch e {
+	case ErrorCodeNotFound, ErrorCodeValidation, ErrorCodeConflict, ErrorCodeCapacityFull, ErrorCodeForbidden, ErrorCodeUnauthenticated, ErrorCodeRateLimited, ErrorCodeReadOnly, ErrorCodeInternal:
+		return true
+	}
+	return false
+}
+
+func (e ErrorCode) String() string {
+	return string(e)
+}
+
+func (e *ErrorCode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErrorCode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErrorCode", str)
+	}
+	return nil
+}
+
+func (e ErrorCode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ErrorCode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ErrorCode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
@@ -1,8 +1,93 @@
 package auth
 
-import "volunteer-scheduler/services"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"volunteer-scheduler/graph/auth/generated"
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
+)
+
+// browserNonceCookie ties a magic link to the browser that requested it.
+const browserNonceCookie = "magic_link_nonce"
 
 type Resolver struct {
-	MagicLinkService *services.MagicLinkService
-	IsProd           bool
+	MagicLinkService      *services.MagicLinkService
+	AccountRequestService *services.AccountRequestService
+	IsProd                bool
+}
+
+// clientInfo returns the IP address and user agent of the current request.
+func clientInfo(ctx context.Context) (string, string) {
+	if req, ok := middleware.RequestFromContext(ctx); ok {
+		return middleware.ClientIP(req), req.UserAgent()
+	}
+	return "", ""
+}
+
+// browserNonce returns the nonce cookie set by requestMagicLink, if any.
+func browserNonce(ctx context.Context) string {
+	if req, ok := middleware.RequestFromContext(ctx); ok {
+		if cookie, err := req.Cookie(browserNonceCookie); err == nil {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+// setBrowserNonceCookie stores nonce for the lifetime of a magic link. An
+// empty nonce clears the cookie.
+func (r *Resolver) setBrowserNonceCookie(ctx context.Context, nonce string) {
+	w, ok := middleware.ResponseWriterFromContext(ctx)
+	if !ok {
+		return
+	}
+	cookie := &http.Cookie{
+		Name:     browserNonceCookie,
+		Value:    nonce,
+		Path:     "/graphql/auth",
+		HttpOnly: true,
+		Secure:   r.IsProd,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int((15 * time.Minute).Seconds()),
+	}
+	if nonce == "" {
+		cookie.MaxAge = -1
+		cookie.Expires = time.Unix(0, 0)
+	}
+	if r.IsProd {
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, cookie)
+}
+
+// startSession creates a session for email once it has proven ownership
+// (magic link or sign-in code) and sets the session cookie on the response.
+// remember requests the longer "remember this device" lifetime.
+// The browser nonce has served its purpose by then and is cleared.
+func (r *Resolver) startSession(ctx context.Context, email string, remember bool) error {
+	ipAddress, userAgent := clientInfo(ctx)
+
+	sessionToken, expiresAt, err := r.MagicLinkService.CreateSessionToken(ctx, email, ipAddress, userAgent, remember)
+	if err != nil {
+		return fmt.Errorf("error creating session token: %w", err)
+	}
+
+	// The cookie expiry matches the DB session exactly; both are renewed
+	// together by RequireAuth while the session is in use.
+	if w, ok := middleware.ResponseWriterFromContext(ctx); ok {
+		middleware.SetSessionCookie(w, sessionToken, expiresAt, r.IsProd)
+	}
+	r.setBrowserNonceCookie(ctx, "")
+
+	return nil
+}
+
+// errorCode is the result code for a failed auth step; see models.ErrorCodeOf.
+func errorCode(err error) *generated.ErrorCode {
+	code := generated.ErrorCode(models.ErrorCodeOf(err))
+	return &code
 }
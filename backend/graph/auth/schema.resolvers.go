@@ -7,38 +7,52 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"time"
 	"volunteer-scheduler/graph/auth/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
 )
 
 // RequestMagicLink is the resolver for the requestMagicLink field.
 func (r *mutationResolver) RequestMagicLink(ctx context.Context, email string) (*generated.MagicLinkResult, error) {
-	// IP and user agent not available at GraphQL layer without middleware,
-	// so pass empty strings for now.
-	token, err := r.MagicLinkService.GenerateMagicLink(ctx, email, "", "")
+	ipAddress, userAgent := clientInfo(ctx)
+
+	// Tie the link to this browser with a nonce cookie; the link will ask for
+	// confirmation if it is opened anywhere else.
+	nonce, err := services.NewBrowserNonce()
+	if err != nil {
+		log.Printf("[auth] %v", err)
+	}
+
+	token, code, err := r.MagicLinkService.GenerateMagicLink(ctx, email, ipAddress, userAgent, nonce)
 	if err != nil {
 		err = fmt.Errorf("error generating magic link: %w", err)
 		return &generated.MagicLinkResult{
 			Success: false,
 			Message: err.Error(),
 			Email:   &email,
+			Code:    errorCode(err),
 		}, nil
 	}
 
-	if err := r.MagicLinkService.SendMagicLinkEmail(ctx, email, token); err != nil {
+	if err := r.MagicLinkService.SendMagicLinkEmail(ctx, email, token, code); err != nil {
 		err = fmt.Errorf("error sending magic link: %w", err)
 		log.Printf("[auth] %v", err)
 		return &generated.MagicLinkResult{
 			Success: false,
 			Message: err.Error(),
 			Email:   &email,
+			Code:    errorCode(err),
 		}, nil
 	}
 
+	r.setBrowserNonceCookie(ctx, nonce)
+
 	return &generated.MagicLinkResult{
 		Success: true,
 		Message: "Magic link sent! Check your email.",
@@ -48,43 +62,68 @@ func (r *mutationResolver) RequestMagicLink(ctx context.Context, email string) (
 
 // ConsumeMagicLink is the resolver for the consumeMagicLink field.
 // On success it sets an HttpOnly session cookie instead of returning the token.
-func (r *mutationResolver) ConsumeMagicLink(ctx context.Context, token string) (*generated.AuthResult, error) {
-	email, err := r.MagicLinkService.ConsumeMagicLink(ctx, token)
+func (r *mutationResolver) ConsumeMagicLink(ctx context.Context, token string, confirmDevice *bool, rememberDevice *bool) (*generated.AuthResult, error) {
+	ipAddress, userAgent := clientInfo(ctx)
+	confirm := confirmDevice != nil && *confirmDevice
+
+	email, err := r.MagicLinkService.ConsumeMagicLink(ctx, token, browserNonce(ctx), confirm, ipAddress, userAgent)
+	if errors.Is(err, services.ErrMagicLinkOtherBrowser) {
+		return &generated.AuthResult{
+			Success:              false,
+			Message:              "This link was requested from a different browser. Confirm to sign in on this device.",
+			ConfirmationRequired: true,
+			Code:                 errorCode(err),
+		}, nil
+	}
 	if err != nil {
 		err = fmt.Errorf("error consuming magic link: %w", err)
 		return &generated.AuthResult{
 			Success: false,
 			Message: err.Error(),
+			Code:    errorCode(err),
 		}, nil
 	}
 
-	sessionToken, expiresAt, err := r.MagicLinkService.CreateSessionToken(ctx, email)
-	if err != nil {
-		err = fmt.Errorf("error creating session token: %w", err)
+	if err := r.startSession(ctx, email, rememberDevice != nil && *rememberDevice); err != nil {
+		log.Printf("[auth] %v", err)
 		return &generated.AuthResult{
 			Success: false,
 			Message: "Failed to create session.",
 			Email:   &email,
+			Code:    errorCode(err),
 		}, nil
 	}
 
-	// Write the session token as an HttpOnly cookie so JavaScript cannot read it.
-	// The cookie expiry matches the DB session exactly (both driven by SESSION_MAX_AGE).
-	if w, ok := middleware.ResponseWriterFromContext(ctx); ok {
-		cookie := &http.Cookie{
-			Name:     "session",
-			Value:    sessionToken,
-			Path:     "/",
-			HttpOnly: true,
-			Secure:   r.IsProd, // HTTPS only in production
-			SameSite: http.SameSiteLaxMode,
-			Expires:  expiresAt,
-		}
-		if r.IsProd {
-			// Frontend and backend are on different Railway domains, so cross-origin.
-			cookie.SameSite = http.SameSiteNoneMode
-		}
-		http.SetCookie(w, cookie)
+	return &generated.AuthResult{
+		Success: true,
+		Message: "Successfully authenticated.",
+		Email:   &email,
+	}, nil
+}
+
+// ConsumeSignInCode is the resolver for the consumeSignInCode field.
+// Like ConsumeMagicLink, on success it sets an HttpOnly session cookie.
+func (r *mutationResolver) ConsumeSignInCode(ctx context.Context, email string, code string, rememberDevice *bool) (*generated.AuthResult, error) {
+	ipAddress, userAgent := clientInfo(ctx)
+
+	email, err := r.MagicLinkService.ConsumeSignInCode(ctx, email, code, ipAddress, userAgent)
+	if err != nil {
+		err = fmt.Errorf("error consuming sign-in code: %w", err)
+		return &generated.AuthResult{
+			Success: false,
+			Message: err.Error(),
+			Code:    errorCode(err),
+		}, nil
+	}
+
+	if err := r.startSession(ctx, email, rememberDevice != nil && *rememberDevice); err != nil {
+		log.Printf("[auth] %v", err)
+		return &generated.AuthResult{
+			Success: false,
+			Message: "Failed to create session.",
+			Email:   &email,
+			Code:    errorCode(err),
+		}, nil
 	}
 
 	return &generated.AuthResult{
@@ -95,18 +134,43 @@ func (r *mutationResolver) ConsumeMagicLink(ctx context.Context, token string) (
 }
 
 // RequestAccount is the resolver for the requestAccount field.
-// No DB record is created — the request is emailed to all admins for review.
+// The request is queued for admins once the requester confirms their email.
 func (r *mutationResolver) RequestAccount(ctx context.Context, email string, firstName string, lastName string) (*generated.RequestResult, error) {
-	if err := r.MagicLinkService.RequestAccount(ctx, email, firstName, lastName); err != nil {
+	ipAddress, _ := clientInfo(ctx)
+	if err := r.AccountRequestService.RequestAccount(ctx, email, firstName, lastName, ipAddress); err != nil {
+		log.Printf("Account request failed: %v", err)
+		if models.ErrorCodeOf(err) == models.ErrorCodeValidation {
+			return &generated.RequestResult{Success: false, Message: err.Error(), Code: errorCode(err)}, nil
+		}
 		return &generated.RequestResult{
 			Success: false,
 			Message: "Failed to submit account request. Please try again later.",
+			Code:    errorCode(err),
+		}, nil
+	}
+
+	return &generated.RequestResult{
+		Success: true,
+		Message: "Check your email to confirm your address. Your request goes to an administrator once you do.",
+	}, nil
+}
+
+// VerifyAccountRequest is the resolver for the verifyAccountRequest field.
+func (r *mutationResolver) VerifyAccountRequest(ctx context.Context, token string) (*generated.RequestResult, error) {
+	if err := r.AccountRequestService.VerifyAccountRequest(ctx, token); err != nil {
+		if !errors.Is(err, services.ErrAccountRequestInvalid) {
+			log.Printf("Account request verification failed: %v", err)
+		}
+		return &generated.RequestResult{
+			Success: false,
+			Message: "This link is invalid or has expired. Please request an account again.",
+			Code:    errorCode(err),
 		}, nil
 	}
 
 	return &generated.RequestResult{
 		Success: true,
-		Message: "Your request has been submitted. An administrator will be in touch soon.",
+		Message: "Thank you. Your request has been sent to an administrator, who will be in touch soon.",
 	}, nil
 }
 
@@ -0,0 +1,17 @@
+package volunteer
+
+// ListSizes is how many items each list field of the volunteer schema is
+// expected to return, for middleware.WithListCosts. Lists not named here
+// are assumed to hold middleware.DefaultListSize items.
+var ListSizes = map[string]int{
+	"Query.eventViews":         50,
+	"Query.eventShiftViews":    20,
+	"Query.ownShifts":          50,
+	"Query.ownFeedback":        20,
+	"Query.ownSessions":        5,
+	"EventView.eventDates":     5,
+	"EventView.shiftSummaries": 10,
+	"FeedbackView.notes":       5,
+	"FeedbackView.attachments": 3,
+	"LookupValues.cities":      50,
+}
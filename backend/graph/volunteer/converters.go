@@ -1,6 +1,7 @@
 package volunteer
 
 import (
+	"context"
 	"volunteer-scheduler/graph/volunteer/generated"
 	"volunteer-scheduler/models"
 )
@@ -83,6 +84,32 @@ func toGenEventShiftSummary(m *models.EventShiftSummary) *generated.EventShiftSu
 	}
 }
 
+func toGenShiftCapacity(m *models.ShiftCapacity) *generated.ShiftCapacity {
+	return &generated.ShiftCapacity{
+		EventID:            m.EventId,
+		ShiftID:            m.ShiftId,
+		AssignedVolunteers: m.AssignedVolunteers,
+		MaxVolunteers:      m.MaxVolunteers,
+	}
+}
+
+// toGenShiftCapacityStream converts a subscription's changes as they arrive,
+// until ctx is done or ms is closed.
+func toGenShiftCapacityStream(ctx context.Context, ms <-chan *models.ShiftCapacity) <-chan *generated.ShiftCapacity {
+	out := make(chan *generated.ShiftCapacity)
+	go func() {
+		defer close(out)
+		for m := range ms {
+			select {
+			case out <- toGenShiftCapacity(m):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
 // Feedback
 
 func toGenFeedbackMetaAttachments(ms []*models.FeedbackMetaAttachment) []*generated.FeedbackMetaAttachment {
@@ -126,7 +153,18 @@ func toGenMutationResult(m *models.MutationResult) *generated.MutationResult {
 		Success: m.Success,
 		Message: m.Message,
 		ID:      m.ID,
+		Code:    toGenErrorCode(m.Code),
+	}
+}
+
+// toGenErrorCode is nil for results that did not fail, or failed for a reason
+// with no code.
+func toGenErrorCode(c models.ErrorCode) *generated.ErrorCode {
+	if c == "" {
+		return nil
 	}
+	g := generated.ErrorCode(c)
+	return &g
 }
 
 // Roles
@@ -165,6 +203,9 @@ func toGenEventView(m *models.EventView) *generated.EventView {
 		EventDates:     toGenEventDateViews(m.EventDates),
 		ServiceTypes:   m.ServiceTypes,
 		ShiftSummaries: toGenEventShiftSummaries(m.ShiftSummaries),
+		Status:         generated.EventStatus(m.Status),
+		SearchRank:     m.SearchRank,
+		SearchSnippet:  m.SearchSnippet,
 	}
 }
 
@@ -336,6 +377,31 @@ func toGenVolunteerShiftView(m *models.VolunteerShiftView) *generated.VolunteerS
 	}
 }
 
+// Sessions
+
+func toGenSessions(ms []*models.Session) []*generated.Session {
+	result := make([]*generated.Session, len(ms))
+	for i, m := range ms {
+		result[i] = toGenSession(m)
+	}
+	return result
+}
+
+func toGenSession(m *models.Session) *generated.Session {
+	if m == nil {
+		return nil
+	}
+	return &generated.Session{
+		ID:             m.ID,
+		UserAgent:      m.UserAgent,
+		IPAddress:      m.IPAddress,
+		CreatedAt:      m.CreatedAt,
+		LastActivityAt: m.LastActivityAt,
+		ExpiresAt:      m.ExpiresAt,
+		Current:        m.Current,
+	}
+}
+
 // Results
 
 func toGenVolunteerMutationResult(m *models.VolunteerMutationResult) *generated.VolunteerMutationResult {
@@ -346,6 +412,7 @@ func toGenVolunteerMutationResult(m *models.VolunteerMutationResult) *generated.
 	return &generated.VolunteerMutationResult{
 		Success: m.Success,
 		Message: m.Message,
+		Code:    toGenErrorCode(m.Code),
 	}
 }
 
@@ -374,6 +441,7 @@ func toModelVolunteerEventFilterInput(g *generated.VolunteerEventFilterInput) *m
 		EventType: eventType,
 		Jobs:      g.Jobs,
 		TimeFrame: timeframe,
+		Search:    g.Search,
 	}
 }
 
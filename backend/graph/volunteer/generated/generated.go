@@ -39,6 +39,7 @@ type Config struct {
 type ResolverRoot interface {
 	Mutation() MutationResolver
 	Query() QueryResolver
+	Subscription() SubscriptionResolver
 }
 
 type DirectiveRoot struct {
@@ -72,8 +73,11 @@ type ComplexityRoot struct {
 		EventType      func(childComplexity int) int
 		ID             func(childComplexity int) int
 		Name           func(childComplexity int) int
+		SearchRank     func(childComplexity int) int
+		SearchSnippet  func(childComplexity int) int
 		ServiceTypes   func(childComplexity int) int
 		ShiftSummaries func(childComplexity int) int
+		Status         func(childComplexity int) int
 		Timezone       func(childComplexity int) int
 		Venue          func(childComplexity int) int
 	}
@@ -132,10 +136,14 @@ type ComplexityRoot struct {
 		AttachFileToFeedback     func(childComplexity int, feedbackID string, file graphql.Upload) int
 		CancelOwnShift           func(childComplexity int, shiftID string) int
 		GiveFeedback             func(childComplexity int, feedback NewFeedbackInput) int
+		RequestErasure           func(childComplexity int, reason *string) int
+		RevokeOtherSessions      func(childComplexity int) int
+		RevokeOwnSession         func(childComplexity int, sessionID string) int
 		UpdateOwnProfile         func(childComplexity int, profile UpdateOwnProfileInput) int
 	}
 
 	MutationResult struct {
+		Code    func(childComplexity int) int
 		ID      func(childComplexity int) int
 		Message func(childComplexity int) int
 		Success func(childComplexity int) int
@@ -147,8 +155,10 @@ type ComplexityRoot struct {
 		EventViews      func(childComplexity int, filter *VolunteerEventFilterInput) int
 		LookupValues    func(childComplexity int) int
 		OwnAttachment   func(childComplexity int, attachmentID int) int
+		OwnDataExport   func(childComplexity int) int
 		OwnFeedback     func(childComplexity int) int
 		OwnProfile      func(childComplexity int) int
+		OwnSessions     func(childComplexity int) int
 		OwnShifts       func(childComplexity int, filter ShiftTimeFilter) int
 	}
 
@@ -158,6 +168,27 @@ type ComplexityRoot struct {
 		Name func(childComplexity int) int
 	}
 
+	Session struct {
+		CreatedAt      func(childComplexity int) int
+		Current        func(childComplexity int) int
+		ExpiresAt      func(childComplexity int) int
+		ID             func(childComplexity int) int
+		IPAddress      func(childComplexity int) int
+		LastActivityAt func(childComplexity int) int
+		UserAgent      func(childComplexity int) int
+	}
+
+	ShiftCapacity struct {
+		AssignedVolunteers func(childComplexity int) int
+		EventID            func(childComplexity int) int
+		MaxVolunteers      func(childComplexity int) int
+		ShiftID            func(childComplexity int) int
+	}
+
+	Subscription struct {
+		ShiftCapacityChanged func(childComplexity int, eventID string) int
+	}
+
 	VenueView struct {
 		Address   func(childComplexity int) int
 		City      func(childComplexity int) int
@@ -169,6 +200,7 @@ type ComplexityRoot struct {
 	}
 
 	VolunteerMutationResult struct {
+		Code    func(childComplexity int) int
 		Message func(childComplexity int) int
 		Success func(childComplexity int) int
 	}
@@ -207,6 +239,9 @@ type MutationResolver interface {
 	UpdateOwnProfile(ctx context.Context, profile UpdateOwnProfileInput) (*VolunteerMutationResult, error)
 	AssignSelfToShift(ctx context.Context, shiftID string) (*MutationResult, error)
 	CancelOwnShift(ctx context.Context, shiftID string) (*MutationResult, error)
+	RevokeOwnSession(ctx context.Context, sessionID string) (*MutationResult, error)
+	RevokeOtherSessions(ctx context.Context) (*MutationResult, error)
+	RequestErasure(ctx context.Context, reason *string) (*VolunteerMutationResult, error)
 }
 type QueryResolver interface {
 	LookupValues(ctx context.Context) (*LookupValues, error)
@@ -217,6 +252,11 @@ type QueryResolver interface {
 	OwnFeedback(ctx context.Context) ([]*FeedbackView, error)
 	OwnProfile(ctx context.Context) (*VolunteerView, error)
 	OwnShifts(ctx context.Context, filter ShiftTimeFilter) ([]*VolunteerShiftView, error)
+	OwnSessions(ctx context.Context) ([]*Session, error)
+	OwnDataExport(ctx context.Context) (string, error)
+}
+type SubscriptionResolver interface {
+	ShiftCapacityChanged(ctx context.Context, eventID string) (<-chan *ShiftCapacity, error)
 }
 
 type executableSchema struct {
@@ -343,6 +383,18 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.EventView.Name(childComplexity), true
+	case "EventView.searchRank":
+		if e.complexity.EventView.SearchRank == nil {
+			break
+		}
+
+		return e.complexity.EventView.SearchRank(childComplexity), true
+	case "EventView.searchSnippet":
+		if e.complexity.EventView.SearchSnippet == nil {
+			break
+		}
+
+		return e.complexity.EventView.SearchSnippet(childComplexity), true
 	case "EventView.serviceTypes":
 		if e.complexity.EventView.ServiceTypes == nil {
 			break
@@ -355,6 +407,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.EventView.ShiftSummaries(childComplexity), true
+	case "EventView.status":
+		if e.complexity.EventView.Status == nil {
+			break
+		}
+
+		return e.complexity.EventView.Status(childComplexity), true
 	case "EventView.timezone":
 		if e.complexity.EventView.Timezone == nil {
 			break
@@ -609,6 +667,34 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.GiveFeedback(childComplexity, args["feedback"].(NewFeedbackInput)), true
+	case "Mutation.requestErasure":
+		if e.complexity.Mutation.RequestErasure == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_requestErasure_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RequestErasure(childComplexity, args["reason"].(*string)), true
+	case "Mutation.revokeOtherSessions":
+		if e.complexity.Mutation.RevokeOtherSessions == nil {
+			break
+		}
+
+		return e.complexity.Mutation.RevokeOtherSessions(childComplexity), true
+	case "Mutation.revokeOwnSession":
+		if e.complexity.Mutation.RevokeOwnSession == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_revokeOwnSession_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RevokeOwnSession(childComplexity, args["sessionId"].(string)), true
 	case "Mutation.updateOwnProfile":
 		if e.complexity.Mutation.UpdateOwnProfile == nil {
 			break
@@ -621,6 +707,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Mutation.UpdateOwnProfile(childComplexity, args["profile"].(UpdateOwnProfileInput)), true
 
+	case "MutationResult.code":
+		if e.complexity.MutationResult.Code == nil {
+			break
+		}
+
+		return e.complexity.MutationResult.Code(childComplexity), true
 	case "MutationResult.id":
 		if e.complexity.MutationResult.ID == nil {
 			break
@@ -690,6 +782,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.OwnAttachment(childComplexity, args["attachmentId"].(int)), true
+	case "Query.ownDataExport":
+		if e.complexity.Query.OwnDataExport == nil {
+			break
+		}
+
+		return e.complexity.Query.OwnDataExport(childComplexity), true
 	case "Query.ownFeedback":
 		if e.complexity.Query.OwnFeedback == nil {
 			break
@@ -702,6 +800,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.OwnProfile(childComplexity), true
+	case "Query.ownSessions":
+		if e.complexity.Query.OwnSessions == nil {
+			break
+		}
+
+		return e.complexity.Query.OwnSessions(childComplexity), true
 	case "Query.ownShifts":
 		if e.complexity.Query.OwnShifts == nil {
 			break
@@ -733,6 +837,86 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.ServiceType.Name(childComplexity), true
 
+	case "Session.createdAt":
+		if e.complexity.Session.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Session.CreatedAt(childComplexity), true
+	case "Session.current":
+		if e.complexity.Session.Current == nil {
+			break
+		}
+
+		return e.complexity.Session.Current(childComplexity), true
+	case "Session.expiresAt":
+		if e.complexity.Session.ExpiresAt == nil {
+			break
+		}
+
+		return e.complexity.Session.ExpiresAt(childComplexity), true
+	case "Session.id":
+		if e.complexity.Session.ID == nil {
+			break
+		}
+
+		return e.complexity.Session.ID(childComplexity), true
+	case "Session.ipAddress":
+		if e.complexity.Session.IPAddress == nil {
+			break
+		}
+
+		return e.complexity.Session.IPAddress(childComplexity), true
+	case "Session.lastActivityAt":
+		if e.complexity.Session.LastActivityAt == nil {
+			break
+		}
+
+		return e.complexity.Session.LastActivityAt(childComplexity), true
+	case "Session.userAgent":
+		if e.complexity.Session.UserAgent == nil {
+			break
+		}
+
+		return e.complexity.Session.UserAgent(childComplexity), true
+
+	case "ShiftCapacity.assignedVolunteers":
+		if e.complexity.ShiftCapacity.AssignedVolunteers == nil {
+			break
+		}
+
+		return e.complexity.ShiftCapacity.AssignedVolunteers(childComplexity), true
+	case "ShiftCapacity.eventId":
+		if e.complexity.ShiftCapacity.EventID == nil {
+			break
+		}
+
+		return e.complexity.ShiftCapacity.EventID(childComplexity), true
+	case "ShiftCapacity.maxVolunteers":
+		if e.complexity.ShiftCapacity.MaxVolunteers == nil {
+			break
+		}
+
+		return e.complexity.ShiftCapacity.MaxVolunteers(childComplexity), true
+	case "ShiftCapacity.shiftId":
+		if e.complexity.ShiftCapacity.ShiftID == nil {
+			break
+		}
+
+		return e.complexity.ShiftCapacity.ShiftID(childComplexity), true
+
+	case "Subscription.shiftCapacityChanged":
+		if e.complexity.Subscription.ShiftCapacityChanged == nil {
+			break
+		}
+
+		args, err := ec.field_Subscription_shiftCapacityChanged_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Subscription.ShiftCapacityChanged(childComplexity, args["eventId"].(string)), true
+
 	case "VenueView.address":
 		if e.complexity.VenueView.Address == nil {
 			break
@@ -776,6 +960,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.VenueView.ZipCode(childComplexity), true
 
+	case "VolunteerMutationResult.code":
+		if e.complexity.VolunteerMutationResult.Code == nil {
+			break
+		}
+
+		return e.complexity.VolunteerMutationResult.Code(childComplexity), true
 	case "VolunteerMutationResult.message":
 		if e.complexity.VolunteerMutationResult.Message == nil {
 			break
@@ -968,6 +1158,23 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 			var buf bytes.Buffer
 			data.MarshalGQL(&buf)
 
+			return &graphql.Response{
+				Data: buf.Bytes(),
+			}
+		}
+	case ast.Subscription:
+		next := ec._Subscription(ctx, opCtx.Operation.SelectionSet)
+
+		var buf bytes.Buffer
+		return func(ctx context.Context) *graphql.Response {
+			buf.Reset()
+			data := next(ctx)
+
+			if data == nil {
+				return nil
+			}
+			data.MarshalGQL(&buf)
+
 			return &graphql.Response{
 				Data: buf.Bytes(),
 			}
@@ -1023,16 +1230,29 @@ var sources = []*ast.Source{
 	{Name: "../../shared/types.graphql", Input: `## Types common to all of the schemas.
 
 type Query {
-  lookupValues: LookupValues!
+  lookupValues: LookupValues! @hasRole(roles: [ADMINISTRATOR, COORDINATOR]) @tokenScope(scopes: [ADMIN_READ_ONLY, REPORTING, VOLUNTEER_SIGNUP])
 }
 
 type Mutation {
   # Feedback
-  attachFileToFeedback(feedbackId: ID!, file: Upload!): MutationResult!
-  giveFeedback (feedback: NewFeedbackInput!): MutationResult!
+  attachFileToFeedback(feedbackId: ID!, file: Upload!): MutationResult! @hasRole(roles: [ADMINISTRATOR, COORDINATOR])
+  giveFeedback (feedback: NewFeedbackInput!): MutationResult! @hasRole(roles: [ADMINISTRATOR, COORDINATOR])
 }
 
 
+#-- Directives --
+
+# Roles allowed to call a root field on /graphql/admin. Fields without it
+# are ADMINISTRATOR-only. Not enforced on /graphql/volunteer, which admits
+# every signed-in volunteer.
+directive @hasRole(roles: [Role!]!) on FIELD_DEFINITION
+
+# API token scopes allowed to call a root field, on both /graphql/admin and
+# /graphql/volunteer. Fields without it are closed to API tokens; login
+# sessions are not affected.
+directive @tokenScope(scopes: [ApiTokenScope!]!) on FIELD_DEFINITION
+
+
 #-- Scalers --
 
 scalar Upload
@@ -1042,6 +1262,33 @@ scalar Upload
 enum Role {
   VOLUNTEER
   ADMINISTRATOR
+  COORDINATOR
+}
+
+# ADMIN_READ_ONLY: every admin and volunteer query, no mutations.
+# REPORTING: events, venues, volunteers and their shifts, read-only.
+# VOLUNTEER_SIGNUP: browse events and sign volunteers up for shifts.
+enum ApiTokenScope {
+  ADMIN_READ_ONLY
+  REPORTING
+  VOLUNTEER_SIGNUP
+}
+
+# Why a request failed. GraphQL errors carry it in extensions.code (with
+# extensions.fields for VALIDATION, and extensions.correlationId for
+# INTERNAL). Mutations report rejected input, missing records and conflicts
+# as GraphQL errors; a result's code is set only for signup outcomes (shift
+# full, event closed) and on the auth endpoint.
+enum ErrorCode {
+  NOT_FOUND
+  VALIDATION
+  CONFLICT
+  CAPACITY_FULL
+  FORBIDDEN
+  UNAUTHENTICATED
+  RATE_LIMITED
+  READ_ONLY
+  INTERNAL
 }
 
 enum EventType {
@@ -1050,6 +1297,16 @@ enum EventType {
   HYBRID
 }
 
+# DRAFT events are seen only by admins until published. COMPLETED events are
+# published ones whose last date has ended. CANCELLED events keep their
+# shifts and signups as history but take no new signups.
+enum EventStatus {
+  DRAFT
+  PUBLISHED
+  CANCELLED
+  COMPLETED
+}
+
 enum FeedbackType {
     BUG
     ENHANCEMENT
@@ -1112,6 +1369,15 @@ type EventShiftSummary {
   maxVolunteers: Int!
 }
 
+# A shift's signup count after it changed, pushed to shiftCapacityChanged
+# subscribers. maxVolunteers is null for no limit.
+type ShiftCapacity {
+  eventId: ID!
+  shiftId: ID!
+  assignedVolunteers: Int!
+  maxVolunteers: Int
+}
+
 # Feedback 
 
 # Both Feedback and FeedbackView (volunteers) use the same 
@@ -1132,6 +1398,7 @@ type MutationResult {
   success: Boolean!
   message: String
   id: ID
+  code: ErrorCode
 }
 
 #-- Input --
@@ -1153,17 +1420,23 @@ input NewFeedbackInput {
 extend type Query {
 
   # Events
-  eventShiftViews(eventId: ID!): [EventShiftView!]!  
-  eventViews(filter: VolunteerEventFilterInput): [EventView!]!
-  eventView(eventId: ID!): EventView!
+  eventShiftViews(eventId: ID!): [EventShiftView!]! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
+  eventViews(filter: VolunteerEventFilterInput): [EventView!]! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
+  eventView(eventId: ID!): EventView! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
 
   # Feedback
-  ownAttachment(attachmentId: Int!): FeedbackAttachmentView!
-  ownFeedback: [FeedbackView!]!
+  ownAttachment(attachmentId: Int!): FeedbackAttachmentView! @tokenScope(scopes: [ADMIN_READ_ONLY])
+  ownFeedback: [FeedbackView!]! @tokenScope(scopes: [ADMIN_READ_ONLY])
 
   # Volunteer 
-  ownProfile: VolunteerView!
-  ownShifts(filter: ShiftTimeFilter!): [VolunteerShiftView!]! 
+  ownProfile: VolunteerView! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
+  ownShifts(filter: ShiftTimeFilter!): [VolunteerShiftView!]! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
+
+  # Sessions
+  ownSessions: [Session!]!
+
+  # Personal data - everything held about the caller as a JSON document
+  ownDataExport: String!
 }
 
 extend type Mutation {
@@ -1175,8 +1448,25 @@ extend type Mutation {
   updateOwnProfile(profile: UpdateOwnProfileInput!): VolunteerMutationResult!    
 
   # Volunteer Shifts
-  assignSelfToShift(shiftId: ID!): MutationResult!  
-  cancelOwnShift(shiftId: ID!): MutationResult!
+  assignSelfToShift(shiftId: ID!): MutationResult! @tokenScope(scopes: [VOLUNTEER_SIGNUP])
+  cancelOwnShift(shiftId: ID!): MutationResult! @tokenScope(scopes: [VOLUNTEER_SIGNUP])
+
+  # Sessions
+  revokeOwnSession(sessionId: ID!): MutationResult!
+  revokeOtherSessions: MutationResult!
+
+  # Personal data - ask an administrator to erase it
+  requestErasure(reason: String): VolunteerMutationResult!
+}
+
+# Subscriptions are served over a websocket on the same endpoint
+# (graphql-transport-ws or graphql-ws), authenticated like any request.
+
+type Subscription {
+
+  # Each change to the signups or limit of one of the event's shifts, from
+  # now on; query eventShiftViews for the current counts.
+  shiftCapacityChanged(eventId: ID!): ShiftCapacity! @tokenScope(scopes: [ADMIN_READ_ONLY, VOLUNTEER_SIGNUP])
 }
 
 
@@ -1209,6 +1499,11 @@ type EventView {
   serviceTypes: [String!]
   eventDates: [EventDateView!]!
   shiftSummaries: [EventShiftSummary!]!
+  status: EventStatus!  # eventViews lists PUBLISHED and COMPLETED events only
+  # Set only when filtered by search: how well the event matched (higher is
+  # better), and an HTML-escaped excerpt with the matches in <mark> tags.
+  searchRank: Float
+  searchSnippet: String
 }
 
 type VenueView {
@@ -1286,6 +1581,19 @@ type VolunteerShiftView {
   venue: VenueView
 }
 
+# Sessions
+
+type Session {
+  id: ID!
+  userAgent: String
+  ipAddress: String
+  createdAt: String!
+  lastActivityAt: String
+  expiresAt: String!
+  current: Boolean!       # - true for the session making this request.
+}
+
+
 ##-- Input --
 
 # Events
@@ -1293,6 +1601,10 @@ type VolunteerShiftView {
 ## The EventFilter reflects all of the fields on which 
 ## a volunteer can filter the events. If you want to add
 ## more ways for them to filter, this is the place.
+##
+## search matches words in event and venue names, descriptions and
+## pre-event instructions, e.g. food bank or "spanish speaker"; the best
+## matches are listed first.
 
 input VolunteerEventFilterInput {
   cities: [String!]
@@ -1300,6 +1612,7 @@ input VolunteerEventFilterInput {
   eventType: EventType
   jobs: [Int!]
   timeFrame: ShiftTimeFilter
+  search: String
 }
 
 # Feedback
@@ -1328,6 +1641,7 @@ input UpdateOwnProfileInput {
 type VolunteerMutationResult {
   success: Boolean!
   message: String
+  code: ErrorCode
 }
 `, BuiltIn: false},
 }
@@ -1397,6 +1711,28 @@ func (ec *executionContext) field_Mutation_giveFeedback_args(ctx context.Context
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_requestErasure_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "reason", ec.unmarshalOString2ᚖstring)
+	if err != nil {
+		return nil, err
+	}
+	args["reason"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_revokeOwnSession_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "sessionId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["sessionId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_updateOwnProfile_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1474,6 +1810,17 @@ func (ec *executionContext) field_Query_ownShifts_args(ctx context.Context, rawA
 	return args, nil
 }
 
+func (ec *executionContext) field_Subscription_shiftCapacityChanged_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "eventId", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["eventId"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field___Directive_args_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -2165,6 +2512,93 @@ func (ec *executionContext) fieldContext_EventView_shiftSummaries(_ context.Cont
 	return fc, nil
 }
 
+func (ec *executionContext) _EventView_status(ctx context.Context, field graphql.CollectedField, obj *EventView) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventView_status,
+		func(ctx context.Context) (any, error) {
+			return obj.Status, nil
+		},
+		nil,
+		ec.marshalNEventStatus2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐEventStatus,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventView_status(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventView",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type EventStatus does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventView_searchRank(ctx context.Context, field graphql.CollectedField, obj *EventView) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventView_searchRank,
+		func(ctx context.Context) (any, error) {
+			return obj.SearchRank, nil
+		},
+		nil,
+		ec.marshalOFloat2ᚖfloat64,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventView_searchRank(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventView",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _EventView_searchSnippet(ctx context.Context, field graphql.CollectedField, obj *EventView) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_EventView_searchSnippet,
+		func(ctx context.Context) (any, error) {
+			return obj.SearchSnippet, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_EventView_searchSnippet(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "EventView",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _FeedbackAttachmentView_filename(ctx context.Context, field graphql.CollectedField, obj *FeedbackAttachmentView) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3108,6 +3542,8 @@ func (ec *executionContext) fieldContext_Mutation_attachFileToFeedback(ctx conte
 				return ec.fieldContext_MutationResult_message(ctx, field)
 			case "id":
 				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
 		},
@@ -3157,6 +3593,8 @@ func (ec *executionContext) fieldContext_Mutation_giveFeedback(ctx context.Conte
 				return ec.fieldContext_MutationResult_message(ctx, field)
 			case "id":
 				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
 		},
@@ -3206,6 +3644,8 @@ func (ec *executionContext) fieldContext_Mutation_addVolunteerFeedbackNote(ctx c
 				return ec.fieldContext_MutationResult_message(ctx, field)
 			case "id":
 				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
 		},
@@ -3253,6 +3693,8 @@ func (ec *executionContext) fieldContext_Mutation_updateOwnProfile(ctx context.C
 				return ec.fieldContext_VolunteerMutationResult_success(ctx, field)
 			case "message":
 				return ec.fieldContext_VolunteerMutationResult_message(ctx, field)
+			case "code":
+				return ec.fieldContext_VolunteerMutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type VolunteerMutationResult", field.Name)
 		},
@@ -3302,6 +3744,8 @@ func (ec *executionContext) fieldContext_Mutation_assignSelfToShift(ctx context.
 				return ec.fieldContext_MutationResult_message(ctx, field)
 			case "id":
 				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
 		},
@@ -3351,6 +3795,8 @@ func (ec *executionContext) fieldContext_Mutation_cancelOwnShift(ctx context.Con
 				return ec.fieldContext_MutationResult_message(ctx, field)
 			case "id":
 				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
 		},
@@ -3369,36 +3815,175 @@ func (ec *executionContext) fieldContext_Mutation_cancelOwnShift(ctx context.Con
 	return fc, nil
 }
 
-func (ec *executionContext) _MutationResult_success(ctx context.Context, field graphql.CollectedField, obj *MutationResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Mutation_revokeOwnSession(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_MutationResult_success,
+		ec.fieldContext_Mutation_revokeOwnSession,
 		func(ctx context.Context) (any, error) {
-			return obj.Success, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RevokeOwnSession(ctx, fc.Args["sessionId"].(string))
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNMutationResult2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐMutationResult,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_MutationResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_revokeOwnSession(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MutationResult",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _MutationResult_message(ctx context.Context, field graphql.CollectedField, obj *MutationResult) (ret graphql.Marshaler) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_MutationResult_success(ctx, field)
+			case "message":
+				return ec.fieldContext_MutationResult_message(ctx, field)
+			case "id":
+				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_revokeOwnSession_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_revokeOtherSessions(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_revokeOtherSessions,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Mutation().RevokeOtherSessions(ctx)
+		},
+		nil,
+		ec.marshalNMutationResult2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐMutationResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_revokeOtherSessions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_MutationResult_success(ctx, field)
+			case "message":
+				return ec.fieldContext_MutationResult_message(ctx, field)
+			case "id":
+				return ec.fieldContext_MutationResult_id(ctx, field)
+			case "code":
+				return ec.fieldContext_MutationResult_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MutationResult", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_requestErasure(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_requestErasure,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RequestErasure(ctx, fc.Args["reason"].(*string))
+		},
+		nil,
+		ec.marshalNVolunteerMutationResult2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐVolunteerMutationResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_requestErasure(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "success":
+				return ec.fieldContext_VolunteerMutationResult_success(ctx, field)
+			case "message":
+				return ec.fieldContext_VolunteerMutationResult_message(ctx, field)
+			case "code":
+				return ec.fieldContext_VolunteerMutationResult_code(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type VolunteerMutationResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_requestErasure_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MutationResult_success(ctx context.Context, field graphql.CollectedField, obj *MutationResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MutationResult_success,
+		func(ctx context.Context) (any, error) {
+			return obj.Success, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_MutationResult_success(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MutationResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MutationResult_message(ctx context.Context, field graphql.CollectedField, obj *MutationResult) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
@@ -3456,6 +4041,35 @@ func (ec *executionContext) fieldContext_MutationResult_id(_ context.Context, fi
 	return fc, nil
 }
 
+func (ec *executionContext) _MutationResult_code(ctx context.Context, field graphql.CollectedField, obj *MutationResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MutationResult_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐErrorCode,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_MutationResult_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MutationResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErrorCode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Query_lookupValues(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3593,6 +4207,12 @@ func (ec *executionContext) fieldContext_Query_eventViews(ctx context.Context, f
 				return ec.fieldContext_EventView_eventDates(ctx, field)
 			case "shiftSummaries":
 				return ec.fieldContext_EventView_shiftSummaries(ctx, field)
+			case "status":
+				return ec.fieldContext_EventView_status(ctx, field)
+			case "searchRank":
+				return ec.fieldContext_EventView_searchRank(ctx, field)
+			case "searchSnippet":
+				return ec.fieldContext_EventView_searchSnippet(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type EventView", field.Name)
 		},
@@ -3654,6 +4274,12 @@ func (ec *executionContext) fieldContext_Query_eventView(ctx context.Context, fi
 				return ec.fieldContext_EventView_eventDates(ctx, field)
 			case "shiftSummaries":
 				return ec.fieldContext_EventView_shiftSummaries(ctx, field)
+			case "status":
+				return ec.fieldContext_EventView_status(ctx, field)
+			case "searchRank":
+				return ec.fieldContext_EventView_searchRank(ctx, field)
+			case "searchSnippet":
+				return ec.fieldContext_EventView_searchSnippet(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type EventView", field.Name)
 		},
@@ -3872,212 +4498,656 @@ func (ec *executionContext) fieldContext_Query_ownShifts(ctx context.Context, fi
 			return nil, fmt.Errorf("no field named %q was found under type VolunteerShiftView", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_ownShifts_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_ownShifts_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_ownSessions(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_ownSessions,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().OwnSessions(ctx)
+		},
+		nil,
+		ec.marshalNSession2ᚕᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐSessionᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_ownSessions(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Session_id(ctx, field)
+			case "userAgent":
+				return ec.fieldContext_Session_userAgent(ctx, field)
+			case "ipAddress":
+				return ec.fieldContext_Session_ipAddress(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Session_createdAt(ctx, field)
+			case "lastActivityAt":
+				return ec.fieldContext_Session_lastActivityAt(ctx, field)
+			case "expiresAt":
+				return ec.fieldContext_Session_expiresAt(ctx, field)
+			case "current":
+				return ec.fieldContext_Session_current(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Session", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query_ownDataExport(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query_ownDataExport,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Query().OwnDataExport(ctx)
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query_ownDataExport(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___type,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.introspectType(fc.Args["name"].(string))
+		},
+		nil,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Query___schema,
+		func(ctx context.Context) (any, error) {
+			return ec.introspectSchema()
+		},
+		nil,
+		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ServiceType_id(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ServiceType_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ServiceType_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ServiceType",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ServiceType_code(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ServiceType_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ServiceType_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ServiceType",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ServiceType_name(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_ServiceType_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_ServiceType_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ServiceType",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_id(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
+		},
+		nil,
+		ec.marshalNID2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_userAgent(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_userAgent,
+		func(ctx context.Context) (any, error) {
+			return obj.UserAgent, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_userAgent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_ipAddress(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_ipAddress,
+		func(ctx context.Context) (any, error) {
+			return obj.IPAddress, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_ipAddress(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_createdAt(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_lastActivityAt(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_lastActivityAt,
+		func(ctx context.Context) (any, error) {
+			return obj.LastActivityAt, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_lastActivityAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Session_expiresAt(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Session_expiresAt,
+		func(ctx context.Context) (any, error) {
+			return obj.ExpiresAt, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Session_expiresAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Session",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Session_current(ctx context.Context, field graphql.CollectedField, obj *Session) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___type,
+		ec.fieldContext_Session_current,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.introspectType(fc.Args["name"].(string))
+			return obj.Current, nil
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Session_current(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Session",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _ShiftCapacity_eventId(ctx context.Context, field graphql.CollectedField, obj *ShiftCapacity) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___schema,
+		ec.fieldContext_ShiftCapacity_eventId,
 		func(ctx context.Context) (any, error) {
-			return ec.introspectSchema()
+			return obj.EventID, nil
 		},
 		nil,
-		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		ec.marshalNID2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ShiftCapacity_eventId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ShiftCapacity",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ServiceType_id(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ShiftCapacity_shiftId(ctx context.Context, field graphql.CollectedField, obj *ShiftCapacity) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ServiceType_id,
+		ec.fieldContext_ShiftCapacity_shiftId,
 		func(ctx context.Context) (any, error) {
-			return obj.ID, nil
+			return obj.ShiftID, nil
 		},
 		nil,
-		ec.marshalNInt2int,
+		ec.marshalNID2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ServiceType_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ShiftCapacity_shiftId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ServiceType",
+		Object:     "ShiftCapacity",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ServiceType_code(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ShiftCapacity_assignedVolunteers(ctx context.Context, field graphql.CollectedField, obj *ShiftCapacity) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ServiceType_code,
+		ec.fieldContext_ShiftCapacity_assignedVolunteers,
 		func(ctx context.Context) (any, error) {
-			return obj.Code, nil
+			return obj.AssignedVolunteers, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_ServiceType_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ShiftCapacity_assignedVolunteers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ServiceType",
+		Object:     "ShiftCapacity",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ServiceType_name(ctx context.Context, field graphql.CollectedField, obj *ServiceType) (ret graphql.Marshaler) {
+func (ec *executionContext) _ShiftCapacity_maxVolunteers(ctx context.Context, field graphql.CollectedField, obj *ShiftCapacity) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_ServiceType_name,
+		ec.fieldContext_ShiftCapacity_maxVolunteers,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			return obj.MaxVolunteers, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalOInt2ᚖint,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_ServiceType_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ShiftCapacity_maxVolunteers(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ServiceType",
+		Object:     "ShiftCapacity",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Subscription_shiftCapacityChanged(ctx context.Context, field graphql.CollectedField) (ret func(ctx context.Context) graphql.Marshaler) {
+	return graphql.ResolveFieldStream(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Subscription_shiftCapacityChanged,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Subscription().ShiftCapacityChanged(ctx, fc.Args["eventId"].(string))
+		},
+		nil,
+		ec.marshalNShiftCapacity2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐShiftCapacity,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Subscription_shiftCapacityChanged(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Subscription",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "eventId":
+				return ec.fieldContext_ShiftCapacity_eventId(ctx, field)
+			case "shiftId":
+				return ec.fieldContext_ShiftCapacity_shiftId(ctx, field)
+			case "assignedVolunteers":
+				return ec.fieldContext_ShiftCapacity_assignedVolunteers(ctx, field)
+			case "maxVolunteers":
+				return ec.fieldContext_ShiftCapacity_maxVolunteers(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ShiftCapacity", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Subscription_shiftCapacityChanged_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
@@ -4342,6 +5412,35 @@ func (ec *executionContext) fieldContext_VolunteerMutationResult_message(_ conte
 	return fc, nil
 }
 
+func (ec *executionContext) _VolunteerMutationResult_code(ctx context.Context, field graphql.CollectedField, obj *VolunteerMutationResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_VolunteerMutationResult_code,
+		func(ctx context.Context) (any, error) {
+			return obj.Code, nil
+		},
+		nil,
+		ec.marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐErrorCode,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_VolunteerMutationResult_code(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "VolunteerMutationResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ErrorCode does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _VolunteerShiftView_shiftId(ctx context.Context, field graphql.CollectedField, obj *VolunteerShiftView) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -6501,7 +7600,7 @@ func (ec *executionContext) unmarshalInputVolunteerEventFilterInput(ctx context.
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"cities", "distance", "eventType", "jobs", "timeFrame"}
+	fieldsInOrder := [...]string{"cities", "distance", "eventType", "jobs", "timeFrame", "search"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -6543,6 +7642,13 @@ func (ec *executionContext) unmarshalInputVolunteerEventFilterInput(ctx context.
 				return it, err
 			}
 			it.TimeFrame = data
+		case "search":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("search"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Search = data
 		}
 	}
 
@@ -6797,6 +7903,15 @@ func (ec *executionContext) _EventView(ctx context.Context, sel ast.SelectionSet
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "status":
+			out.Values[i] = ec._EventView_status(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "searchRank":
+			out.Values[i] = ec._EventView_searchRank(ctx, field, obj)
+		case "searchSnippet":
+			out.Values[i] = ec._EventView_searchSnippet(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7232,6 +8347,27 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "revokeOwnSession":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_revokeOwnSession(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "revokeOtherSessions":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_revokeOtherSessions(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "requestErasure":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_requestErasure(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7275,6 +8411,8 @@ func (ec *executionContext) _MutationResult(ctx context.Context, sel ast.Selecti
 			out.Values[i] = ec._MutationResult_message(ctx, field, obj)
 		case "id":
 			out.Values[i] = ec._MutationResult_id(ctx, field, obj)
+		case "code":
+			out.Values[i] = ec._MutationResult_code(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7370,7 +8508,51 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_eventViews(ctx, field)
+				res = ec._Query_eventViews(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "eventView":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_eventView(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "ownAttachment":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_ownAttachment(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7383,7 +8565,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "eventView":
+		case "ownFeedback":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -7392,7 +8574,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_eventView(ctx, field)
+				res = ec._Query_ownFeedback(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7405,7 +8587,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "ownAttachment":
+		case "ownProfile":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -7414,7 +8596,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_ownAttachment(ctx, field)
+				res = ec._Query_ownProfile(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7427,7 +8609,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "ownFeedback":
+		case "ownShifts":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -7436,7 +8618,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_ownFeedback(ctx, field)
+				res = ec._Query_ownShifts(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7449,7 +8631,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "ownProfile":
+		case "ownSessions":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -7458,7 +8640,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_ownProfile(ctx, field)
+				res = ec._Query_ownSessions(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7471,7 +8653,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
-		case "ownShifts":
+		case "ownDataExport":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -7480,7 +8662,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_ownShifts(ctx, field)
+				res = ec._Query_ownDataExport(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -7573,6 +8755,137 @@ func (ec *executionContext) _ServiceType(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
+var sessionImplementors = []string{"Session"}
+
+func (ec *executionContext) _Session(ctx context.Context, sel ast.SelectionSet, obj *Session) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, sessionImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Session")
+		case "id":
+			out.Values[i] = ec._Session_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "userAgent":
+			out.Values[i] = ec._Session_userAgent(ctx, field, obj)
+		case "ipAddress":
+			out.Values[i] = ec._Session_ipAddress(ctx, field, obj)
+		case "createdAt":
+			out.Values[i] = ec._Session_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastActivityAt":
+			out.Values[i] = ec._Session_lastActivityAt(ctx, field, obj)
+		case "expiresAt":
+			out.Values[i] = ec._Session_expiresAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "current":
+			out.Values[i] = ec._Session_current(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var shiftCapacityImplementors = []string{"ShiftCapacity"}
+
+func (ec *executionContext) _ShiftCapacity(ctx context.Context, sel ast.SelectionSet, obj *ShiftCapacity) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, shiftCapacityImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ShiftCapacity")
+		case "eventId":
+			out.Values[i] = ec._ShiftCapacity_eventId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "shiftId":
+			out.Values[i] = ec._ShiftCapacity_shiftId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "assignedVolunteers":
+			out.Values[i] = ec._ShiftCapacity_assignedVolunteers(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxVolunteers":
+			out.Values[i] = ec._ShiftCapacity_maxVolunteers(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var subscriptionImplementors = []string{"Subscription"}
+
+func (ec *executionContext) _Subscription(ctx context.Context, sel ast.SelectionSet) func(ctx context.Context) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, subscriptionImplementors)
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: "Subscription",
+	})
+	if len(fields) != 1 {
+		graphql.AddErrorf(ctx, "must subscribe to exactly one stream")
+		return nil
+	}
+
+	switch fields[0].Name {
+	case "shiftCapacityChanged":
+		return ec._Subscription_shiftCapacityChanged(ctx, fields[0])
+	default:
+		panic("unknown field " + strconv.Quote(fields[0].Name))
+	}
+}
+
 var venueViewImplementors = []string{"VenueView"}
 
 func (ec *executionContext) _VenueView(ctx context.Context, sel ast.SelectionSet, obj *VenueView) graphql.Marshaler {
@@ -7648,6 +8961,8 @@ func (ec *executionContext) _VolunteerMutationResult(ctx context.Context, sel as
 			}
 		case "message":
 			out.Values[i] = ec._VolunteerMutationResult_message(ctx, field, obj)
+		case "code":
+			out.Values[i] = ec._VolunteerMutationResult_code(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -8150,6 +9465,75 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 
 // region    ***************************** type.gotpl *****************************
 
+func (ec *executionContext) unmarshalNApiTokenScope2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScope(ctx context.Context, v any) (APITokenScope, error) {
+	var res APITokenScope
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNApiTokenScope2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScope(ctx context.Context, sel ast.SelectionSet, v APITokenScope) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalNApiTokenScope2ᚕvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScopeᚄ(ctx context.Context, v any) ([]APITokenScope, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]APITokenScope, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNApiTokenScope2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScope(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalNApiTokenScope2ᚕvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScopeᚄ(ctx context.Context, sel ast.SelectionSet, v []APITokenScope) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNApiTokenScope2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐAPITokenScope(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v any) (bool, error) {
 	res, err := graphql.UnmarshalBoolean(v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -8328,6 +9712,16 @@ func (ec *executionContext) marshalNEventShiftView2ᚖvolunteerᚑschedulerᚋgr
 	return ec._EventShiftView(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNEventStatus2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐEventStatus(ctx context.Context, v any) (EventStatus, error) {
+	var res EventStatus
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNEventStatus2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐEventStatus(ctx context.Context, sel ast.SelectionSet, v EventStatus) graphql.Marshaler {
+	return v
+}
+
 func (ec *executionContext) unmarshalNEventType2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐEventType(ctx context.Context, v any) (EventType, error) {
 	var res EventType
 	err := res.UnmarshalGQL(v)
@@ -8844,6 +10238,74 @@ func (ec *executionContext) marshalNServiceType2ᚖvolunteerᚑschedulerᚋgraph
 	return ec._ServiceType(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNSession2ᚕᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐSessionᚄ(ctx context.Context, sel ast.SelectionSet, v []*Session) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNSession2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐSession(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNSession2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐSession(ctx context.Context, sel ast.SelectionSet, v *Session) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._Session(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNShiftCapacity2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐShiftCapacity(ctx context.Context, sel ast.SelectionSet, v ShiftCapacity) graphql.Marshaler {
+	return ec._ShiftCapacity(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNShiftCapacity2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐShiftCapacity(ctx context.Context, sel ast.SelectionSet, v *ShiftCapacity) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ShiftCapacity(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNShiftTimeFilter2volunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐShiftTimeFilter(ctx context.Context, v any) (ShiftTimeFilter, error) {
 	var res ShiftTimeFilter
 	err := res.UnmarshalGQL(v)
@@ -9291,6 +10753,22 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) unmarshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐErrorCode(ctx context.Context, v any) (*ErrorCode, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(ErrorCode)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOErrorCode2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐErrorCode(ctx context.Context, sel ast.SelectionSet, v *ErrorCode) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOEventType2ᚖvolunteerᚑschedulerᚋgraphᚋvolunteerᚋgeneratedᚐEventType(ctx context.Context, v any) (*EventType, error) {
 	if v == nil {
 		return nil, nil
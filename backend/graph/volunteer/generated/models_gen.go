@@ -40,6 +40,9 @@ type EventView struct {
 	ServiceTypes   []string             `json:"serviceTypes,omitempty"`
 	EventDates     []*EventDateView     `json:"eventDates"`
 	ShiftSummaries []*EventShiftSummary `json:"shiftSummaries"`
+	Status         EventStatus          `json:"status"`
+	SearchRank     *float64             `json:"searchRank,omitempty"`
+	SearchSnippet  *string              `json:"searchSnippet,omitempty"`
 }
 
 type FeedbackAttachmentView struct {
@@ -94,9 +97,10 @@ type Mutation struct {
 }
 
 type MutationResult struct {
-	Success bool    `json:"success"`
-	Message *string `json:"message,omitempty"`
-	ID      *string `json:"id,omitempty"`
+	Success bool       `json:"success"`
+	Message *string    `json:"message,omitempty"`
+	ID      *string    `json:"id,omitempty"`
+	Code    *ErrorCode `json:"code,omitempty"`
 }
 
 type NewFeedbackInput struct {
@@ -115,6 +119,26 @@ type ServiceType struct {
 	Name string `json:"name"`
 }
 
+type Session struct {
+	ID             string  `json:"id"`
+	UserAgent      *string `json:"userAgent,omitempty"`
+	IPAddress      *string `json:"ipAddress,omitempty"`
+	CreatedAt      string  `json:"createdAt"`
+	LastActivityAt *string `json:"lastActivityAt,omitempty"`
+	ExpiresAt      string  `json:"expiresAt"`
+	Current        bool    `json:"current"`
+}
+
+type ShiftCapacity struct {
+	EventID            string `json:"eventId"`
+	ShiftID            string `json:"shiftId"`
+	AssignedVolunteers int    `json:"assignedVolunteers"`
+	MaxVolunteers      *int   `json:"maxVolunteers,omitempty"`
+}
+
+type Subscription struct {
+}
+
 type UpdateOwnProfileInput struct {
 	FirstName string  `json:"firstName"`
 	LastName  string  `json:"lastName"`
@@ -140,6 +164,7 @@ type VolunteerEventFilterInput struct {
 	EventType *EventType       `json:"eventType,omitempty"`
 	Jobs      []int            `json:"jobs,omitempty"`
 	TimeFrame *ShiftTimeFilter `json:"timeFrame,omitempty"`
+	Search    *string          `json:"search,omitempty"`
 }
 
 type VolunteerFeedbackNoteInput struct {
@@ -148,8 +173,9 @@ type VolunteerFeedbackNoteInput struct {
 }
 
 type VolunteerMutationResult struct {
-	Success bool    `json:"success"`
-	Message *string `json:"message,omitempty"`
+	Success bool       `json:"success"`
+	Message *string    `json:"message,omitempty"`
+	Code    *ErrorCode `json:"code,omitempty"`
 }
 
 type VolunteerShiftView struct {
@@ -178,6 +204,191 @@ type VolunteerView struct {
 	Roles     []Role  `json:"roles"`
 }
 
+type APITokenScope string
+
+const (
+	APITokenScopeAdminReadOnly   APITokenScope = "ADMIN_READ_ONLY"
+	APITokenScopeReporting       APITokenScope = "REPORTING"
+	APITokenScopeVolunteerSignup APITokenScope = "VOLUNTEER_SIGNUP"
+)
+
+var AllAPITokenScope = []APITokenScope{
+	APITokenScopeAdminReadOnly,
+	APITokenScopeReporting,
+	APITokenScopeVolunteerSignup,
+}
+
+func (e APITokenScope) IsValid() bool {
+	switch e {
+	case APITokenScopeAdminReadOnly, APITokenScopeReporting, APITokenScopeVolunteerSignup:
+		return true
+	}
+	return false
+}
+
+func (e APITokenScope) String() string {
+	return string(e)
+}
+
+func (e *APITokenScope) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = APITokenScope(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ApiTokenScope", str)
+	}
+	return nil
+}
+
+func (e APITokenScope) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *APITokenScope) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e APITokenScope) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation      ErrorCode = "VALIDATION"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeCapacityFull    ErrorCode = "CAPACITY_FULL"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	ErrorCodeRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrorCodeReadOnly        ErrorCode = "READ_ONLY"
+	ErrorCodeInternal        ErrorCode = "INTERNAL"
+)
+
+var AllErrorCode = []ErrorCode{
+	ErrorCodeNotFound,
+	ErrorCodeValidation,
+	ErrorCodeConflict,
+	ErrorCodeCapacityFull,
+	ErrorCodeForbidden,
+	ErrorCodeUnauthenticated,
+	ErrorCodeRateLimited,
+	ErrorCodeReadOnly,
+	ErrorCodeInternal,
+}
+
+func (e ErrorCode) IsValid() bool {
+	switch e {
+	case ErrorCodeNotFound, ErrorCodeValidation, ErrorCodeConflict, ErrorCodeCapacityFull, ErrorCodeForbidden, ErrorCodeUnauthenticated, ErrorCodeRateLimited, ErrorCodeReadOnly, ErrorCodeInternal:
+		return true
+	}
+	return false
+}
+
+func (e ErrorCode) String() string {
+	return string(e)
+}
+
+func (e *ErrorCode) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ErrorCode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ErrorCode", str)
+	}
+	return nil
+}
+
+func (e ErrorCode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ErrorCode) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ErrorCode) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "DRAFT"
+	EventStatusPublished EventStatus = "PUBLISHED"
+	EventStatusCancelled EventStatus = "CANCELLED"
+	EventStatusCompleted EventStatus = "COMPLETED"
+)
+
+var AllEventStatus = []EventStatus{
+	EventStatusDraft,
+	EventStatusPublished,
+	EventStatusCancelled,
+	EventStatusCompleted,
+}
+
+func (e EventStatus) IsValid() bool {
+	switch e {
+	case EventStatusDraft, EventStatusPublished, EventStatusCancelled, EventStatusCompleted:
+		return true
+	}
+	return false
+}
+
+func (e EventStatus) String() string {
+	return string(e)
+}
+
+func (e *EventStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = EventStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid EventStatus", str)
+	}
+	return nil
+}
+
+func (e EventStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *EventStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e EventStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type EventType string
 
 const (
@@ -415,16 +626,18 @@ type Role string
 const (
 	RoleVolunteer     Role = "VOLUNTEER"
 	RoleAdministrator Role = "ADMINISTRATOR"
+	RoleCoordinator   Role = "COORDINATOR"
 )
 
 var AllRole = []Role{
 	RoleVolunteer,
 	RoleAdministrator,
+	RoleCoordinator,
 }
 
 func (e Role) IsValid() bool {
 	switch e {
-	case RoleVolunteer, RoleAdministrator:
+	case RoleVolunteer, RoleAdministrator, RoleCoordinator:
 		return true
 	}
 	return false
@@ -0,0 +1,30 @@
+package volunteer
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+
+	"volunteer-scheduler/middleware"
+)
+
+// ReadOnlyWhenImpersonating refuses every mutation made while an
+// administrator is viewing the schema as a volunteer (see
+// middleware.Impersonate), so impersonation can never change anything.
+// Register it with handler.Server.AroundOperations.
+func ReadOnlyWhenImpersonating(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	if _, impersonating := middleware.ImpersonatorFromContext(ctx); impersonating {
+		oc := graphql.GetOperationContext(ctx)
+		if oc.Operation != nil && oc.Operation.Operation == ast.Mutation {
+			return graphql.OneShot(&graphql.Response{
+				Errors: gqlerror.List{{
+					Message:    "read-only: changes are disabled while viewing as a volunteer",
+					Extensions: map[string]any{"code": "READ_ONLY"},
+				}},
+			})
+		}
+	}
+	return next(ctx)
+}
@@ -7,10 +7,13 @@ import (
 
 // Resolver holds the services needed by GraphQL resolvers
 type Resolver struct {
-	DB               *sql.DB
-	EventService     *services.EventService
-	VolunteerService *services.VolunteerService
-	ShiftService     *services.ShiftService
-	VenueService     *services.VenueService
-	FeedbackService  *services.FeedbackService
+	DB                *sql.DB
+	EventService      *services.EventService
+	VolunteerService  *services.VolunteerService
+	ShiftService      *services.ShiftService
+	VenueService      *services.VenueService
+	FeedbackService   *services.FeedbackService
+	SessionService    *services.SessionService
+	PrivacyService    *services.PrivacyService
+	ShiftCapacityFeed *services.ShiftCapacityFeed
 }
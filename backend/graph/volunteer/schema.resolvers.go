@@ -7,16 +7,16 @@ package volunteer
 
 import (
 	"context"
-	"fmt"
 	"volunteer-scheduler/graph/volunteer/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
 )
 
 // AddVolunteerFeedbackNote is the resolver for the addVolunteerFeedbackNote field.
 func (r *mutationResolver) AddVolunteerFeedbackNote(ctx context.Context, note generated.VolunteerFeedbackNoteInput) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.FeedbackService.AddVolunteerFeedbackNote(ctx, volId, toModelVolunteerFeedbackNoteInput(note))
@@ -30,7 +30,7 @@ func (r *mutationResolver) AddVolunteerFeedbackNote(ctx context.Context, note ge
 func (r *mutationResolver) UpdateOwnProfile(ctx context.Context, profile generated.UpdateOwnProfileInput) (*generated.VolunteerMutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	modProf := toModelUpdateOwnProfileInput(&profile)
@@ -46,7 +46,7 @@ func (r *mutationResolver) UpdateOwnProfile(ctx context.Context, profile generat
 func (r *mutationResolver) AssignSelfToShift(ctx context.Context, shiftID string) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.ShiftService.AssignSelfToShift(ctx, shiftID, volId)
@@ -61,7 +61,7 @@ func (r *mutationResolver) AssignSelfToShift(ctx context.Context, shiftID string
 func (r *mutationResolver) CancelOwnShift(ctx context.Context, shiftID string) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.ShiftService.CancelOwnShift(ctx, shiftID, volId)
@@ -72,6 +72,54 @@ func (r *mutationResolver) CancelOwnShift(ctx context.Context, shiftID string) (
 	return toGenMutationResult(result), nil
 }
 
+// RevokeOwnSession is the resolver for the revokeOwnSession field.
+func (r *mutationResolver) RevokeOwnSession(ctx context.Context, sessionID string) (*generated.MutationResult, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.SessionService.RevokeOwnSession(ctx, volId, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGenMutationResult(result), nil
+}
+
+// RevokeOtherSessions is the resolver for the revokeOtherSessions field.
+func (r *mutationResolver) RevokeOtherSessions(ctx context.Context) (*generated.MutationResult, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+	token, ok := middleware.SessionTokenFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.SessionService.RevokeOtherSessions(ctx, volId, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGenMutationResult(result), nil
+}
+
+// RequestErasure is the resolver for the requestErasure field.
+func (r *mutationResolver) RequestErasure(ctx context.Context, reason *string) (*generated.VolunteerMutationResult, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+
+	result, err := r.PrivacyService.RequestErasure(ctx, volId, reason)
+	if err != nil {
+		return nil, err
+	}
+	return toGenVolunteerMutationResult(result), nil
+}
+
 // EventShiftViews is the resolver for the eventShiftViews field.
 func (r *queryResolver) EventShiftViews(ctx context.Context, eventID string) ([]*generated.EventShiftView, error) {
 	sv, err := r.ShiftService.FetchEventShiftViews(ctx, eventID)
@@ -85,7 +133,7 @@ func (r *queryResolver) EventShiftViews(ctx context.Context, eventID string) ([]
 func (r *queryResolver) EventViews(ctx context.Context, filter *generated.VolunteerEventFilterInput) ([]*generated.EventView, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 	ev, err := r.EventService.FetchEventViews(ctx, toModelVolunteerEventFilterInput(filter), &volId)
 	if err != nil {
@@ -107,7 +155,7 @@ func (r *queryResolver) EventView(ctx context.Context, eventID string) (*generat
 func (r *queryResolver) OwnAttachment(ctx context.Context, attachmentID int) (*generated.FeedbackAttachmentView, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 	att, err := r.FeedbackService.FetchOwnAttachment(ctx, attachmentID, volId)
 	if err != nil {
@@ -120,7 +168,7 @@ func (r *queryResolver) OwnAttachment(ctx context.Context, attachmentID int) (*g
 func (r *queryResolver) OwnFeedback(ctx context.Context) ([]*generated.FeedbackView, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	fb, err := r.FeedbackService.FetchOwnFeedback(ctx, volId)
@@ -134,7 +182,7 @@ func (r *queryResolver) OwnFeedback(ctx context.Context) ([]*generated.FeedbackV
 func (r *queryResolver) OwnProfile(ctx context.Context) (*generated.VolunteerView, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 	v, err := r.VolunteerService.FetchOwnProfile(ctx, volId)
 	if err != nil {
@@ -147,7 +195,7 @@ func (r *queryResolver) OwnProfile(ctx context.Context) (*generated.VolunteerVie
 func (r *queryResolver) OwnShifts(ctx context.Context, filter generated.ShiftTimeFilter) ([]*generated.VolunteerShiftView, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	shifts, err := r.VolunteerService.FetchOwnShifts(ctx, volId, toModelShiftTimeFilter(filter))
@@ -157,3 +205,60 @@ func (r *queryResolver) OwnShifts(ctx context.Context, filter generated.ShiftTim
 
 	return toGenVolunteerShiftViews(shifts), nil
 }
+
+// OwnSessions is the resolver for the ownSessions field.
+func (r *queryResolver) OwnSessions(ctx context.Context) ([]*generated.Session, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+	// Where and on what devices the volunteer is signed in is theirs alone;
+	// revoking is already refused with every other mutation.
+	if _, impersonating := middleware.ImpersonatorFromContext(ctx); impersonating {
+		return nil, models.NewError(models.ErrorCodeReadOnly, "read-only: sessions are not available while viewing as a volunteer")
+	}
+	token, _ := middleware.SessionTokenFromContext(ctx)
+
+	sessions, err := r.SessionService.FetchOwnSessions(ctx, volId, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGenSessions(sessions), nil
+}
+
+// OwnDataExport is the resolver for the ownDataExport field.
+func (r *queryResolver) OwnDataExport(ctx context.Context) (string, error) {
+	volId, ok := middleware.VolunteerIdFromContext(ctx)
+	if !ok {
+		return "", models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
+	}
+	// The export is for the volunteer; viewing as them is not a way to get it.
+	if _, impersonating := middleware.ImpersonatorFromContext(ctx); impersonating {
+		return "", models.NewError(models.ErrorCodeReadOnly, "read-only: the data export is not available while viewing as a volunteer")
+	}
+
+	export, err := r.PrivacyService.ExportVolunteerData(ctx, volId)
+	if err != nil {
+		return "", err
+	}
+	return string(export), nil
+}
+
+// ShiftCapacityChanged is the resolver for the shiftCapacityChanged field.
+func (r *subscriptionResolver) ShiftCapacityChanged(ctx context.Context, eventID string) (<-chan *generated.ShiftCapacity, error) {
+	// Only events a volunteer could open in eventView can be watched.
+	if _, err := r.EventService.FetchEventView(ctx, eventID); err != nil {
+		return nil, err
+	}
+	changes, err := r.ShiftCapacityFeed.SubscribeToEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return toGenShiftCapacityStream(ctx, changes), nil
+}
+
+// Subscription returns generated.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
+
+type subscriptionResolver struct{ *Resolver }
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"volunteer-scheduler/graph/volunteer/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
 
 	"github.com/99designs/gqlgen/graphql"
 )
@@ -40,7 +41,7 @@ func (r *mutationResolver) AttachFileToFeedback(ctx context.Context, feedbackID
 func (r *mutationResolver) GiveFeedback(ctx context.Context, feedback generated.NewFeedbackInput) (*generated.MutationResult, error) {
 	volId, ok := middleware.VolunteerIdFromContext(ctx)
 	if !ok {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeUnauthenticated, "unauthorized")
 	}
 
 	result, err := r.FeedbackService.CreateNewFeedback(ctx, volId, toModelNewFeedbackInput(feedback))
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// AuthorizeTokenScope enforces the @tokenScope directive on root fields for
+// requests made with an API token (see RequireAuth). A token may only call
+// fields whose @tokenScope lists its scope; fields without the directive are
+// closed to every token. Session requests are not affected. Register it with
+// handler.Server.AroundRootFields on each authenticated schema, and wrapped
+// in SubscriptionRootFields for subscriptions.
+func AuthorizeTokenScope(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	scope, viaAPIToken := APITokenScopeFromContext(ctx)
+	if !viaAPIToken {
+		return next(ctx)
+	}
+
+	fc := graphql.GetRootFieldContext(ctx)
+	if fc == nil || fc.Field.Field == nil || fc.Field.Definition == nil {
+		return next(ctx)
+	}
+	// Introspection (__schema, __type, __typename) carries no data.
+	if strings.HasPrefix(fc.Field.Name, "__") {
+		return next(ctx)
+	}
+
+	if d := fc.Field.Definition.Directives.ForName("tokenScope"); d != nil {
+		if arg := d.Arguments.ForName("scopes"); arg != nil && arg.Value != nil {
+			for _, child := range arg.Value.Children {
+				if child.Value.Raw == string(scope) {
+					return next(ctx)
+				}
+			}
+		}
+	}
+
+	graphql.AddError(ctx, &gqlerror.Error{
+		Message:    "forbidden: not allowed for this API token",
+		Path:       graphql.GetPath(ctx),
+		Extensions: map[string]any{"code": "FORBIDDEN"},
+	})
+	return graphql.Null
+}
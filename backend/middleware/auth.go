@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 	"volunteer-scheduler/models"
@@ -11,42 +13,60 @@ import (
 // If the token is missing or invalid, it returns a 401 Unauthorized response.
 // On success, stores the volunteer ID, role, ResponseWriter, and Request in
 // the request context (the latter two so resolvers can set/clear cookies).
-func RequireAuth(magicLinkService *services.MagicLinkService, next http.Handler) http.Handler {
+//
+// A bearer token starting with services.APITokenPrefix is checked against
+// apiTokenService instead, and the token's scope is stored in the context for
+// AuthorizeTokenScope. A nil apiTokenService rejects API tokens.
+func RequireAuth(magicLinkService *services.MagicLinkService, apiTokenService *services.APITokenService, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
-		// 1. Try the HttpOnly session cookie first.
-		var token string
-		if cookie, err := r.Cookie("session"); err == nil {
-			token = cookie.Value
+		token, fromCookie := requestToken(r)
+		if token == "" {
+			http.Error(w, `{"errors":[{"message":"unauthorized"}]}`, http.StatusUnauthorized)
+			return
 		}
 
-		// 2. Fall back to the Authorization: Bearer header (keeps API clients working).
-		if token == "" {
-			authHeader := r.Header.Get("Authorization")
-			if authHeader != "" {
-				parts := strings.SplitN(authHeader, " ", 2)
-				if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
-					token = parts[1]
+		if !fromCookie && services.IsAPIToken(token) {
+			if apiTokenService == nil {
+				http.Error(w, `{"errors":[{"message":"invalid or expired API token"}]}`, http.StatusUnauthorized)
+				return
+			}
+			apiToken, err := apiTokenService.ValidateAPIToken(r.Context(), token, ClientIP(r))
+			if err != nil {
+				if !errors.Is(err, services.ErrAPITokenInvalid) {
+					log.Printf("API token check failed: %v", err)
 				}
+				http.Error(w, `{"errors":[{"message":"invalid or expired API token"}]}`, http.StatusUnauthorized)
+				return
 			}
-		}
 
-		if token == "" {
-			http.Error(w, `{"errors":[{"message":"unauthorized"}]}`, http.StatusUnauthorized)
+			// No session token: session resolvers have nothing to act on.
+			ctx := ContextWithVolunteerId(r.Context(), apiToken.VolunteerId)
+			ctx = ContextWithVolunteerRoles(ctx, apiToken.Roles)
+			ctx = ContextWithAPITokenScope(ctx, apiToken.Scope)
+			ctx = ContextWithResponseWriter(ctx, w)
+			ctx = ContextWithRequest(ctx, r)
+			next.ServeHTTP(w, r.WithContext(ctx))
 			return
 		}
 
 		// Validate the session token — returns volunteer ID and roles.
-		volId, roles, err := magicLinkService.ValidateSessionToken(r.Context(), token)
+		session, err := magicLinkService.ValidateSessionToken(r.Context(), token)
 		if err != nil {
 			http.Error(w, `{"errors":[{"message":"invalid or expired session"}]}`, http.StatusUnauthorized)
 			return
 		}
 
+		// Sliding renewal extended the session; keep the cookie in step.
+		if session.RenewedUntil != nil && fromCookie {
+			SetSessionCookie(w, token, *session.RenewedUntil, isProd())
+		}
+
 		// Store volunteer ID, roles, ResponseWriter, and Request in the context
 		// so resolvers can read the session cookie and set/clear it on login/logout.
-		ctx := ContextWithVolunteerId(r.Context(), volId)
-		ctx = ContextWithVolunteerRoles(ctx, roles)
+		ctx := ContextWithVolunteerId(r.Context(), session.VolunteerId)
+		ctx = ContextWithVolunteerRoles(ctx, session.Roles)
+		ctx = ContextWithSessionToken(ctx, token)
 		ctx = ContextWithResponseWriter(ctx, w)
 		ctx = ContextWithRequest(ctx, r)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -54,11 +74,13 @@ func RequireAuth(magicLinkService *services.MagicLinkService, next http.Handler)
 }
 
 // RequireAdmin wraps RequireAuth and additionally enforces that the caller
-// has the ADMINISTRATOR role. Returns 403 Forbidden if they do not.
-func RequireAdmin(magicLinkService *services.MagicLinkService, next http.Handler) http.Handler {
-	return RequireAuth(magicLinkService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+// has the ADMINISTRATOR or COORDINATOR role. Returns 403 Forbidden if they do
+// not. Which fields and records a coordinator may use is decided inside the
+// admin schema (see admin.AuthorizeRootField).
+func RequireAdmin(magicLinkService *services.MagicLinkService, apiTokenService *services.APITokenService, next http.Handler) http.Handler {
+	return RequireAuth(magicLinkService, apiTokenService, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		roles, ok := VolunteerRolesFromContext(r.Context())
-		if !ok || !hasRole(roles, string(models.RoleAdministrator)) {
+		if !ok || !(hasRole(roles, string(models.RoleAdministrator)) || hasRole(roles, string(models.RoleCoordinator))) {
 			http.Error(w, `{"errors":[{"message":"forbidden"}]}`, http.StatusForbidden)
 			return
 		}
@@ -66,6 +88,22 @@ func RequireAdmin(magicLinkService *services.MagicLinkService, next http.Handler
 	}))
 }
 
+// requestToken returns the credential a request carries: the HttpOnly session
+// cookie, or failing that an Authorization: Bearer token (API clients).
+func requestToken(r *http.Request) (token string, fromCookie bool) {
+	if cookie, err := r.Cookie("session"); err == nil && cookie.Value != "" {
+		return cookie.Value, true
+	}
+	authHeader := r.Header.Get("Authorization")
+	if authHeader != "" {
+		parts := strings.SplitN(authHeader, " ", 2)
+		if len(parts) == 2 && strings.ToLower(parts[0]) == "bearer" {
+			return parts[1], false
+		}
+	}
+	return "", false
+}
+
 // hasRole returns true when role is present in the roles slice.
 func hasRole(roles []string, role string) bool {
 	for _, r := range roles {
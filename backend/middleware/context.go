@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"net/http"
+	"volunteer-scheduler/models"
 )
 
 // These key are "private" to avoid collisions with other packages.
@@ -13,6 +14,9 @@ const (
 	volunteerRoleContextKey contextKey = "volunteerRole"
 	responseWriterKey       contextKey = "httpResponseWriter"
 	httpRequestKey          contextKey = "httpRequest"
+	sessionTokenKey         contextKey = "sessionToken"
+	impersonatorKey         contextKey = "impersonator"
+	apiTokenScopeKey        contextKey = "apiTokenScope"
 )
 
 // ContextWithVolunteerId stores the authenticated volunteer id in the context.
@@ -37,6 +41,44 @@ func VolunteerRolesFromContext(ctx context.Context) ([]string, bool) {
 	return roles, ok
 }
 
+// ContextWithSessionToken stores the caller's raw session token in the context,
+// so resolvers can tell which session is the current one.
+func ContextWithSessionToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, sessionTokenKey, token)
+}
+
+// SessionTokenFromContext retrieves the caller's raw session token from the context.
+func SessionTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(sessionTokenKey).(string)
+	return token, ok
+}
+
+// ContextWithImpersonator marks the request as an administrator viewing the
+// volunteer schema as someone else; adminId is the real caller.
+func ContextWithImpersonator(ctx context.Context, adminId int) context.Context {
+	return context.WithValue(ctx, impersonatorKey, adminId)
+}
+
+// ImpersonatorFromContext returns the administrator behind an impersonated
+// request. ok is false for ordinary requests.
+func ImpersonatorFromContext(ctx context.Context) (int, bool) {
+	adminId, ok := ctx.Value(impersonatorKey).(int)
+	return adminId, ok
+}
+
+// ContextWithAPITokenScope marks the request as made with an API token
+// rather than a login session.
+func ContextWithAPITokenScope(ctx context.Context, scope models.APITokenScope) context.Context {
+	return context.WithValue(ctx, apiTokenScopeKey, scope)
+}
+
+// APITokenScopeFromContext returns the scope of the API token behind the
+// request. ok is false for session (cookie or bearer) requests.
+func APITokenScopeFromContext(ctx context.Context) (models.APITokenScope, bool) {
+	scope, ok := ctx.Value(apiTokenScopeKey).(models.APITokenScope)
+	return scope, ok
+}
+
 // HTTP keys
 // HTTPresponse writer key - so the resolver can call SetCookie() when the user logs in or out.
 
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"log"
+
+	"volunteer-scheduler/models"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// ErrorPresenter turns resolver errors into GraphQL errors with a
+// machine-readable extensions.code (see models.ErrorCode), and field-level
+// details in extensions.fields for validation errors. Anything that is not a
+// *models.Error (or a missing row or malformed ID) is an internal error: its
+// text may name tables or hosts, so the client only gets a correlation ID,
+// which is logged with the real error. Errors built as *gqlerror.Error for
+// the client, such as query validation and authorization failures, pass
+// through unchanged. Register it with handler.Server.SetErrorPresenter.
+func ErrorPresenter(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	if gqlErr.Err == nil {
+		return gqlErr
+	}
+	cause := gqlErr.Err
+
+	var appErr *models.Error
+	switch code := models.ErrorCodeOf(cause); {
+	case errors.As(cause, &appErr) && code != models.ErrorCodeInternal:
+		gqlErr.Message = appErr.Message
+		gqlErr.Extensions = map[string]any{"code": code}
+		if len(appErr.Fields) > 0 {
+			fields := make([]map[string]string, len(appErr.Fields))
+			for i, f := range appErr.Fields {
+				fields[i] = map[string]string{"field": f.Field, "message": f.Message}
+			}
+			gqlErr.Extensions["fields"] = fields
+		}
+		return gqlErr
+	case errors.Is(cause, sql.ErrNoRows):
+		gqlErr.Message = "not found"
+		gqlErr.Extensions = map[string]any{"code": code}
+		return gqlErr
+	case code == models.ErrorCodeValidation:
+		gqlErr.Message = "invalid ID"
+		gqlErr.Extensions = map[string]any{"code": code}
+		return gqlErr
+	}
+
+	id := correlationID()
+	log.Printf("GraphQL error %s at %v: %v", id, gqlErr.Path, cause)
+	message := "internal server error"
+	if appErr != nil {
+		message = appErr.Message
+	}
+	return &gqlerror.Error{
+		Message:    message,
+		Path:       gqlErr.Path,
+		Extensions: map[string]any{"code": models.ErrorCodeInternal, "correlationId": id},
+	}
+}
+
+// correlationID is a short random ID that ties an error shown to a client to
+// its log line.
+func correlationID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
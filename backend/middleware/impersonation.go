@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
+)
+
+// ImpersonationHeader carries the token returned by the admin
+// startImpersonation mutation.
+const ImpersonationHeader = "X-Impersonate"
+
+// Impersonate lets an administrator call the volunteer schema as another
+// volunteer. It runs inside RequireAuth: when the X-Impersonate header is
+// present, the caller must be an ADMINISTRATOR holding a live impersonation
+// token, and the volunteer id and roles in the context are replaced with the
+// impersonated volunteer's. The real caller is kept via
+// ContextWithImpersonator so the schema can refuse mutations.
+func Impersonate(impersonationService *services.ImpersonationService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(ImpersonationHeader)
+		if token == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Impersonation is for an administrator at a browser, never a script.
+		_, viaAPIToken := APITokenScopeFromContext(r.Context())
+		adminId, ok := VolunteerIdFromContext(r.Context())
+		roles, _ := VolunteerRolesFromContext(r.Context())
+		if !ok || viaAPIToken || !hasRole(roles, string(models.RoleAdministrator)) {
+			http.Error(w, `{"errors":[{"message":"forbidden"}]}`, http.StatusForbidden)
+			return
+		}
+
+		volId, volRoles, err := impersonationService.ValidateImpersonation(r.Context(), token, adminId)
+		if err != nil {
+			if !errors.Is(err, services.ErrImpersonationInvalid) {
+				log.Printf("Impersonation check failed: %v", err)
+			}
+			http.Error(w, `{"errors":[{"message":"impersonation expired or invalid"}]}`, http.StatusForbidden)
+			return
+		}
+
+		ctx := ContextWithVolunteerId(r.Context(), volId)
+		ctx = ContextWithVolunteerRoles(ctx, volRoles)
+		ctx = ContextWithImpersonator(ctx, adminId)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,285 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// QueryLimits bounds how much work a single GraphQL operation may ask for.
+// Complexity is gqlgen's: every field costs 1 plus the cost of its
+// selections, and list fields multiply their selections by the number of
+// items they are expected to return (see WithListCosts). Depth counts nested
+// selection sets, so `{ eventViews { eventDates { startDateTime } } }` is 3.
+type QueryLimits struct {
+	MaxComplexity int
+	MaxDepth      int
+}
+
+// QueryLimitsFromEnv returns fallback overridden by the endpoint's
+// environment variables, e.g. for endpoint "VOLUNTEER":
+//
+//	GRAPHQL_VOLUNTEER_MAX_COMPLEXITY
+//	GRAPHQL_VOLUNTEER_MAX_DEPTH
+//
+// Missing or non-positive values keep the fallback.
+func QueryLimitsFromEnv(endpoint string, fallback QueryLimits) QueryLimits {
+	limits := fallback
+	if val, err := strconv.Atoi(os.Getenv("GRAPHQL_" + endpoint + "_MAX_COMPLEXITY")); err == nil && val > 0 {
+		limits.MaxComplexity = val
+	}
+	if val, err := strconv.Atoi(os.Getenv("GRAPHQL_" + endpoint + "_MAX_DEPTH")); err == nil && val > 0 {
+		limits.MaxDepth = val
+	}
+	return limits
+}
+
+// ============================================================================
+// List costs
+// ============================================================================
+
+// DefaultListSize is the number of items assumed for a list field that has
+// no entry in its schema's list sizes.
+const DefaultListSize = 10
+
+// defaultFirst matches the page size the services use when a connection
+// field is queried without `first`.
+const defaultFirst = 50
+
+// WithListCosts wraps es so that list fields cost their selections times the
+// number of items they are expected to return: sizes["Type.field"] if set,
+// DefaultListSize otherwise. A field taking `first` is a page of that many
+// items; the edges of the connection it returns are then counted once, not
+// multiplied again. Complexity functions set on the generated Config still
+// take precedence. It panics if sizes names a field that is not a list, so
+// a renamed field cannot silently fall back to the default.
+func WithListCosts(es graphql.ExecutableSchema, sizes map[string]int) graphql.ExecutableSchema {
+	for name := range sizes {
+		typeName, field, _ := strings.Cut(name, ".")
+		var fieldDef *ast.FieldDefinition
+		if def := es.Schema().Types[typeName]; def != nil {
+			fieldDef = def.Fields.ForName(field)
+		}
+		if fieldDef == nil || fieldDef.Type.Elem == nil {
+			panic(fmt.Sprintf("list size for %s: no such list field", name))
+		}
+	}
+	return &listCostSchema{ExecutableSchema: es, sizes: sizes}
+}
+
+type listCostSchema struct {
+	graphql.ExecutableSchema
+	sizes map[string]int
+}
+
+func (s *listCostSchema) Complexity(ctx context.Context, typeName, field string, childComplexity int, args map[string]any) (int, bool) {
+	if c, ok := s.ExecutableSchema.Complexity(ctx, typeName, field, childComplexity, args); ok {
+		return c, ok
+	}
+	def := s.Schema().Types[typeName]
+	if def == nil {
+		return 0, false
+	}
+	fieldDef := def.Fields.ForName(field)
+	if fieldDef == nil {
+		return 0, false
+	}
+
+	if fieldDef.Arguments.ForName("first") != nil {
+		first := defaultFirst
+		if n, ok := args["first"].(int); ok && n >= 0 {
+			first = n
+		}
+		return listCost(childComplexity, first), true
+	}
+	if fieldDef.Type.Elem == nil || strings.HasSuffix(typeName, "Connection") {
+		return 0, false
+	}
+	size, ok := s.sizes[typeName+"."+field]
+	if !ok {
+		size = DefaultListSize
+	}
+	return listCost(childComplexity, size), true
+}
+
+// listCost is 1 + childComplexity*size, saturating rather than overflowing.
+func listCost(childComplexity, size int) int {
+	if size <= 0 || childComplexity <= 0 {
+		return 1
+	}
+	if childComplexity > (math.MaxInt-1)/size {
+		return math.MaxInt
+	}
+	return 1 + childComplexity*size
+}
+
+// ============================================================================
+// Depth limit
+// ============================================================================
+
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations nested deeper than Max. Introspection fields
+// are not counted: the standard introspection query is deep by design and is
+// switched off in production anyway.
+type DepthLimit struct {
+	Max int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d DepthLimit) Validate(graphql.ExecutableSchema) error {
+	if d.Max <= 0 {
+		return errors.New("DepthLimit.Max must be positive")
+	}
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if op == nil {
+		return nil
+	}
+	if depth := selectionDepth(op.SelectionSet); depth > d.Max {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.Max)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// selectionDepth is the number of nested field levels in set. Validation has
+// already rejected fragment cycles.
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, sel := range set {
+		var d int
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(s.SelectionSet)
+		case *ast.InlineFragment:
+			d = selectionDepth(s.SelectionSet)
+		case *ast.FragmentSpread:
+			if s.Definition != nil {
+				d = selectionDepth(s.Definition.SelectionSet)
+			}
+		}
+		depth = max(depth, d)
+	}
+	return depth
+}
+
+// ============================================================================
+// Persisted query allowlist
+// ============================================================================
+
+const (
+	errPersistedQueryNotFound   = "PERSISTED_QUERY_NOT_FOUND"
+	errPersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+)
+
+// PersistedQueryAllowlist only runs operations whose SHA-256 hash is in a
+// manifest shipped with the frontend. Clients may send the full query, whose
+// hash must be listed, or only its hash in the Apollo persistedQuery
+// extension, in which case the query is taken from the manifest. Requests
+// made with an API token are exempt: integrations write their own queries and
+// are limited by @tokenScope instead.
+//
+// Use it in place of extension.AutomaticPersistedQuery, which would let any
+// client register new queries.
+type PersistedQueryAllowlist struct {
+	queries map[string]string // by hex SHA-256 of the query
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = (*PersistedQueryAllowlist)(nil)
+
+// LoadPersistedQueryAllowlist reads a manifest: a JSON object mapping each
+// query's hex SHA-256 hash to the query text.
+func LoadPersistedQueryAllowlist(path string) (*PersistedQueryAllowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var queries map[string]string
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("parse persisted queries: %w", err)
+	}
+	return NewPersistedQueryAllowlist(queries)
+}
+
+// NewPersistedQueryAllowlist returns an allowlist of queries, keyed by the
+// hex SHA-256 hash of each query.
+func NewPersistedQueryAllowlist(queries map[string]string) (*PersistedQueryAllowlist, error) {
+	allowlist := &PersistedQueryAllowlist{queries: make(map[string]string, len(queries))}
+	for hash, query := range queries {
+		hash = strings.ToLower(hash)
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("persisted query %s does not match its hash", hash)
+		}
+		allowlist.queries[hash] = query
+	}
+	return allowlist, nil
+}
+
+func (a *PersistedQueryAllowlist) ExtensionName() string {
+	return "PersistedQueryAllowlist"
+}
+
+func (a *PersistedQueryAllowlist) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (a *PersistedQueryAllowlist) MutateOperationParameters(ctx context.Context, params *graphql.RawParams) *gqlerror.Error {
+	if _, viaAPIToken := APITokenScopeFromContext(ctx); viaAPIToken {
+		return nil
+	}
+
+	if params.Query == "" {
+		hash, _ := params.Extensions["persistedQuery"].(map[string]any)["sha256Hash"].(string)
+		query, ok := a.queries[strings.ToLower(hash)]
+		if !ok {
+			err := gqlerror.Errorf("PersistedQueryNotFound")
+			errcode.Set(err, errPersistedQueryNotFound)
+			return err
+		}
+		params.Query = query
+		return nil
+	}
+
+	if _, ok := a.queries[queryHash(params.Query)]; !ok {
+		err := gqlerror.Errorf("query is not in the persisted query allowlist")
+		errcode.Set(err, errPersistedQueryNotAllowed)
+		return err
+	}
+	return nil
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
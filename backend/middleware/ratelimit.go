@@ -0,0 +1,262 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/services"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// maxRateLimitedBodyBytes caps how much of a request body is buffered to
+// find its operation. Auth requests are a few hundred bytes.
+const maxRateLimitedBodyBytes = 1 << 20 // 1 MB
+
+// RateLimit allows Limit requests per Window.
+type RateLimit struct {
+	Limit  int
+	Window time.Duration
+}
+
+// DefaultAuthRateLimits applies to /graphql/auth when AUTH_RATE_LIMITS is
+// unset. Operations that send email are the tightest; "*" covers any other
+// root field.
+const DefaultAuthRateLimits = "requestMagicLink=10/1h,requestAccount=3/1h," +
+	"consumeMagicLink=30/15m,consumeSignInCode=20/15m,*=120/1m"
+
+// ParseRateLimits parses a spec of the form "operation=limit/window,...",
+// e.g. "requestAccount=3/1h,*=120/1m". Windows use time.ParseDuration syntax.
+// "*" sets the limit for operations not listed. An empty spec or "off"
+// returns no limits; callers substitute DefaultAuthRateLimits for unset.
+func ParseRateLimits(spec string) (map[string]RateLimit, error) {
+	rules := map[string]RateLimit{}
+	spec = strings.TrimSpace(spec)
+	if spec == "" || strings.EqualFold(spec, "off") {
+		return rules, nil
+	}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		op, rate, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit %q: expected operation=limit/window", part)
+		}
+		rule, err := ParseRateLimit(rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit %q: %w", part, err)
+		}
+		rules[strings.TrimSpace(op)] = rule
+	}
+	return rules, nil
+}
+
+// ParseRateLimit parses a single "limit/window" rate, e.g. "60/1m".
+func ParseRateLimit(rate string) (RateLimit, error) {
+	limitStr, windowStr, ok := strings.Cut(rate, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("expected limit/window")
+	}
+	limit, err := strconv.Atoi(strings.TrimSpace(limitStr))
+	if err != nil || limit < 1 {
+		return RateLimit{}, fmt.Errorf("limit must be a positive integer")
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(windowStr))
+	if err != nil || window <= 0 {
+		return RateLimit{}, fmt.Errorf("window must be a positive duration")
+	}
+	return RateLimit{Limit: limit, Window: window}, nil
+}
+
+// RateLimitOperations limits each GraphQL root field per client IP, using
+// rules keyed by field name (see ParseRateLimits). Every aliased copy of a
+// field in a request counts as a hit. scope namespaces the counters, e.g.
+// "auth". Requests over a limit get a 429 with a GraphQL error body and a
+// Retry-After header. If the limiter itself fails the request is let
+// through: an outage of the counter store must not lock everyone out of
+// signing in.
+//
+// Only requests that carry their query text can be classified, so GET and
+// JSON POST requests without one (persisted-query hashes) and POST bodies
+// of any other type (multipart forms) are refused with a 400.
+func RateLimitOperations(limiter *services.RateLimiter, scope string, rules map[string]RateLimit, next http.Handler) http.Handler {
+	if len(rules) == 0 {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Query         string `json:"query"`
+			OperationName string `json:"operationName"`
+		}
+		switch r.Method {
+		case http.MethodGet:
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+		case http.MethodPost:
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				writeBadRequest(w, "requests must be application/json")
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRateLimitedBodyBytes))
+			if err != nil {
+				writeBadRequest(w, "could not read request body")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			if err := json.Unmarshal(body, &req); err != nil {
+				writeBadRequest(w, "could not parse request body")
+				return
+			}
+		default:
+			next.ServeHTTP(w, r)
+			return
+		}
+		if strings.TrimSpace(req.Query) == "" {
+			writeBadRequest(w, "requests must include the query text")
+			return
+		}
+
+		ip := ClientIP(r)
+		for _, op := range rootFields(req.Query, req.OperationName) {
+			rule, ok := rules[op]
+			if !ok {
+				rule, ok = rules["*"]
+				if !ok {
+					continue
+				}
+			}
+
+			key := scope + ":" + op + ":" + ip
+			allowed, retryAfter, err := limiter.Allow(r.Context(), key, rule.Limit, rule.Window)
+			if err != nil {
+				log.Printf("[ratelimit] %v", err)
+				continue
+			}
+			if !allowed {
+				log.Printf("[ratelimit] %s exceeded by %s", op, ip)
+				writeRateLimited(w, retryAfter)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimitRequests limits every request to next to rule per client IP, for
+// plain HTTP endpoints that are not GraphQL. scope namespaces the counters.
+// Like RateLimitOperations it fails open if the limiter fails.
+func RateLimitRequests(limiter *services.RateLimiter, scope string, rule RateLimit, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := ClientIP(r)
+		allowed, retryAfter, err := limiter.Allow(r.Context(), scope+":"+ip, rule.Limit, rule.Window)
+		if err != nil {
+			log.Printf("[ratelimit] %v", err)
+		} else if !allowed {
+			log.Printf("[ratelimit] %s exceeded by %s", scope, ip)
+			writeRateLimited(w, retryAfter)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeRateLimited writes a 429 whose body a GraphQL client can parse like
+// any other error response.
+func writeRateLimited(w http.ResponseWriter, retryAfter time.Duration) {
+	secs := int(math.Ceil(retryAfter.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]any{{
+			"message": "too many requests; please try again later",
+			"extensions": map[string]any{
+				"code":       "RATE_LIMITED",
+				"retryAfter": secs,
+			},
+		}},
+	})
+}
+
+// writeBadRequest writes a 400 whose body a GraphQL client can parse.
+func writeBadRequest(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]any{
+		"errors": []map[string]any{{
+			"message":    message,
+			"extensions": map[string]any{"code": "VALIDATION"},
+		}},
+	})
+}
+
+// rootFields returns the root field names of the operation a GraphQL query
+// will execute, once per response key: aliased copies of a field each run
+// its resolver, so each is listed. A query that cannot be parsed counts as
+// "*" so malformed requests are limited too; gqlgen rejects them afterwards.
+func rootFields(query, operationName string) []string {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return []string{"*"}
+	}
+
+	var op *ast.OperationDefinition
+	if operationName != "" {
+		op = doc.Operations.ForName(operationName)
+	} else if len(doc.Operations) == 1 {
+		op = doc.Operations[0]
+	}
+	if op == nil {
+		return []string{"*"}
+	}
+
+	// Keyed by response key (alias, or name when unaliased), as identical
+	// fields under one key are merged and resolved once.
+	seen := map[string]string{}
+	var collect func(ast.SelectionSet, map[string]bool)
+	collect = func(set ast.SelectionSet, visited map[string]bool) {
+		for _, sel := range set {
+			switch s := sel.(type) {
+			case *ast.Field:
+				key := s.Alias
+				if key == "" {
+					key = s.Name
+				}
+				seen[key] = s.Name
+			case *ast.InlineFragment:
+				collect(s.SelectionSet, visited)
+			case *ast.FragmentSpread:
+				if frag := doc.Fragments.ForName(s.Name); frag != nil && !visited[s.Name] {
+					visited[s.Name] = true
+					collect(frag.SelectionSet, visited)
+				}
+			}
+		}
+	}
+	collect(op.SelectionSet, map[string]bool{})
+
+	fields := make([]string, 0, len(seen))
+	for _, name := range seen {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
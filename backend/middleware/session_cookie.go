@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"net/http"
+	"os"
+	"time"
+)
+
+// SetSessionCookie writes the HttpOnly "session" cookie so JavaScript cannot
+// read the token. Its expiry should match the DB session exactly.
+func SetSessionCookie(w http.ResponseWriter, token string, expiresAt time.Time, isProd bool) {
+	cookie := &http.Cookie{
+		Name:     "session",
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   isProd, // HTTPS only in production
+		SameSite: http.SameSiteLaxMode,
+		Expires:  expiresAt,
+	}
+	if isProd {
+		// Frontend and backend are on different Railway domains, so cross-origin.
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	http.SetCookie(w, cookie)
+}
+
+// isProd mirrors the APP_ENV check in main for middleware that sets cookies.
+func isProd() bool {
+	return os.Getenv("APP_ENV") == "production"
+}
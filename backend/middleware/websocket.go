@@ -0,0 +1,125 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"slices"
+	"time"
+
+	"volunteer-scheduler/services"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+// websocketRecheckInterval is how often an open websocket's session or API
+// token is validated again.
+const websocketRecheckInterval = time.Minute
+
+// WebsocketOriginAllowed is the websocket upgrader's CheckOrigin. Browsers
+// attach the session cookie to a websocket handshake from any page and no
+// CORS preflight guards it, so only allowedOrigin may open one. Handshakes
+// without an Origin (non-browser clients) are allowed; RequireAuth still
+// authenticates them.
+func WebsocketOriginAllowed(allowedOrigin string) func(r *http.Request) bool {
+	allowed := normalizeOrigin(allowedOrigin)
+	return func(r *http.Request) bool {
+		origin := requestOrigin(r)
+		return origin == "" || origin == allowed
+	}
+}
+
+// WebsocketAuth returns the websocket transport's InitFunc. The handshake
+// has already passed RequireAuth; while the socket stays open its cookie or
+// bearer token is validated again every websocketRecheckInterval, the same
+// way, and the socket is closed once the session or token has ended or the
+// caller's roles have changed. The client then reconnects and is authorized
+// afresh.
+func WebsocketAuth(magicLinkService *services.MagicLinkService, apiTokenService *services.APITokenService) transport.WebsocketInitFunc {
+	return func(ctx context.Context, _ transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+		r, ok := RequestFromContext(ctx)
+		if !ok {
+			return ctx, nil, errors.New("unauthorized")
+		}
+		// The roles behind the credential itself: an impersonated request
+		// carries the volunteer's roles in ctx instead.
+		roles, err := revalidate(ctx, r, magicLinkService, apiTokenService)
+		if err != nil {
+			return ctx, nil, errors.New("unauthorized")
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		go func() {
+			defer cancel()
+			ticker := time.NewTicker(websocketRecheckInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					current, err := revalidate(ctx, r, magicLinkService, apiTokenService)
+					if err != nil {
+						log.Printf("Closing websocket: %v", err)
+						return
+					}
+					if !slices.Equal(current, roles) {
+						return
+					}
+				}
+			}
+		}()
+		return ctx, nil, nil
+	}
+}
+
+// revalidate checks the credential r was authenticated with, as RequireAuth
+// does but without renewing a session, and returns the caller's current
+// roles.
+func revalidate(ctx context.Context, r *http.Request, magicLinkService *services.MagicLinkService, apiTokenService *services.APITokenService) ([]string, error) {
+	token, fromCookie := requestToken(r)
+	if !fromCookie && services.IsAPIToken(token) {
+		if apiTokenService == nil {
+			return nil, errors.New("API tokens are not accepted")
+		}
+		apiToken, err := apiTokenService.ValidateAPIToken(ctx, token, ClientIP(r))
+		if err != nil {
+			return nil, err
+		}
+		return apiToken.Roles, nil
+	}
+	// Checked, not validated: a socket's keep-alive is not activity, and the
+	// cookie could not be refreshed from here anyway.
+	session, err := magicLinkService.CheckSessionToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return session.Roles, nil
+}
+
+// SubscriptionRootFields applies a root field middleware, such as
+// AuthorizeTokenScope, to the root fields of subscriptions, which gqlgen
+// resolves without its root field middleware. Register the result with
+// handler.Server.AroundFields.
+func SubscriptionRootFields(mw graphql.RootFieldMiddleware) graphql.FieldMiddleware {
+	return func(ctx context.Context, next graphql.Resolver) (any, error) {
+		fc := graphql.GetFieldContext(ctx)
+		if fc == nil || fc.Object != "Subscription" || !fc.IsResolver {
+			return next(ctx)
+		}
+
+		allowed := false
+		rctx := graphql.WithRootFieldContext(ctx, &graphql.RootFieldContext{Object: fc.Object, Field: fc.Field})
+		mw(rctx, func(context.Context) graphql.Marshaler {
+			allowed = true
+			return graphql.Null
+		})
+		if !allowed {
+			// mw has already added the error to the response.
+			return nil, nil
+		}
+		return next(ctx)
+	}
+}
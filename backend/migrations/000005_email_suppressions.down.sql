@@ -0,0 +1,5 @@
+-- Revert: drop the email suppression list
+
+DROP TABLE IF EXISTS email_suppressions;
+
+DROP TYPE IF EXISTS email_suppression_reason;
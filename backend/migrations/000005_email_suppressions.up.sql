@@ -0,0 +1,18 @@
+-- Addresses we must stop mailing: hard bounces and spam complaints reported
+-- by the email provider's delivery-event webhook.
+
+CREATE TYPE email_suppression_reason AS ENUM (
+    'BOUNCE',
+    'COMPLAINT'
+);
+
+CREATE TABLE email_suppressions (
+    suppression_id SERIAL PRIMARY KEY,
+    email          TEXT NOT NULL,
+    reason         email_suppression_reason NOT NULL,
+    detail         TEXT,
+    created_at     TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per address, matched case-insensitively.
+CREATE UNIQUE INDEX idx_email_suppressions_email ON email_suppressions(LOWER(email));
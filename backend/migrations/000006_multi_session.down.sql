@@ -0,0 +1,14 @@
+-- Revert: back to one session per email. Keep only the newest session for
+-- each email so the unique constraint can be restored.
+
+DELETE FROM sessions s
+USING sessions newer
+WHERE newer.email = s.email
+  AND (newer.created_at, newer.id) > (s.created_at, s.id);
+
+ALTER TABLE sessions
+    DROP COLUMN user_agent,
+    DROP COLUMN ip_address;
+
+ALTER TABLE sessions
+    ADD CONSTRAINT sessions_email_key UNIQUE (email);
@@ -0,0 +1,9 @@
+-- Allow several concurrent sessions per volunteer (phone + laptop) and record
+-- enough about each one for the volunteer to recognise and revoke it.
+
+ALTER TABLE sessions
+    DROP CONSTRAINT sessions_email_key;
+
+ALTER TABLE sessions
+    ADD COLUMN ip_address VARCHAR(45),
+    ADD COLUMN user_agent TEXT;
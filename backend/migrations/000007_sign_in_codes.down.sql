@@ -0,0 +1,5 @@
+-- Revert: drop sign-in codes from magic links
+
+ALTER TABLE magic_links
+    DROP COLUMN IF EXISTS code_attempts,
+    DROP COLUMN IF EXISTS code_hash;
@@ -0,0 +1,7 @@
+-- A 6-digit sign-in code is sent alongside each magic link, for when the link
+-- opens in a different browser than the one the volunteer is signing in on.
+-- The code shares the magic link's row, so using either one consumes both.
+
+ALTER TABLE magic_links
+    ADD COLUMN code_hash     VARCHAR(64),
+    ADD COLUMN code_attempts INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,9 @@
+-- Revert: drop browser binding and consumer details. Hashed tokens cannot be
+-- turned back into usable links, so outstanding links are discarded.
+
+DELETE FROM magic_links;
+
+ALTER TABLE magic_links
+    DROP COLUMN IF EXISTS consumed_user_agent,
+    DROP COLUMN IF EXISTS consumed_ip,
+    DROP COLUMN IF EXISTS browser_nonce_hash;
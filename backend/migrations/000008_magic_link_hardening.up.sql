@@ -0,0 +1,11 @@
+-- Store magic-link tokens as SHA-256 hex digests, like sessions.token, so a
+-- database read or backup cannot be replayed as a sign-in. Also bind each
+-- link to the requesting browser and record who consumed it.
+
+UPDATE magic_links
+SET    token = encode(sha256(convert_to(token, 'UTF8')), 'hex');
+
+ALTER TABLE magic_links
+    ADD COLUMN browser_nonce_hash  VARCHAR(64),
+    ADD COLUMN consumed_ip         VARCHAR(45),
+    ADD COLUMN consumed_user_agent TEXT;
@@ -0,0 +1,3 @@
+-- Revert: drop rate limit counters
+
+DROP TABLE IF EXISTS rate_limits;
@@ -0,0 +1,13 @@
+-- Fixed-window request counters shared by every server replica. Each row is
+-- one key (operation + client IP) in one window; expired windows are purged
+-- by the daily cleanup job.
+
+CREATE TABLE rate_limits (
+    key          VARCHAR(255) NOT NULL,
+    window_start TIMESTAMP    NOT NULL,
+    window_end   TIMESTAMP    NOT NULL,
+    hits         INTEGER      NOT NULL DEFAULT 0,
+    PRIMARY KEY (key, window_start)
+);
+
+CREATE INDEX idx_rate_limits_window_end ON rate_limits(window_end);
@@ -0,0 +1,4 @@
+-- Revert: drop the remember-device flag
+
+ALTER TABLE sessions
+    DROP COLUMN IF EXISTS remember;
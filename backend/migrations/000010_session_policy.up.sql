@@ -0,0 +1,6 @@
+-- "Remember this device" sessions get a longer lifetime and idle timeout.
+-- The flag is kept on the session so sliding renewal extends it by the
+-- right amount.
+
+ALTER TABLE sessions
+    ADD COLUMN remember BOOLEAN NOT NULL DEFAULT false;
@@ -0,0 +1,5 @@
+-- Revert: drop the coordinator role and its funding entity scopes
+
+DROP TABLE IF EXISTS coordinator_funding_entities;
+
+DELETE FROM roles WHERE role_name = 'COORDINATOR';
@@ -0,0 +1,17 @@
+-- ============================================================================
+-- MIGRATION 000011: Coordinator role
+--
+-- Regional coordinators manage events, shifts and rosters for the funding
+-- entities they are assigned to, and nothing else. As with ADMINISTRATOR,
+-- COORDINATOR always implies VOLUNTEER (enforced in the application layer).
+-- ============================================================================
+
+INSERT INTO roles (role_name) VALUES ('COORDINATOR');
+
+CREATE TABLE coordinator_funding_entities (
+    volunteer_id      INT NOT NULL REFERENCES volunteers(volunteer_id) ON DELETE CASCADE,
+    funding_entity_id INT NOT NULL REFERENCES funding_entities(id)     ON DELETE CASCADE,
+    PRIMARY KEY (volunteer_id, funding_entity_id)
+);
+
+CREATE INDEX idx_coordinator_funding_entities_fe ON coordinator_funding_entities(funding_entity_id);
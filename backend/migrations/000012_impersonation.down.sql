@@ -0,0 +1,3 @@
+-- Revert: drop the impersonation audit trail
+
+DROP TABLE IF EXISTS impersonation_sessions;
@@ -0,0 +1,24 @@
+-- ============================================================================
+-- MIGRATION 000012: Read-only impersonation
+--
+-- An administrator can view the volunteer schema as a chosen volunteer for a
+-- limited time to reproduce what they see. Every session is kept as an audit
+-- record (who, whom, when and why); rows are never deleted by the app.
+-- ============================================================================
+
+CREATE TABLE impersonation_sessions (
+    id                 SERIAL PRIMARY KEY,
+    token              VARCHAR(64) NOT NULL UNIQUE,  -- SHA-256 hex of the token
+    admin_id           INT NOT NULL REFERENCES volunteers(volunteer_id),
+    volunteer_id       INT NOT NULL REFERENCES volunteers(volunteer_id),
+    reason             TEXT NOT NULL,
+    volunteer_notified BOOLEAN NOT NULL DEFAULT false,
+    started_at         TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at         TIMESTAMP NOT NULL,
+    ended_at           TIMESTAMP,
+    last_used_at       TIMESTAMP,
+    request_count      INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_impersonation_sessions_volunteer ON impersonation_sessions(volunteer_id, started_at DESC);
+CREATE INDEX idx_impersonation_sessions_admin     ON impersonation_sessions(admin_id, started_at DESC);
@@ -0,0 +1,3 @@
+-- Revert: drop in-flight SSO sign-ins
+
+DROP TABLE IF EXISTS oidc_login_states;
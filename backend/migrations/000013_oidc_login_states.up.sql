@@ -0,0 +1,17 @@
+-- ============================================================================
+-- MIGRATION 000013: OpenID Connect single sign-on
+--
+-- One row per SSO sign-in in flight, between the redirect to the identity
+-- provider and its callback. Kept in Postgres (not memory) so the callback
+-- may land on any replica. Rows are single-use and short-lived.
+-- ============================================================================
+
+CREATE TABLE oidc_login_states (
+    state         VARCHAR(64) PRIMARY KEY,   -- SHA-256 hex of the state parameter
+    code_verifier VARCHAR(128) NOT NULL,     -- PKCE verifier
+    nonce         VARCHAR(64)  NOT NULL,     -- echoed in the ID token
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_oidc_login_states_expires ON oidc_login_states(expires_at);
@@ -0,0 +1,26 @@
+-- ============================================================================
+-- MIGRATION 000014: API tokens
+--
+-- Long-lived bearer tokens for scripts and partner integrations. Each acts as
+-- its owner, limited to one scope. Only the SHA-256 of the token is stored;
+-- token_prefix is kept so admins can tell tokens apart.
+-- ============================================================================
+
+CREATE TABLE api_tokens (
+    id            SERIAL PRIMARY KEY,
+    name          VARCHAR(100) NOT NULL,
+    token         VARCHAR(64) NOT NULL UNIQUE,   -- SHA-256 hex of the token
+    token_prefix  VARCHAR(16) NOT NULL,
+    owner_id      INTEGER NOT NULL REFERENCES volunteers(volunteer_id) ON DELETE CASCADE,
+    scope         VARCHAR(20) NOT NULL
+                  CHECK (scope IN ('ADMIN_READ_ONLY', 'VOLUNTEER_SIGNUP', 'REPORTING')),
+    created_by    INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMP NOT NULL,
+    last_used_at  TIMESTAMP,
+    last_used_ip  VARCHAR(45),
+    revoked_at    TIMESTAMP,
+    revoked_by    INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_api_tokens_owner ON api_tokens(owner_id);
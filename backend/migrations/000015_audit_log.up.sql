@@ -0,0 +1,37 @@
+-- ============================================================================
+-- MIGRATION 000015: Audit log
+--
+-- One row per admin mutation: who, what, which record, the fields it changed
+-- and from where. Append-only: a trigger refuses UPDATE and DELETE. actor_id
+-- deliberately has no foreign key, so history survives deleting the actor;
+-- actor_name keeps who it was.
+-- ============================================================================
+
+CREATE TABLE audit_log (
+    id            BIGSERIAL PRIMARY KEY,
+    actor_id      INTEGER,
+    actor_name    TEXT,
+    via_api_token BOOLEAN NOT NULL DEFAULT FALSE,
+    operation     VARCHAR(100) NOT NULL,   -- GraphQL mutation name
+    entity        VARCHAR(50),             -- e.g. Event, Volunteer; NULL if unknown
+    entity_id     VARCHAR(50),
+    changes       JSONB NOT NULL DEFAULT '[]',  -- [{field, before, after}]
+    success       BOOLEAN NOT NULL,
+    error         TEXT,
+    ip_address    VARCHAR(45),
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_created ON audit_log(created_at);
+CREATE INDEX idx_audit_log_actor ON audit_log(actor_id, created_at);
+CREATE INDEX idx_audit_log_entity ON audit_log(entity, entity_id, created_at);
+
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION audit_log_append_only();
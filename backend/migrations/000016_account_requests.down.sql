@@ -0,0 +1,3 @@
+-- Revert: drop the account request queue
+
+DROP TABLE IF EXISTS account_requests;
@@ -0,0 +1,33 @@
+-- ============================================================================
+-- MIGRATION 000016: Account request queue
+--
+-- requestAccount used to email the admins and store nothing. Requests are now
+-- kept here. A request reaches the admins' queue only once the requester has
+-- clicked the link sent to their address (verified_at); an admin then
+-- approves it (creating or reactivating the volunteer) or denies it.
+-- ============================================================================
+
+CREATE TABLE account_requests (
+    id                    SERIAL PRIMARY KEY,
+    email                 VARCHAR(255) NOT NULL,
+    first_name            VARCHAR(100) NOT NULL,
+    last_name             VARCHAR(100) NOT NULL,
+    status                VARCHAR(10) NOT NULL DEFAULT 'PENDING'
+                          CHECK (status IN ('PENDING', 'APPROVED', 'DENIED')),
+    verify_token          VARCHAR(64) UNIQUE,      -- SHA-256 hex; cleared once verified
+    verify_expires_at     TIMESTAMP NOT NULL,
+    verified_at           TIMESTAMP,
+    ip_address            VARCHAR(45),
+    created_at            TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_by            INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL,
+    decided_at            TIMESTAMP,
+    decision_message      TEXT,
+    volunteer_id          INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL
+);
+
+-- One open request per address, so repeat submissions refresh it rather than
+-- queueing duplicates.
+CREATE UNIQUE INDEX idx_account_requests_open_email
+    ON account_requests (LOWER(email)) WHERE status = 'PENDING';
+
+CREATE INDEX idx_account_requests_status ON account_requests (status, created_at);
@@ -0,0 +1,5 @@
+-- Revert: drop erasure requests. Volunteers already erased stay anonymized.
+
+DROP TABLE IF EXISTS erasure_requests;
+
+ALTER TABLE volunteers DROP COLUMN IF EXISTS erased_at;
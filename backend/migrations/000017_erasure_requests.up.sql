@@ -0,0 +1,32 @@
+-- ============================================================================
+-- MIGRATION 000017: Erasure requests
+--
+-- A volunteer can ask to be forgotten; an admin approves or denies the
+-- request. Approving anonymizes the volunteer in place rather than deleting
+-- the row: volunteer_shifts keep counting towards hours reports, and
+-- feedback and feedback_notes (ON DELETE RESTRICT) keep pointing at a
+-- volunteer, whose name, contact details and free text are redacted.
+--
+-- audit_log is append-only; migrations 000023 and 000025 give erasure a
+-- redaction path.
+-- ============================================================================
+
+ALTER TABLE volunteers ADD COLUMN erased_at TIMESTAMP;
+
+CREATE TABLE erasure_requests (
+    id                    SERIAL PRIMARY KEY,
+    volunteer_id          INTEGER NOT NULL REFERENCES volunteers(volunteer_id) ON DELETE CASCADE,
+    reason                TEXT,
+    status                VARCHAR(10) NOT NULL DEFAULT 'PENDING'
+                          CHECK (status IN ('PENDING', 'APPROVED', 'DENIED')),
+    created_at            TIMESTAMP NOT NULL DEFAULT NOW(),
+    decided_by            INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL,
+    decided_at            TIMESTAMP,
+    decision_message      TEXT
+);
+
+-- One open request per volunteer.
+CREATE UNIQUE INDEX idx_erasure_requests_open_volunteer
+    ON erasure_requests (volunteer_id) WHERE status = 'PENDING';
+
+CREATE INDEX idx_erasure_requests_status ON erasure_requests (status, created_at);
@@ -0,0 +1,7 @@
+-- Revert: stop sending shift capacity notifications
+
+DROP TRIGGER IF EXISTS shifts_capacity_changed ON shifts;
+DROP FUNCTION IF EXISTS shifts_capacity_changed();
+DROP TRIGGER IF EXISTS volunteer_shifts_capacity_changed ON volunteer_shifts;
+DROP FUNCTION IF EXISTS volunteer_shifts_capacity_changed();
+DROP FUNCTION IF EXISTS notify_shift_capacity(INT);
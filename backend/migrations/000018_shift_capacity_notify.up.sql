@@ -0,0 +1,64 @@
+-- ============================================================================
+-- MIGRATION 000018: Shift capacity notifications
+--
+-- Every change to a shift's signups or max_volunteers sends the shift's new
+-- capacity on the shift_capacity channel (LISTEN/NOTIFY), so each API
+-- replica can push it to the subscribers it holds, whichever replica (or
+-- script) made the change. The payload is JSON:
+--   {"eventId": 1, "shiftId": 2, "assigned": 3, "max": 4}
+-- max is null for an unlimited shift. Nothing is sent for a shift that no
+-- longer exists (a cascade from deleting it).
+-- ============================================================================
+
+CREATE FUNCTION notify_shift_capacity(p_shift_id INT) RETURNS void AS $$
+DECLARE
+    payload TEXT;
+BEGIN
+    SELECT json_build_object(
+               'eventId',  o.event_id,
+               'shiftId',  s.shift_id,
+               'assigned', (SELECT COUNT(*) FROM volunteer_shifts vs
+                            WHERE vs.shift_id = s.shift_id AND vs.cancelled_at IS NULL),
+               'max',      s.max_volunteers
+           )::text
+      INTO payload
+      FROM shifts s
+      JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+     WHERE s.shift_id = p_shift_id;
+
+    IF payload IS NOT NULL THEN
+        PERFORM pg_notify('shift_capacity', payload);
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION volunteer_shifts_capacity_changed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM notify_shift_capacity(OLD.shift_id);
+        RETURN OLD;
+    END IF;
+    IF TG_OP = 'UPDATE' AND OLD.shift_id <> NEW.shift_id THEN
+        PERFORM notify_shift_capacity(OLD.shift_id);
+    END IF;
+    PERFORM notify_shift_capacity(NEW.shift_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER volunteer_shifts_capacity_changed
+    AFTER INSERT OR DELETE OR UPDATE OF shift_id, cancelled_at ON volunteer_shifts
+    FOR EACH ROW EXECUTE FUNCTION volunteer_shifts_capacity_changed();
+
+CREATE FUNCTION shifts_capacity_changed() RETURNS trigger AS $$
+BEGIN
+    IF OLD.max_volunteers IS DISTINCT FROM NEW.max_volunteers THEN
+        PERFORM notify_shift_capacity(NEW.shift_id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER shifts_capacity_changed
+    AFTER UPDATE OF max_volunteers ON shifts
+    FOR EACH ROW EXECUTE FUNCTION shifts_capacity_changed();
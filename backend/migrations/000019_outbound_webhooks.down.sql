@@ -0,0 +1,4 @@
+-- Revert: drop outbound webhooks
+
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,47 @@
+-- ============================================================================
+-- MIGRATION 000019: Outbound webhooks
+--
+-- Admin-managed subscriptions that are POSTed a signed JSON payload when
+-- volunteers sign up for or cancel shifts, and when events are created,
+-- changed or deleted. secret is kept in the clear because every delivery is
+-- signed with it; it is only shown to admins when generated.
+--
+-- Each change is queued as one webhook_deliveries row per subscription and
+-- sent by a background dispatcher, which retries failures with backoff. The
+-- rows double as the delivery log.
+-- ============================================================================
+
+CREATE TABLE webhook_subscriptions (
+    id            SERIAL PRIMARY KEY,
+    url           TEXT NOT NULL,
+    secret        VARCHAR(64) NOT NULL,
+    event_types   TEXT[] NOT NULL,
+    description   VARCHAR(200),
+    is_active     BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by    INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP
+);
+
+CREATE TABLE webhook_deliveries (
+    id                SERIAL PRIMARY KEY,
+    subscription_id   INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type        VARCHAR(20) NOT NULL,
+    payload           JSONB NOT NULL,
+    status            VARCHAR(10) NOT NULL DEFAULT 'PENDING'
+                      CHECK (status IN ('PENDING', 'DELIVERED', 'FAILED')),
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_attempt_at   TIMESTAMP,
+    last_status_code  INTEGER,
+    last_error        TEXT,
+    created_at        TIMESTAMP NOT NULL DEFAULT NOW(),
+    delivered_at      TIMESTAMP
+);
+
+-- The dispatcher's queue.
+CREATE INDEX idx_webhook_deliveries_due
+    ON webhook_deliveries (next_attempt_at) WHERE status = 'PENDING';
+
+CREATE INDEX idx_webhook_deliveries_subscription
+    ON webhook_deliveries (subscription_id, created_at);
@@ -0,0 +1,11 @@
+-- Revert: drop event keyword search
+
+DROP TRIGGER IF EXISTS opportunities_search_changed ON opportunities;
+DROP FUNCTION IF EXISTS opportunities_search_changed();
+DROP TRIGGER IF EXISTS venues_search_changed ON venues;
+DROP FUNCTION IF EXISTS venues_search_changed();
+DROP TRIGGER IF EXISTS events_search_changed ON events;
+DROP FUNCTION IF EXISTS events_search_changed();
+DROP INDEX IF EXISTS idx_events_search;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
+DROP FUNCTION IF EXISTS event_search_vector(INT, TEXT, TEXT, INT);
@@ -0,0 +1,75 @@
+-- ============================================================================
+-- MIGRATION 000020: Event keyword search
+--
+-- events.search_vector indexes an event's words for full-text search,
+-- weighted by where they appear:
+--   A  event name
+--   B  venue name
+--   C  description
+--   D  its opportunities' pre-event instructions
+-- The text lives in three tables, so triggers on each keep the vector
+-- current rather than a generated column.
+-- ============================================================================
+
+CREATE FUNCTION event_search_vector(p_event_id INT, p_name TEXT, p_description TEXT, p_venue_id INT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector('english', COALESCE(p_name, '')), 'A')
+        || setweight(to_tsvector('english', COALESCE(
+               (SELECT venue_name FROM venues WHERE venue_id = p_venue_id), '')), 'B')
+        || setweight(to_tsvector('english', COALESCE(p_description, '')), 'C')
+        || setweight(to_tsvector('english', COALESCE(
+               (SELECT string_agg(pre_event_instructions, ' ') FROM opportunities
+                 WHERE event_id = p_event_id), '')), 'D');
+$$ LANGUAGE sql STABLE;
+
+ALTER TABLE events ADD COLUMN search_vector tsvector;
+
+UPDATE events SET search_vector = event_search_vector(event_id, event_name, description, venue_id);
+
+CREATE INDEX idx_events_search ON events USING GIN (search_vector);
+
+CREATE FUNCTION events_search_changed() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := event_search_vector(NEW.event_id, NEW.event_name, NEW.description, NEW.venue_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_search_changed
+    BEFORE INSERT OR UPDATE OF event_name, description, venue_id ON events
+    FOR EACH ROW EXECUTE FUNCTION events_search_changed();
+
+CREATE FUNCTION venues_search_changed() RETURNS trigger AS $$
+BEGIN
+    UPDATE events
+       SET search_vector = event_search_vector(event_id, event_name, description, venue_id)
+     WHERE venue_id = NEW.venue_id;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER venues_search_changed
+    AFTER UPDATE OF venue_name ON venues
+    FOR EACH ROW EXECUTE FUNCTION venues_search_changed();
+
+-- Deleting an event cascades to its opportunities; the UPDATE then finds no
+-- event row and does nothing.
+CREATE FUNCTION opportunities_search_changed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        UPDATE events
+           SET search_vector = event_search_vector(event_id, event_name, description, venue_id)
+         WHERE event_id = OLD.event_id;
+    END IF;
+    IF TG_OP = 'INSERT' OR (TG_OP = 'UPDATE' AND NEW.event_id <> OLD.event_id) THEN
+        UPDATE events
+           SET search_vector = event_search_vector(event_id, event_name, description, venue_id)
+         WHERE event_id = NEW.event_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER opportunities_search_changed
+    AFTER INSERT OR DELETE OR UPDATE OF event_id, pre_event_instructions ON opportunities
+    FOR EACH ROW EXECUTE FUNCTION opportunities_search_changed();
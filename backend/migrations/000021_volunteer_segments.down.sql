@@ -0,0 +1,3 @@
+-- Revert: drop volunteer segments
+
+DROP TABLE IF EXISTS volunteer_segments;
@@ -0,0 +1,17 @@
+-- ============================================================================
+-- MIGRATION 000021: Volunteer segments
+--
+-- Named volunteer filters that admins save and reuse. filter is the admin
+-- VolunteerFilterInput as JSON; names are unique ignoring case.
+-- ============================================================================
+
+CREATE TABLE volunteer_segments (
+    id          SERIAL PRIMARY KEY,
+    name        VARCHAR(100) NOT NULL,
+    filter      JSONB NOT NULL DEFAULT '{}',
+    created_by  INTEGER REFERENCES volunteers(volunteer_id) ON DELETE SET NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP
+);
+
+CREATE UNIQUE INDEX volunteer_segments_name_key ON volunteer_segments (LOWER(name));
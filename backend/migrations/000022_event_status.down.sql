@@ -0,0 +1,7 @@
+-- Revert: drop event status
+
+DROP INDEX IF EXISTS idx_events_status;
+ALTER TABLE events
+    DROP COLUMN IF EXISTS cancelled_at,
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
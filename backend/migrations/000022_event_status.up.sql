@@ -0,0 +1,25 @@
+-- ============================================================================
+-- MIGRATION 000022: Event status
+--
+-- Events start as drafts that only admins see, and are published now or at
+-- publish_at. Cancelling keeps the event, its shifts and its signups (the
+-- upcoming signups are marked cancelled); a published event whose last date
+-- has ended is completed.
+--
+-- Events that already exist were visible from the moment they were created,
+-- so they are published (or completed). The column default stays PUBLISHED
+-- for rows inserted outside CreateEvent, which always sets the status.
+-- ============================================================================
+
+ALTER TABLE events
+    ADD COLUMN status        VARCHAR(10) NOT NULL DEFAULT 'PUBLISHED'
+                             CHECK (status IN ('DRAFT', 'PUBLISHED', 'CANCELLED', 'COMPLETED')),
+    ADD COLUMN publish_at    TIMESTAMP,
+    ADD COLUMN cancelled_at  TIMESTAMP;
+
+UPDATE events e
+   SET status = 'COMPLETED'
+ WHERE (SELECT MAX(ed.end_date_time) FROM event_dates ed WHERE ed.event_id = e.event_id) < NOW();
+
+-- Scheduled publishing and completion look for these.
+CREATE INDEX idx_events_status ON events (status);
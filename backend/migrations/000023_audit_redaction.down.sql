@@ -0,0 +1,8 @@
+-- Revert: close the audit log redaction path. Redacted values cannot be restored.
+DROP FUNCTION IF EXISTS audit_redact_changes(JSONB, TEXT[]);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
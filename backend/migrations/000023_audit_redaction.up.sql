@@ -0,0 +1,63 @@
+-- ============================================================================
+-- MIGRATION 000023: Audit log redaction
+--
+-- audit_log stays append-only, with one sanctioned exception: a transaction
+-- that sets app.audit_redaction to 'on' may rewrite actor_name, ip_address
+-- and changes, and nothing else. Erasing a volunteer uses it to remove their
+-- name and address from the entries they made. Migration 000025 replaces the
+-- setting with a role only a SECURITY DEFINER function acts as.
+--
+-- New entries no longer record the values of personal data fields (see
+-- auditRedactedFields in services/audit_services.go); this scrubs them from
+-- the entries written before, and the actor details of volunteers already
+-- erased.
+-- ============================================================================
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND current_setting('app.audit_redaction', true) = 'on'
+       AND to_jsonb(NEW) - 'actor_name' - 'ip_address' - 'changes'
+         = to_jsonb(OLD) - 'actor_name' - 'ip_address' - 'changes' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Replaces the before/after values of the named fields in a changes array
+-- with "[redacted]", keeping whether each side existed.
+CREATE FUNCTION audit_redact_changes(changes JSONB, fields TEXT[]) RETURNS JSONB AS $$
+    SELECT COALESCE(jsonb_agg(
+        CASE WHEN c->>'field' = ANY(fields) THEN
+            jsonb_strip_nulls(jsonb_build_object(
+                'field',  c->'field',
+                'before', CASE WHEN c ? 'before' THEN '"[redacted]"'::jsonb END,
+                'after',  CASE WHEN c ? 'after'  THEN '"[redacted]"'::jsonb END))
+        ELSE c END
+        ORDER BY ord), '[]'::jsonb)
+    FROM jsonb_array_elements(changes) WITH ORDINALITY AS x(c, ord)
+$$ LANGUAGE sql IMMUTABLE;
+
+SELECT set_config('app.audit_redaction', 'on', true);
+
+UPDATE audit_log SET changes = audit_redact_changes(changes,
+    ARRAY['email', 'first_name', 'ip_address', 'last_name'])
+WHERE entity = 'AccountRequest';
+
+UPDATE audit_log SET changes = audit_redact_changes(changes, ARRAY['reason'])
+WHERE entity = 'ErasureRequest';
+
+UPDATE audit_log SET changes = audit_redact_changes(changes,
+    ARRAY['app_page_name', 'subject', 'text'])
+WHERE entity = 'Feedback';
+
+UPDATE audit_log SET changes = audit_redact_changes(changes,
+    ARRAY['email', 'first_name', 'last_name', 'latitude', 'longitude', 'phone', 'zip_code'])
+WHERE entity = 'Volunteer';
+
+UPDATE audit_log a SET actor_name = 'Erased Volunteer', ip_address = NULL
+FROM volunteers v
+WHERE v.volunteer_id = a.actor_id AND v.erased_at IS NOT NULL;
+
+SELECT set_config('app.audit_redaction', 'off', true);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS feedback_notes_email_message_id_key;
+ALTER TABLE feedback_notes DROP COLUMN IF EXISTS email_message_id;
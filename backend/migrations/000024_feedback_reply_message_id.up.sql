@@ -0,0 +1,11 @@
+-- ============================================================================
+-- MIGRATION 000024: Inbound reply Message-IDs
+--
+-- Mail providers retry webhook deliveries, so the same emailed reply can
+-- arrive more than once. A note stored from an email keeps the message's
+-- Message-ID; the unique index makes a repeat a no-op.
+-- ============================================================================
+
+ALTER TABLE feedback_notes ADD COLUMN email_message_id TEXT;
+
+CREATE UNIQUE INDEX feedback_notes_email_message_id_key ON feedback_notes (email_message_id);
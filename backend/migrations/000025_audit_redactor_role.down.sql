@@ -0,0 +1,17 @@
+-- Revert: back to 000023's session-setting redaction path.
+DROP FUNCTION IF EXISTS redact_erased_volunteer_audit(INT, TEXT, TEXT[]);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND current_setting('app.audit_redaction', true) = 'on'
+       AND to_jsonb(NEW) - 'actor_name' - 'ip_address' - 'changes'
+         = to_jsonb(OLD) - 'actor_name' - 'ip_address' - 'changes' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+REVOKE ALL ON audit_log FROM audit_redactor;
+DROP ROLE IF EXISTS audit_redactor;
@@ -0,0 +1,60 @@
+-- ============================================================================
+-- MIGRATION 000025: Audit redaction role
+--
+-- Migration 000023 let any transaction rewrite audit_log by setting
+-- app.audit_redaction. The exception now belongs to audit_redactor, a role
+-- nobody can log in as: the trigger allows the redaction UPDATE only when
+-- that role is the current user, which happens inside
+-- redact_erased_volunteer_audit, a SECURITY DEFINER function it owns.
+-- Erasing a volunteer calls the function; nothing else can redact.
+--
+-- A superuser, or a member of audit_redactor, can still act as the role, so
+-- the application should connect as neither.
+-- ============================================================================
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = 'audit_redactor') THEN
+        CREATE ROLE audit_redactor NOLOGIN;
+    END IF;
+END;
+$$;
+
+GRANT SELECT, UPDATE (actor_name, ip_address, changes) ON audit_log TO audit_redactor;
+
+CREATE OR REPLACE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND current_user = 'audit_redactor'
+       AND to_jsonb(NEW) - 'actor_name' - 'ip_address' - 'changes'
+         = to_jsonb(OLD) - 'actor_name' - 'ip_address' - 'changes' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+-- Renames the volunteer to erased_name and drops their IP address on the
+-- entries they made, and redacts fields in the changes recorded about them.
+CREATE FUNCTION redact_erased_volunteer_audit(vol_id INT, erased_name TEXT, fields TEXT[]) RETURNS void
+LANGUAGE plpgsql SECURITY DEFINER SET search_path = public, pg_temp AS $$
+BEGIN
+    UPDATE audit_log a SET actor_name = erased_name, ip_address = NULL
+    WHERE a.actor_id = vol_id;
+
+    UPDATE audit_log a SET changes = audit_redact_changes(a.changes, fields)
+    WHERE a.entity = 'Volunteer' AND a.entity_id = vol_id::text;
+END;
+$$;
+
+-- Unless run by a superuser, changing the owner needs membership in the new
+-- owner and its CREATE privilege on the schema; hold both only as long as
+-- that takes.
+GRANT audit_redactor TO CURRENT_USER;
+GRANT CREATE ON SCHEMA public TO audit_redactor;
+ALTER FUNCTION redact_erased_volunteer_audit(INT, TEXT, TEXT[]) OWNER TO audit_redactor;
+REVOKE CREATE ON SCHEMA public FROM audit_redactor;
+REVOKE audit_redactor FROM CURRENT_USER;
+
+REVOKE EXECUTE ON FUNCTION redact_erased_volunteer_audit(INT, TEXT, TEXT[]) FROM PUBLIC;
+GRANT EXECUTE ON FUNCTION redact_erased_volunteer_audit(INT, TEXT, TEXT[]) TO CURRENT_USER;
@@ -0,0 +1,40 @@
+package models
+
+// Enums.
+
+type AccountRequestStatus string
+
+const (
+	AccountRequestStatusPending  AccountRequestStatus = "PENDING"
+	AccountRequestStatusApproved AccountRequestStatus = "APPROVED"
+	AccountRequestStatusDenied   AccountRequestStatus = "DENIED"
+)
+
+// Output types.
+
+// A request for a volunteer account, made from the login page. Only requests
+// whose email address has been verified are shown to admins.
+type AccountRequest struct {
+	ID                  string
+	Email               string
+	FirstName           string
+	LastName            string
+	Status              AccountRequestStatus
+	ExistingVolunteerID *string // an inactive volunteer with the same email
+	CreatedAt           string
+	VerifiedAt          string
+	DecidedByID         *string
+	DecidedByName       *string
+	DecidedAt           *string
+	DecisionMessage     *string
+	VolunteerID         *string // the account created or reactivated on approval
+}
+
+// Input types.
+
+type ApproveAccountRequestInput struct {
+	Role Role
+
+	// Required when Role is COORDINATOR.
+	FundingEntityIDs []int
+}
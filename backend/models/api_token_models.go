@@ -0,0 +1,51 @@
+package models
+
+// Enums.
+
+// What an API token may do. Which root fields each scope reaches is declared
+// in the schemas with @tokenScope.
+type APITokenScope string
+
+const (
+	APITokenScopeAdminReadOnly   APITokenScope = "ADMIN_READ_ONLY"
+	APITokenScopeVolunteerSignup APITokenScope = "VOLUNTEER_SIGNUP"
+	APITokenScopeReporting       APITokenScope = "REPORTING"
+)
+
+// Output types.
+
+// An API token as listed to administrators. The secret itself is never
+// returned after creation; Prefix identifies it.
+type APIToken struct {
+	ID            string
+	Name          string
+	Prefix        string
+	OwnerID       string
+	OwnerName     string
+	Scope         APITokenScope
+	CreatedByID   *string
+	CreatedByName *string
+	CreatedAt     string
+	ExpiresAt     string
+	LastUsedAt    *string
+	LastUsedIP    *string
+	RevokedAt     *string
+}
+
+// Returned when a token is created. Token is shown once.
+type APITokenResult struct {
+	Success  bool
+	Message  *string
+	APIToken *APIToken
+	Token    *string
+	Code     ErrorCode
+}
+
+// Input types.
+
+type NewAPITokenInput struct {
+	Name          string
+	OwnerID       *string // defaults to the creating administrator
+	Scope         APITokenScope
+	ExpiresInDays *int
+}
@@ -0,0 +1,40 @@
+package models
+
+// Output types.
+
+// One admin mutation as recorded in the audit log.
+type AuditEntry struct {
+	ID          string
+	ActorID     *string
+	ActorName   *string
+	ViaAPIToken bool
+	Operation   string
+	Entity      *string
+	EntityID    *string
+	Changes     []*AuditChange
+	Success     bool
+	Error       *string
+	IPAddress   *string
+	CreatedAt   string
+}
+
+// One changed field. Before and After are JSON values; nil when the record
+// did not exist on that side (create, delete).
+type AuditChange struct {
+	Field  string
+	Before *string
+	After  *string
+}
+
+// Input types.
+
+// Filters for the audit log; every field is optional. From and To are
+// timestamps or dates, and To is inclusive.
+type AuditLogFilter struct {
+	ActorID  *string
+	Entity   *string
+	EntityID *string
+	From     *string
+	To       *string
+	Limit    *int
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrorCode says what kind of failure an error is, so clients can react to it
+// without matching on the message. GraphQL errors carry it in
+// extensions.code, and failed mutation results in their code field.
+//
+// A mutation that cannot do what was asked (bad input, a missing record, a
+// state conflict) returns a nil result and an *Error, which reaches the
+// client as a GraphQL error. Only outcomes a volunteer meets in normal use,
+// a full shift or an event that no longer takes signups, come back as a
+// result with Success false and a nil error; so does every failure on the
+// auth endpoint.
+type ErrorCode string
+
+const (
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeValidation      ErrorCode = "VALIDATION"
+	ErrorCodeConflict        ErrorCode = "CONFLICT"
+	ErrorCodeCapacityFull    ErrorCode = "CAPACITY_FULL"
+	ErrorCodeForbidden       ErrorCode = "FORBIDDEN"
+	ErrorCodeUnauthenticated ErrorCode = "UNAUTHENTICATED"
+	ErrorCodeRateLimited     ErrorCode = "RATE_LIMITED"
+	ErrorCodeReadOnly        ErrorCode = "READ_ONLY"
+	ErrorCodeInternal        ErrorCode = "INTERNAL"
+)
+
+// FieldError is a problem with one input field. Field is the input's
+// GraphQL name, e.g. "email" or "eventDates".
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error is a failure whose Message is safe to show to the caller. Err, if
+// set, is the underlying cause; it is logged, never shown.
+type Error struct {
+	Code    ErrorCode
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// NewError returns an Error with a formatted message.
+func NewError(code ErrorCode, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewFieldError returns a validation Error about a single input field.
+func NewFieldError(field, format string, args ...any) *Error {
+	msg := fmt.Sprintf(format, args...)
+	return &Error{
+		Code:    ErrorCodeValidation,
+		Message: msg,
+		Fields:  []FieldError{{Field: field, Message: msg}},
+	}
+}
+
+// InvalidID is the validation error for an ID argument that is not a number.
+func InvalidID(field string) *Error {
+	return NewFieldError(field, "invalid %s", field)
+}
+
+// ErrorCodeOf classifies err. Besides *Error, a missing row is NOT_FOUND and
+// an unparsable number VALIDATION; anything else is INTERNAL.
+func ErrorCodeOf(err error) ErrorCode {
+	var e *Error
+	var numErr *strconv.NumError
+	switch {
+	case errors.As(err, &e):
+		return e.Code
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrorCodeNotFound
+	case errors.As(err, &numErr):
+		return ErrorCodeValidation
+	default:
+		return ErrorCodeInternal
+	}
+}
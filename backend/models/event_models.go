@@ -23,6 +23,10 @@ type EventView struct {
 	Timezone       string
 	ServiceTypes   []string
 	ShiftSummaries []*EventShiftSummary
+	Status         EventStatus
+	// Set only when the events were filtered by a keyword search.
+	SearchRank    *float64
+	SearchSnippet *string
 }
 
 type Event struct {
@@ -39,6 +43,12 @@ type Event struct {
 	ShiftSummaries  []*EventShiftSummary
 	RecurrenceGroup *RecurrenceGroup
 	RecurrenceOrder *int
+	Status          EventStatus
+	PublishAt       *string // when a draft is (or was) published
+	CancelledAt     *string
+	// Set only when the events were filtered by a keyword search.
+	SearchRank    *float64
+	SearchSnippet *string
 }
 
 type RecurrenceGroup struct {
@@ -67,6 +77,18 @@ type EventDateView struct {
 	EndDateTime   string
 }
 
+// A page of the events connection.
+type EventConnection struct {
+	Edges      []*EventEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type EventEdge struct {
+	Cursor string
+	Node   *Event
+}
+
 // Input types for queries (e.g., filters).
 
 // Filter's events on the Manage Events page
@@ -75,6 +97,8 @@ type EventFilterInput struct {
 	EventType *EventType
 	Jobs      []int
 	TimeFrame *ShiftsTimeFilter
+	Search    *string
+	Statuses  []EventStatus
 }
 
 // Filter's events on the Volunteer Events page
@@ -84,6 +108,12 @@ type VolunteerEventFilterInput struct {
 	EventType *EventType
 	Jobs      []int
 	TimeFrame *ShiftsTimeFilter
+	Search    *string
+}
+
+type EventSort struct {
+	Field     EventSortField
+	Direction *SortDirection // nil: ascending
 }
 
 //  Input types for new rows.
@@ -105,6 +135,7 @@ type NewEventInput struct {
 	ServiceTypes    []int
 	EventDates      []*NewEventDateInput
 	Recurrence      *RecurrenceInput
+	PublishAt       *string // nil: a draft until PublishEvent
 }
 
 type NewEventDateInput struct {
@@ -148,6 +179,17 @@ const (
 	EventTypeHybrid   EventType = "HYBRID"
 )
 
+// Volunteers see PUBLISHED and COMPLETED events in listings, and CANCELLED
+// ones only by ID.
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "DRAFT"
+	EventStatusPublished EventStatus = "PUBLISHED"
+	EventStatusCancelled EventStatus = "CANCELLED"
+	EventStatusCompleted EventStatus = "COMPLETED"
+)
+
 type ShiftsTimeFilter string
 
 const (
@@ -182,3 +224,10 @@ const (
 	RecurrenceUpdateScopeThisOnly      RecurrenceUpdateScope = "THIS_ONLY"
 	RecurrenceUpdateScopeThisAndFuture RecurrenceUpdateScope = "THIS_AND_FUTURE"
 )
+
+type EventSortField string
+
+const (
+	EventSortStartDate EventSortField = "START_DATE"
+	EventSortName      EventSortField = "NAME"
+)
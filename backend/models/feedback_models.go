@@ -69,6 +69,18 @@ type FeedbackView struct {
 	Attachments []*FeedbackMetaAttachment
 }
 
+// A page of the feedback connection.
+type FeedbackConnection struct {
+	Edges      []*FeedbackEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type FeedbackEdge struct {
+	Cursor string
+	Node   *Feedback
+}
+
 // Input types
 
 type FeedbackFilterInput struct {
@@ -76,6 +88,11 @@ type FeedbackFilterInput struct {
 	Type   *FeedbackType
 }
 
+type FeedbackSort struct {
+	Field     FeedbackSortField
+	Direction *SortDirection // nil: descending, newest first
+}
+
 type NewFeedbackInput struct {
 	Type        FeedbackType
 	Subject     string
@@ -134,3 +151,10 @@ const (
 	FeedbackNoteTypeVolunteerNote   FeedbackNoteType = "VOLUNTEER_NOTE"
 	FeedbackNoteTypeEmailToVoluneer FeedbackNoteType = "EMAIL_TO_VOLUNTEER"
 )
+
+type FeedbackSortField string
+
+const (
+	FeedbackSortCreatedAt     FeedbackSortField = "CREATED_AT"
+	FeedbackSortLastUpdatedAt FeedbackSortField = "LAST_UPDATED_AT"
+)
@@ -0,0 +1,36 @@
+package models
+
+// Output types.
+
+// One read-only "view as volunteer" session, kept as an audit record.
+type Impersonation struct {
+	ID                string
+	AdminID           string
+	AdminName         string
+	VolunteerID       string
+	VolunteerName     string
+	Reason            string
+	VolunteerNotified bool
+	StartedAt         string
+	ExpiresAt         string
+	EndedAt           *string
+	RequestCount      int
+}
+
+// Returned when an impersonation starts. Token is sent back on
+// /graphql/volunteer requests in the X-Impersonate header; it is shown once.
+type ImpersonationResult struct {
+	Success       bool
+	Message       *string
+	Impersonation *Impersonation
+	Token         *string
+	Code          ErrorCode
+}
+
+// Input types.
+
+type StartImpersonationInput struct {
+	VolunteerID     string
+	Reason          string
+	NotifyVolunteer bool
+}
@@ -16,11 +16,28 @@ type MutationResult struct {
 	Success bool
 	Message *string
 	ID      *string
+	Code    ErrorCode // why Success is false, if known
 }
 
 type VolunteerMutationResult struct {
 	Success bool
 	Message *string
+	Code    ErrorCode
+}
+
+// Paging (API-wide). Connection fields take a PageInput and return their
+// nodes with one cursor each, a PageInfo and the total number of matches.
+
+type PageInput struct {
+	First *int    // page size; defaults to 50, at most 200
+	After *string // cursor of the last row of the previous page
+}
+
+type PageInfo struct {
+	HasNextPage     bool
+	HasPreviousPage bool
+	StartCursor     *string
+	EndCursor       *string
 }
 
 // Enums (system-wide).
@@ -30,6 +47,14 @@ type Role string
 const (
 	RoleVolunteer     Role = "VOLUNTEER"
 	RoleAdministrator Role = "ADMINISTRATOR"
+	RoleCoordinator   Role = "COORDINATOR"
+)
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
 )
 
 // HasRole returns true when the provided role is present in the roles slice.
@@ -0,0 +1,28 @@
+package models
+
+// Enums.
+
+type ErasureRequestStatus string
+
+const (
+	ErasureRequestStatusPending  ErasureRequestStatus = "PENDING"
+	ErasureRequestStatusApproved ErasureRequestStatus = "APPROVED"
+	ErasureRequestStatusDenied   ErasureRequestStatus = "DENIED"
+)
+
+// Output types.
+
+// A volunteer's request to have their personal data erased. Once approved,
+// VolunteerName reads "Erased Volunteer".
+type ErasureRequest struct {
+	ID              string
+	VolunteerID     string
+	VolunteerName   string
+	Reason          *string
+	Status          ErasureRequestStatus
+	CreatedAt       string
+	DecidedByID     *string
+	DecidedByName   *string
+	DecidedAt       *string
+	DecisionMessage *string
+}
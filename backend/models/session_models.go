@@ -0,0 +1,15 @@
+package models
+
+// Output types.
+
+// A signed-in device. Volunteers see their own; the token itself is never
+// exposed.
+type Session struct {
+	ID             string
+	UserAgent      *string
+	IPAddress      *string
+	CreatedAt      string
+	LastActivityAt *string
+	ExpiresAt      string
+	Current        bool
+}
@@ -31,6 +31,15 @@ type EventShiftView struct {
 	AssignedVolunteers int
 }
 
+// ShiftCapacity is a shift's signup count after a change, as pushed to
+// shiftCapacityChanged subscribers. MaxVolunteers is nil for no limit.
+type ShiftCapacity struct {
+	EventId            string
+	ShiftId            string
+	AssignedVolunteers int
+	MaxVolunteers      *int
+}
+
 type Opportunity struct {
 	ID                   string
 	JobId                int
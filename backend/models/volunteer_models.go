@@ -39,14 +39,84 @@ type Volunteer struct {
 	ZipCode   *string
 	Distance  *int
 	Roles     []Role
+
+	// Funding entities a COORDINATOR manages; empty for everyone else.
+	FundingEntityIDs []int
+
+	// Set when the address hard-bounced or reported a complaint.
+	EmailUndeliverable *EmailSuppressionReason
+
+	// Set on the admin volunteer lists.
+	HoursServed  *float64
+	SignupCount  *int
+	LastServedAt *string
+}
+
+type EmailSuppressionReason string
+
+const (
+	EmailSuppressionBounce    EmailSuppressionReason = "BOUNCE"
+	EmailSuppressionComplaint EmailSuppressionReason = "COMPLAINT"
+)
+
+// A page of the volunteers connection.
+type VolunteerConnection struct {
+	Edges      []*VolunteerEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type VolunteerEdge struct {
+	Cursor string
+	Node   *Volunteer
+}
+
+// A page of one volunteer's shifts.
+type VolunteerShiftConnection struct {
+	Edges      []*VolunteerShiftEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type VolunteerShiftEdge struct {
+	Cursor string
+	Node   *VolunteerShift
 }
 
 // Input types for queries (e.g., filters).
 
+// Saved segments store the filter as JSON, hence the tags.
 type VolunteerFilterInput struct {
-	FirstName *string
-	LastName  *string
-	Email     *string
+	FirstName      *string                 `json:"firstName,omitempty"`
+	LastName       *string                 `json:"lastName,omitempty"`
+	Email          *string                 `json:"email,omitempty"`
+	IsActive       *bool                   `json:"isActive,omitempty"` // nil: active only
+	Roles          []Role                  `json:"roles,omitempty"`
+	Near           *VolunteerDistanceInput `json:"near,omitempty"`
+	LastServedFrom *string                 `json:"lastServedFrom,omitempty"`
+	LastServedTo   *string                 `json:"lastServedTo,omitempty"`
+	JobTypeIDs     []int                   `json:"jobTypeIds,omitempty"`
+	MinHours       *float64                `json:"minHours,omitempty"`
+	MaxHours       *float64                `json:"maxHours,omitempty"`
+	MinSignups     *int                    `json:"minSignups,omitempty"`
+	MaxSignups     *int                    `json:"maxSignups,omitempty"`
+}
+
+// Exactly one of VenueID and ZipCode is set.
+type VolunteerDistanceInput struct {
+	VenueID *string `json:"venueId,omitempty"`
+	ZipCode *string `json:"zipCode,omitempty"`
+	Miles   int     `json:"miles"`
+}
+
+type VolunteerSort struct {
+	Field     VolunteerSortField
+	Direction *SortDirection // nil: ascending
+}
+
+type VolunteerShiftSort struct {
+	Field     VolunteerShiftSortField
+	Direction *SortDirection // nil: ascending
 }
 
 type VolunteerShiftView struct {
@@ -75,6 +145,9 @@ type NewVolunteerInput struct {
 	ZipCode   *string
 	Distance  *int
 	Role      Role
+
+	// Required when Role is COORDINATOR.
+	FundingEntityIDs []int
 }
 
 // Input for updates.
@@ -88,6 +161,9 @@ type UpdateVolunteerInput struct {
 	ZipCode   *string
 	Distance  *int
 	Role      Role
+
+	// Required when Role is COORDINATOR.
+	FundingEntityIDs []int
 }
 
 type UpdateOwnProfileInput struct {
@@ -98,3 +174,44 @@ type UpdateOwnProfileInput struct {
 	ZipCode   *string
 	Distance  *int
 }
+
+type VolunteerSortField string
+
+const (
+	VolunteerSortLastName  VolunteerSortField = "LAST_NAME"
+	VolunteerSortFirstName VolunteerSortField = "FIRST_NAME"
+	VolunteerSortEmail     VolunteerSortField = "EMAIL"
+	VolunteerSortCreatedAt VolunteerSortField = "CREATED_AT"
+)
+
+type VolunteerShiftSortField string
+
+const (
+	VolunteerShiftSortStart      VolunteerShiftSortField = "START"
+	VolunteerShiftSortAssignedAt VolunteerShiftSortField = "ASSIGNED_AT"
+)
+
+// Segments.
+
+// A named volunteer filter.
+type VolunteerSegment struct {
+	ID            string
+	Name          string
+	Filter        VolunteerFilterInput
+	CreatedByID   *string
+	CreatedByName *string
+	CreatedAt     string
+	UpdatedAt     *string
+}
+
+type NewVolunteerSegmentInput struct {
+	Name   string
+	Filter VolunteerFilterInput
+}
+
+// Nil fields are left unchanged.
+type UpdateVolunteerSegmentInput struct {
+	ID     string
+	Name   *string
+	Filter *VolunteerFilterInput
+}
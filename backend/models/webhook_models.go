@@ -0,0 +1,100 @@
+package models
+
+// Enums.
+
+// A scheduling change a webhook subscription can be sent.
+type WebhookEventType string
+
+const (
+	WebhookEventShiftAssigned  WebhookEventType = "SHIFT_ASSIGNED"
+	WebhookEventShiftCancelled WebhookEventType = "SHIFT_CANCELLED"
+	WebhookEventEventCreated   WebhookEventType = "EVENT_CREATED"
+	WebhookEventEventUpdated   WebhookEventType = "EVENT_UPDATED"
+	WebhookEventEventDeleted   WebhookEventType = "EVENT_DELETED"
+	WebhookEventEventCancelled WebhookEventType = "EVENT_CANCELLED"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "FAILED"
+)
+
+// Output types.
+
+// A URL that is sent the chosen event types. The signing secret is never
+// returned after it is generated.
+type WebhookSubscription struct {
+	ID            string
+	URL           string
+	Description   *string
+	EventTypes    []WebhookEventType
+	IsActive      bool
+	CreatedByID   *string
+	CreatedByName *string
+	CreatedAt     string
+	UpdatedAt     *string
+}
+
+// Returned when a subscription is created or its secret rotated. Secret is
+// shown once.
+type WebhookSubscriptionResult struct {
+	Success      bool
+	Message      *string
+	Subscription *WebhookSubscription
+	Secret       *string
+	Code         ErrorCode
+}
+
+// One payload queued for one subscription, with the outcome of its latest
+// attempt. Payload is the JSON body as sent.
+type WebhookDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventType      WebhookEventType
+	Payload        string
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  *string
+	LastAttemptAt  *string
+	LastStatusCode *int
+	LastError      *string
+	CreatedAt      string
+	DeliveredAt    *string
+}
+
+type WebhookDeliveryConnection struct {
+	Edges      []*WebhookDeliveryEdge
+	PageInfo   PageInfo
+	TotalCount int
+}
+
+type WebhookDeliveryEdge struct {
+	Cursor string
+	Node   *WebhookDelivery
+}
+
+// Input types.
+
+type NewWebhookSubscriptionInput struct {
+	URL         string
+	Description *string
+	EventTypes  []WebhookEventType
+}
+
+// Nil fields are left unchanged.
+type UpdateWebhookSubscriptionInput struct {
+	ID          string
+	URL         *string
+	Description *string
+	EventTypes  []WebhookEventType
+	IsActive    *bool
+}
+
+type WebhookDeliveryFilterInput struct {
+	SubscriptionID *string
+	Status         *WebhookDeliveryStatus
+	EventType      *WebhookEventType
+}
@@ -0,0 +1,310 @@
+// Package publicfeed serves upcoming volunteer needs to the organisation's
+// public website: GET /public/events.json for scripts and GET
+// /public/events/widget for an <iframe>. Neither needs a session. Only
+// public fields of events are served — never anything about volunteers —
+// and responses are cached, so the routes are cheap to hit from every page
+// view. Rate limit them per client IP with middleware.RateLimitRequests.
+package publicfeed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+
+	// maxCacheEntries bounds the cache; each distinct filter is one entry.
+	maxCacheEntries = 256
+)
+
+// Handlers serves the public events feed.
+type Handlers struct {
+	Events *services.EventService
+
+	// FrontendURL is where links to an event's sign-up page point.
+	FrontendURL string
+
+	// CacheTTL is how long a response is reused, here and by browsers and
+	// CDNs (Cache-Control max-age). Zero disables caching.
+	CacheTTL time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// Event is one event as published in the feed.
+type Event struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description *string     `json:"description"`
+	EventType   string      `json:"eventType"`
+	City        *string     `json:"city"`
+	Timezone    string      `json:"timezone"`
+	Dates       []EventDate `json:"dates"`
+	Jobs        []Job       `json:"jobs"`
+	URL         string      `json:"url"`
+}
+
+type EventDate struct {
+	StartDateTime string `json:"startDateTime"`
+	EndDateTime   string `json:"endDateTime"`
+}
+
+// Job is one opportunity of an event with its seats still open across all
+// of its shifts.
+type Job struct {
+	JobName    string `json:"jobName"`
+	OpenSeats  int    `json:"openSeats"`
+	TotalSeats int    `json:"totalSeats"`
+}
+
+// Feed is the body of /public/events.json.
+type Feed struct {
+	Events      []Event `json:"events"`
+	GeneratedAt string  `json:"generatedAt"`
+}
+
+// JSON handles GET /public/events.json. Any site may read it (CORS *).
+//
+// Query parameters, each optional, filter like the volunteer events page:
+//
+//	cities     city names, comma-separated or repeated
+//	eventType  IN_PERSON, VIRTUAL or HYBRID
+//	jobs       job type IDs, comma-separated or repeated
+//	limit      number of events, 1-100 (default 20)
+func (h *Handlers) JSON() http.Handler {
+	return h.serve("json", "application/json; charset=utf-8", func(feed *Feed) ([]byte, error) {
+		return json.Marshal(feed)
+	}, func(w http.ResponseWriter) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+	})
+}
+
+// Widget handles GET /public/events/widget: the same events as a
+// self-contained HTML page to embed in an <iframe>. It takes the JSON
+// route's query parameters. The page runs no scripts and loads nothing.
+func (h *Handlers) Widget() http.Handler {
+	return h.serve("widget", "text/html; charset=utf-8", renderWidget, func(w http.ResponseWriter) {
+		w.Header().Set("Content-Security-Policy",
+			"default-src 'none'; style-src 'unsafe-inline'; base-uri 'none'; form-action 'none'")
+	})
+}
+
+// serve fetches (or reuses) the feed for the request's filter, renders it
+// with render and writes it with contentType and any extra headers.
+func (h *Handlers) serve(format, contentType string, render func(*Feed) ([]byte, error), headers func(http.ResponseWriter)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			writeError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		filter, limit, err := parseQuery(r.URL.Query())
+		if err != nil {
+			writeError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := format + "?" + cacheKey(filter, limit)
+
+		resp, ok := h.cached(key)
+		if !ok {
+			events, err := h.Events.FetchPublicEventViews(r.Context(), filter)
+			if err != nil {
+				log.Printf("publicfeed: %v", err)
+				writeError(w, "events are unavailable; please try again later", http.StatusInternalServerError)
+				return
+			}
+			body, err := render(h.feed(events, limit))
+			if err != nil {
+				log.Printf("publicfeed: rendering %s: %v", format, err)
+				writeError(w, "events are unavailable; please try again later", http.StatusInternalServerError)
+				return
+			}
+			sum := sha256.Sum256(body)
+			resp = cachedResponse{
+				body:    body,
+				etag:    `"` + hex.EncodeToString(sum[:8]) + `"`,
+				expires: time.Now().Add(h.CacheTTL),
+			}
+			h.store(key, resp)
+		}
+
+		headers(w)
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.CacheTTL.Seconds())))
+		w.Header().Set("ETag", resp.etag)
+		if r.Header.Get("If-None-Match") == resp.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Method == http.MethodHead {
+			return
+		}
+		w.Write(resp.body)
+	})
+}
+
+// feed keeps the public fields of up to limit events.
+func (h *Handlers) feed(events []*models.EventView, limit int) *Feed {
+	feed := &Feed{Events: []Event{}, GeneratedAt: time.Now().UTC().Format(time.RFC3339)}
+	base := strings.TrimRight(h.FrontendURL, "/")
+	for _, e := range events {
+		if len(feed.Events) == limit {
+			break
+		}
+		ev := Event{
+			ID:          e.ID,
+			Name:        e.Name,
+			Description: e.Description,
+			EventType:   string(e.EventType),
+			Timezone:    e.Timezone,
+			Dates:       []EventDate{},
+			Jobs:        []Job{},
+			URL:         base + "/events/" + url.PathEscape(e.ID),
+		}
+		if e.Venue != nil {
+			ev.City = &e.Venue.City
+		}
+		for _, d := range e.EventDates {
+			ev.Dates = append(ev.Dates, EventDate{StartDateTime: d.StartDateTime, EndDateTime: d.EndDateTime})
+		}
+		for _, s := range e.ShiftSummaries {
+			ev.Jobs = append(ev.Jobs, Job{
+				JobName:    s.JobName,
+				OpenSeats:  max(s.MaxVolunteers-s.AssignedVolunteers, 0),
+				TotalSeats: s.MaxVolunteers,
+			})
+		}
+		feed.Events = append(feed.Events, ev)
+	}
+	return feed
+}
+
+// parseQuery reads the filter parameters. The error is safe to show.
+func parseQuery(q url.Values) (models.VolunteerEventFilterInput, int, error) {
+	var filter models.VolunteerEventFilterInput
+	filter.Cities = listParam(q, "cities")
+
+	if v := strings.TrimSpace(q.Get("eventType")); v != "" {
+		eventType := models.EventType(strings.ToUpper(v))
+		switch eventType {
+		case models.EventTypeInPerson, models.EventTypeVirtual, models.EventTypeHybrid:
+		default:
+			return filter, 0, fmt.Errorf("eventType must be IN_PERSON, VIRTUAL or HYBRID")
+		}
+		filter.EventType = &eventType
+	}
+
+	for _, v := range listParam(q, "jobs") {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, 0, fmt.Errorf("jobs must be job type IDs")
+		}
+		filter.Jobs = append(filter.Jobs, id)
+	}
+
+	limit := defaultLimit
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxLimit {
+			return filter, 0, fmt.Errorf("limit must be between 1 and %d", maxLimit)
+		}
+		limit = n
+	}
+	return filter, limit, nil
+}
+
+// listParam reads a parameter given as a comma-separated list, repeated, or
+// both, dropping empty items.
+func listParam(q url.Values, name string) []string {
+	var items []string
+	for _, v := range q[name] {
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
+
+// cacheKey is the same for filters that select the same events.
+func cacheKey(filter models.VolunteerEventFilterInput, limit int) string {
+	cities := append([]string(nil), filter.Cities...)
+	sort.Strings(cities)
+	jobs := append([]int(nil), filter.Jobs...)
+	sort.Ints(jobs)
+	eventType := ""
+	if filter.EventType != nil {
+		eventType = string(*filter.EventType)
+	}
+	return fmt.Sprintf("cities=%q&eventType=%s&jobs=%v&limit=%d", cities, eventType, jobs, limit)
+}
+
+func (h *Handlers) cached(key string) (cachedResponse, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	resp, ok := h.cache[key]
+	if !ok || time.Now().After(resp.expires) {
+		return cachedResponse{}, false
+	}
+	return resp, true
+}
+
+func (h *Handlers) store(key string, resp cachedResponse) {
+	if h.CacheTTL <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.cache == nil {
+		h.cache = map[string]cachedResponse{}
+	}
+	if len(h.cache) >= maxCacheEntries {
+		now := time.Now()
+		for k, v := range h.cache {
+			if now.After(v.expires) {
+				delete(h.cache, k)
+			}
+		}
+		if len(h.cache) >= maxCacheEntries {
+			clear(h.cache)
+		}
+	}
+	h.cache[key] = resp
+}
+
+// writeError matches the {"errors":[...]} shape the rest of the API uses.
+func writeError(w http.ResponseWriter, msg string, status int) {
+	var body bytes.Buffer
+	json.NewEncoder(&body).Encode(map[string]any{
+		"errors": []map[string]string{{"message": msg}},
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body.Bytes())
+}
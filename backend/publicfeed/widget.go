@@ -0,0 +1,73 @@
+package publicfeed
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+)
+
+var widgetTemplate = template.Must(template.New("widget").Funcs(template.FuncMap{
+	"when": formatDate,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Volunteer opportunities</title>
+<style>
+body { margin: 0; font-family: system-ui, sans-serif; font-size: 14px; color: #1f2933; background: transparent; }
+ul { list-style: none; margin: 0; padding: 0; }
+li.event { border: 1px solid #d9e2ec; border-radius: 6px; padding: 12px; margin: 0 0 10px; background: #fff; }
+h2 { font-size: 16px; margin: 0 0 4px; }
+h2 a { color: #1d4ed8; text-decoration: none; }
+.meta { color: #52606d; margin: 0 0 6px; }
+.jobs li { display: inline-block; margin: 2px 6px 2px 0; padding: 2px 8px; border-radius: 10px; background: #e6f6ff; }
+.jobs li.full { background: #f0f4f8; color: #7b8794; }
+.empty { color: #52606d; }
+</style>
+</head>
+<body>
+{{- if .Events}}
+<ul>
+{{- range .Events}}
+<li class="event">
+<h2><a href="{{.URL}}" target="_blank" rel="noopener">{{.Name}}</a></h2>
+{{- $tz := .Timezone}}
+<p class="meta">{{range $i, $d := .Dates}}{{if $i}} · {{end}}{{when $d.StartDateTime $tz}}{{end}}{{with .City}} — {{.}}{{end}}</p>
+{{- if .Jobs}}
+<ul class="jobs">
+{{- range .Jobs}}
+<li{{if eq .OpenSeats 0}} class="full"{{end}}>{{.JobName}}: {{if eq .OpenSeats 0}}full{{else}}{{.OpenSeats}} open{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+{{- else}}
+<p class="empty">No upcoming volunteer opportunities right now.</p>
+{{- end}}
+</body>
+</html>
+`))
+
+func renderWidget(feed *Feed) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := widgetTemplate.Execute(&buf, feed); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// formatDate shows an RFC 3339 time in the event's timezone, or as given if
+// either can't be parsed.
+func formatDate(value, timezone string) string {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return value
+	}
+	if loc, err := time.LoadLocation(timezone); err == nil {
+		t = t.In(loc)
+	}
+	return t.Format("Mon Jan 2, 3:04 PM MST")
+}
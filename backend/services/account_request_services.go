@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/models"
+)
+
+// ErrAccountRequestInvalid is returned for an unknown, expired or already
+// used account request verification link.
+var ErrAccountRequestInvalid error = models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired verification link")
+
+// accountRequestVerifyTTL is how long a requester has to confirm their email.
+const accountRequestVerifyTTL = 24 * time.Hour
+
+// accountRequestVerifyPath is the frontend page the verification link opens.
+const accountRequestVerifyPath = "/auth/verify-request"
+
+// AccountRequestService keeps the queue of account requests made from the
+// login page. A request is shown to admins once the requester has confirmed
+// their email address; approving it creates (or reactivates) the volunteer
+// through VolunteerService.
+type AccountRequestService struct {
+	DB         *sql.DB
+	mailer     *Mailer
+	volunteers *VolunteerService
+}
+
+func NewAccountRequestService(db *sql.DB, mailer *Mailer, volunteers *VolunteerService) *AccountRequestService {
+	return &AccountRequestService{DB: db, mailer: mailer, volunteers: volunteers}
+}
+
+// Requester side.
+
+// RequestAccount records a request and emails the requester a link to
+// confirm their address. Asking again before confirming refreshes the
+// request and sends a new link. The caller learns nothing about whether the
+// address already has an account or an open request.
+func (s *AccountRequestService) RequestAccount(ctx context.Context, email, firstName, lastName, ipAddress string) error {
+	email = strings.TrimSpace(email)
+	firstName = strings.TrimSpace(firstName)
+	lastName = strings.TrimSpace(lastName)
+	if firstName == "" || lastName == "" {
+		return &models.Error{
+			Code:    models.ErrorCodeValidation,
+			Message: "first and last name are required",
+			Fields: []models.FieldError{
+				{Field: "firstName", Message: "first name is required"},
+				{Field: "lastName", Message: "last name is required"},
+			},
+		}
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return models.NewFieldError("email", "invalid email address %q", email)
+	}
+
+	var active bool
+	err := s.DB.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM volunteers WHERE LOWER(email) = LOWER($1) AND is_active = true)",
+		email).Scan(&active)
+	if err != nil {
+		return fmt.Errorf("error checking for an existing account: %w", err)
+	}
+	if active {
+		log.Printf("Account request for %s ignored: the address already has an active account", email)
+		return nil
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return fmt.Errorf("failed to generate verification token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	// An unverified open request is refreshed; a verified one is already in
+	// the queue, so the upsert leaves it alone and returns no row.
+	var id int
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO account_requests (email, first_name, last_name, verify_token, verify_expires_at, ip_address)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		ON CONFLICT (LOWER(email)) WHERE status = 'PENDING'
+		DO UPDATE SET
+			first_name        = EXCLUDED.first_name,
+			last_name         = EXCLUDED.last_name,
+			verify_token      = EXCLUDED.verify_token,
+			verify_expires_at = EXCLUDED.verify_expires_at,
+			ip_address        = EXCLUDED.ip_address,
+			created_at        = NOW()
+		WHERE account_requests.verified_at IS NULL
+		RETURNING id
+	`, email, firstName, lastName, hashSessionToken(token),
+		time.Now().UTC().Add(accountRequestVerifyTTL), ipAddress).Scan(&id)
+	if err == sql.ErrNoRows {
+		log.Printf("Account request for %s ignored: a verified request is already pending", email)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error storing account request: %w", err)
+	}
+
+	verifyURL := fmt.Sprintf("%s%s?token=%s", frontendBaseURL(), accountRequestVerifyPath, token)
+	if err := sendAccountRequestVerify(ctx, s.mailer, firstName, email, verifyURL); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	return nil
+}
+
+// VerifyAccountRequest confirms the requester's email address from the link
+// sent by RequestAccount, which puts the request in the admins' queue, and
+// notifies the admins.
+func (s *AccountRequestService) VerifyAccountRequest(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrAccountRequestInvalid
+	}
+
+	var id int
+	var email, firstName, lastName string
+	err := s.DB.QueryRowContext(ctx, `
+		UPDATE account_requests
+		SET verified_at = NOW(), verify_token = NULL
+		WHERE verify_token = $1 AND verified_at IS NULL
+		  AND verify_expires_at > NOW() AND status = 'PENDING'
+		RETURNING id, email, first_name, last_name
+	`, hashSessionToken(token)).Scan(&id, &email, &firstName, &lastName)
+	if err == sql.ErrNoRows {
+		return ErrAccountRequestInvalid
+	}
+	if err != nil {
+		return fmt.Errorf("error verifying account request: %w", err)
+	}
+
+	adminEmails, err := fetchAdminEmails(ctx, s.DB)
+	if err != nil {
+		log.Printf("Warning: account request %d verified but admins could not be notified: %v", id, err)
+		return nil
+	}
+	if len(adminEmails) == 0 {
+		log.Printf("Warning: account request from %s %s <%s> but no admins found to notify", firstName, lastName, email)
+		return nil
+	}
+
+	// An inactive account with this email is reactivated on approval, so say so.
+	var existingID int
+	var existingFirst, existingLast string
+	err = s.DB.QueryRowContext(ctx,
+		"SELECT volunteer_id, first_name, last_name FROM volunteers WHERE LOWER(email) = LOWER($1) AND is_active = false",
+		email).Scan(&existingID, &existingFirst, &existingLast)
+	if err == nil {
+		existingName := existingFirst + " " + existingLast
+		return sendActivateAccountRequest(ctx, s.mailer, adminEmails, firstName, lastName, email, existingName, existingID)
+	}
+	return sendNewAccountRequest(ctx, s.mailer, adminEmails, firstName, lastName, email)
+}
+
+// Queries.
+
+// FetchAccountRequests returns verified requests with the given status:
+// pending ones oldest first, decided ones most recently decided first.
+func (s *AccountRequestService) FetchAccountRequests(ctx context.Context, status models.AccountRequestStatus) ([]*models.AccountRequest, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT
+			a.id,
+			a.email,
+			a.first_name,
+			a.last_name,
+			a.status,
+			(SELECT volunteer_id FROM volunteers
+			 WHERE LOWER(email) = LOWER(a.email) AND is_active = false LIMIT 1),
+			a.created_at,
+			a.verified_at,
+			a.decided_by,
+			d.first_name || ' ' || d.last_name,
+			a.decided_at,
+			a.decision_message,
+			a.volunteer_id
+		FROM account_requests a
+		LEFT JOIN volunteers d ON d.volunteer_id = a.decided_by
+		WHERE a.verified_at IS NOT NULL AND a.status = $1
+		ORDER BY a.decided_at DESC NULLS LAST, a.created_at
+	`, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("error querying account requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*models.AccountRequest{}
+	for rows.Next() {
+		var r models.AccountRequest
+		var id int
+		var existingId, decidedBy, volId sql.NullInt64
+		var decidedByName, decidedAt, message sql.NullString
+		if err := rows.Scan(&id, &r.Email, &r.FirstName, &r.LastName, &r.Status, &existingId,
+			&r.CreatedAt, &r.VerifiedAt, &decidedBy, &decidedByName, &decidedAt, &message, &volId); err != nil {
+			return nil, fmt.Errorf("error scanning account request: %w", err)
+		}
+		r.ID = strconv.Itoa(id)
+		r.ExistingVolunteerID = nullIntString(existingId)
+		r.DecidedByID = nullIntString(decidedBy)
+		r.DecidedByName = nullStringPtr(decidedByName)
+		r.DecidedAt = nullStringPtr(decidedAt)
+		r.DecisionMessage = nullStringPtr(message)
+		r.VolunteerID = nullIntString(volId)
+		requests = append(requests, &r)
+	}
+	return requests, rows.Err()
+}
+
+// Mutations.
+
+// ApproveAccountRequest creates the requested account, or reactivates the
+// inactive one with the same email, with the given role. The request is
+// claimed first, so two admins approving at once cannot both create it. The
+// result's ID is the volunteer's.
+func (s *AccountRequestService) ApproveAccountRequest(ctx context.Context, adminId int, requestId string, input models.ApproveAccountRequestInput) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(requestId)
+	if err != nil {
+		return nil, models.InvalidID("requestId")
+	}
+	if err := validateRoleAssignment(input.Role, input.FundingEntityIDs); err != nil {
+		return nil, err
+	}
+
+	var email, firstName, lastName string
+	err = s.DB.QueryRowContext(ctx, `
+		UPDATE account_requests
+		SET status = 'APPROVED', decided_by = $2, decided_at = NOW()
+		WHERE id = $1 AND status = 'PENDING' AND verified_at IS NOT NULL
+		RETURNING email, first_name, last_name
+	`, idInt, adminId).Scan(&email, &firstName, &lastName)
+	if err == sql.ErrNoRows {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Account request not found or already decided.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error approving account request: %w", err)
+	}
+
+	var existingId int
+	var isActive bool
+	err = s.DB.QueryRowContext(ctx,
+		"SELECT volunteer_id, is_active FROM volunteers WHERE LOWER(email) = LOWER($1) ORDER BY is_active DESC LIMIT 1",
+		email).Scan(&existingId, &isActive)
+
+	var result *models.MutationResult
+	switch {
+	case err != nil && err != sql.ErrNoRows:
+		result, err = nil, fmt.Errorf("error looking up volunteer: %w", err)
+	case err == nil && isActive:
+		// Created by hand since the request was made; just close the request.
+		volStr := strconv.Itoa(existingId)
+		result = &models.MutationResult{
+			Success: true,
+			Message: ptrString("This email address already has an active account; the request has been closed."),
+			ID:      &volStr,
+		}
+	case err == nil:
+		result, err = s.volunteers.ReactivateVolunteer(ctx, adminId, existingId, input.Role, input.FundingEntityIDs)
+	default:
+		result, err = s.volunteers.CreateVolunteer(ctx, adminId, models.NewVolunteerInput{
+			FirstName:        firstName,
+			LastName:         lastName,
+			Email:            email,
+			Role:             input.Role,
+			FundingEntityIDs: input.FundingEntityIDs,
+		})
+	}
+
+	if err != nil {
+		// Put the request back in the queue.
+		if _, rbErr := s.DB.ExecContext(ctx, `
+			UPDATE account_requests SET status = 'PENDING', decided_by = NULL, decided_at = NULL
+			WHERE id = $1
+		`, idInt); rbErr != nil {
+			log.Printf("Warning: could not return account request %d to the queue: %v", idInt, rbErr)
+		}
+		return nil, err
+	}
+
+	if _, err := s.DB.ExecContext(ctx,
+		"UPDATE account_requests SET volunteer_id = $2 WHERE id = $1", idInt, *result.ID); err != nil {
+		log.Printf("Warning: could not link account request %d to volunteer %s: %v", idInt, *result.ID, err)
+	}
+	return result, nil
+}
+
+// DenyAccountRequest closes a pending request and tells the requester, with
+// message if one is given.
+func (s *AccountRequestService) DenyAccountRequest(ctx context.Context, adminId int, requestId string, message *string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(requestId)
+	if err != nil {
+		return nil, models.InvalidID("requestId")
+	}
+	var msg string
+	if message != nil {
+		msg = strings.TrimSpace(*message)
+	}
+
+	var email, firstName string
+	err = s.DB.QueryRowContext(ctx, `
+		UPDATE account_requests
+		SET status = 'DENIED', decided_by = $2, decided_at = NOW(), decision_message = NULLIF($3, '')
+		WHERE id = $1 AND status = 'PENDING' AND verified_at IS NOT NULL
+		RETURNING email, first_name
+	`, idInt, adminId, msg).Scan(&email, &firstName)
+	if err == sql.ErrNoRows {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Account request not found or already decided.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error denying account request: %w", err)
+	}
+
+	if err := sendAccountRequestDenied(ctx, s.mailer, firstName, email, msg); err != nil {
+		log.Printf("Warning: failed to send account request denial to %s: %v", email, err)
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Account request denied."),
+		ID:      &requestId,
+	}, nil
+}
+
+// Cleanup removes requests whose verification link expired unused.
+// Typically called periodically (e.g., daily background job).
+func (s *AccountRequestService) Cleanup(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx,
+		"DELETE FROM account_requests WHERE verified_at IS NULL AND verify_expires_at < NOW()")
+	if err != nil {
+		return fmt.Errorf("error cleaning up account requests: %w", err)
+	}
+	return nil
+}
+
+// Helpers.
+
+// fetchAdminEmails returns the addresses of all active administrators.
+func fetchAdminEmails(ctx context.Context, DB *sql.DB) ([]string, error) {
+	rows, err := DB.QueryContext(ctx, `
+		SELECT v.email
+		FROM   volunteers v
+		JOIN   volunteer_roles vr ON vr.volunteer_id = v.volunteer_id
+		JOIN   roles r            ON r.role_id = vr.role_id
+		WHERE  r.role_name = 'ADMINISTRATOR' AND v.is_active = true
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching admin emails: %w", err)
+	}
+	defer rows.Close()
+
+	var adminEmails []string
+	for rows.Next() {
+		var adminEmail string
+		if err := rows.Scan(&adminEmail); err != nil {
+			return nil, fmt.Errorf("error scanning admin email: %w", err)
+		}
+		adminEmails = append(adminEmails, adminEmail)
+	}
+	return adminEmails, rows.Err()
+}
+
+func nullIntString(n sql.NullInt64) *string {
+	if !n.Valid {
+		return nil
+	}
+	s := strconv.FormatInt(n.Int64, 10)
+	return &s
+}
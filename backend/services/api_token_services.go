@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/models"
+)
+
+// ErrAPITokenInvalid is returned for an unknown, expired or revoked API token,
+// or one whose owner has been deactivated.
+var ErrAPITokenInvalid error = models.NewError(models.ErrorCodeUnauthenticated, "API token expired or invalid")
+
+// APITokenPrefix starts every API token, so RequireAuth can tell one from a
+// session token and secret scanners can recognise leaked tokens.
+const APITokenPrefix = "vsk_"
+
+// Default and maximum token lifetime, in days. API_TOKEN_MAX_DAYS overrides
+// the maximum.
+const defaultAPITokenDays = 90
+
+func apiTokenMaxDays() int {
+	if val, err := strconv.Atoi(os.Getenv("API_TOKEN_MAX_DAYS")); err == nil && val > 0 {
+		return val
+	}
+	return 365
+}
+
+// IsAPIToken reports whether a bearer token is an API token rather than a
+// session token.
+func IsAPIToken(token string) bool {
+	return strings.HasPrefix(token, APITokenPrefix)
+}
+
+// APITokenService manages long-lived, scoped bearer tokens for scripts and
+// integrations. A token acts as its owner, with the owner's current roles,
+// limited to the fields its scope allows.
+type APITokenService struct {
+	DB *sql.DB
+}
+
+func NewAPITokenService(db *sql.DB) *APITokenService {
+	return &APITokenService{DB: db}
+}
+
+// ValidatedAPIToken is the caller behind a valid API token.
+type ValidatedAPIToken struct {
+	TokenID     int
+	VolunteerId int
+	Roles       []string
+	Scope       models.APITokenScope
+}
+
+// Queries.
+
+// FetchAPITokens returns every token, live ones first, newest first.
+func (s *APITokenService) FetchAPITokens(ctx context.Context) ([]*models.APIToken, error) {
+	rows, err := s.DB.QueryContext(ctx, apiTokenSelect+`
+		ORDER BY (t.revoked_at IS NULL AND t.expires_at > NOW()) DESC, t.created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	tokens := []*models.APIToken{}
+	for rows.Next() {
+		tok, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, rows.Err()
+}
+
+// Mutations.
+
+// CreateAPIToken issues a token and returns it; the token is not stored and
+// cannot be shown again.
+func (s *APITokenService) CreateAPIToken(ctx context.Context, adminId int, input models.NewAPITokenInput) (*models.APITokenResult, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, models.NewError(models.ErrorCodeValidation, "A name is required.")
+	}
+	if len(name) > 100 {
+		return nil, models.NewError(models.ErrorCodeValidation, "Name must be 100 characters or fewer.")
+	}
+
+	switch input.Scope {
+	case models.APITokenScopeAdminReadOnly, models.APITokenScopeVolunteerSignup, models.APITokenScopeReporting:
+	default:
+		return nil, models.NewFieldError("scope", "invalid scope")
+	}
+
+	days := defaultAPITokenDays
+	if input.ExpiresInDays != nil {
+		days = *input.ExpiresInDays
+	}
+	if maxDays := apiTokenMaxDays(); days < 1 || days > maxDays {
+		return nil, models.NewError(models.ErrorCodeValidation, "Expiry must be between 1 and %d days.", maxDays)
+	}
+
+	ownerId := adminId
+	if input.OwnerID != nil {
+		id, err := strconv.Atoi(*input.OwnerID)
+		if err != nil {
+			return nil, models.InvalidID("ownerId")
+		}
+		ownerId = id
+	}
+
+	var isActive bool
+	err := s.DB.QueryRowContext(ctx,
+		"SELECT is_active FROM volunteers WHERE volunteer_id = $1", ownerId,
+	).Scan(&isActive)
+	if err == sql.ErrNoRows || (err == nil && !isActive) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Owner not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error looking up token owner: %w", err)
+	}
+
+	// Admin-side scopes only make sense for someone who can use /graphql/admin.
+	if input.Scope != models.APITokenScopeVolunteerSignup {
+		roles, err := fetchVolunteerRoleNames(ctx, s.DB, ownerId)
+		if err != nil {
+			return nil, err
+		}
+		if !hasRoleName(roles, models.RoleAdministrator) && !hasRoleName(roles, models.RoleCoordinator) {
+			return nil, models.NewError(models.ErrorCodeValidation, "This scope needs an administrator or coordinator as owner.")
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate API token: %w", err)
+	}
+	token := APITokenPrefix + hex.EncodeToString(tokenBytes)
+
+	var id int
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO api_tokens (name, token, token_prefix, owner_id, scope, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, name, hashSessionToken(token), token[:len(APITokenPrefix)+8], ownerId, string(input.Scope), adminId,
+		time.Now().UTC().AddDate(0, 0, days)).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("error creating API token: %w", err)
+	}
+
+	tok, err := s.fetchAPIToken(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.APITokenResult{
+		Success:  true,
+		Message:  ptrString("API token created. Copy it now; it will not be shown again."),
+		APIToken: tok,
+		Token:    &token,
+	}, nil
+}
+
+// RevokeAPIToken stops a token from working. It takes effect on the next
+// request, since every request re-checks the token.
+func (s *APITokenService) RevokeAPIToken(ctx context.Context, adminId int, tokenId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(tokenId)
+	if err != nil {
+		return nil, models.InvalidID("tokenId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE api_tokens
+		SET revoked_at = NOW(), revoked_by = $2
+		WHERE id = $1 AND revoked_at IS NULL
+	`, idInt, adminId)
+	if err != nil {
+		return nil, fmt.Errorf("error revoking API token: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "API token not found or already revoked.")
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("API token revoked."),
+		ID:      &tokenId,
+	}, nil
+}
+
+// Validation.
+
+// ValidateAPIToken checks a bearer token and returns its owner, the owner's
+// current roles and the token's scope, recording when and from where it was
+// last used.
+func (s *APITokenService) ValidateAPIToken(ctx context.Context, token, ipAddress string) (*ValidatedAPIToken, error) {
+	var v ValidatedAPIToken
+	var scope string
+	err := s.DB.QueryRowContext(ctx, `
+		UPDATE api_tokens t
+		SET last_used_at = NOW(), last_used_ip = NULLIF($2, '')
+		FROM volunteers v
+		WHERE t.token = $1
+		  AND t.revoked_at IS NULL
+		  AND t.expires_at > NOW()
+		  AND v.volunteer_id = t.owner_id
+		  AND v.is_active = TRUE
+		RETURNING t.id, t.owner_id, t.scope
+	`, hashSessionToken(token), ipAddress).Scan(&v.TokenID, &v.VolunteerId, &scope)
+	if err == sql.ErrNoRows {
+		return nil, ErrAPITokenInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error validating API token: %w", err)
+	}
+	v.Scope = models.APITokenScope(scope)
+
+	v.Roles, err = fetchVolunteerRoleNames(ctx, s.DB, v.VolunteerId)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// Helpers.
+
+const apiTokenSelect = `
+	SELECT
+		t.id,
+		t.name,
+		t.token_prefix,
+		t.owner_id,
+		o.first_name || ' ' || o.last_name,
+		t.scope,
+		t.created_by,
+		c.first_name || ' ' || c.last_name,
+		t.created_at,
+		t.expires_at,
+		t.last_used_at,
+		t.last_used_ip,
+		t.revoked_at
+	FROM api_tokens t
+	JOIN volunteers o ON o.volunteer_id = t.owner_id
+	LEFT JOIN volunteers c ON c.volunteer_id = t.created_by
+`
+
+func (s *APITokenService) fetchAPIToken(ctx context.Context, id int) (*models.APIToken, error) {
+	row := s.DB.QueryRowContext(ctx, apiTokenSelect+" WHERE t.id = $1", id)
+	return scanAPIToken(row)
+}
+
+func scanAPIToken(row interface{ Scan(...any) error }) (*models.APIToken, error) {
+	var tok models.APIToken
+	var id, ownerId int
+	var scope string
+	var createdBy sql.NullInt64
+	var createdByName, lastUsedAt, lastUsedIP, revokedAt sql.NullString
+	err := row.Scan(
+		&id,
+		&tok.Name,
+		&tok.Prefix,
+		&ownerId,
+		&tok.OwnerName,
+		&scope,
+		&createdBy,
+		&createdByName,
+		&tok.CreatedAt,
+		&tok.ExpiresAt,
+		&lastUsedAt,
+		&lastUsedIP,
+		&revokedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning API token: %w", err)
+	}
+	tok.ID = strconv.Itoa(id)
+	tok.OwnerID = strconv.Itoa(ownerId)
+	tok.Scope = models.APITokenScope(scope)
+	if createdBy.Valid {
+		s := strconv.FormatInt(createdBy.Int64, 10)
+		tok.CreatedByID = &s
+	}
+	if createdByName.Valid {
+		tok.CreatedByName = &createdByName.String
+	}
+	if lastUsedAt.Valid {
+		tok.LastUsedAt = &lastUsedAt.String
+	}
+	if lastUsedIP.Valid {
+		tok.LastUsedIP = &lastUsedIP.String
+	}
+	if revokedAt.Valid {
+		tok.RevokedAt = &revokedAt.String
+	}
+	return &tok, nil
+}
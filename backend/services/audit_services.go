@@ -0,0 +1,360 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/models"
+)
+
+// AuditService records and lists the audit log: one append-only entry per
+// admin mutation, with the fields it changed on its target record.
+type AuditService struct {
+	DB *sql.DB
+}
+
+func NewAuditService(db *sql.DB) *AuditService {
+	return &AuditService{DB: db}
+}
+
+// AuditRecord is what the admin schema knows about one mutation.
+type AuditRecord struct {
+	ActorID     *int
+	ViaAPIToken bool
+	Operation   string
+	Entity      string // empty when the mutation has no single target
+	EntityID    string
+	Before      map[string]any
+	After       map[string]any
+	Success     bool
+	Error       string
+	IPAddress   string
+}
+
+// auditSnapshots read one record as a JSON object, keyed by entity name. Each
+// takes the entity's ID as $1 (text). Related rows that admins change through
+// the same mutations (roles, signups, notes) are folded in so they show up in
+// the diff; secrets and derived columns are left out.
+var auditSnapshots = map[string]string{
+	"AccountRequest": `
+		SELECT to_jsonb(a) - 'verify_token' FROM account_requests a WHERE a.id = $1::int`,
+	"APIToken": `
+		SELECT to_jsonb(t) - 'token' FROM api_tokens t WHERE t.id = $1::int`,
+	"ErasureRequest": `
+		SELECT to_jsonb(r) FROM erasure_requests r WHERE r.id = $1::int`,
+	"Event": `
+		SELECT (to_jsonb(e) - 'search_vector') || jsonb_build_object('service_type_ids', ARRAY(
+			SELECT service_type_id FROM event_service_types WHERE event_id = e.event_id ORDER BY 1))
+		FROM events e WHERE e.event_id = $1::int`,
+	"EventDate": `
+		SELECT to_jsonb(d) FROM event_dates d WHERE d.event_date_id = $1::int`,
+	"Feedback": `
+		SELECT to_jsonb(f) || jsonb_build_object(
+			'note_count', (SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = f.feedback_id),
+			'attachment_count', (SELECT COUNT(*) FROM feedback_attachments WHERE feedback_id = f.feedback_id))
+		FROM feedback f WHERE f.feedback_id = $1::int`,
+	"FundingEntity": `
+		SELECT to_jsonb(f) FROM funding_entities f WHERE f.id = $1::int`,
+	"Impersonation": `
+		SELECT to_jsonb(i) - 'token' - 'last_used_at' - 'request_count'
+		FROM impersonation_sessions i WHERE i.id = $1::int`,
+	"JobType": `
+		SELECT to_jsonb(j) FROM job_types j WHERE j.job_type_id = $1::int`,
+	"Opportunity": `
+		SELECT to_jsonb(o) FROM opportunities o WHERE o.opportunity_id = $1::int`,
+	"Shift": `
+		SELECT to_jsonb(s) || jsonb_build_object('volunteer_ids', ARRAY(
+			SELECT volunteer_id FROM volunteer_shifts
+			WHERE shift_id = s.shift_id AND cancelled_at IS NULL ORDER BY 1))
+		FROM shifts s WHERE s.shift_id = $1::int`,
+	"Staff": `
+		SELECT to_jsonb(s) FROM staff s WHERE s.staff_id = $1::int`,
+	"Venue": `
+		SELECT to_jsonb(v) FROM venues v WHERE v.venue_id = $1::int`,
+	"Volunteer": `
+		SELECT to_jsonb(v) || jsonb_build_object(
+			'roles', ARRAY(
+				SELECT r.role_name FROM volunteer_roles vr JOIN roles r ON r.role_id = vr.role_id
+				WHERE vr.volunteer_id = v.volunteer_id ORDER BY 1),
+			'funding_entity_ids', ARRAY(
+				SELECT funding_entity_id FROM coordinator_funding_entities
+				WHERE volunteer_id = v.volunteer_id ORDER BY 1),
+			'email_suppressed', EXISTS(
+				SELECT 1 FROM email_suppressions WHERE LOWER(email) = LOWER(v.email)),
+			'session_count', (SELECT COUNT(*) FROM sessions WHERE volunteer_id = v.volunteer_id))
+		FROM volunteers v WHERE v.volunteer_id = $1::int`,
+	"VolunteerSegment": `
+		SELECT to_jsonb(g) FROM volunteer_segments g WHERE g.id = $1::int`,
+	"WebhookDelivery": `
+		SELECT to_jsonb(d) - 'payload' FROM webhook_deliveries d WHERE d.id = $1::int`,
+	"WebhookSubscription": `
+		SELECT to_jsonb(w) - 'secret' FROM webhook_subscriptions w WHERE w.id = $1::int`,
+}
+
+// auditRedactedFields are the snapshot fields holding personal data. The
+// audit log records that they changed but not their values, so an erasure
+// has nothing to take back out of it. Keep in step with the list migration
+// 000023 scrubbed from older entries.
+var auditRedactedFields = map[string][]string{
+	"AccountRequest": {"email", "first_name", "ip_address", "last_name"},
+	"ErasureRequest": {"reason"},
+	"Feedback":       {"app_page_name", "subject", "text"},
+	"Volunteer":      {"email", "first_name", "last_name", "latitude", "longitude", "phone", "zip_code"},
+}
+
+// auditRedacted stands in for a redacted value.
+var auditRedacted = json.RawMessage(`"[redacted]"`)
+
+// Snapshot returns the current state of one record, or nil if it does not
+// exist (or entity has no snapshot).
+func (s *AuditService) Snapshot(ctx context.Context, entity, id string) (map[string]any, error) {
+	query, ok := auditSnapshots[entity]
+	if !ok || id == "" {
+		return nil, nil
+	}
+	if _, err := strconv.Atoi(id); err != nil {
+		return nil, nil
+	}
+
+	var raw []byte
+	err := s.DB.QueryRowContext(ctx, query, id).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %s for audit: %w", entity, id, err)
+	}
+	var snap map[string]any
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, fmt.Errorf("error decoding %s %s for audit: %w", entity, id, err)
+	}
+	return snap, nil
+}
+
+// Record appends one entry to the audit log.
+func (s *AuditService) Record(ctx context.Context, rec AuditRecord) error {
+	changes, err := json.Marshal(redactAuditChanges(rec.Entity, diffSnapshots(rec.Before, rec.After)))
+	if err != nil {
+		return fmt.Errorf("error encoding audit changes: %w", err)
+	}
+
+	_, err = s.DB.ExecContext(ctx, `
+		INSERT INTO audit_log
+			(actor_id, actor_name, via_api_token, operation, entity, entity_id, changes, success, error, ip_address)
+		VALUES ($1,
+			(SELECT first_name || ' ' || last_name FROM volunteers WHERE volunteer_id = $1),
+			$2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, NULLIF($8, ''), NULLIF($9, ''))
+	`, rec.ActorID, rec.ViaAPIToken, rec.Operation, rec.Entity, rec.EntityID, changes,
+		rec.Success, rec.Error, rec.IPAddress)
+	if err != nil {
+		return fmt.Errorf("error writing audit log: %w", err)
+	}
+	return nil
+}
+
+// FetchAuditLog returns entries matching filter, newest first.
+func (s *AuditService) FetchAuditLog(ctx context.Context, filter *models.AuditLogFilter) ([]*models.AuditEntry, error) {
+	var conditions []string
+	var args []any
+	add := func(cond string, val any) {
+		args = append(args, val)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+
+	limit := 100
+	if filter != nil {
+		if filter.ActorID != nil {
+			id, err := strconv.Atoi(*filter.ActorID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid actor id %s: %w", *filter.ActorID, err)
+			}
+			add("actor_id = $%d", id)
+		}
+		if filter.Entity != nil && *filter.Entity != "" {
+			add("entity = $%d", *filter.Entity)
+		}
+		if filter.EntityID != nil && *filter.EntityID != "" {
+			add("entity_id = $%d", *filter.EntityID)
+		}
+		if filter.From != nil && *filter.From != "" {
+			from, err := parseAuditTime(*filter.From, false)
+			if err != nil {
+				return nil, err
+			}
+			add("created_at >= $%d", from)
+		}
+		if filter.To != nil && *filter.To != "" {
+			to, err := parseAuditTime(*filter.To, true)
+			if err != nil {
+				return nil, err
+			}
+			add("created_at <= $%d", to)
+		}
+		if filter.Limit != nil {
+			if *filter.Limit < 1 || *filter.Limit > 1000 {
+				return nil, models.NewFieldError("limit", "limit must be between 1 and 1000")
+			}
+			limit = *filter.Limit
+		}
+	}
+
+	query := `
+		SELECT id, actor_id, actor_name, via_api_token, operation, entity, entity_id,
+		       changes, success, error, ip_address, created_at
+		FROM audit_log`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying audit log: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []*models.AuditEntry{}
+	for rows.Next() {
+		var e models.AuditEntry
+		var id int64
+		var actorId sql.NullInt64
+		var actorName, entity, entityId, errMsg, ip sql.NullString
+		var changes []byte
+		if err := rows.Scan(&id, &actorId, &actorName, &e.ViaAPIToken, &e.Operation, &entity, &entityId,
+			&changes, &e.Success, &errMsg, &ip, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("error scanning audit entry: %w", err)
+		}
+		e.ID = strconv.FormatInt(id, 10)
+		if actorId.Valid {
+			s := strconv.FormatInt(actorId.Int64, 10)
+			e.ActorID = &s
+		}
+		e.ActorName = nullStringPtr(actorName)
+		e.Entity = nullStringPtr(entity)
+		e.EntityID = nullStringPtr(entityId)
+		e.Error = nullStringPtr(errMsg)
+		e.IPAddress = nullStringPtr(ip)
+		if e.Changes, err = decodeAuditChanges(changes); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+// Helpers.
+
+// auditChange is the stored form of models.AuditChange.
+type auditChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before,omitempty"` // omitted: record did not exist
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// diffSnapshots lists the fields that differ between two snapshots, sorted by
+// name. A nil side (record created or deleted) reports every field.
+func diffSnapshots(before, after map[string]any) []auditChange {
+	fields := map[string]bool{}
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	changes := []auditChange{}
+	for _, k := range names {
+		b := auditValue(before, k)
+		a := auditValue(after, k)
+		if !bytes.Equal(b, a) {
+			changes = append(changes, auditChange{Field: k, Before: b, After: a})
+		}
+	}
+	return changes
+}
+
+// redactAuditChanges replaces the values of entity's personal data fields,
+// keeping whether each side existed.
+func redactAuditChanges(entity string, changes []auditChange) []auditChange {
+	for _, field := range auditRedactedFields[entity] {
+		for i := range changes {
+			if changes[i].Field != field {
+				continue
+			}
+			if changes[i].Before != nil {
+				changes[i].Before = auditRedacted
+			}
+			if changes[i].After != nil {
+				changes[i].After = auditRedacted
+			}
+		}
+	}
+	return changes
+}
+
+// auditValue JSON-encodes one snapshot field; nil for a missing snapshot.
+func auditValue(snap map[string]any, key string) json.RawMessage {
+	if snap == nil {
+		return nil
+	}
+	b, _ := json.Marshal(snap[key]) // values came from JSON, so this cannot fail
+	return b
+}
+
+func decodeAuditChanges(raw []byte) ([]*models.AuditChange, error) {
+	var stored []auditChange
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return nil, fmt.Errorf("error decoding audit changes: %w", err)
+	}
+	changes := make([]*models.AuditChange, len(stored))
+	for i, c := range stored {
+		changes[i] = &models.AuditChange{Field: c.Field, Before: rawJSONPtr(c.Before), After: rawJSONPtr(c.After)}
+	}
+	return changes, nil
+}
+
+func rawJSONPtr(raw json.RawMessage) *string {
+	if len(raw) == 0 {
+		return nil
+	}
+	s := string(raw)
+	return &s
+}
+
+func nullStringPtr(ns sql.NullString) *string {
+	if !ns.Valid {
+		return nil
+	}
+	return &ns.String
+}
+
+// parseAuditTime accepts an RFC 3339 timestamp or a date. A date used as the
+// end of a range covers that whole day.
+func parseAuditTime(s string, endOfRange bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02 15:04:05", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, models.NewError(models.ErrorCodeValidation, "invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	if endOfRange {
+		t = t.Add(24*time.Hour - time.Nanosecond)
+	}
+	return t, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	before := map[string]any{"name": "Old", "city": "Portland", "roles": []any{"VOLUNTEER"}}
+	after := map[string]any{"name": "New", "city": "Portland", "roles": []any{"ADMINISTRATOR", "VOLUNTEER"}, "zip": "97204"}
+
+	got := diffSnapshots(before, after)
+	var fields []string
+	for _, c := range got {
+		fields = append(fields, c.Field)
+	}
+	if want := []string{"name", "roles", "zip"}; !reflect.DeepEqual(fields, want) {
+		t.Fatalf("changed fields = %v, want %v", fields, want)
+	}
+	if string(got[1].Before) != `["VOLUNTEER"]` || string(got[1].After) != `["ADMINISTRATOR","VOLUNTEER"]` {
+		t.Errorf("roles change = %s -> %s", got[1].Before, got[1].After)
+	}
+}
+
+func TestDiffSnapshots_CreateAndDelete(t *testing.T) {
+	rec := map[string]any{"name": "Venue", "city": "Portland"}
+
+	for _, c := range diffSnapshots(nil, rec) {
+		if c.Before != nil || c.After == nil {
+			t.Errorf("create: field %s = %s -> %s", c.Field, c.Before, c.After)
+		}
+	}
+	for _, c := range diffSnapshots(rec, nil) {
+		if c.Before == nil || c.After != nil {
+			t.Errorf("delete: field %s = %s -> %s", c.Field, c.Before, c.After)
+		}
+	}
+	if got := diffSnapshots(rec, rec); len(got) != 0 {
+		t.Errorf("unchanged record: got %d changes", len(got))
+	}
+}
+
+func TestRedactAuditChanges(t *testing.T) {
+	before := map[string]any{"email": "old@example.com", "phone": nil, "is_active": true}
+	after := map[string]any{"email": "new@example.com", "phone": "555-0100", "is_active": false}
+
+	got := redactAuditChanges("Volunteer", diffSnapshots(before, after))
+	if len(got) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", got)
+	}
+	for _, c := range got {
+		switch c.Field {
+		case "email", "phone":
+			if string(c.Before) != `"[redacted]"` || string(c.After) != `"[redacted]"` {
+				t.Errorf("%s: expected redacted values, got %s -> %s", c.Field, c.Before, c.After)
+			}
+		case "is_active":
+			if string(c.Before) != "true" || string(c.After) != "false" {
+				t.Errorf("is_active must not be redacted, got %s -> %s", c.Before, c.After)
+			}
+		}
+	}
+
+	for _, c := range redactAuditChanges("Volunteer", diffSnapshots(nil, after)) {
+		if c.Before != nil {
+			t.Errorf("create: field %s gained a before value %s", c.Field, c.Before)
+		}
+	}
+}
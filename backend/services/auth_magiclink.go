@@ -3,14 +3,14 @@ package services
 import (
 	"context"
 	"crypto/rand"
-	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
-	"strconv"
 	"time"
+	"volunteer-scheduler/models"
 
 	"github.com/lib/pq"
 )
@@ -29,9 +29,42 @@ func NewMagicLinkService(DB *sql.DB, mailer *Mailer) *MagicLinkService {
 	}
 }
 
-// GenerateMagicLink creates a new magic link token and stores it in the database
-// Returns the token string if successful
-func (s *MagicLinkService) GenerateMagicLink(ctx context.Context, email, ipAddress, userAgent string) (string, error) {
+// ErrMagicLinkOtherBrowser is returned by ConsumeMagicLink when browser
+// binding is on and the link was opened somewhere other than the browser that
+// requested it. The link is left unused so the caller can ask the volunteer to
+// confirm and retry with confirmDevice set.
+var ErrMagicLinkOtherBrowser error = models.NewError(models.ErrorCodeUnauthenticated, "this sign-in link was requested from a different browser")
+
+// MagicLinkBrowserBinding reports whether links must be opened in the browser
+// that requested them (MAGIC_LINK_BROWSER_BINDING=true) or explicitly
+// confirmed on another device.
+func MagicLinkBrowserBinding() bool {
+	return os.Getenv("MAGIC_LINK_BROWSER_BINDING") == "true"
+}
+
+// hashMagicLinkToken returns the representation stored in magic_links.token
+// and magic_links.browser_nonce_hash: the same SHA-256 hex digest used for
+// session tokens. The raw token only ever exists in the email.
+func hashMagicLinkToken(token string) string {
+	return hashSessionToken(token)
+}
+
+// NewBrowserNonce returns a random value for the cookie that ties a magic
+// link to the browser that requested it.
+func NewBrowserNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate browser nonce: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// GenerateMagicLink creates a new magic link token and stores its hash in the
+// database alongside a 6-digit sign-in code that can be used instead of the
+// link. browserNonce, if set, is the value of the requesting browser's nonce
+// cookie.
+// Returns the token and code if successful
+func (s *MagicLinkService) GenerateMagicLink(ctx context.Context, email, ipAddress, userAgent, browserNonce string) (string, string, error) {
 	// Rate limiting: check if user has requested too many links recently (5 per hour)
 	rateLimitQuery := `
 		SELECT COUNT(*) FROM magic_links
@@ -43,56 +76,58 @@ func (s *MagicLinkService) GenerateMagicLink(ctx context.Context, email, ipAddre
 		log.Printf("Error checking rate limit: %v", err)
 	}
 	if count >= 5 {
-		return "", fmt.Errorf("too many magic link requests; please try again later")
+		return "", "", models.NewError(models.ErrorCodeRateLimited, "too many magic link requests; please try again later")
 	}
 
 	// Make sure this email is in the DB and the user is active.
 	row := s.DB.QueryRowContext(ctx, "SELECT is_active FROM volunteers WHERE email = $1", email)
 	if row == nil {
-		return "", fmt.Errorf("no volunteer account found for this email")
+		return "", "", models.NewError(models.ErrorCodeNotFound, "no volunteer account found for this email")
 	}
 	var isActive bool
 	err = row.Scan(&isActive)
 	if err != nil {
-		return "", fmt.Errorf("no volunteer account found for this email")
+		return "", "", models.NewError(models.ErrorCodeNotFound, "no volunteer account found for this email")
 	}
 	if !isActive {
-		return "", fmt.Errorf("volunteer account found for this email is inactive")
+		return "", "", models.NewError(models.ErrorCodeForbidden, "volunteer account found for this email is inactive")
 	}
 
 	// Generate a random token (32 bytes = 64 hex chars)
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
-		return "", fmt.Errorf("failed to generate token: %w", err)
+		return "", "", fmt.Errorf("failed to generate token: %w", err)
 	}
 	token := hex.EncodeToString(tokenBytes)
 
+	code, err := generateSignInCode()
+	if err != nil {
+		return "", "", err
+	}
+
 	// Calculate expiration time (15 minutes by default)
 	expiresAt := time.Now().Add(15 * time.Minute)
 
 	// Insert token into database
 	insertQuery := `
-		INSERT INTO magic_links (email, token, created_at, expires_at, ip_address, user_agent)
-		VALUES ($1, $2, NOW(), $3, $4, $5)
+		INSERT INTO magic_links (email, token, code_hash, created_at, expires_at, ip_address, user_agent, browser_nonce_hash)
+		VALUES ($1, $2, $3, NOW(), $4, NULLIF($5, ''), NULLIF($6, ''), $7)
 	`
-	if _, err := s.DB.ExecContext(ctx, insertQuery, email, token, expiresAt, ipAddress, userAgent); err != nil {
-		return "", fmt.Errorf("failed to store magic link token: %w", err)
+	var nonceHash sql.NullString
+	if browserNonce != "" {
+		nonceHash = sql.NullString{String: hashMagicLinkToken(browserNonce), Valid: true}
+	}
+	if _, err := s.DB.ExecContext(ctx, insertQuery, email, hashMagicLinkToken(token), hashSignInCode(email, code),
+		expiresAt, ipAddress, userAgent, nonceHash); err != nil {
+		return "", "", fmt.Errorf("failed to store magic link token: %w", err)
 	}
 
-	return token, nil
+	return token, code, nil
 }
 
-// SendMagicLinkEmail sends the magic link email to the user
-func (s *MagicLinkService) SendMagicLinkEmail(ctx context.Context, to, token string) error {
-	appURL := os.Getenv("FRONTEND_BASE_URL")
-	if appURL == "" {
-		appURL = "http://localhost:3000"
-	}
-
-	// Remove trailing slash if present
-	if appURL[len(appURL)-1] == '/' {
-		appURL = appURL[:len(appURL)-1]
-	}
+// SendMagicLinkEmail sends the magic link and sign-in code email to the user
+func (s *MagicLinkService) SendMagicLinkEmail(ctx context.Context, to, token, code string) error {
+	appURL := frontendBaseURL()
 
 	callbackPath := os.Getenv("MAGIC_LINK_CALLBACK_PATH")
 	if callbackPath == "" {
@@ -121,15 +156,17 @@ func (s *MagicLinkService) SendMagicLinkEmail(ctx context.Context, to, token str
             <a href="%s" style="display: inline-block; padding: 12px 24px; background-color: #0066cc; color: #ffffff; text-decoration: none; border-radius: 5px; margin: 20px 0; font-weight: bold;">Sign In</a>
             <p>Or copy and paste this link in your browser:</p>
             <p style="word-break: break-all;"><code>%s</code></p>
+            <p>Signing in on a different device or browser? Enter this code on the sign-in page instead:</p>
+            <p style="font-size: 28px; font-weight: bold; letter-spacing: 6px; text-align: center; margin: 20px 0;">%s</p>
             <div style="background-color: #fff3cd; padding: 10px; border-left: 4px solid #ffc107; margin: 20px 0;">
-                <strong>Security Note:</strong> This link will expire in 15 minutes. If you did not request this link, please ignore this email.
+                <strong>Security Note:</strong> This link and code will expire in 15 minutes. If you did not request this link, please ignore this email.
             </div>
             <p>Thank you,<br>The Volunteer Scheduler Team</p>
         </div>
     </div>
 </body>
 </html>
-    `, callbackURL, callbackURL)
+    `, callbackURL, callbackURL, code)
 
 	// Text email body
 	textBody := fmt.Sprintf(`
@@ -139,13 +176,17 @@ We received a request to sign you in to Volunteer Scheduler. Click the link belo
 
 %s
 
-This link will expire in 15 minutes.
+Signing in on a different device or browser? Enter this code on the sign-in page instead:
+
+    %s
+
+This link and code will expire in 15 minutes.
 
 If you did not request this link, please ignore this email.
 
 Thank you,
 The Volunteer Scheduler Team
-    `, callbackURL)
+    `, callbackURL, code)
 
 	if err := s.mailer.SendEmail(ctx, to, subject, htmlBody, textBody); err != nil {
 		return fmt.Errorf("failed to send magic link email: %w", err)
@@ -155,37 +196,53 @@ The Volunteer Scheduler Team
 	return nil
 }
 
-// ConsumeMagicLink validates and consumes a magic link token
+// ConsumeMagicLink validates and consumes a magic link token, recording the
+// IP address and user agent that used it.
+// When browser binding is on, a link opened without the requesting browser's
+// nonce fails with ErrMagicLinkOtherBrowser unless confirmDevice is set.
 // Returns the email if valid; returns error if invalid or expired
-func (s *MagicLinkService) ConsumeMagicLink(ctx context.Context, token string) (string, error) {
+func (s *MagicLinkService) ConsumeMagicLink(ctx context.Context, token, browserNonce string, confirmDevice bool, ipAddress, userAgent string) (string, error) {
 	if token == "" {
-		return "", fmt.Errorf("token is required")
+		return "", models.NewFieldError("token", "token is required")
 	}
+	hashedToken := hashMagicLinkToken(token)
 
 	// Query for the token (must not be used, and must not be expired)
 	query := `
-		SELECT email FROM magic_links
+		SELECT email, browser_nonce_hash FROM magic_links
 		WHERE token = $1 AND used_at IS NULL AND expires_at > NOW()
 		LIMIT 1
 	`
 
 	var email string
-	if err := s.DB.QueryRowContext(ctx, query, token).Scan(&email); err != nil {
+	var nonceHash sql.NullString
+	if err := s.DB.QueryRowContext(ctx, query, hashedToken).Scan(&email, &nonceHash); err != nil {
 		if err == sql.ErrNoRows {
-			return "", fmt.Errorf("invalid or expired magic link")
+			return "", models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired magic link")
 		}
 		return "", fmt.Errorf("error retrieving magic link: %w", err)
 	}
 
-	// Mark the token as used
+	if MagicLinkBrowserBinding() && nonceHash.Valid && !confirmDevice {
+		got := hashMagicLinkToken(browserNonce)
+		if browserNonce == "" || subtle.ConstantTimeCompare([]byte(got), []byte(nonceHash.String)) != 1 {
+			return "", ErrMagicLinkOtherBrowser
+		}
+	}
+
+	// Mark the token as used. The used_at check makes this the single point
+	// of consumption if the link is opened twice at once.
 	updateQuery := `
 		UPDATE magic_links
-		SET used_at = NOW()
-		WHERE token = $1
+		SET used_at = NOW(), consumed_ip = NULLIF($2, ''), consumed_user_agent = NULLIF($3, '')
+		WHERE token = $1 AND used_at IS NULL
 	`
-	if _, err := s.DB.ExecContext(ctx, updateQuery, token); err != nil {
-		log.Printf("Warning: failed to mark token as used: %v", err)
-		// Continue anyway - the token was valid, we just couldn't mark it
+	res, err := s.DB.ExecContext(ctx, updateQuery, hashedToken, ipAddress, userAgent)
+	if err != nil {
+		return "", fmt.Errorf("error consuming magic link: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return "", models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired magic link")
 	}
 
 	return email, nil
@@ -208,6 +265,16 @@ func (s *MagicLinkService) CleanupExpiredTokens(ctx context.Context) error {
 		log.Printf("Cleaned up %d expired magic link tokens", rowsAffected)
 	}
 
+	// Volunteers can now hold many sessions, so expired ones pile up unless
+	// we remove them too.
+	result, err = s.DB.ExecContext(ctx, "DELETE FROM sessions WHERE expires_at < NOW()")
+	if err != nil {
+		return fmt.Errorf("error deleting expired sessions: %w", err)
+	}
+	if rowsAffected, _ = result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("Cleaned up %d expired sessions", rowsAffected)
+	}
+
 	return nil
 }
 
@@ -224,14 +291,22 @@ type SessionClaims struct {
 // CreateSessionToken creates a session token for the authenticated user.
 // Roles are no longer cached in the sessions row; they are looked up at
 // validation time via the volunteer_roles junction table.
+// Each login adds a new session, so a volunteer can be signed in on several
+// devices at once; ipAddress and userAgent identify the device in the
+// volunteer's session list. remember asks for the longer "remember this
+// device" lifetime (see sessionPolicyFor).
 // Returns the token and the exact expiry time so the caller can set a matching cookie.
-func (s *MagicLinkService) CreateSessionToken(ctx context.Context, email string) (string, time.Time, error) {
+func (s *MagicLinkService) CreateSessionToken(ctx context.Context, email, ipAddress, userAgent string, remember bool) (string, time.Time, error) {
 
-	// Look up volunteer ID — only to confirm the account exists.
+	// Look up volunteer ID and roles — roles only to choose the session policy.
 	volunteerId, err := fetchVolunteerIdByEmail(ctx, s.DB, email)
 	if err != nil {
 		return "", time.Time{}, err
 	}
+	roles, err := fetchVolunteerRoleNames(ctx, s.DB, volunteerId)
+	if err != nil {
+		return "", time.Time{}, err
+	}
 
 	// Generate session token.
 	tokenBytes := make([]byte, 32)
@@ -242,63 +317,143 @@ func (s *MagicLinkService) CreateSessionToken(ctx context.Context, email string)
 
 	// Hash the hex string — the same representation that arrives in the cookie,
 	// so ValidateSessionToken and Logout can re-derive the same hash.
-	hashToken := sha256.Sum256([]byte(sessionToken))
-	hexHashToken := hex.EncodeToString(hashToken[:])
+	hexHashToken := hashSessionToken(sessionToken)
 
-	// Get session max age from environment (default 8 hours).
-	sessionMaxAgeStr := os.Getenv("SESSION_MAX_AGE")
-	sessionMaxAge := 28800 // 8 hours in seconds
-	if val, err := strconv.Atoi(sessionMaxAgeStr); err == nil {
-		sessionMaxAge = val
-	}
-	expiresAt := time.Now().UTC().Add(time.Duration(sessionMaxAge) * time.Second)
+	policy := sessionPolicyFor(roles, remember)
+	now := time.Now().UTC()
+	expiresAt := policy.renewedExpiry(now, now)
 
 	insertQuery := `
-        INSERT INTO sessions (email, token, created_at, expires_at, volunteer_id)
-        VALUES ($1, $2, NOW(), $3, $4)
-        ON CONFLICT (email) DO UPDATE SET
-            token        = EXCLUDED.token,
-            created_at   = NOW(),
-            expires_at   = EXCLUDED.expires_at,
-            volunteer_id = EXCLUDED.volunteer_id
+        INSERT INTO sessions (email, token, created_at, last_activity_at, expires_at, volunteer_id, ip_address, user_agent, remember)
+        VALUES ($1, $2, NOW(), NOW(), $3, $4, NULLIF($5, ''), NULLIF($6, ''), $7)
     `
-	if _, err := s.DB.ExecContext(ctx, insertQuery, email, hexHashToken, expiresAt, volunteerId); err != nil {
+	if _, err := s.DB.ExecContext(ctx, insertQuery, email, hexHashToken, expiresAt, volunteerId, ipAddress, userAgent, remember); err != nil {
 		return "", time.Time{}, fmt.Errorf("failed to store session: %w", err)
 	}
 
 	return sessionToken, expiresAt, nil
 }
 
+// ValidatedSession is what ValidateSessionToken learns about a valid token.
+type ValidatedSession struct {
+	VolunteerId int
+	Roles       []string
+	// RenewedUntil is set when sliding renewal extended the session, so the
+	// caller can refresh the cookie to the new expiry.
+	RenewedUntil *time.Time
+}
+
 // ValidateSessionToken validates the session token and returns the volunteer ID
 // and roles for the session owner, joining volunteer_roles at validation time.
-func (s *MagicLinkService) ValidateSessionToken(ctx context.Context, token string) (int, []string, error) {
+// A session idle for longer than its policy's IdleTimeout is deleted and
+// rejected; an active one past half its lifetime is renewed, up to its
+// policy's absolute deadline.
+func (s *MagicLinkService) ValidateSessionToken(ctx context.Context, token string) (*ValidatedSession, error) {
+	hexHashToken := hashSessionToken(token)
+	row, policy, err := s.liveSession(ctx, hexHashToken)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &ValidatedSession{VolunteerId: row.volunteerId, Roles: row.roles}
 
-	hashToken := sha256.Sum256([]byte(token))
-	hexHashToken := hex.EncodeToString(hashToken[:])
+	// TIMESTAMP columns come back without a zone; they are stored as UTC.
+	now := time.Now().UTC()
+	expiresAt := asUTC(row.expiresAt)
+	createdAt := asUTC(row.createdAt)
 
+	if policy.shouldRenew(createdAt, expiresAt, now) {
+		renewed := policy.renewedExpiry(createdAt, now)
+		if _, err := s.DB.ExecContext(ctx,
+			"UPDATE sessions SET last_activity_at = NOW(), expires_at = $2 WHERE token = $1",
+			hexHashToken, renewed); err == nil {
+			session.RenewedUntil = &renewed
+		}
+		return session, nil
+	}
+
+	// Update last activity.
+	s.DB.ExecContext(ctx, "UPDATE sessions SET last_activity_at = NOW() WHERE token = $1", hexHashToken)
+
+	return session, nil
+}
+
+// CheckSessionToken reports whether token is still a live session, like
+// ValidateSessionToken, without counting as activity: the session is neither
+// touched nor renewed. Open websockets use it, so a tab left open but unused
+// still reaches the idle timeout.
+func (s *MagicLinkService) CheckSessionToken(ctx context.Context, token string) (*ValidatedSession, error) {
+	row, _, err := s.liveSession(ctx, hashSessionToken(token))
+	if err != nil {
+		return nil, err
+	}
+	return &ValidatedSession{VolunteerId: row.volunteerId, Roles: row.roles}, nil
+}
+
+// storedSession is a sessions row with its holder's current roles.
+type storedSession struct {
+	volunteerId int
+	roles       []string
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+// liveSession looks up an unexpired session by token hash and applies the
+// idle timeout of its policy, deleting the session if it has been idle too
+// long.
+func (s *MagicLinkService) liveSession(ctx context.Context, hexHashToken string) (*storedSession, SessionPolicy, error) {
 	query := `
         SELECT s.volunteer_id,
-               COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles
+               COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles,
+               s.remember,
+               s.created_at,
+               s.expires_at,
+               EXTRACT(EPOCH FROM NOW() - COALESCE(s.last_activity_at, s.created_at))::BIGINT AS idle_seconds
         FROM   sessions s
         LEFT   JOIN volunteer_roles vr ON vr.volunteer_id = s.volunteer_id
         LEFT   JOIN roles r            ON r.role_id = vr.role_id
         WHERE  s.token = $1 AND s.expires_at > NOW()
-        GROUP  BY s.volunteer_id
+        GROUP  BY s.id
         LIMIT  1
     `
-	var volunteerId int
+	var row storedSession
 	var roles pq.StringArray
-	if err := s.DB.QueryRowContext(ctx, query, hexHashToken).Scan(&volunteerId, &roles); err != nil {
+	var remember bool
+	var idleSeconds int64
+	if err := s.DB.QueryRowContext(ctx, query, hexHashToken).Scan(&row.volunteerId, &roles, &remember, &row.createdAt, &row.expiresAt, &idleSeconds); err != nil {
 		if err == sql.ErrNoRows {
-			return 0, nil, fmt.Errorf("invalid or expired session token")
+			return nil, SessionPolicy{}, models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired session token")
 		}
-		return 0, nil, fmt.Errorf("error validating session: %w", err)
+		return nil, SessionPolicy{}, fmt.Errorf("error validating session: %w", err)
 	}
+	row.roles = []string(roles)
 
-	// Update last activity.
-	s.DB.ExecContext(ctx, "UPDATE sessions SET last_activity_at = NOW() WHERE token = $1", hexHashToken)
+	policy := sessionPolicyFor(roles, remember)
+	if time.Duration(idleSeconds)*time.Second > policy.IdleTimeout {
+		s.DB.ExecContext(ctx, "DELETE FROM sessions WHERE token = $1", hexHashToken)
+		return nil, SessionPolicy{}, models.NewError(models.ErrorCodeUnauthenticated, "session expired after inactivity")
+	}
+	return &row, policy, nil
+}
 
-	return volunteerId, []string(roles), nil
+// asUTC reads the wall clock of a zoneless TIMESTAMP value as UTC.
+func asUTC(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// fetchVolunteerRoleNames returns the role names held by a volunteer.
+func fetchVolunteerRoleNames(ctx context.Context, DB *sql.DB, volunteerId int) ([]string, error) {
+	var roles pq.StringArray
+	err := DB.QueryRowContext(ctx, `
+		SELECT COALESCE(array_agg(r.role_name), '{}')
+		FROM   volunteer_roles vr
+		JOIN   roles r ON r.role_id = vr.role_id
+		WHERE  vr.volunteer_id = $1
+	`, volunteerId).Scan(&roles)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up volunteer roles: %w", err)
+	}
+	return []string(roles), nil
 }
 
 // fetchVolunteerIdByEmail looks up the volunteer ID for an email address.
@@ -307,7 +462,7 @@ func fetchVolunteerIdByEmail(ctx context.Context, DB *sql.DB, email string) (int
 	err := DB.QueryRowContext(ctx,
 		"SELECT volunteer_id FROM volunteers WHERE email = $1", email).Scan(&volunteerId)
 	if err == sql.ErrNoRows {
-		return 0, fmt.Errorf("no volunteer account found for this email")
+		return 0, models.NewError(models.ErrorCodeNotFound, "no volunteer account found for this email")
 	}
 	if err != nil {
 		return 0, fmt.Errorf("error looking up volunteer: %w", err)
@@ -315,63 +470,9 @@ func fetchVolunteerIdByEmail(ctx context.Context, DB *sql.DB, email string) (int
 	return volunteerId, nil
 }
 
-// RequestAccount sends an account request email to all admins.
-// No DB record is created — the admin reviews the request and
-// creates or reactivates the volunteer manually if approved.
-// If the email belongs to an existing inactive volunteer, the admin
-// receives a reactivation request with the existing account details instead.
-func (s *MagicLinkService) RequestAccount(ctx context.Context, email, firstName, lastName string) error {
-
-	// Fetch all admin email addresses.
-	rows, err := s.DB.QueryContext(ctx, `
-		SELECT v.email
-		FROM   volunteers v
-		JOIN   volunteer_roles vr ON vr.volunteer_id = v.volunteer_id
-		JOIN   roles r            ON r.role_id = vr.role_id
-		WHERE  r.role_name = 'ADMINISTRATOR' AND v.is_active = true
-	`)
-	if err != nil {
-		return fmt.Errorf("error fetching admin emails: %w", err)
-	}
-	defer rows.Close()
-
-	var adminEmails []string
-	for rows.Next() {
-		var adminEmail string
-		if err := rows.Scan(&adminEmail); err != nil {
-			return fmt.Errorf("error scanning admin email: %w", err)
-		}
-		adminEmails = append(adminEmails, adminEmail)
-	}
-
-	if len(adminEmails) == 0 {
-		// No admins found — log it but still return success so we
-		// don't reveal anything about the system to the requester.
-		log.Printf("Warning: account request from %s %s <%s> but no admins found to notify", firstName, lastName, email)
-		return nil
-	}
-
-	// Check whether this email belongs to an existing inactive volunteer.
-	var existingID int
-	var existingFirst, existingLast string
-	err = s.DB.QueryRowContext(ctx,
-		"SELECT volunteer_id, first_name, last_name FROM volunteers WHERE email = $1 AND is_active = false",
-		email).Scan(&existingID, &existingFirst, &existingLast)
-
-	if err == nil {
-		// Inactive account found — send a reactivation request.
-		existingName := existingFirst + " " + existingLast
-		return sendActivateAccountRequest(ctx, s.mailer, adminEmails, firstName, lastName, email, existingName, existingID)
-	}
-
-	// No inactive account — send a standard new account request.
-	return sendNewAccountRequest(ctx, s.mailer, adminEmails, firstName, lastName, email)
-}
-
 func (s *MagicLinkService) Logout(ctx context.Context, token string) error {
 
-	hashToken := sha256.Sum256([]byte(token))
-	hexHashToken := hex.EncodeToString(hashToken[:])
+	hexHashToken := hashSessionToken(token)
 
 	_, err := s.DB.ExecContext(ctx, "DELETE FROM sessions where token = $1", hexHashToken)
 	return err
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"volunteer-scheduler/models"
+)
+
+// signInCodeMaxAttempts is how many wrong guesses a code tolerates before it
+// (and its magic link) is locked. Combined with GenerateMagicLink's limit of
+// 5 outstanding links per hour, that caps guessing at 25 per email per hour.
+const signInCodeMaxAttempts = 5
+
+var signInCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// generateSignInCode returns a uniformly random 6-digit code.
+func generateSignInCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate sign-in code: %w", err)
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// processSignInCodeKey is the key used when SIGN_IN_CODE_SECRET is unset:
+// random per process, so outstanding codes stop working on restart and are
+// not shared between replicas. Production refuses to start without the
+// secret (see cmd/server).
+var processSignInCodeKey = sync.OnceValue(func() []byte {
+	log.Printf("[auth] SIGN_IN_CODE_SECRET not set; sign-in codes are keyed per process")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("failed to generate sign-in code key: %v", err))
+	}
+	return key
+})
+
+func signInCodeKey() []byte {
+	if secret := os.Getenv("SIGN_IN_CODE_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return processSignInCodeKey()
+}
+
+// hashSignInCode returns the representation stored in magic_links.code_hash:
+// an HMAC keyed with a server secret, since a million possible codes are
+// otherwise trivially brute forced from a leaked table. The email is mixed in,
+// normalized, so equal codes for different people hash differently and
+// GenerateMagicLink and ConsumeSignInCode agree however the address was typed.
+func hashSignInCode(email, code string) string {
+	mac := hmac.New(sha256.New, signInCodeKey())
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(email)) + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ConsumeSignInCode validates and consumes the 6-digit code from the most
+// recent magic link sent to email. It is the code-based twin of
+// ConsumeMagicLink: a correct code marks the link used, so the code and link
+// are single use together, and the consuming IP and user agent are recorded
+// the same way. Each wrong guess is counted, and after
+// signInCodeMaxAttempts the code is expired early; the volunteer must request
+// a new one, which GenerateMagicLink rate limits. email is matched
+// case-insensitively.
+// Returns the email the link was sent to if valid.
+func (s *MagicLinkService) ConsumeSignInCode(ctx context.Context, email, code, ipAddress, userAgent string) (string, error) {
+	email = strings.TrimSpace(email)
+	code = strings.TrimSpace(code)
+	if email == "" || !signInCodePattern.MatchString(code) {
+		return "", models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired code")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the row so concurrent guesses are counted one at a time.
+	query := `
+		SELECT id, email, code_hash, code_attempts FROM magic_links
+		WHERE LOWER(email) = LOWER($1) AND code_hash IS NOT NULL AND used_at IS NULL AND expires_at > NOW()
+		ORDER BY created_at DESC
+		LIMIT 1
+		FOR UPDATE
+	`
+	var id, attempts int
+	var linkEmail, codeHash string
+	if err := tx.QueryRowContext(ctx, query, email).Scan(&id, &linkEmail, &codeHash, &attempts); err != nil {
+		if err == sql.ErrNoRows {
+			return "", models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired code")
+		}
+		return "", fmt.Errorf("error retrieving sign-in code: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSignInCode(email, code)), []byte(codeHash)) != 1 {
+		attempts++
+		if attempts >= signInCodeMaxAttempts {
+			// Expire rather than mark used: the row keeps counting toward the
+			// rate limit in GenerateMagicLink.
+			_, err = tx.ExecContext(ctx,
+				"UPDATE magic_links SET code_attempts = $2, expires_at = NOW() WHERE id = $1", id, attempts)
+		} else {
+			_, err = tx.ExecContext(ctx,
+				"UPDATE magic_links SET code_attempts = $2 WHERE id = $1", id, attempts)
+		}
+		if err != nil {
+			return "", fmt.Errorf("error recording sign-in attempt: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return "", fmt.Errorf("error recording sign-in attempt: %w", err)
+		}
+		if attempts >= signInCodeMaxAttempts {
+			return "", models.NewError(models.ErrorCodeRateLimited, "too many incorrect codes; please request a new sign-in email")
+		}
+		return "", models.NewError(models.ErrorCodeUnauthenticated, "invalid or expired code")
+	}
+
+	consumeQuery := `
+		UPDATE magic_links
+		SET used_at = NOW(), consumed_ip = NULLIF($2, ''), consumed_user_agent = NULLIF($3, '')
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, consumeQuery, id, ipAddress, userAgent); err != nil {
+		return "", fmt.Errorf("error consuming sign-in code: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("error consuming sign-in code: %w", err)
+	}
+
+	// The address the link was sent to, as the volunteer's account has it.
+	return linkEmail, nil
+}
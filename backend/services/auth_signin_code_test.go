@@ -0,0 +1,45 @@
+package services
+
+import "testing"
+
+func TestGenerateSignInCode(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		code, err := generateSignInCode()
+		if err != nil {
+			t.Fatalf("generateSignInCode: %v", err)
+		}
+		if !signInCodePattern.MatchString(code) {
+			t.Fatalf("code %q is not 6 digits", code)
+		}
+		seen[code] = true
+	}
+	if len(seen) < 45 {
+		t.Errorf("expected codes to vary, got %d distinct of 50", len(seen))
+	}
+}
+
+func TestHashSignInCode_BindsEmail(t *testing.T) {
+	a := hashSignInCode("a@example.com", "123456")
+	if a != hashSignInCode("a@example.com", "123456") {
+		t.Error("hash must be deterministic")
+	}
+	if a == hashSignInCode("b@example.com", "123456") {
+		t.Error("same code for different emails must hash differently")
+	}
+	if len(a) != 64 {
+		t.Errorf("expected 64 hex chars to fit code_hash, got %d", len(a))
+	}
+}
+
+func TestHashSignInCode_KeyedAndNormalized(t *testing.T) {
+	t.Setenv("SIGN_IN_CODE_SECRET", "one")
+	a := hashSignInCode("A@Example.com ", "123456")
+	if a != hashSignInCode("a@example.com", "123456") {
+		t.Error("email case and surrounding space must not change the hash")
+	}
+	t.Setenv("SIGN_IN_CODE_SECRET", "two")
+	if a == hashSignInCode("a@example.com", "123456") {
+		t.Error("hash must depend on the server secret")
+	}
+}
@@ -295,6 +295,99 @@ func sendActivateAccountRequest(ctx context.Context, mailer *Mailer, adminEmails
 	return nil
 }
 
+// sendAccountRequestVerify asks a requester to confirm their email address
+// before their account request is shown to admins.
+func sendAccountRequestVerify(ctx context.Context, mailer *Mailer, firstName, email, verifyURL string) error {
+	data := accountRequestVerifyData{
+		FirstName: firstName,
+		VerifyURL: verifyURL,
+	}
+
+	subject := "Confirm Your Volunteer Scheduler Account Request"
+	htmlBody, err := renderTemplate(accountRequestVerifyHTMLTmpl, data)
+	if err != nil {
+		return err
+	}
+	textBody, err := renderTemplate(accountRequestVerifyTextTmpl, data)
+	if err != nil {
+		return err
+	}
+
+	return mailer.SendEmail(ctx, email, subject, htmlBody, textBody)
+}
+
+// sendAccountRequestDenied tells a requester their request was denied, with
+// the admin's message if one was given.
+func sendAccountRequestDenied(ctx context.Context, mailer *Mailer, firstName, email, message string) error {
+	data := accountRequestDeniedData{
+		FirstName: firstName,
+		Message:   message,
+	}
+
+	subject := "Your Volunteer Scheduler Account Request"
+	htmlBody, err := renderTemplate(accountRequestDeniedHTMLTmpl, data)
+	if err != nil {
+		return err
+	}
+	textBody, err := renderTemplate(accountRequestDeniedTextTmpl, data)
+	if err != nil {
+		return err
+	}
+
+	return mailer.SendEmail(ctx, email, subject, htmlBody, textBody)
+}
+
+// sendErasureRequested tells the admins a volunteer has asked to be erased.
+// Failures are logged per recipient.
+func sendErasureRequested(ctx context.Context, mailer *Mailer, adminEmails []string, firstName, lastName string, volId int, reason string) {
+	data := erasureRequestedData{
+		FirstName:   firstName,
+		LastName:    lastName,
+		VolunteerID: volId,
+		Reason:      reason,
+	}
+
+	subject := fmt.Sprintf("Data Erasure Request — %s %s", firstName, lastName)
+	htmlBody, err := renderTemplate(erasureRequestedHTMLTmpl, data)
+	if err != nil {
+		log.Printf("Warning: failed to render erasure request notification: %v", err)
+		return
+	}
+	textBody, err := renderTemplate(erasureRequestedTextTmpl, data)
+	if err != nil {
+		log.Printf("Warning: failed to render erasure request notification: %v", err)
+		return
+	}
+
+	for _, adminEmail := range adminEmails {
+		if err := mailer.SendEmail(ctx, adminEmail, subject, htmlBody, textBody); err != nil {
+			log.Printf("Warning: failed to send erasure request notification to %s: %v", adminEmail, err)
+		}
+	}
+}
+
+// sendErasureDecision tells a volunteer their erasure request was carried
+// out, or denied with the admin's message if one was given.
+func sendErasureDecision(ctx context.Context, mailer *Mailer, firstName, email string, approved bool, message string) error {
+	data := erasureDecisionData{
+		FirstName: firstName,
+		Approved:  approved,
+		Message:   message,
+	}
+
+	subject := "Your Data Erasure Request"
+	htmlBody, err := renderTemplate(erasureDecisionHTMLTmpl, data)
+	if err != nil {
+		return err
+	}
+	textBody, err := renderTemplate(erasureDecisionTextTmpl, data)
+	if err != nil {
+		return err
+	}
+
+	return mailer.SendEmail(ctx, email, subject, htmlBody, textBody)
+}
+
 // sendEventCancelledToVolunteer and sendEventCancelledToStaff are called from
 // DeleteEvent in event_services.go, which has already fetched and formatted
 // the shift times, so we accept pre-formatted strings here.
@@ -338,3 +431,25 @@ func sendEventCancelledToStaff(ctx context.Context, mailer *Mailer, firstName, e
 
 	return mailer.SendEmail(ctx, email, subject, htmlBody, textBody)
 }
+
+// sendImpersonationNotice tells a volunteer that an admin is viewing the app
+// as them.
+func sendImpersonationNotice(ctx context.Context, mailer *Mailer, firstName, email, adminName, reason string) error {
+	data := impersonationNoticeData{
+		FirstName: firstName,
+		AdminName: adminName,
+		Reason:    reason,
+	}
+
+	subject := "An Administrator Is Viewing Your Volunteer Account"
+	htmlBody, err := renderTemplate(impersonationNoticeHTMLTmpl, data)
+	if err != nil {
+		return err
+	}
+	textBody, err := renderTemplate(impersonationNoticeTextTmpl, data)
+	if err != nil {
+		return err
+	}
+
+	return mailer.SendEmail(ctx, email, subject, htmlBody, textBody)
+}
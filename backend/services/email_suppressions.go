@@ -0,0 +1,91 @@
+package services
+
+// email_suppressions.go
+//
+// Addresses the email provider has reported as hard-bouncing or as having
+// marked our mail as spam. Mailer.SendEmail refuses to send to them, which
+// protects our sender reputation; admins see the flag on the Volunteer type
+// and can fix the address or clear the suppression.
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"volunteer-scheduler/models"
+)
+
+// ErrEmailSuppressed is returned by Mailer.SendEmail when the recipient is on
+// the suppression list.
+var ErrEmailSuppressed = errors.New("email address is suppressed")
+
+type SuppressionList struct {
+	DB *sql.DB
+}
+
+func NewSuppressionList(db *sql.DB) *SuppressionList {
+	return &SuppressionList{DB: db}
+}
+
+// Add records (or updates) a suppression for the address. A complaint is never
+// downgraded to a bounce, since complaints are the more serious signal.
+func (l *SuppressionList) Add(ctx context.Context, email string, reason models.EmailSuppressionReason, detail string) error {
+	_, err := l.DB.ExecContext(ctx, `
+		INSERT INTO email_suppressions (email, reason, detail)
+		VALUES ($1, $2, NULLIF($3, ''))
+		ON CONFLICT (LOWER(email)) DO UPDATE
+		SET reason     = CASE WHEN email_suppressions.reason = 'COMPLAINT' THEN email_suppressions.reason ELSE EXCLUDED.reason END,
+			detail     = EXCLUDED.detail,
+			created_at = NOW()
+	`, email, string(reason), detail)
+	if err != nil {
+		return fmt.Errorf("error recording email suppression: %w", err)
+	}
+	return nil
+}
+
+// Reason returns the suppression reason for the address, or nil if the
+// address may be mailed.
+func (l *SuppressionList) Reason(ctx context.Context, email string) (*models.EmailSuppressionReason, error) {
+	var reason string
+	err := l.DB.QueryRowContext(ctx,
+		"SELECT reason FROM email_suppressions WHERE LOWER(email) = LOWER($1)", email,
+	).Scan(&reason)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking email suppression: %w", err)
+	}
+	r := models.EmailSuppressionReason(reason)
+	return &r, nil
+}
+
+// ClearEmailSuppression lets an admin mail a volunteer's address again, e.g.
+// after the volunteer confirms their mailbox is fixed.
+func (s *VolunteerService) ClearEmailSuppression(ctx context.Context, volId string) (*models.MutationResult, error) {
+	volInt, err := strconv.Atoi(volId)
+	if err != nil {
+		return nil, models.InvalidID("volunteerId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, `
+		DELETE FROM email_suppressions es
+		USING volunteers v
+		WHERE v.volunteer_id = $1 AND LOWER(es.email) = LOWER(v.email)
+	`, volInt)
+	if err != nil {
+		return nil, friendlyDBError(err)
+	}
+
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Volunteer's email address is not suppressed.")
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Email address will receive mail again."),
+		ID:      &volId,
+	}, nil
+}
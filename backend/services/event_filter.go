@@ -20,9 +20,10 @@ import (
 // the order, because Go maps have no guaranteed iteration order.
 
 // ** Filtering for Managing Events **
-func filterEvents(ctx context.Context, filter *models.EventFilterInput, db *sql.DB) (map[int]*models.Event, []int, error) {
-	query := `
-        SELECT 
+
+// eventListColumns and eventListFrom are shared by the plain and paged event
+// listings; scanEventRow reads the columns in this order.
+const eventListColumns = `
             e.event_id,
             e.event_name,
             e.description,
@@ -41,74 +42,121 @@ func filterEvents(ctx context.Context, filter *models.EventFilterInput, db *sql.
 			rg.pattern,
 			rg.max_occurrences,
 			rg.weekday_ordinal,
-			earliest.first_date
+			earliest.first_date,
+			` + eventStatusColumn + `,
+			e.publish_at,
+			e.cancelled_at`
+
+const eventListFrom = `
         FROM events e
         LEFT JOIN venues v ON e.venue_id = v.venue_id
         LEFT JOIN recurrence_groups rg ON rg.id = e.recurrence_group_id
 		JOIN funding_entities fe ON e.funding_entity_id = fe.id
-        LEFT JOIN opportunities opp ON e.event_id = opp.event_id
-		LEFT JOIN job_types jt ON jt.job_type_id = opp.job_type_id
 		LEFT JOIN (
 			SELECT event_id, MIN(start_date_time) as first_date
 			FROM event_dates
 			GROUP BY event_id
-		) earliest ON e.event_id = earliest.event_id
-		WHERE 1=1
-    `
+		) earliest ON e.event_id = earliest.event_id`
 
-	args := []any{}
-	argCount := 1
+// eventSortOrders are the sort options for the events connection. Events
+// without dates sort after all others.
+var eventSortOrders = map[models.EventSortField]keysetOrder{
+	models.EventSortStartDate: {expr: "COALESCE(earliest.first_date, 'infinity'::timestamp)", sqlType: "timestamp", idExpr: "e.event_id"},
+	models.EventSortName:      {expr: "LOWER(e.event_name)", sqlType: "text", idExpr: "e.event_id"},
+}
 
-	if filter != nil {
-		// Filter by cities.
-		if len(filter.Cities) > 0 {
-			placeholders := []string{}
-			for _, city := range filter.Cities {
-				placeholders = append(placeholders, fmt.Sprintf("$%d", argCount))
-				args = append(args, city)
-				argCount++
-			}
-			query += fmt.Sprintf(" AND v.city IN (%s)", strings.Join(placeholders, ","))
-		}
+// eventFilterConditions turns filter into WHERE conditions over the
+// eventListFrom tables, appending their arguments to args. Jobs are matched
+// with EXISTS so each event is still one row.
+func eventFilterConditions(filter *models.EventFilterInput, args *[]any) []string {
+	conds := []string{}
+	if filter == nil {
+		return conds
+	}
 
-		// Filter by event type.
-		if filter.EventType != nil {
-			switch *filter.EventType {
-			case "VIRTUAL":
-				query += " AND e.event_is_virtual = true AND e.venue_id IS NULL"
-			case "IN_PERSON":
-				query += " AND e.event_is_virtual = false"
-			case "HYBRID":
-				query += " AND e.event_is_virtual = true AND e.venue_id IS NOT NULL"
-			}
+	// Filter by cities.
+	if len(filter.Cities) > 0 {
+		*args = append(*args, pq.Array(filter.Cities))
+		conds = append(conds, fmt.Sprintf("v.city = ANY($%d)", len(*args)))
+	}
+
+	// Filter by event type.
+	if filter.EventType != nil {
+		switch *filter.EventType {
+		case "VIRTUAL":
+			conds = append(conds, "e.event_is_virtual = true AND e.venue_id IS NULL")
+		case "IN_PERSON":
+			conds = append(conds, "e.event_is_virtual = false")
+		case "HYBRID":
+			conds = append(conds, "e.event_is_virtual = true AND e.venue_id IS NOT NULL")
 		}
+	}
 
-		// Filter by Jobs.
-		if len(filter.Jobs) > 0 {
-			placeholders := []string{}
-			for _, jobId := range filter.Jobs {
-				placeholders = append(placeholders, fmt.Sprintf("$%d", argCount))
-				args = append(args, jobId)
-				argCount++
-			}
-			query += fmt.Sprintf(" AND opp.job_type_id IN (%s)", strings.Join(placeholders, ","))
+	// Filter by Jobs.
+	if len(filter.Jobs) > 0 {
+		*args = append(*args, pq.Array(filter.Jobs))
+		conds = append(conds, fmt.Sprintf(`EXISTS (
+			SELECT 1 FROM opportunities opp
+			WHERE opp.event_id = e.event_id AND opp.job_type_id = ANY($%d))`, len(*args)))
+	}
+
+	// Filter by TimeFrame.
+	if filter.TimeFrame != nil {
+		switch *filter.TimeFrame {
+		case "UPCOMING":
+			conds = append(conds, "earliest.first_date >= NOW()")
+		case "PAST":
+			conds = append(conds, "earliest.first_date < NOW()")
+		case "ALL":
+			// NO filter needed
 		}
+	}
 
-		// Filter by TimeFrame.
-		if filter.TimeFrame != nil {
-			switch *filter.TimeFrame {
-			case "UPCOMING":
-				query += " AND earliest.first_date >= NOW()"
-			case "PAST":
-				query += " AND earliest.first_date < NOW()"
-			case "ALL":
-				// NO filter needed
-			}
+	// Filter by keywords.
+	if search := eventSearchText(filter.Search); search != "" {
+		*args = append(*args, search)
+		conds = append(conds, eventSearchCondition(len(*args)))
+	}
+
+	// Filter by status.
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, st := range filter.Statuses {
+			statuses[i] = string(st)
 		}
+		*args = append(*args, pq.Array(statuses))
+		conds = append(conds, fmt.Sprintf("(%s) = ANY($%d)", eventStatusColumn, len(*args)))
 	}
+	return conds
+}
+
+// whereClause joins conditions into a WHERE clause ("" when there are none).
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
 
-	// Get the events in order of start date.
-	query += " ORDER BY earliest.first_date ASC NULLS LAST"
+func filterEvents(ctx context.Context, filter *models.EventFilterInput, scope FundingScope, db *sql.DB) (map[int]*models.Event, []int, error) {
+	args := []any{}
+	conds := eventFilterConditions(filter, &args)
+	if cond := eventScopeCondition(scope, &args); cond != "" {
+		conds = append(conds, cond)
+	}
+	// Get the events in order of start date, the best matches first when
+	// searching.
+	columns, orderBy := eventListColumns, " ORDER BY earliest.first_date ASC NULLS LAST"
+	search := ""
+	if filter != nil {
+		search = eventSearchText(filter.Search)
+	}
+	if search != "" {
+		args = append(args, search)
+		columns += eventSearchColumns(len(args))
+		orderBy = " ORDER BY search_rank DESC, earliest.first_date ASC NULLS LAST"
+	}
+	query := "SELECT" + columns + eventListFrom + whereClause(conds) + orderBy
 
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -116,139 +164,232 @@ func filterEvents(ctx context.Context, filter *models.EventFilterInput, db *sql.
 	}
 	defer rows.Close()
 
-	// Each row represents an event that *might* meet the
-	// criteria. Turn each row into an event.
+	// Each row represents an event that meets the criteria.
 	eventsMap := make(map[int]*models.Event)
 	// orderedIDs preserves the ORDER BY from the SQL query so the caller
 	// can reassemble the slice in the correct order after map operations.
 	orderedIDs := make([]int, 0)
 
 	for rows.Next() {
-		var e models.Event
-		var eventInt int
-		var venueInt sql.NullInt64
-		var isVirtual bool
-		var firstDate *time.Time
-		var eventDesc, venueName, streetAddress, city, state, zip sql.NullString
-		var fundingEntityId int
-		var fundingEntityName string
-		var recurGrpId, recurPattern, recurWdOrd sql.NullString
-		var recurOrder, recurMax sql.NullInt32
-
-		err := rows.Scan(
-			&eventInt,
-			&e.Name,
-			&eventDesc,
-			&isVirtual,
-			&venueInt,
-			&venueName,
-			&streetAddress,
-			&city,
-			&state,
-			&zip,
-			&e.Timezone,
-			&fundingEntityId,
-			&fundingEntityName,
-			&recurGrpId,
-			&recurOrder,
-			&recurPattern,
-			&recurMax,
-			&recurWdOrd,
-			&firstDate,
-		)
+		var match eventSearchMatch
+		var extra []any
+		if search != "" {
+			extra = match.dest()
+		}
+		e, eventInt, err := scanEventRow(rows.Scan, extra...)
 		if err != nil {
-			return nil, nil, fmt.Errorf("error scanning event: %w", err)
+			return nil, nil, err
+		}
+		if search != "" {
+			e.SearchRank, e.SearchSnippet = &match.rank, match.snippet()
 		}
+		orderedIDs = append(orderedIDs, eventInt)
+		eventsMap[eventInt] = e
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading events: %w", err)
+	}
 
-		// Have we already processed this event? (There SHOULD be just
-		// one row per event with the current SQL, but worth checking).
-		_, exists := eventsMap[eventInt]
-		if !exists {
-			// Unprocessed event. Get the data that will be repeated in subsequent rows
-			// for this event ID.
+	if err := addEventDetails(ctx, db, eventsMap, orderedIDs); err != nil {
+		return nil, nil, err
+	}
+	return eventsMap, orderedIDs, nil
+}
 
-			e.ServiceTypes = []string{}
-			e.EventDates = []*models.EventDate{}
-			e.ShiftSummaries = []*models.EventShiftSummary{}
+// filterEventsPage returns one page of the events matching filter within
+// scope, sorted by order, with the total number of matches. Dates, service
+// types and shift summaries are loaded for that page only.
+func filterEventsPage(ctx context.Context, db *sql.DB, filter *models.EventFilterInput, scope FundingScope, order keysetOrder, req pageRequest) (*models.EventConnection, error) {
+	args := []any{}
+	conds := eventFilterConditions(filter, &args)
+	if cond := eventScopeCondition(scope, &args); cond != "" {
+		conds = append(conds, cond)
+	}
 
-			e.ID = strconv.Itoa(eventInt)
-			if eventDesc.Valid {
-				e.Description = &eventDesc.String
-			}
-			e.EventType = GetEventType(isVirtual, venueInt.Valid)
+	var total int
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*)"+eventListFrom+whereClause(conds), args...).Scan(&total); err != nil {
+		return nil, fmt.Errorf("error counting events: %w", err)
+	}
 
-			if venueInt.Valid {
-				var venue models.Venue
-				// Since venue is present, the other fields must also be
-				// not null - they are NOT NULL in DB. The exceptions are
-				// name and zip.
-				venue.ID = strconv.Itoa(int(venueInt.Int64))
-				venue.Address = streetAddress.String
-				venue.City = city.String
-				venue.State = state.String
-				if venueName.Valid {
-					venue.Name = &venueName.String
-				} else {
-					venue.Name = nil
-				}
-				if zip.Valid {
-					venue.ZipCode = &zip.String
-				} else {
-					venue.ZipCode = nil
-				}
-				e.Venue = &venue
-			} else {
-				e.Venue = nil
-			}
+	if req.cursor != nil {
+		conds = append(conds, order.after(req.cursor, &args))
+	}
+	// A search filters the page and adds snippets; the sort is still order.
+	columns := eventListColumns + ", " + order.selectKey()
+	search := ""
+	if filter != nil {
+		search = eventSearchText(filter.Search)
+	}
+	if search != "" {
+		args = append(args, search)
+		columns += eventSearchColumns(len(args))
+	}
+	args = append(args, req.limit+1)
+	query := "SELECT" + columns + eventListFrom +
+		whereClause(conds) +
+		" ORDER BY " + order.orderBy() +
+		fmt.Sprintf(" LIMIT $%d", len(args))
 
-			e.FundingEntity = models.FundingEntity{ID: fundingEntityId, Name: fundingEntityName}
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying events page: %w", err)
+	}
+	defer rows.Close()
 
-			if recurGrpId.Valid {
-				rg := &models.RecurrenceGroup{GroupID: recurGrpId.String}
-				e.RecurrenceGroup = rg
-				if recurOrder.Valid {
-					order := int(recurOrder.Int32)
-					e.RecurrenceOrder = &order
-				} else {
-					return nil, nil, fmt.Errorf("recurrence_order is required when recurrence_group_id is not null.")
-				}
-				if recurPattern.Valid {
-					rg.Pattern = recurPattern.String
-				}
-				if recurMax.Valid {
-					max := int(recurMax.Int32)
-					rg.MaxOccurrences = &max
-				}
-				if recurWdOrd.Valid {
-					rg.WeekdayOrdinal = &recurWdOrd.String
-				}
-			}
+	eventsMap := make(map[int]*models.Event)
+	var ids []int
+	var keys []string
+	for rows.Next() {
+		var key string
+		var match eventSearchMatch
+		extra := []any{&key}
+		if search != "" {
+			extra = append(extra, match.dest()...)
+		}
+		e, eventInt, err := scanEventRow(rows.Scan, extra...)
+		if err != nil {
+			return nil, err
+		}
+		if search != "" {
+			e.SearchRank, e.SearchSnippet = &match.rank, match.snippet()
+		}
+		eventsMap[eventInt] = e
+		ids = append(ids, eventInt)
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading events page: %w", err)
+	}
 
-			// Record this ID the first time we see it to preserve the DB's ORDER BY.
-			// Note, we do this at the same time we add the event to the events map, so
-			// we know the same events are in both places.
-			orderedIDs = append(orderedIDs, eventInt)
+	info, cursors := req.pageInfo(keys, ids)
+	ids = ids[:len(cursors)]
+	if err := addEventDetails(ctx, db, eventsMap, ids); err != nil {
+		return nil, err
+	}
 
-			// Add the new event to the map.
-			eventsMap[eventInt] = &e
+	conn := &models.EventConnection{
+		Edges:      make([]*models.EventEdge, len(ids)),
+		PageInfo:   info,
+		TotalCount: total,
+	}
+	for i, id := range ids {
+		conn.Edges[i] = &models.EventEdge{Cursor: cursors[i], Node: eventsMap[id]}
+	}
+	return conn, nil
+}
+
+// scanEventRow reads one row of eventListColumns, followed by extra.
+func scanEventRow(scan func(dest ...any) error, extra ...any) (*models.Event, int, error) {
+	var e models.Event
+	var eventInt int
+	var venueInt sql.NullInt64
+	var isVirtual bool
+	var firstDate *time.Time
+	var eventDesc, venueName, streetAddress, city, state, zip sql.NullString
+	var fundingEntityId int
+	var fundingEntityName string
+	var recurGrpId, recurPattern, recurWdOrd sql.NullString
+	var recurOrder, recurMax sql.NullInt32
+
+	dest := []any{
+		&eventInt,
+		&e.Name,
+		&eventDesc,
+		&isVirtual,
+		&venueInt,
+		&venueName,
+		&streetAddress,
+		&city,
+		&state,
+		&zip,
+		&e.Timezone,
+		&fundingEntityId,
+		&fundingEntityName,
+		&recurGrpId,
+		&recurOrder,
+		&recurPattern,
+		&recurMax,
+		&recurWdOrd,
+		&firstDate,
+		&e.Status,
+		&e.PublishAt,
+		&e.CancelledAt,
+	}
+	if err := scan(append(dest, extra...)...); err != nil {
+		return nil, 0, fmt.Errorf("error scanning event: %w", err)
+	}
+
+	e.ServiceTypes = []string{}
+	e.EventDates = []*models.EventDate{}
+	e.ShiftSummaries = []*models.EventShiftSummary{}
+
+	e.ID = strconv.Itoa(eventInt)
+	if eventDesc.Valid {
+		e.Description = &eventDesc.String
+	}
+	e.EventType = GetEventType(isVirtual, venueInt.Valid)
+
+	if venueInt.Valid {
+		var venue models.Venue
+		// Since venue is present, the other fields must also be
+		// not null - they are NOT NULL in DB. The exceptions are
+		// name and zip.
+		venue.ID = strconv.Itoa(int(venueInt.Int64))
+		venue.Address = streetAddress.String
+		venue.City = city.String
+		venue.State = state.String
+		if venueName.Valid {
+			venue.Name = &venueName.String
+		}
+		if zip.Valid {
+			venue.ZipCode = &zip.String
 		}
+		e.Venue = &venue
 	}
 
-	// Get things that occur in multiples (service types, event dates, and shift
-	// summaries) and add them to the events.
-	serviceTypesMap, err := fetchServiceTypesMap(ctx, db, orderedIDs)
+	e.FundingEntity = models.FundingEntity{ID: fundingEntityId, Name: fundingEntityName}
+
+	if recurGrpId.Valid {
+		rg := &models.RecurrenceGroup{GroupID: recurGrpId.String}
+		e.RecurrenceGroup = rg
+		if recurOrder.Valid {
+			order := int(recurOrder.Int32)
+			e.RecurrenceOrder = &order
+		} else {
+			return nil, 0, fmt.Errorf("recurrence_order is required when recurrence_group_id is not null.")
+		}
+		if recurPattern.Valid {
+			rg.Pattern = recurPattern.String
+		}
+		if recurMax.Valid {
+			max := int(recurMax.Int32)
+			rg.MaxOccurrences = &max
+		}
+		if recurWdOrd.Valid {
+			rg.WeekdayOrdinal = &recurWdOrd.String
+		}
+	}
+	return &e, eventInt, nil
+}
+
+// addEventDetails gets things that occur in multiples (service types, event
+// dates, and shift summaries) for the events in ids and adds them.
+func addEventDetails(ctx context.Context, db *sql.DB, eventsMap map[int]*models.Event, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	serviceTypesMap, err := fetchServiceTypesMap(ctx, db, ids)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting service types: %w", err)
+		return fmt.Errorf("error getting service types: %w", err)
 	}
 	for key, serviceTypes := range *serviceTypesMap {
-		for _, stName := range serviceTypes {
-			eventsMap[key].ServiceTypes = append(eventsMap[key].ServiceTypes, stName)
-		}
+		eventsMap[key].ServiceTypes = append(eventsMap[key].ServiceTypes, serviceTypes...)
 	}
 
-	datesMap, err := fetchDatesMap(ctx, db, orderedIDs)
+	datesMap, err := fetchDatesMap(ctx, db, ids)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting event dates: %w", err)
+		return fmt.Errorf("error getting event dates: %w", err)
 	}
 	for key, dates := range *datesMap {
 		for _, date := range dates {
@@ -256,17 +397,16 @@ func filterEvents(ctx context.Context, filter *models.EventFilterInput, db *sql.
 		}
 	}
 
-	summariesMap, err := fetchShiftSummariesMap(ctx, db, orderedIDs)
+	summariesMap, err := fetchShiftSummariesMap(ctx, db, ids)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error getting shift summaries: %w", err)
+		return fmt.Errorf("error getting shift summaries: %w", err)
 	}
 	for key, summaries := range *summariesMap {
 		for _, summ := range summaries {
 			eventsMap[key].ShiftSummaries = append(eventsMap[key].ShiftSummaries, &summ)
 		}
 	}
-
-	return eventsMap, orderedIDs, nil
+	return nil
 }
 
 // ** Filtering Volunteer Events **
@@ -274,12 +414,16 @@ func filterEvents(ctx context.Context, filter *models.EventFilterInput, db *sql.
 // get the events they want to see. We currently filter on:
 //   - cities OR distance (if the user has provided a zipcode),
 //   - event type (virtual, in-person, or hybrid),
-//   - jobs, and
-//   - timeframe (past, upcoming, all).
+//   - jobs,
+//   - timeframe (past, upcoming, all), and
+//   - keywords (see event_search.go).
+//
+// Only published and completed events are listed (see event_status.go).
 //
 // We use a 2-pass strategy. This function handles the first pass. This pass
 // returns both the map of events (keyed by event_id) and the slice of event
-// IDs in the order they came back from the DB (ORDER BY earliest event date ASC).
+// IDs in the order they came back from the DB (ORDER BY earliest event date ASC,
+// or by search rank first when searching).
 func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilterInput, db *sql.DB, volId int) (map[int]*models.EventView, []int, error) {
 
 	// If distance is used, will need the volunteer's lat/lng.
@@ -315,6 +459,21 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 		}
 	}
 
+	// A keyword search is always $1, so the rank and snippet columns can use
+	// it as well as the filter.
+	args := []interface{}{}
+	argCount := 1
+	search := ""
+	searchColumns := ""
+	if filter != nil {
+		search = eventSearchText(filter.Search)
+	}
+	if search != "" {
+		args = append(args, search)
+		argCount++
+		searchColumns = eventSearchColumns(1)
+	}
+
 	// This first assignment to the query var has no filtering, but that will get added below.
 	query := `
         SELECT 
@@ -331,7 +490,8 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 			v.latitude,
 			v.longitude,
 			e.timezone,
-			earliest.first_date
+			earliest.first_date,
+			` + eventStatusColumn + `` + searchColumns + `
         FROM events e
         LEFT JOIN venues v ON e.venue_id = v.venue_id
         LEFT JOIN opportunities opp ON e.event_id = opp.event_id
@@ -341,12 +501,9 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 			FROM event_dates
 			GROUP BY event_id
 		) earliest ON e.event_id = earliest.event_id
-		WHERE 1=1
+		WHERE ` + eventListedCondition + `
     `
 
-	args := []interface{}{}
-	argCount := 1
-
 	// Add the filtering stuff to the query.
 	if filter != nil {
 
@@ -396,10 +553,20 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 				// NO filter needed
 			}
 		}
+
+		// Filter by keywords.
+		if search != "" {
+			query += " AND " + eventSearchCondition(1)
+		}
 	}
 
-	// Get the events in order of start date.
-	query += " ORDER BY earliest.first_date ASC NULLS LAST"
+	// Get the events in order of start date, the best matches first when
+	// searching.
+	if search != "" {
+		query += " ORDER BY search_rank DESC, earliest.first_date ASC NULLS LAST"
+	} else {
+		query += " ORDER BY earliest.first_date ASC NULLS LAST"
+	}
 
 	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -423,8 +590,9 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 		var firstDate *time.Time
 		var eventDesc, venueName, streetAddress, city, state, zip sql.NullString
 		var vLat, vLng sql.NullFloat64
+		var match eventSearchMatch
 
-		err := rows.Scan(
+		dest := []any{
 			&eventInt,
 			&e.Name,
 			&eventDesc,
@@ -439,7 +607,12 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 			&vLng,
 			&e.Timezone,
 			&firstDate,
-		)
+			&e.Status,
+		}
+		if search != "" {
+			dest = append(dest, match.dest()...)
+		}
+		err := rows.Scan(dest...)
 		if err != nil {
 			return nil, nil, fmt.Errorf("error scanning event: %w", err)
 		}
@@ -448,6 +621,9 @@ func fetchFilteredPassOne(ctx context.Context, filter *models.VolunteerEventFilt
 		_, exists := eventsMap[eventInt]
 		if !exists {
 			e.ID = strconv.Itoa(eventInt)
+			if search != "" {
+				e.SearchRank, e.SearchSnippet = &match.rank, match.snippet()
+			}
 			if eventDesc.Valid {
 				e.Description = &eventDesc.String
 			}
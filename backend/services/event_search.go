@@ -0,0 +1,75 @@
+package services
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"volunteer-scheduler/models"
+)
+
+// Keyword search over events.search_vector (migration 000020): event names,
+// venue names, descriptions and pre-event instructions. Searches use
+// websearch_to_tsquery, so they take what people type into search boxes:
+// words, "quoted phrases", "or" and -excluded words.
+
+// maxEventSearchLength bounds a keyword search; it is a few words, not a
+// document.
+const maxEventSearchLength = 200
+
+// eventSearchHeadline is ts_headline's options for snippets. Matches are
+// marked with control characters, not tags, so searchSnippet can escape the
+// text before marking them up.
+const eventSearchHeadline = `MaxFragments=2, MinWords=8, MaxWords=20, FragmentDelimiter=" … ", ` +
+	"StartSel=\"\x01\", StopSel=\"\x02\""
+
+// eventSearchText is the search to run, or "" if search is unset or blank.
+func eventSearchText(search *string) string {
+	if search == nil {
+		return ""
+	}
+	return strings.TrimSpace(*search)
+}
+
+// validateEventSearch rejects a search too long to be a few keywords.
+func validateEventSearch(search *string) error {
+	if len(eventSearchText(search)) > maxEventSearchLength {
+		return models.NewFieldError("search", "search must be at most %d characters", maxEventSearchLength)
+	}
+	return nil
+}
+
+// eventSearchCondition matches the events (e) found by the search at $arg.
+func eventSearchCondition(arg int) string {
+	return fmt.Sprintf("e.search_vector @@ websearch_to_tsquery('english', $%d)", arg)
+}
+
+// eventSearchColumns selects search_rank and a snippet for the search at
+// $arg over events e and venues v; eventSearchMatch scans them.
+func eventSearchColumns(arg int) string {
+	return fmt.Sprintf(`,
+			ts_rank(e.search_vector, websearch_to_tsquery('english', $%[1]d)) AS search_rank,
+			ts_headline('english',
+				concat_ws(' … ', e.event_name, v.venue_name, e.description,
+					(SELECT string_agg(o.pre_event_instructions, ' … ') FROM opportunities o
+					  WHERE o.event_id = e.event_id)),
+				websearch_to_tsquery('english', $%[1]d), '%[2]s')`, arg, eventSearchHeadline)
+}
+
+// eventSearchMatch receives the eventSearchColumns of one row.
+type eventSearchMatch struct {
+	rank     float64
+	headline string
+}
+
+func (m *eventSearchMatch) dest() []any {
+	return []any{&m.rank, &m.headline}
+}
+
+// snippet is the headline HTML-escaped, with each match wrapped in <mark>.
+func (m *eventSearchMatch) snippet() *string {
+	s := html.EscapeString(m.headline)
+	s = strings.ReplaceAll(s, "\x01", "<mark>")
+	s = strings.ReplaceAll(s, "\x02", "</mark>")
+	return &s
+}
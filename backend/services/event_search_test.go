@@ -0,0 +1,32 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"volunteer-scheduler/models"
+)
+
+func TestEventSearchSnippet(t *testing.T) {
+	m := eventSearchMatch{headline: "Bring <gloves> & \x01food\x02 for the \x01bank\x02"}
+	want := "Bring &lt;gloves&gt; &amp; <mark>food</mark> for the <mark>bank</mark>"
+	if got := *m.snippet(); got != want {
+		t.Errorf("snippet() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateEventSearch(t *testing.T) {
+	for _, search := range []string{"", "  food bank  ", strings.Repeat("a", maxEventSearchLength)} {
+		if err := validateEventSearch(&search); err != nil {
+			t.Errorf("validateEventSearch(%q) = %v, want ok", search, err)
+		}
+	}
+	long := strings.Repeat("a", maxEventSearchLength+1)
+	err := validateEventSearch(&long)
+	if e, ok := err.(*models.Error); !ok || e.Code != models.ErrorCodeValidation {
+		t.Errorf("validateEventSearch(too long) = %v, want a validation error", err)
+	}
+	if got := eventSearchText(nil); got != "" {
+		t.Errorf("eventSearchText(nil) = %q, want empty", got)
+	}
+}
@@ -3,9 +3,11 @@
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"volunteer-scheduler/models"
 
@@ -129,6 +131,11 @@ func (s *EventService) FetchLookups(ctx context.Context) (*models.LookupValues,
 //    part of the criteria (the volunteers' lat/lng data is stored in their profiles)
 
 func (s *EventService) FetchEventViews(ctx context.Context, filter *models.VolunteerEventFilterInput, volId *int) ([]*models.EventView, error) {
+	if filter != nil {
+		if err := validateEventSearch(filter.Search); err != nil {
+			return nil, err
+		}
+	}
 
 	// Translate all of the filter stuff to a set of events that potentially meet
 	// all of the user's criteria. If there are no filters, the call to pass 1 just
@@ -167,6 +174,17 @@ func (s *EventService) FetchEventViews(ctx context.Context, filter *models.Volun
 	return events, nil
 }
 
+// FetchPublicEventViews returns the upcoming events with shifts for the public
+// feed. It filters like FetchEventViews, except by distance, which needs a
+// volunteer's location.
+func (s *EventService) FetchPublicEventViews(ctx context.Context, filter models.VolunteerEventFilterInput) ([]*models.EventView, error) {
+	upcoming := models.ShiftsFilterUpcoming
+	filter.Distance = nil
+	filter.TimeFrame = &upcoming
+	noVolunteer := 0
+	return s.FetchEventViews(ctx, &filter, &noVolunteer)
+}
+
 func (s *EventService) FetchEventView(ctx context.Context, eventId string) (*models.EventView, error) {
 	eventInt, err := strconv.Atoi(eventId)
 	if err != nil {
@@ -184,10 +202,11 @@ func (s *EventService) FetchEventView(ctx context.Context, eventId string) (*mod
             v.city,
             v.state,
             v.zip_code,
-			e.timezone
+			e.timezone,
+			` + eventStatusColumn + `
         FROM events e
         LEFT JOIN venues v ON e.venue_id = v.venue_id
-		WHERE e.event_id = $1
+		WHERE e.event_id = $1 AND ` + eventVisibleCondition + `
     `
 
 	row := s.DB.QueryRowContext(ctx, query, eventInt)
@@ -210,7 +229,12 @@ func (s *EventService) FetchEventView(ctx context.Context, eventId string) (*mod
 		&state,
 		&zip,
 		&e.Timezone,
+		&e.Status,
 	)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Drafts are not shown to volunteers.
+		return nil, models.NewError(models.ErrorCodeNotFound, "event not found")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error scanning event: %w", err)
 	}
@@ -267,12 +291,17 @@ func (s *EventService) FetchEventView(ctx context.Context, eventId string) (*mod
 	return &e, nil
 }
 
-func (s *EventService) FetchEvents(ctx context.Context, filter *models.EventFilterInput) ([]*models.Event, error) {
+func (s *EventService) FetchEvents(ctx context.Context, filter *models.EventFilterInput, scope FundingScope) ([]*models.Event, error) {
+	if filter != nil {
+		if err := validateEventSearch(filter.Search); err != nil {
+			return nil, err
+		}
+	}
 
 	// Translate all of the filter stuff to a set of events that meet all of the
 	// caller's criteria. If there are no filters, return all events.
 
-	eventsMap, orderedIDs, err := filterEvents(ctx, filter, s.DB)
+	eventsMap, orderedIDs, err := filterEvents(ctx, filter, scope, s.DB)
 	if err != nil {
 		return nil, fmt.Errorf("error querying events: %w", err)
 	}
@@ -294,6 +323,29 @@ func (s *EventService) FetchEvents(ctx context.Context, filter *models.EventFilt
 	return events, nil
 }
 
+// FetchEventsPage returns one page of the events matching filter within the
+// caller's funding scope, sorted by start date unless sort says otherwise.
+func (s *EventService) FetchEventsPage(ctx context.Context, filter *models.EventFilterInput, scope FundingScope, sort *models.EventSort, page *models.PageInput) (*models.EventConnection, error) {
+	if filter != nil {
+		if err := validateEventSearch(filter.Search); err != nil {
+			return nil, err
+		}
+	}
+	req, err := newPageRequest(page)
+	if err != nil {
+		return nil, err
+	}
+	order := eventSortOrders[models.EventSortStartDate]
+	if sort != nil {
+		o, ok := eventSortOrders[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown event sort field %s", sort.Field)
+		}
+		order = o.withDirection(sort.Direction)
+	}
+	return filterEventsPage(ctx, s.DB, filter, scope, order, req)
+}
+
 func (s *EventService) FetchEvent(ctx context.Context, eventId string) (*models.Event, error) {
 	eventInt, err := strconv.Atoi(eventId)
 	if err != nil {
@@ -320,7 +372,10 @@ func (s *EventService) FetchEvent(ctx context.Context, eventId string) (*models.
 			e.recurrence_order,
 			rg.pattern,
 			rg.max_occurrences,
-			rg.weekday_ordinal
+			rg.weekday_ordinal,
+			` + eventStatusColumn + `,
+			e.publish_at,
+			e.cancelled_at
         FROM events e
         LEFT JOIN venues v ON e.venue_id = v.venue_id
 		JOIN funding_entities fe ON fe.id = e.funding_entity_id
@@ -365,6 +420,9 @@ func (s *EventService) FetchEvent(ctx context.Context, eventId string) (*models.
 		&recurPattern,
 		&recurMax,
 		&recurWdOrd,
+		&e.Status,
+		&e.PublishAt,
+		&e.CancelledAt,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error scanning event: %w", err)
@@ -481,7 +539,7 @@ func (s *EventService) CreateEvent(ctx context.Context, newEvent models.NewEvent
 	} else {
 		// A venue is required.
 		if newEvent.VenueId == nil {
-			return nil, fmt.Errorf("A venue is required for in-person and hybrid events.")
+			return nil, models.NewFieldError("venueId", "A venue is required for in-person and hybrid events.")
 		}
 
 		venueInt, err := strconv.Atoi(*newEvent.VenueId)
@@ -498,20 +556,38 @@ func (s *EventService) CreateEvent(ctx context.Context, newEvent models.NewEvent
 	//  * Each day's end time should not be before it's start time.
 	// Do this checking before we start a transaction and have to roll back.
 	if len(newEvent.EventDates) == 0 {
-		return nil, fmt.Errorf("There must be at least one event date.")
+		return nil, models.NewFieldError("eventDates", "There must be at least one event date.")
 	}
 	for i := 0; i < len(newEvent.EventDates); i++ {
 		if newEvent.EventDates[i].EndDateTime <= newEvent.EventDates[i].StartDateTime {
-			return nil, fmt.Errorf("End time must be after start time for each date.")
+			return nil, models.NewFieldError("eventDates", "End time must be after start time for each date.")
 		}
 	}
 
+	// The event is a draft unless it has a publish time, which is entered
+	// in its timezone like its dates. From here on it is in UTC.
+	if newEvent.PublishAt != nil && strings.TrimSpace(*newEvent.PublishAt) != "" {
+		publishUTC, err := DateTimeToUTC(strings.TrimSpace(*newEvent.PublishAt), newEvent.Timezone)
+		if err != nil {
+			return nil, models.NewFieldError("publishAt", "invalid date and time %q: use %s", *newEvent.PublishAt, Layout)
+		}
+		newEvent.PublishAt = publishUTC
+	} else {
+		newEvent.PublishAt = nil
+	}
+
 	// This function is about to split. We *might* be creating a
 	// recurring event, or we might be creating a single event.
 	// If single, call createSingleEvent and return the result.
 	if newEvent.Recurrence == nil {
 		// Create a single event.
-		return s.createSingleEvent(ctx, newEvent, contactIdPtr, virtualEvent, venueIdPtr)
+		res, err := s.createSingleEvent(ctx, newEvent, contactIdPtr, virtualEvent, venueIdPtr)
+		if err == nil && res.Success && res.ID != nil {
+			if id, convErr := strconv.Atoi(*res.ID); convErr == nil {
+				enqueueEventWebhook(ctx, s.DB, models.WebhookEventEventCreated, []int{id})
+			}
+		}
+		return res, err
 	}
 
 	// To create reucurring events, everything starts with the dates.
@@ -527,6 +603,7 @@ func (s *EventService) CreateEvent(ctx context.Context, newEvent models.NewEvent
 	groupId := uuid.New()
 
 	var eventOneId *string
+	var createdIds []int
 
 	// Now we're ready to create all of these events.
 	// Put this whole thing into a transaction.
@@ -576,6 +653,9 @@ func (s *EventService) CreateEvent(ctx context.Context, newEvent models.NewEvent
 		if key == 1 {
 			eventOneId = mut.ID
 		}
+		if id, err := strconv.Atoi(*mut.ID); err == nil {
+			createdIds = append(createdIds, id)
+		}
 
 		log.Printf("created event instance with order %v and id %v", key, *mut.ID)
 	}
@@ -589,6 +669,7 @@ func (s *EventService) CreateEvent(ctx context.Context, newEvent models.NewEvent
 	if eventOneId == nil {
 		log.Printf("Unable to get the id of the first event ?!")
 	}
+	enqueueEventWebhook(ctx, s.DB, models.WebhookEventEventCreated, createdIds)
 
 	// No errors.
 	return &models.MutationResult{
@@ -614,11 +695,11 @@ func (s *EventService) CreateEventDate(ctx context.Context, dates models.AddEven
 		return nil, fmt.Errorf("unable to get timezone from event: %w", err)
 	}
 	if recurGrpId.Valid {
-		return nil, fmt.Errorf("Adding dates to an existing recurring event is not allowed.")
+		return nil, models.NewError(models.ErrorCodeConflict, "Adding dates to an existing recurring event is not allowed.")
 	}
 
 	if dates.EndDateTime <= dates.StartDateTime {
-		return nil, fmt.Errorf("end time must be after start time")
+		return nil, models.NewFieldError("endDateTime", "end time must be after start time")
 	}
 
 	var startUTC, endUTC *string
@@ -675,7 +756,7 @@ func (s *EventService) UpdateEvent(ctx context.Context, event models.UpdateEvent
 		venueInt = nil
 	} else {
 		if event.VenueId == nil {
-			return nil, fmt.Errorf("failed to update event; non-virtual event must have a venue id")
+			return nil, models.NewFieldError("venueId", "failed to update event; non-virtual event must have a venue id")
 		}
 		idInt, err := strconv.Atoi(*event.VenueId)
 		if err != nil {
@@ -790,6 +871,7 @@ func (s *EventService) UpdateEvent(ctx context.Context, event models.UpdateEvent
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit event update: %w", err)
 	}
+	enqueueEventWebhook(ctx, s.DB, models.WebhookEventEventUpdated, affectedIDs)
 
 	return &models.MutationResult{
 		Success: true,
@@ -823,11 +905,11 @@ func (s *EventService) UpdateEventDate(ctx context.Context, evDate models.Update
 		return nil, fmt.Errorf("failed to get timezone from events: %w", err)
 	}
 	if recurGrpId.Valid {
-		return nil, fmt.Errorf("Changing dates for an existing recurring event is not allowed.")
+		return nil, models.NewError(models.ErrorCodeConflict, "Changing dates for an existing recurring event is not allowed.")
 	}
 
 	if evDate.EndDateTime <= evDate.StartDateTime {
-		return nil, fmt.Errorf("end time must be after start time")
+		return nil, models.NewFieldError("endDateTime", "end time must be after start time")
 	}
 
 	var startUTC, endUTC *string
@@ -936,19 +1018,27 @@ func (s *EventService) DeleteEvent(ctx context.Context, eventId string, scope *m
 	sendDeleteEventEmailsForShifts(ctx, s.Mailer, volMap, shiftsMap, evName, staffEmail, staffFirstName)
 
 	// Finally, delete the event(s) (which will cascade to the opportunities, shifts, and volunteer_shifts).
+	// The webhook payload is built first, while the events still exist.
+	var webhookData *webhookEventData
 	if scope == nil || *scope == models.RecurrenceUpdateScopeThisOnly {
+		webhookData = eventWebhookData(ctx, s.DB, models.WebhookEventEventDeleted, []int{eventInt})
 		_, err = s.DB.ExecContext(ctx, "DELETE FROM events WHERE event_id = $1", eventInt)
 		if err != nil {
 			log.Printf("DB error: %v", err)
 			return nil, friendlyDBError(err)
 		}
 	} else {
+		webhookData = eventWebhookData(ctx, s.DB, models.WebhookEventEventDeleted,
+			recurringEventIds(ctx, s.DB, recurGrpId.String, int(recurOrder.Int32)))
 		_, err = s.DB.ExecContext(ctx, "DELETE FROM events WHERE recurrence_group_id = $1::uuid AND recurrence_order >= $2", recurGrpId, recurOrder)
 		if err != nil {
 			log.Printf("DB error: %v", err)
 			return nil, friendlyDBError(err)
 		}
 	}
+	if webhookData != nil {
+		enqueueWebhook(ctx, s.DB, models.WebhookEventEventDeleted, webhookData)
+	}
 	// Whether or not the scope was THIS_AND_FUTURE, we might not have any events left
 	// with the UUID. If that's the case, get rid of the row from the table.
 	if recurGrpId.Valid {
@@ -977,7 +1067,7 @@ func (s *EventService) DeleteEvent(ctx context.Context, eventId string, scope *m
 func (s *EventService) DeleteEventDate(ctx context.Context, evDateId string) (*models.MutationResult, error) {
 	dateInt, err := strconv.Atoi(evDateId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid event date id %s: %w", evDateId, err)
+		return nil, models.InvalidID("eventDateId")
 	}
 
 	_, err = s.DB.ExecContext(ctx, "DELETE FROM event_dates WHERE event_date_id = $1", dateInt)
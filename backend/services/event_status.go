@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
+)
+
+// eventStatusColumn is the status of events e as of now. A draft whose
+// publish time has passed is published even before UpdateEventStatuses
+// records it, so scheduled events appear on time.
+const eventStatusColumn = `CASE WHEN e.status = 'DRAFT' AND e.publish_at <= NOW() THEN 'PUBLISHED' ELSE e.status END`
+
+// eventListedCondition keeps the events volunteers browse: published ones,
+// and completed ones for the past time frame. Drafts and cancelled events
+// are left out.
+const eventListedCondition = "(" + eventStatusColumn + ") IN ('PUBLISHED', 'COMPLETED')"
+
+// eventVisibleCondition keeps the events volunteers can open by ID: those
+// listed, and cancelled ones, which their past signups still link to.
+const eventVisibleCondition = "(" + eventStatusColumn + ") <> 'DRAFT'"
+
+// upcomingSignupsQuery finds the signups a cancellation takes away: those
+// not already cancelled, for shifts that have not ended, of the events in $1.
+// Its columns are the ones makeEmailMapForShifts reads.
+const upcomingSignupsQuery = `
+	SELECT
+		v.volunteer_id,
+		s.shift_id,
+		v.email,
+		v.first_name,
+		s.shift_start,
+		s.shift_end
+	FROM volunteer_shifts vs
+	JOIN volunteers v    ON v.volunteer_id = vs.volunteer_id
+	JOIN shifts s        ON s.shift_id = vs.shift_id
+	JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+	WHERE o.event_id = ANY($1) AND vs.cancelled_at IS NULL AND s.shift_end > NOW()
+	ORDER BY v.volunteer_id, s.shift_start`
+
+// statusEvent is what PublishEvent and CancelEvent need to know about the
+// event they were asked to change.
+type statusEvent struct {
+	id             int
+	name           string
+	timezone       string
+	status         models.EventStatus
+	recurGrpId     sql.NullString
+	recurOrder     sql.NullInt32
+	staffEmail     sql.NullString
+	staffFirstName sql.NullString
+}
+
+func (s *EventService) fetchStatusEvent(ctx context.Context, eventId string) (*statusEvent, *models.Error, error) {
+	eventInt, err := strconv.Atoi(eventId)
+	if err != nil {
+		return nil, models.InvalidID("eventId"), nil
+	}
+	ev := statusEvent{id: eventInt}
+	err = s.DB.QueryRowContext(ctx, `
+		SELECT e.event_name, e.timezone, `+eventStatusColumn+`,
+			e.recurrence_group_id, e.recurrence_order, sc.email, sc.first_name
+		FROM events e
+		LEFT JOIN staff sc ON sc.staff_id = e.staff_contact_id
+		WHERE e.event_id = $1
+	`, eventInt).Scan(&ev.name, &ev.timezone, &ev.status, &ev.recurGrpId, &ev.recurOrder, &ev.staffEmail, &ev.staffFirstName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Event not found."), nil
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting event: %w", err)
+	}
+	return &ev, nil, nil
+}
+
+// idsInScope is the event alone, or with THIS_AND_FUTURE it and the later
+// events of its recurrence group.
+func (s *EventService) idsInScope(ctx context.Context, ev *statusEvent, scope *models.RecurrenceUpdateScope) ([]int, *models.Error, error) {
+	if scope == nil || *scope == models.RecurrenceUpdateScopeThisOnly {
+		return []int{ev.id}, nil, nil
+	}
+	if !ev.recurGrpId.Valid || !ev.recurOrder.Valid {
+		return nil, models.NewFieldError("scope", "This event does not recur."), nil
+	}
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT event_id FROM events
+		WHERE recurrence_group_id = $1::uuid AND recurrence_order >= $2
+	`, ev.recurGrpId.String, ev.recurOrder.Int32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting recurring events: %w", err)
+	}
+	defer rows.Close()
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, nil, fmt.Errorf("error scanning recurring event: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil, rows.Err()
+}
+
+// PublishEvent makes a draft visible to volunteers, now or, with publishAt
+// (in the event's timezone, like its dates), at that time. Publishing a
+// scheduled draft again reschedules it. With THIS_AND_FUTURE the later
+// drafts of a recurring event are published too.
+func (s *EventService) PublishEvent(ctx context.Context, eventId string, publishAt *string, scope *models.RecurrenceUpdateScope) (*models.MutationResult, error) {
+	ev, verr, err := s.fetchStatusEvent(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+	if verr != nil {
+		return nil, verr
+	}
+	switch ev.status {
+	case models.EventStatusPublished:
+		return nil, models.NewError(models.ErrorCodeConflict, "This event is already published.")
+	case models.EventStatusCancelled:
+		return nil, models.NewError(models.ErrorCodeConflict, "A cancelled event cannot be published.")
+	case models.EventStatusCompleted:
+		return nil, models.NewError(models.ErrorCodeConflict, "This event has already ended.")
+	}
+
+	var when *string
+	if publishAt != nil && strings.TrimSpace(*publishAt) != "" {
+		when, err = DateTimeToUTC(strings.TrimSpace(*publishAt), ev.timezone)
+		if err != nil {
+			return nil, models.NewFieldError("publishAt", "invalid date and time %q: use %s", *publishAt, Layout)
+		}
+	}
+
+	ids, verr, err := s.idsInScope(ctx, ev, scope)
+	if err != nil {
+		return nil, err
+	}
+	if verr != nil {
+		return nil, verr
+	}
+
+	var scheduled bool
+	err = s.DB.QueryRowContext(ctx, `
+		WITH published AS (
+			UPDATE events
+			   SET publish_at = COALESCE($2::timestamp, NOW()),
+			       status = CASE WHEN COALESCE($2::timestamp, NOW()) <= NOW() THEN 'PUBLISHED' ELSE 'DRAFT' END
+			 WHERE event_id = ANY($1) AND status = 'DRAFT'
+			 RETURNING status
+		)
+		SELECT COALESCE(BOOL_OR(status = 'DRAFT'), FALSE) FROM published
+	`, pq.Array(ids), when).Scan(&scheduled)
+	if err != nil {
+		return nil, fmt.Errorf("error publishing event: %w", err)
+	}
+
+	msg := "Event published."
+	if scheduled {
+		msg = "Event scheduled to be published."
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString(msg),
+		ID:      &eventId,
+	}, nil
+}
+
+// CancelEvent cancels an event without deleting it: its shifts and signups
+// are kept as history, the upcoming signups are cancelled and those
+// volunteers (and the staff contact) are emailed as DeleteEvent does, and
+// no one can sign up again. With THIS_AND_FUTURE the later events of a
+// recurring event are cancelled too; ones already over are left alone.
+func (s *EventService) CancelEvent(ctx context.Context, eventId string, scope *models.RecurrenceUpdateScope) (*models.MutationResult, error) {
+	ev, verr, err := s.fetchStatusEvent(ctx, eventId)
+	if err != nil {
+		return nil, err
+	}
+	if verr != nil {
+		return nil, verr
+	}
+	switch ev.status {
+	case models.EventStatusCancelled:
+		return nil, models.NewError(models.ErrorCodeConflict, "This event is already cancelled.")
+	case models.EventStatusCompleted:
+		return nil, models.NewError(models.ErrorCodeConflict, "This event has already ended.")
+	}
+
+	ids, verr, err := s.idsInScope(ctx, ev, scope)
+	if err != nil {
+		return nil, err
+	}
+	if verr != nil {
+		return nil, verr
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		UPDATE events
+		   SET status = 'CANCELLED', cancelled_at = NOW()
+		 WHERE event_id = ANY($1) AND status IN ('DRAFT', 'PUBLISHED')
+		 RETURNING event_id
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("error cancelling event: %w", err)
+	}
+	var cancelled []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning cancelled event: %w", err)
+		}
+		cancelled = append(cancelled, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error cancelling event: %w", err)
+	}
+
+	// Who to tell, read before their signups are cancelled. This runs
+	// outside the transaction, which has not touched the signups yet.
+	volMap, dbTimesMap, err := makeEmailMapForShifts(ctx, s.DB, upcomingSignupsQuery, []any{pq.Array(cancelled)}, map[int]*ShiftSummary{})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE volunteer_shifts vs
+		   SET cancelled_at = NOW()
+		  FROM shifts s
+		  JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+		 WHERE vs.shift_id = s.shift_id AND o.event_id = ANY($1)
+		   AND vs.cancelled_at IS NULL AND s.shift_end > NOW()
+	`, pq.Array(cancelled))
+	if err != nil {
+		return nil, fmt.Errorf("error cancelling signups: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	// As in DeleteEvent, the name and timezone are this event's even when
+	// later ones of its group are cancelled too.
+	shiftsMap := formatShiftTimes(dbTimesMap, ev.timezone)
+	sendDeleteEventEmailsForShifts(ctx, s.Mailer, volMap, shiftsMap, ev.name, ev.staffEmail.String, ev.staffFirstName.String)
+	enqueueEventWebhook(ctx, s.DB, models.WebhookEventEventCancelled, cancelled)
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Event cancelled."),
+		ID:      &eventId,
+	}, nil
+}
+
+// UpdateEventStatuses records the changes that come with time: drafts whose
+// publish time has passed are published, and published events whose last
+// date has ended are completed.
+func (s *EventService) UpdateEventStatuses(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, `
+		UPDATE events SET status = 'PUBLISHED'
+		WHERE status = 'DRAFT' AND publish_at <= NOW()
+	`)
+	if err != nil {
+		return fmt.Errorf("error publishing scheduled events: %w", err)
+	}
+	_, err = s.DB.ExecContext(ctx, `
+		UPDATE events e SET status = 'COMPLETED'
+		WHERE e.status = 'PUBLISHED'
+		  AND (SELECT MAX(ed.end_date_time) FROM event_dates ed WHERE ed.event_id = e.event_id) < NOW()
+	`)
+	if err != nil {
+		return fmt.Errorf("error completing past events: %w", err)
+	}
+	return nil
+}
+
+// RunEventStatusScheduler calls UpdateEventStatuses every few minutes until
+// ctx is cancelled.
+func (s *EventService) RunEventStatusScheduler(ctx context.Context) {
+	if err := s.UpdateEventStatuses(ctx); err != nil {
+		log.Printf("Event status scheduler error: %v", err)
+	}
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.UpdateEventStatuses(ctx); err != nil {
+				log.Printf("Event status scheduler error: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
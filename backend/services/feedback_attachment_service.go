@@ -39,20 +39,13 @@ var allowedMIMETypes = map[string]bool{
 func (s *FeedbackService) AttachFileToFeedback(ctx context.Context, feedbackID int, filename string, mimeType string, data []byte) (*models.MutationResult, error) {
 
 	if len(data) > maxAttachmentBytes {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString(fmt.Sprintf(
-				"File is too large (%d bytes). Maximum allowed size is 5 MB.", len(data),
-			)),
-		}, nil
+		return nil, models.NewError(models.ErrorCodeValidation,
+			"File is too large (%d bytes). Maximum allowed size is 5 MB.", len(data))
 	}
 
 	// Check the client-supplied content type against the allowlist
 	if !allowedMIMETypes[mimeType] {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("File type not allowed."),
-		}, nil
+		return nil, models.NewError(models.ErrorCodeValidation, "File type not allowed.")
 	}
 
 	// Also sniff the actual bytes — don't trust the client alone
@@ -61,18 +54,12 @@ func (s *FeedbackService) AttachFileToFeedback(ctx context.Context, feedbackID i
 	detected = strings.SplitN(detected, ";", 2)[0]
 	detected = strings.TrimSpace(detected)
 	if !allowedMIMETypes[detected] {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("File type not allowed."),
-		}, nil
+		return nil, models.NewError(models.ErrorCodeValidation, "File type not allowed.")
 	}
 	// Sniffed type must also match what the client declared — prevents
 	// uploading a text file disguised as image/png, etc.
 	if detected != mimeType {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("File content does not match the declared type."),
-		}, nil
+		return nil, models.NewError(models.ErrorCodeValidation, "File content does not match the declared type.")
 	}
 
 	insert := `
@@ -94,10 +81,7 @@ func (s *FeedbackService) AttachFileToFeedback(ctx context.Context, feedbackID i
 
 	if err != nil {
 		// A FK violation means the feedbackID doesn't exist.
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Failed to store attachment. Verify the feedback ID is valid."),
-		}, fmt.Errorf("AttachFileToFeedback: %w", err)
+		return nil, fmt.Errorf("AttachFileToFeedback: %w", err)
 	}
 
 	idStr := strconv.Itoa(id)
@@ -163,7 +147,7 @@ func (s *FeedbackService) FetchAttachment(ctx context.Context, attachmentID int)
 
 	err := s.DB.QueryRowContext(ctx, query, attachmentID).Scan(&filename, &mimeType, &raw)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("attachment %d not found", attachmentID)
+		return nil, models.NewError(models.ErrorCodeNotFound, "attachment %d not found", attachmentID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("GetAttachmentData: %w", err)
@@ -194,14 +178,14 @@ func (s *FeedbackService) FetchOwnAttachment(ctx context.Context, attachmentID i
 
 	err := s.DB.QueryRowContext(ctx, query, attachmentID).Scan(&filename, &mimeType, &raw, &volInt)
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("attachment %d not found", attachmentID)
+		return nil, models.NewError(models.ErrorCodeNotFound, "attachment %d not found", attachmentID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("GetAttachmentData: %w", err)
 	}
 
 	if volInt != volId {
-		return nil, fmt.Errorf("unauthorized")
+		return nil, models.NewError(models.ErrorCodeForbidden, "you may only view attachments on your own feedback")
 	}
 
 	return &models.FeedbackAttachmentView{
@@ -0,0 +1,184 @@
+package services
+
+// feedback_email_replies.go
+//
+// Threads email replies from volunteers back into their feedback conversation.
+// Outgoing feedback emails carry a signed Reply-To such as
+//
+//	feedback+42.1f3a9c0b7d2e4f6a@reply.example.org
+//
+// The inbound-mail webhook (see package webhooks) hands the parsed message to
+// AddEmailReplyNote, which checks the signature and the sender before storing
+// the body as a VOLUNTEER_NOTE.
+//
+// Configuration:
+//
+//	INBOUND_REPLY_DOMAIN   domain whose mail is routed to the webhook
+//	INBOUND_EMAIL_SECRET   HMAC key used to sign reply addresses
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/mail"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"volunteer-scheduler/models"
+)
+
+const feedbackReplyPrefix = "feedback+"
+
+// Hex characters of the HMAC kept in the address. 64 bits is plenty to stop
+// guessing while keeping the address short enough for mail clients.
+const feedbackReplySigLen = 16
+
+// feedbackReplyTokenRe finds a reply token anywhere in a subject or body, for
+// providers that rewrite or drop the plus-addressed recipient.
+var feedbackReplyTokenRe = regexp.MustCompile(`feedback\+(\d+)\.([0-9a-f]{16})`)
+
+// FeedbackReplyAddress returns the signed Reply-To address for a feedback
+// thread. ok is false when inbound replies are not configured.
+func FeedbackReplyAddress(feedbackID int) (string, bool) {
+	domain := os.Getenv("INBOUND_REPLY_DOMAIN")
+	secret := os.Getenv("INBOUND_EMAIL_SECRET")
+	if domain == "" || secret == "" {
+		return "", false
+	}
+	return fmt.Sprintf("%s%d.%s@%s", feedbackReplyPrefix, feedbackID, signFeedbackID(feedbackID, secret), domain), true
+}
+
+func signFeedbackID(feedbackID int, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("feedback:" + strconv.Itoa(feedbackID)))
+	return hex.EncodeToString(mac.Sum(nil))[:feedbackReplySigLen]
+}
+
+// parseFeedbackReplyToken finds the first validly signed reply token in the
+// candidates (recipient addresses, then subject and body) and returns its
+// feedback ID.
+func parseFeedbackReplyToken(secret string, candidates ...string) (int, bool) {
+	for _, c := range candidates {
+		for _, m := range feedbackReplyTokenRe.FindAllStringSubmatch(strings.ToLower(c), -1) {
+			id, err := strconv.Atoi(m[1])
+			if err != nil {
+				continue
+			}
+			if hmac.Equal([]byte(m[2]), []byte(signFeedbackID(id, secret))) {
+				return id, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// quotedReplyHeaderRe matches the attribution line most clients put above the
+// quoted original ("On Tue, Jan 2, 2035 at 9:00 AM Jane <x@y> wrote:").
+var quotedReplyHeaderRe = regexp.MustCompile(`(?i)^on .+ wrote:\s*$`)
+
+// stripQuotedReply returns only the new text the volunteer typed, dropping
+// the quoted original and anything after a signature delimiter or an
+// Outlook-style "Original Message" separator.
+func stripQuotedReply(body string) string {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	var kept []string
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, ">") ||
+			quotedReplyHeaderRe.MatchString(trimmed) ||
+			line == "-- " ||
+			strings.HasPrefix(trimmed, "-----Original Message-----") ||
+			(strings.HasPrefix(trimmed, "From: ") && len(kept) > 0 && strings.TrimSpace(kept[len(kept)-1]) == "") {
+			break
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+// InboundEmail is a parsed inbound message as delivered by the mail provider.
+// MessageID is the message's Message-ID header, used to ignore redeliveries.
+type InboundEmail struct {
+	MessageID string
+	From      string
+	To        []string
+	Subject   string
+	Text      string
+}
+
+// AddEmailReplyNote stores an emailed reply as a VOLUNTEER_NOTE on the feedback
+// named by the signed reply address.
+//
+// Returns a VALIDATION, NOT_FOUND or FORBIDDEN *models.Error when the message
+// can't be matched to a thread, the thread has since been deleted, or it was
+// not sent by the feedback's submitter; the
+// webhook acknowledges those so the provider won't retry. A message whose
+// Message-ID was already stored succeeds again without adding a note.
+func (s *FeedbackService) AddEmailReplyNote(ctx context.Context, msg InboundEmail) (*models.MutationResult, error) {
+	secret := os.Getenv("INBOUND_EMAIL_SECRET")
+	if secret == "" {
+		return nil, fmt.Errorf("inbound email replies are not configured")
+	}
+
+	candidates := append(append([]string{}, msg.To...), msg.Subject, msg.Text)
+	feedbackID, ok := parseFeedbackReplyToken(secret, candidates...)
+	if !ok {
+		return nil, models.NewError(models.ErrorCodeValidation, "Message does not reference a feedback thread.")
+	}
+
+	sender, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return nil, models.NewError(models.ErrorCodeValidation, "Sender address is not valid.")
+	}
+
+	query := `
+		SELECT
+			f.volunteer_id,
+			f.status,
+			v.email
+		FROM feedback f
+		JOIN volunteers v ON v.volunteer_id = f.volunteer_id
+		WHERE f.feedback_id = $1
+	`
+	var creatorId int
+	var status, creatorEmail string
+	err = s.DB.QueryRowContext(ctx, query, feedbackID).Scan(&creatorId, &status, &creatorEmail)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Feedback thread no longer exists.")
+	}
+	if err != nil {
+		return nil, friendlyDBError(err)
+	}
+
+	// The signed address proves the thread; the sender check stops anyone who
+	// was forwarded the email from posting as the volunteer.
+	if !strings.EqualFold(sender.Address, creatorEmail) {
+		return nil, models.NewError(models.ErrorCodeForbidden, "Sender is not the submitter of this feedback.")
+	}
+
+	note := stripQuotedReply(msg.Text)
+	if note == "" {
+		return nil, models.NewError(models.ErrorCodeValidation, "Reply is empty.")
+	}
+
+	noteInt, inserted, err := s.insertVolunteerNote(ctx, creatorId, feedbackID, note, status, strings.TrimSpace(msg.MessageID))
+	if err != nil {
+		return nil, err
+	}
+
+	noteId := strconv.Itoa(noteInt)
+	message := "Successfully added emailed reply to feedback."
+	if !inserted {
+		message = "Emailed reply was already added to feedback."
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString(message),
+		ID:      &noteId,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package services
+
+// ============================================================================
+// Unit tests for feedback_email_replies.go helpers.
+//
+// stripQuotedReply and parseFeedbackReplyToken are pure — no database needed.
+// ============================================================================
+
+import (
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// stripQuotedReply
+// ============================================================================
+
+func TestStripQuotedReply(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Thanks, that fixed it.", "Thanks, that fixed it."},
+		{"gmail attribution", "Yes.\n\nOn Tue, Jan 2, 2035 at 9:00 AM Admin <a@example.com> wrote:\n> Did it work?", "Yes."},
+		{"bare quote", "Sure\r\n> original", "Sure"},
+		{"signature", "Chrome.\n-- \nJane Doe\n555-1234", "Chrome."},
+		{"outlook", "No.\n\n-----Original Message-----\nFrom: Admin", "No."},
+		{"outlook headers", "Maybe.\n\nFrom: Admin <a@example.com>\nSent: Tuesday", "Maybe."},
+		{"only quote", "> nothing new", ""},
+	}
+	for _, c := range cases {
+		if got := stripQuotedReply(c.in); got != c.want {
+			t.Errorf("%s: want %q, got %q", c.name, c.want, got)
+		}
+	}
+}
+
+// ============================================================================
+// FeedbackReplyAddress / parseFeedbackReplyToken
+// ============================================================================
+
+func TestFeedbackReplyAddress_RoundTrip(t *testing.T) {
+	t.Setenv("INBOUND_REPLY_DOMAIN", "reply.example.org")
+	t.Setenv("INBOUND_EMAIL_SECRET", "s3cret")
+
+	addr, ok := FeedbackReplyAddress(42)
+	if !ok {
+		t.Fatal("expected reply address to be configured")
+	}
+	if !strings.HasPrefix(addr, "feedback+42.") || !strings.HasSuffix(addr, "@reply.example.org") {
+		t.Errorf("unexpected address %q", addr)
+	}
+
+	// Mail clients may upper-case or wrap the address in a display name.
+	id, ok := parseFeedbackReplyToken("s3cret", "Feedback <"+strings.ToUpper(addr)+">")
+	if !ok || id != 42 {
+		t.Errorf("want 42, got %d (ok=%v)", id, ok)
+	}
+}
+
+func TestParseFeedbackReplyToken_RejectsWrongSecret(t *testing.T) {
+	t.Setenv("INBOUND_REPLY_DOMAIN", "reply.example.org")
+	t.Setenv("INBOUND_EMAIL_SECRET", "s3cret")
+	addr, _ := FeedbackReplyAddress(7)
+
+	if _, ok := parseFeedbackReplyToken("other", addr); ok {
+		t.Error("token signed with a different secret must not match")
+	}
+}
+
+func TestParseFeedbackReplyToken_RejectsTamperedID(t *testing.T) {
+	t.Setenv("INBOUND_REPLY_DOMAIN", "reply.example.org")
+	t.Setenv("INBOUND_EMAIL_SECRET", "s3cret")
+	addr, _ := FeedbackReplyAddress(7)
+
+	tampered := strings.Replace(addr, "feedback+7.", "feedback+8.", 1)
+	if _, ok := parseFeedbackReplyToken("s3cret", tampered); ok {
+		t.Error("changing the feedback id must invalidate the signature")
+	}
+}
+
+func TestFeedbackReplyAddress_NotConfigured(t *testing.T) {
+	t.Setenv("INBOUND_REPLY_DOMAIN", "")
+	t.Setenv("INBOUND_EMAIL_SECRET", "")
+	if _, ok := FeedbackReplyAddress(1); ok {
+		t.Error("expected ok=false without INBOUND_REPLY_DOMAIN/INBOUND_EMAIL_SECRET")
+	}
+}
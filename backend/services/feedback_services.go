@@ -3,11 +3,13 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
-	"strings"
 	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
 )
 
 // services/feedback_service.go
@@ -161,14 +163,14 @@ func (s *FeedbackService) FetchFeedback(ctx context.Context, filter *models.Feed
 
 	if filter != nil {
 		if filter.Status != nil {
-			dbstatus := strings.ToLower(string(*filter.Status))
+			dbstatus := string(*filter.Status)
 			query += " AND f.status = $" + strconv.Itoa(argcount)
 			args = append(args, dbstatus)
 			argcount++
 		}
 
 		if filter.Type != nil {
-			dbtype := strings.ToLower(string(*filter.Type))
+			dbtype := string(*filter.Type)
 			query += " AND f.feedback_type = $" + strconv.Itoa(argcount)
 			args = append(args, dbtype)
 			argcount++
@@ -271,6 +273,200 @@ func (s *FeedbackService) FetchFeedback(ctx context.Context, filter *models.Feed
 	return feedback, nil
 }
 
+// feedbackSortOrders are the sort options of the admin feedback connection.
+// Both default to newest first.
+var feedbackSortOrders = map[models.FeedbackSortField]keysetOrder{
+	models.FeedbackSortCreatedAt: {
+		expr:    "f.created_at",
+		sqlType: "timestamp",
+		idExpr:  "f.feedback_id",
+		desc:    true,
+	},
+	models.FeedbackSortLastUpdatedAt: {
+		expr:    "COALESCE(f.last_updated_at, f.created_at)",
+		sqlType: "timestamp",
+		idExpr:  "f.feedback_id",
+		desc:    true,
+	},
+}
+
+// FetchFeedbackPage returns one page of the feedback matching filter, with
+// each item's notes and attachment metadata.
+func (s *FeedbackService) FetchFeedbackPage(ctx context.Context, filter *models.FeedbackFilterInput, sort *models.FeedbackSort, page *models.PageInput) (*models.FeedbackConnection, error) {
+	req, err := newPageRequest(page)
+	if err != nil {
+		return nil, err
+	}
+	order := feedbackSortOrders[models.FeedbackSortCreatedAt]
+	if sort != nil {
+		o, ok := feedbackSortOrders[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown feedback sort field %s", sort.Field)
+		}
+		order = o.withDirection(sort.Direction)
+	}
+
+	var conds []string
+	var args []any
+	if filter != nil {
+		if filter.Status != nil {
+			args = append(args, string(*filter.Status))
+			conds = append(conds, fmt.Sprintf("f.status = $%d", len(args)))
+		}
+		if filter.Type != nil {
+			args = append(args, string(*filter.Type))
+			conds = append(conds, fmt.Sprintf("f.feedback_type = $%d", len(args)))
+		}
+	}
+
+	conn := &models.FeedbackConnection{}
+	err = s.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM feedback f`+whereClause(conds), args...).Scan(&conn.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("error counting feedback: %w", err)
+	}
+
+	if req.cursor != nil {
+		conds = append(conds, order.after(req.cursor, &args))
+	}
+	args = append(args, req.limit+1)
+	query := `
+		SELECT
+			f.feedback_id,
+			COALESCE(fc.first_name, ''),
+			COALESCE(fc.last_name, ''),
+			f.feedback_type,
+			f.status,
+			f.subject,
+			f.app_page_name,
+			f.text,
+			f.created_at,
+			f.last_updated_at,
+			f.resolved_at,
+			` + order.selectKey() + `
+		FROM feedback f
+		LEFT JOIN volunteers fc ON fc.volunteer_id = f.volunteer_id` +
+		whereClause(conds) + `
+		ORDER BY ` + order.orderBy() + fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying feedback: %w", err)
+	}
+	defer rows.Close()
+
+	var fbs []*models.Feedback
+	var keys []string
+	var ids []int
+	for rows.Next() {
+		var fb models.Feedback
+		var fbInt int
+		var fcFname, fcLname, fbType, fbStatus, key string
+		var lastUpdateAt, resolvedAt sql.NullString
+
+		err := rows.Scan(
+			&fbInt,
+			&fcFname,
+			&fcLname,
+			&fbType,
+			&fbStatus,
+			&fb.Subject,
+			&fb.AppPageName,
+			&fb.Text,
+			&fb.CreatedAt,
+			&lastUpdateAt,
+			&resolvedAt,
+			&key,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning feedback: %w", err)
+		}
+		fb.ID = strconv.Itoa(fbInt)
+		fb.VolunteerName = fcFname + " " + fcLname
+		fb.Type = models.FeedbackType(fbType)
+		fb.Status = models.FeedbackStatus(fbStatus)
+		if lastUpdateAt.Valid {
+			fb.LastUpdatedAt = &lastUpdateAt.String
+		}
+		if resolvedAt.Valid {
+			fb.ResolvedAt = &resolvedAt.String
+		}
+		fbs = append(fbs, &fb)
+		keys = append(keys, key)
+		ids = append(ids, fbInt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading feedback: %w", err)
+	}
+	rows.Close()
+
+	info, cursors := req.pageInfo(keys, ids)
+	fbs = fbs[:len(cursors)]
+	if err := s.addFeedbackNotes(ctx, fbs); err != nil {
+		return nil, err
+	}
+	for i, fb := range fbs {
+		fb.Attachments, err = s.fetchMetaAttachments(ctx, ids[i])
+		if err != nil {
+			return nil, fmt.Errorf("error fetching attachments for feedback with id %d: %w", ids[i], err)
+		}
+	}
+
+	conn.PageInfo = info
+	conn.Edges = make([]*models.FeedbackEdge, len(cursors))
+	for i, c := range cursors {
+		conn.Edges[i] = &models.FeedbackEdge{Cursor: c, Node: fbs[i]}
+	}
+	return conn, nil
+}
+
+// addFeedbackNotes loads the notes of every feedback item in fbs with one
+// query, oldest first.
+func (s *FeedbackService) addFeedbackNotes(ctx context.Context, fbs []*models.Feedback) error {
+	if len(fbs) == 0 {
+		return nil
+	}
+	byID := make(map[int]*models.Feedback, len(fbs))
+	ids := make([]int, 0, len(fbs))
+	for _, fb := range fbs {
+		id, _ := strconv.Atoi(fb.ID)
+		byID[id] = fb
+		ids = append(ids, id)
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT
+			fn.feedback_id,
+			COALESCE(nc.first_name, ''),
+			COALESCE(nc.last_name, ''),
+			fn.note_type,
+			fn.note,
+			fn.created_at
+		FROM feedback_notes fn
+		LEFT JOIN volunteers nc ON nc.volunteer_id = fn.volunteer_id
+		WHERE fn.feedback_id = ANY($1)
+		ORDER BY fn.feedback_id, fn.created_at
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("error querying feedback notes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var note models.FeedbackNote
+		var fbInt int
+		var ncFname, ncLname, noteType string
+		if err := rows.Scan(&fbInt, &ncFname, &ncLname, &noteType, &note.Note, &note.CreatedAt); err != nil {
+			return fmt.Errorf("error scanning feedback note: %w", err)
+		}
+		note.Creator = ncFname + " " + ncLname
+		note.NoteType = models.FeedbackNoteType(noteType)
+		fb := byID[fbInt]
+		fb.Notes = append(fb.Notes, &note)
+	}
+	return rows.Err()
+}
+
 func (s *FeedbackService) FetchFeedbackDetail(ctx context.Context, feedbackId string) (*models.Feedback, error) {
 
 	fbInt, err := strconv.Atoi(feedbackId)
@@ -443,6 +639,12 @@ func (s *FeedbackService) EmailFeedbackSubmitter(ctx context.Context, adminId in
 		return nil, friendlyDBError(err)
 	}
 
+	// When inbound replies are configured, the volunteer can answer by simply
+	// replying; the signed Reply-To address threads it back to this feedback.
+	if replyTo, ok := FeedbackReplyAddress(input.FeedbackID); ok {
+		ctx = ContextWithReplyTo(ctx, replyTo)
+	}
+
 	subject = "re: " + subject
 	err = s.Mailer.SendEmail(ctx, email, subject, "", input.EmailText)
 	if err != nil {
@@ -500,35 +702,69 @@ func (s *FeedbackService) AddVolunteerFeedbackNote(ctx context.Context, volId in
 		return nil, friendlyDBError(err)
 	}
 	if volId != creatorId {
-		return nil, fmt.Errorf("volunteers may only add notes to their own feedback.")
+		return nil, models.NewError(models.ErrorCodeForbidden, "volunteers may only add notes to their own feedback.")
 	}
 
-	var noteInt int
-	err = s.DB.QueryRowContext(ctx, insertNote, note.FeedbackId, volId, note.Note, string(models.FeedbackNoteTypeVolunteerNote)).Scan(&noteInt)
+	noteInt, _, err := s.insertVolunteerNote(ctx, volId, note.FeedbackId, note.Note, status, "")
 	if err != nil {
-		return nil, friendlyDBError(err)
+		return nil, err
+	}
+
+	noteId := strconv.Itoa(noteInt)
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Successfully added note to feedback."),
+		ID:      &noteId,
+	}, nil
+}
+
+// insertVolunteerNote adds a VOLUNTEER_NOTE to the thread. If the feedback was
+// waiting on an answer to a question, it is reopened and the admin who asked
+// is notified. Shared by the GraphQL mutation and inbound email replies.
+//
+// messageID is the Message-ID of the email the note came from, or "". If a
+// note was already stored for it, that note's ID is returned with inserted
+// false and nothing else changes.
+func (s *FeedbackService) insertVolunteerNote(ctx context.Context, volId int, feedbackId int, note string, status string, messageID string) (noteInt int, inserted bool, err error) {
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO feedback_notes (
+			feedback_id,
+			volunteer_id,
+			note,
+			note_type,
+			email_message_id,
+			created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), NOW())
+		ON CONFLICT (email_message_id) DO NOTHING
+		RETURNING note_id
+	`, feedbackId, volId, note, string(models.FeedbackNoteTypeVolunteerNote), messageID).Scan(&noteInt)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = s.DB.QueryRowContext(ctx,
+			"SELECT note_id FROM feedback_notes WHERE email_message_id = $1", messageID).Scan(&noteInt)
+		if err != nil {
+			return 0, false, friendlyDBError(err)
+		}
+		return noteInt, false, nil
+	}
+	if err != nil {
+		return 0, false, friendlyDBError(err)
 	}
 
 	if status == string(models.FeedbackStatusQuestion) {
-		_, err = s.DB.ExecContext(ctx, "UPDATE feedback SET status = $1, last_updated_at = NOW() WHERE feedback_id = $2", string(models.FeedbackStatusOpen), note.FeedbackId)
+		_, err = s.DB.ExecContext(ctx, "UPDATE feedback SET status = $1, last_updated_at = NOW() WHERE feedback_id = $2", string(models.FeedbackStatusOpen), feedbackId)
 		if err != nil {
-			return nil, friendlyDBError(err)
+			return 0, false, friendlyDBError(err)
 		}
 		// Notify the admin who asked the question that the volunteer has replied.
-		s.notifyAdminOfVolunteerReply(ctx, note.FeedbackId)
+		s.notifyAdminOfVolunteerReply(ctx, feedbackId)
 	} else {
-		_, err = s.DB.ExecContext(ctx, "UPDATE feedback SET last_updated_at = NOW() WHERE feedback_id = $1", note.FeedbackId)
+		_, err = s.DB.ExecContext(ctx, "UPDATE feedback SET last_updated_at = NOW() WHERE feedback_id = $1", feedbackId)
 		if err != nil {
-			return nil, friendlyDBError(err)
+			return 0, false, friendlyDBError(err)
 		}
 	}
 
-	noteId := strconv.Itoa(noteInt)
-	return &models.MutationResult{
-		Success: true,
-		Message: ptrString("Successfully added note to feedback."),
-		ID:      &noteId,
-	}, nil
+	return noteInt, true, nil
 }
 
 func (s *FeedbackService) AddFeedbackNote(ctx context.Context, adminId int, note models.FeedbackNoteInput) (*models.MutationResult, error) {
@@ -3,9 +3,12 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"strings"
 	"volunteer-scheduler/models"
 )
 
@@ -14,6 +17,16 @@ func ptrString(s string) *string {
 	return &s
 }
 
+// frontendBaseURL is where links in emails point: FRONTEND_BASE_URL without
+// a trailing slash, defaulting to the local dev server.
+func frontendBaseURL() string {
+	appURL := os.Getenv("FRONTEND_BASE_URL")
+	if appURL == "" {
+		appURL = "http://localhost:3000"
+	}
+	return strings.TrimSuffix(appURL, "/")
+}
+
 // ============================================================================
 // Fetching things from the DB
 // ============================================================================
@@ -48,43 +61,65 @@ func GetEventType(isVirtual bool, hasVenue bool) models.EventType {
 
 // ** Handling shift assignments **
 
-func assignVolToShift(ctx context.Context, DB *sql.DB, mailer *Mailer, shiftId string, volId int) (*models.MutationResult, error) {
+// assignVolToShift signs volId up for a shift of a published event. Admins
+// (byAdmin) may also staff drafts and record signups for completed events;
+// no one can sign up for a cancelled event.
+func assignVolToShift(ctx context.Context, DB *sql.DB, mailer *Mailer, shiftId string, volId int, byAdmin bool) (*models.MutationResult, error) {
 	shiftInt, err := strconv.Atoi(shiftId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Invalid shiftId."),
-			ID:      &shiftId,
-		}, err
+		return nil, models.InvalidID("shiftId")
 	}
 
 	query := `
 		SELECT
 			s.shift_id,
 			COUNT(vs.volunteer_id) FILTER (WHERE vs.cancelled_at IS NULL) as curr_vols,
-			s.max_volunteers
+			s.max_volunteers,
+			` + eventStatusColumn + `
 		FROM shifts s
+		JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+		JOIN events e ON e.event_id = o.event_id
 		LEFT JOIN volunteer_shifts vs 
 			ON s.shift_id = vs.shift_id
 		WHERE s.shift_id = $1
-		GROUP BY s.shift_id, s.max_volunteers
+		GROUP BY s.shift_id, s.max_volunteers, e.status, e.publish_at
 	`
 
 	var sId, currVols, maxVols int
+	var status models.EventStatus
 
-	err = DB.QueryRowContext(ctx, query, shiftInt).Scan(&sId, &currVols, &maxVols)
+	err = DB.QueryRowContext(ctx, query, shiftInt).Scan(&sId, &currVols, &maxVols, &status)
+	if err == nil && status == models.EventStatusDraft && !byAdmin {
+		// Volunteers cannot see drafts, so neither can they see this shift.
+		err = sql.ErrNoRows
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		err = models.NewError(models.ErrorCodeNotFound, "shift not found")
+	}
 	if err != nil {
+		return nil, err
+	}
+	closed := ""
+	switch {
+	case status == models.EventStatusCancelled:
+		closed = "Failed to assign volunteer to shift: the event has been cancelled."
+	case status == models.EventStatusCompleted && !byAdmin:
+		closed = "Failed to assign volunteer to shift: the event has ended."
+	}
+	if closed != "" {
 		return &models.MutationResult{
 			Success: false,
-			Message: ptrString("Failed to assign volunteer to shift: unable to query shift assignments."),
+			Message: ptrString(closed),
 			ID:      nil,
-		}, err
+			Code:    models.ErrorCodeConflict,
+		}, nil
 	}
 	if currVols >= maxVols {
 		return &models.MutationResult{
 			Success: false,
 			Message: ptrString("Failed to assign volunteer to shift: shift is full."),
 			ID:      nil,
+			Code:    models.ErrorCodeCapacityFull,
 		}, nil
 	}
 
@@ -96,12 +131,9 @@ func assignVolToShift(ctx context.Context, DB *sql.DB, mailer *Mailer, shiftId s
 	`
 	_, err = DB.ExecContext(ctx, insert, volId, shiftInt)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Failed to assign volunteer to shift."),
-			ID:      nil,
-		}, err
+		return nil, friendlyDBError(err)
 	}
+	enqueueShiftWebhook(ctx, DB, models.WebhookEventShiftAssigned, shiftInt, volId)
 
 	err = sendAssignmentConfirmation(ctx, DB, mailer, shiftInt, volId)
 	if err != nil {
@@ -125,11 +157,7 @@ func assignVolToShift(ctx context.Context, DB *sql.DB, mailer *Mailer, shiftId s
 func cancelShiftAssignment(ctx context.Context, DB *sql.DB, mailer *Mailer, shiftId string, volId int) (*models.MutationResult, error) {
 	shiftInt, err := strconv.Atoi(shiftId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("ShiftId is not valid."),
-			ID:      nil,
-		}, err
+		return nil, models.InvalidID("shiftId")
 	}
 
 	update := `
@@ -137,22 +165,17 @@ func cancelShiftAssignment(ctx context.Context, DB *sql.DB, mailer *Mailer, shif
 		SET cancelled_at = NOW()
 		WHERE volunteer_id = $1 AND shift_id = $2
 	`
-	_, err = DB.ExecContext(ctx, update, volId, shiftInt)
+	res, err := DB.ExecContext(ctx, update, volId, shiftInt)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Failed to cancel shift assignment."),
-			ID:      nil,
-		}, err
+		return nil, err
+	}
+	if n, _ := res.RowsAffected(); n > 0 {
+		enqueueShiftWebhook(ctx, DB, models.WebhookEventShiftCancelled, shiftInt, volId)
 	}
 
 	err = sendCancellationConfirmation(ctx, DB, mailer, shiftInt, volId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Cancelled shift assignment; failed to send email to volunteer."),
-			ID:      nil,
-		}, err
+		return nil, err
 	}
 
 	return &models.MutationResult{
@@ -206,7 +229,7 @@ func addNewOpportunityShifts(ctx context.Context, shifts []*models.NewShiftInput
 
 	// A new opportunity requires at least one shift.
 	if len(shifts) == 0 {
-		return fmt.Errorf("no shifts found; a new opportunity requires at least one shift")
+		return models.NewFieldError("shifts", "no shifts found; a new opportunity requires at least one shift")
 	}
 	for _, shift := range shifts {
 		err := addNewOpportunityShift(ctx, shift, oppId, tx)
@@ -261,7 +284,7 @@ func addNewOpportunityShift(ctx context.Context, shift *models.NewShiftInput, op
 	`
 	err = tx.QueryRowContext(ctx, insert, oppId, startUTC, endUTC, maxVols).Scan(&shiftId)
 	if err != nil {
-		return fmt.Errorf("error adding shift to new opportunity: %w", err)
+		return friendlyDBError(err)
 
 	}
 	// No errors.
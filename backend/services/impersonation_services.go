@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/models"
+)
+
+// ErrImpersonationInvalid is returned for an unknown, expired or ended
+// impersonation token, or one that belongs to another administrator.
+var ErrImpersonationInvalid error = models.NewError(models.ErrorCodeUnauthenticated, "impersonation expired or invalid")
+
+// ImpersonationService lets an administrator view the volunteer schema as a
+// chosen volunteer, read-only and for a limited time, so they can reproduce
+// what that volunteer sees. Every session is kept as an audit record.
+type ImpersonationService struct {
+	DB     *sql.DB
+	mailer *Mailer
+}
+
+func NewImpersonationService(db *sql.DB, mailer *Mailer) *ImpersonationService {
+	return &ImpersonationService{DB: db, mailer: mailer}
+}
+
+// impersonationMaxAge is how long an impersonation token is valid.
+// IMPERSONATION_MAX_AGE (seconds), default 1800 (30 minutes).
+func impersonationMaxAge() time.Duration {
+	return envSeconds("IMPERSONATION_MAX_AGE", 30*time.Minute)
+}
+
+// Queries.
+
+// FetchImpersonations returns the audit trail, newest first, optionally for
+// one volunteer only.
+func (s *ImpersonationService) FetchImpersonations(ctx context.Context, volunteerId *string) ([]*models.Impersonation, error) {
+	var volInt *int
+	if volunteerId != nil {
+		id, err := strconv.Atoi(*volunteerId)
+		if err != nil {
+			return nil, fmt.Errorf("invalid volunteer id %s: %w", *volunteerId, err)
+		}
+		volInt = &id
+	}
+
+	rows, err := s.DB.QueryContext(ctx, impersonationSelect+`
+		WHERE $1::INT IS NULL OR i.volunteer_id = $1
+		ORDER BY i.started_at DESC
+	`, volInt)
+	if err != nil {
+		return nil, fmt.Errorf("error querying impersonations: %w", err)
+	}
+	defer rows.Close()
+
+	imps := []*models.Impersonation{}
+	for rows.Next() {
+		imp, err := scanImpersonation(rows)
+		if err != nil {
+			return nil, err
+		}
+		imps = append(imps, imp)
+	}
+	return imps, rows.Err()
+}
+
+// Mutations.
+
+// StartImpersonation opens a read-only impersonation of a volunteer and
+// returns the token the admin's browser sends in the X-Impersonate header.
+func (s *ImpersonationService) StartImpersonation(ctx context.Context, adminId int, input models.StartImpersonationInput) (*models.ImpersonationResult, error) {
+	volInt, err := strconv.Atoi(input.VolunteerID)
+	if err != nil {
+		return nil, models.InvalidID("volunteerId")
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, models.NewError(models.ErrorCodeValidation, "A reason is required to view as a volunteer.")
+	}
+	if volInt == adminId {
+		return nil, models.NewError(models.ErrorCodeValidation, "You cannot impersonate yourself.")
+	}
+
+	var firstName, email string
+	var isActive bool
+	err = s.DB.QueryRowContext(ctx,
+		"SELECT first_name, email, is_active FROM volunteers WHERE volunteer_id = $1", volInt,
+	).Scan(&firstName, &email, &isActive)
+	if err == sql.ErrNoRows || (err == nil && !isActive) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Volunteer not found.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error looking up volunteer: %w", err)
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	var id int
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO impersonation_sessions (token, admin_id, volunteer_id, reason, volunteer_notified, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`, hashSessionToken(token), adminId, volInt, reason, input.NotifyVolunteer,
+		time.Now().UTC().Add(impersonationMaxAge())).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("error starting impersonation: %w", err)
+	}
+
+	imp, err := s.fetchImpersonation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.NotifyVolunteer {
+		if err := sendImpersonationNotice(ctx, s.mailer, firstName, email, imp.AdminName, reason); err != nil {
+			log.Printf("Warning: failed to send impersonation notice to %s: %v", email, err)
+		}
+	}
+
+	return &models.ImpersonationResult{
+		Success:       true,
+		Message:       ptrString("Viewing as volunteer."),
+		Impersonation: imp,
+		Token:         &token,
+	}, nil
+}
+
+// EndImpersonation closes one of the admin's own impersonation sessions.
+func (s *ImpersonationService) EndImpersonation(ctx context.Context, adminId int, impersonationId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(impersonationId)
+	if err != nil {
+		return nil, models.InvalidID("impersonationId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE impersonation_sessions
+		SET ended_at = NOW()
+		WHERE id = $1 AND admin_id = $2 AND ended_at IS NULL
+	`, idInt, adminId)
+	if err != nil {
+		return nil, fmt.Errorf("error ending impersonation: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Impersonation not found or already ended.")
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Impersonation ended."),
+		ID:      &impersonationId,
+	}, nil
+}
+
+// Validation.
+
+// ValidateImpersonation checks an X-Impersonate token presented by adminId
+// and returns the impersonated volunteer's id and roles. Each use is counted
+// on the audit record.
+func (s *ImpersonationService) ValidateImpersonation(ctx context.Context, token string, adminId int) (int, []string, error) {
+	var volId int
+	err := s.DB.QueryRowContext(ctx, `
+		UPDATE impersonation_sessions
+		SET last_used_at = NOW(), request_count = request_count + 1
+		WHERE token = $1 AND admin_id = $2 AND ended_at IS NULL AND expires_at > NOW()
+		RETURNING volunteer_id
+	`, hashSessionToken(token), adminId).Scan(&volId)
+	if err == sql.ErrNoRows {
+		return 0, nil, ErrImpersonationInvalid
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("error validating impersonation: %w", err)
+	}
+
+	roles, err := fetchVolunteerRoleNames(ctx, s.DB, volId)
+	if err != nil {
+		return 0, nil, err
+	}
+	return volId, roles, nil
+}
+
+// Helpers.
+
+const impersonationSelect = `
+	SELECT
+		i.id,
+		i.admin_id,
+		a.first_name || ' ' || a.last_name,
+		i.volunteer_id,
+		v.first_name || ' ' || v.last_name,
+		i.reason,
+		i.volunteer_notified,
+		i.started_at,
+		i.expires_at,
+		i.ended_at,
+		i.request_count
+	FROM impersonation_sessions i
+	JOIN volunteers a ON a.volunteer_id = i.admin_id
+	JOIN volunteers v ON v.volunteer_id = i.volunteer_id
+`
+
+func (s *ImpersonationService) fetchImpersonation(ctx context.Context, id int) (*models.Impersonation, error) {
+	row := s.DB.QueryRowContext(ctx, impersonationSelect+" WHERE i.id = $1", id)
+	return scanImpersonation(row)
+}
+
+func scanImpersonation(row interface{ Scan(...any) error }) (*models.Impersonation, error) {
+	var imp models.Impersonation
+	var id, adminId, volId int
+	var endedAt sql.NullString
+	err := row.Scan(
+		&id,
+		&adminId,
+		&imp.AdminName,
+		&volId,
+		&imp.VolunteerName,
+		&imp.Reason,
+		&imp.VolunteerNotified,
+		&imp.StartedAt,
+		&imp.ExpiresAt,
+		&endedAt,
+		&imp.RequestCount)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning impersonation: %w", err)
+	}
+	imp.ID = strconv.Itoa(id)
+	imp.AdminID = strconv.Itoa(adminId)
+	imp.VolunteerID = strconv.Itoa(volId)
+	if endedAt.Valid {
+		imp.EndedAt = &endedAt.String
+	}
+	return &imp, nil
+}
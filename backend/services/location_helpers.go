@@ -10,6 +10,8 @@ import (
 	"net/url"
 	"strconv"
 	"time"
+
+	"volunteer-scheduler/models"
 )
 
 // ============================================================================
@@ -155,12 +157,12 @@ const Layout = "2006-01-02 15:04:05" // expected layout of dates in input from c
 func DateTimeToUTC(dateTimeStr string, ianaZone string) (*string, error) {
 	loc, err := time.LoadLocation(ianaZone)
 	if err != nil {
-		return nil, fmt.Errorf("invalid timezone %s: %w", ianaZone, err)
+		return nil, models.NewError(models.ErrorCodeValidation, "invalid timezone %s", ianaZone)
 	}
 
 	datetime, err := time.ParseInLocation(Layout, dateTimeStr, loc)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing %s: %w", dateTimeStr, err)
+		return nil, models.NewError(models.ErrorCodeValidation, "invalid date and time %q", dateTimeStr)
 	}
 
 	rfc := datetime.UTC().Format(time.RFC3339)
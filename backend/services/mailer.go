@@ -64,8 +64,7 @@ const newAccountRequestHTMLTmpl = emailHeader + `
                     <td ` + tdValueAlt + `>{{.Email}}</td>
                 </tr>
             ` + tableClose + `
-            <p>To approve this request, log in to the Volunteer Scheduler and create an account for this volunteer.</p>
-            <p>If you do not recognize this person or do not wish to approve their request, no action is needed.</p>
+            <p>The requester has confirmed this email address. To review the request, sign in to the Volunteer Scheduler and open Account Requests, where you can approve or deny it.</p>
 ` + emailFooter
 
 const newAccountRequestTextTmpl = `A new volunteer account request has been submitted:
@@ -73,9 +72,7 @@ const newAccountRequestTextTmpl = `A new volunteer account request has been subm
 Name:  {{.FirstName}} {{.LastName}}
 Email: {{.Email}}
 
-To approve this request, log in to the Volunteer Scheduler and create an account for this volunteer.
-
-If you do not recognize this person or do not wish to approve their request, no action is needed.
+The requester has confirmed this email address. To review the request, sign in to the Volunteer Scheduler and open Account Requests, where you can approve or deny it.
 
 Thank you,
 Volunteer Scheduler`
@@ -114,12 +111,7 @@ const activateAccountRequestHTMLTmpl = emailHeader + `
                     <td ` + tdValueAlt + `>{{.ExistingID}}</td>
                 </tr>
             ` + tableClose + `
-            <p>You have two options:</p>
-            <ul>
-                <li><strong>Reactivate</strong> the existing account (volunteer ID {{.ExistingID}}) to preserve their shift history.</li>
-                <li><strong>Create a new account</strong> if you believe the email address has been reassigned to a different person.</li>
-            </ul>
-            <p>If you do not recognize this person or do not wish to approve their request, no action is needed.</p>
+            <p>The requester has confirmed this email address. Approving the request under Account Requests <strong>reactivates</strong> the existing account (volunteer ID {{.ExistingID}}) and preserves their shift history. Deny it if you do not recognize this person.</p>
 ` + emailFooter
 
 const activateAccountRequestTextTmpl = `An account access request has been submitted for an email address that belongs to an existing inactive account.
@@ -132,11 +124,7 @@ Existing inactive account:
   Name on record: {{.ExistingName}}
   Volunteer ID:   {{.ExistingID}}
 
-You have two options:
-  1. Reactivate the existing account (volunteer ID {{.ExistingID}}) to preserve their shift history.
-  2. Create a new account if you believe the email address has been reassigned to a different person.
-
-If you do not recognize this person or do not wish to approve their request, no action is needed.
+The requester has confirmed this email address. Approving the request under Account Requests reactivates the existing account (volunteer ID {{.ExistingID}}) and preserves their shift history. Deny it if you do not recognize this person.
 
 Thank you,
 Volunteer Scheduler`
@@ -149,6 +137,122 @@ type activateAccountRequestData struct {
 	ExistingID   int
 }
 
+// ============================================================================
+// Account request: confirm the requester's email address
+// ============================================================================
+
+const accountRequestVerifyHTMLTmpl = emailHeader + `
+            <p>Hello {{.FirstName}},</p>
+            <p>We received a request for a Volunteer Scheduler account for this email address. Please confirm it is yours so we can pass your request on to an administrator:</p>
+            <a href="{{.VerifyURL}}" style="display: inline-block; padding: 12px 24px; background-color: #0066cc; color: #ffffff; text-decoration: none; border-radius: 5px; margin: 20px 0; font-weight: bold;">Confirm Email Address</a>
+            <p>Or copy and paste this link in your browser:</p>
+            <p style="word-break: break-all;"><code>{{.VerifyURL}}</code></p>
+            <p>This link expires in 24 hours. If you did not request an account, please ignore this email.</p>
+` + emailFooter
+
+const accountRequestVerifyTextTmpl = `Hello {{.FirstName}},
+
+We received a request for a Volunteer Scheduler account for this email address. Please confirm it is yours so we can pass your request on to an administrator:
+
+{{.VerifyURL}}
+
+This link expires in 24 hours. If you did not request an account, please ignore this email.
+
+Thank you,
+Volunteer Scheduler`
+
+type accountRequestVerifyData struct {
+	FirstName string
+	VerifyURL string
+}
+
+// ============================================================================
+// Account request denied
+// ============================================================================
+
+const accountRequestDeniedHTMLTmpl = emailHeader + `
+            <p>Hello {{.FirstName}},</p>
+            <p>Thank you for your interest in volunteering. An administrator has reviewed your account request and was not able to approve it.</p>
+            {{if .Message}}<div style="background-color: #ffffff; padding: 10px; border-left: 4px solid #0066cc; margin: 20px 0;">{{.Message}}</div>{{end}}
+            <p>If you have questions, please reply to this email.</p>
+` + emailFooter
+
+const accountRequestDeniedTextTmpl = `Hello {{.FirstName}},
+
+Thank you for your interest in volunteering. An administrator has reviewed your account request and was not able to approve it.
+{{if .Message}}
+{{.Message}}
+{{end}}
+If you have questions, please reply to this email.
+
+Thank you,
+Volunteer Scheduler`
+
+type accountRequestDeniedData struct {
+	FirstName string
+	Message   string
+}
+
+// ============================================================================
+// Erasure requested (to admins)
+// ============================================================================
+
+const erasureRequestedHTMLTmpl = emailHeader + `
+            <p>{{.FirstName}} {{.LastName}} (volunteer ID {{.VolunteerID}}) has asked for their personal data to be erased.</p>
+            {{if .Reason}}<div style="background-color: #ffffff; padding: 10px; border-left: 4px solid #0066cc; margin: 20px 0;">{{.Reason}}</div>{{end}}
+            <p>Review the request under Erasure Requests. Approving it anonymizes the volunteer's profile and feedback; their shift hours stay in reports.</p>
+` + emailFooter
+
+const erasureRequestedTextTmpl = `{{.FirstName}} {{.LastName}} (volunteer ID {{.VolunteerID}}) has asked for their personal data to be erased.
+{{if .Reason}}
+{{.Reason}}
+{{end}}
+Review the request under Erasure Requests. Approving it anonymizes the volunteer's profile and feedback; their shift hours stay in reports.
+
+Thank you,
+Volunteer Scheduler`
+
+type erasureRequestedData struct {
+	FirstName   string
+	LastName    string
+	VolunteerID int
+	Reason      string
+}
+
+// ============================================================================
+// Erasure request decided (to the volunteer)
+// ============================================================================
+
+const erasureDecisionHTMLTmpl = emailHeader + `
+            <p>Hello {{.FirstName}},</p>
+            {{if .Approved}}<p>As you asked, we have erased your personal data from Volunteer Scheduler and closed your account. This is the last email we will send to this address.</p>
+            <p>We keep an anonymous record of the shifts you worked so our volunteer hours stay accurate. Thank you for your time with us.</p>
+            {{else}}<p>An administrator has reviewed your request to erase your personal data and was not able to approve it.</p>
+            {{if .Message}}<div style="background-color: #ffffff; padding: 10px; border-left: 4px solid #0066cc; margin: 20px 0;">{{.Message}}</div>{{end}}
+            <p>If you have questions, please reply to this email.</p>{{end}}
+` + emailFooter
+
+const erasureDecisionTextTmpl = `Hello {{.FirstName}},
+{{if .Approved}}
+As you asked, we have erased your personal data from Volunteer Scheduler and closed your account. This is the last email we will send to this address.
+
+We keep an anonymous record of the shifts you worked so our volunteer hours stay accurate. Thank you for your time with us.
+{{else}}
+An administrator has reviewed your request to erase your personal data and was not able to approve it.
+{{if .Message}}
+{{.Message}}
+{{end}}
+If you have questions, please reply to this email.
+{{end}}
+Thank you,
+Volunteer Scheduler`
+
+type erasureDecisionData struct {
+	FirstName string
+	Approved  bool
+	Message   string
+}
+
 // ============================================================================
 // Signup Confirmed
 // ============================================================================
@@ -548,6 +652,48 @@ type eventCancelledStaffData struct {
 	Shifts    []ShiftSummary
 }
 
+// ============================================================================
+// Impersonation notice (an admin viewed the app as this volunteer)
+// ============================================================================
+
+const impersonationNoticeHTMLTmpl = emailHeader + `
+            <p>Hello {{.FirstName}},</p>
+            <p>An administrator is viewing the Volunteer Scheduler as you, to help
+            with something you reported. They can see your events and shifts but
+            cannot change anything.</p>
+            ` + tableOpen + `
+                <tr>
+                    <td ` + tdLabel + `>Administrator</td>
+                    <td ` + tdValue + `>{{.AdminName}}</td>
+                </tr>
+                <tr>
+                    <td ` + tdLabel + `>Reason</td>
+                    <td ` + tdValueAlt + `>{{.Reason}}</td>
+                </tr>
+            ` + tableClose + `
+            <p>If you did not expect this, please contact us.</p>
+` + emailFooter
+
+const impersonationNoticeTextTmpl = `Hello {{.FirstName}},
+
+An administrator is viewing the Volunteer Scheduler as you, to help with
+something you reported. They can see your events and shifts but cannot
+change anything.
+
+Administrator: {{.AdminName}}
+Reason:        {{.Reason}}
+
+If you did not expect this, please contact us.
+
+Thank you,
+Volunteer Scheduler`
+
+type impersonationNoticeData struct {
+	FirstName string
+	AdminName string
+	Reason    string
+}
+
 // ============================================================================
 // Template rendering helper
 // ============================================================================
@@ -574,10 +720,11 @@ type EmailTransport interface {
 
 // Mailer wraps the email transport and configuration
 type Mailer struct {
-	transport EmailTransport
-	fromEmail string
-	fromName  string
-	apiKey    string
+	transport    EmailTransport
+	fromEmail    string
+	fromName     string
+	apiKey       string
+	suppressions *SuppressionList
 }
 
 // NewMailer creates a new Mailer instance based on environment configuration
@@ -603,7 +750,13 @@ func NewMailer(apiKey string) (*Mailer, error) {
 	var transport EmailTransport
 	var err error
 
-	if useResend() {
+	if useSMTP() {
+		transport, err = NewSMTPTransport(fromEmail, fromName)
+		if err != nil {
+			return nil, err
+		}
+		log.Println("Email transport: SMTP relay")
+	} else if useResend() {
 		transport, err = NewResendTransport(apiKey, fromEmail, fromName)
 		if err != nil {
 			return nil, err
@@ -624,6 +777,12 @@ func NewMailer(apiKey string) (*Mailer, error) {
 	}, nil
 }
 
+// useSMTP selects the authenticated SMTP relay. It takes precedence over
+// Resend so that production deployments can send through their own server.
+func useSMTP() bool {
+	return os.Getenv("EMAIL_TRANSPORT") == "smtp"
+}
+
 // useResend determines which email transport to use
 func useResend() bool {
 	if os.Getenv("USE_RESEND") == "true" {
@@ -635,8 +794,42 @@ func useResend() bool {
 	return false
 }
 
+type replyToKey struct{}
+
+// ContextWithReplyTo overrides the MAIL_REPLY_TO address for emails sent with
+// the returned context (e.g. a per-thread address for inbound replies).
+func ContextWithReplyTo(ctx context.Context, replyTo string) context.Context {
+	return context.WithValue(ctx, replyToKey{}, replyTo)
+}
+
+// replyToFromContext returns the per-message Reply-To if one was set,
+// otherwise the fallback.
+func replyToFromContext(ctx context.Context, fallback string) string {
+	if replyTo, ok := ctx.Value(replyToKey{}).(string); ok && replyTo != "" {
+		return replyTo
+	}
+	return fallback
+}
+
+// UseSuppressionList makes SendEmail skip addresses that have bounced or
+// complained.
+func (m *Mailer) UseSuppressionList(list *SuppressionList) {
+	m.suppressions = list
+}
+
 // SendEmail sends an email via the configured transport
 func (m *Mailer) SendEmail(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	if m.suppressions != nil {
+		reason, err := m.suppressions.Reason(ctx, to)
+		if err != nil {
+			// Don't let a failed lookup block mail; the provider will
+			// report the bounce again if the address is still bad.
+			log.Printf("SendEmail: %v", err)
+		} else if reason != nil {
+			log.Printf("SendEmail: not sending %q to %s (%s)", subject, to, *reason)
+			return fmt.Errorf("%w: %s (%s)", ErrEmailSuppressed, to, *reason)
+		}
+	}
 	return m.transport.SendEmail(ctx, to, subject, htmlBody, textBody)
 }
 
@@ -683,7 +876,7 @@ func (r *ResendTransport) SendEmail(ctx context.Context, to, subject, htmlBody,
 		from = fmt.Sprintf("%s <%s>", r.fromName, r.fromEmail)
 	}
 
-	replyTo := os.Getenv("MAIL_REPLY_TO")
+	replyTo := replyToFromContext(ctx, os.Getenv("MAIL_REPLY_TO"))
 
 	request := ResendRequest{
 		From:    from,
@@ -759,31 +952,12 @@ func NewMailhogTransport(fromEmail, fromName string) (*MailhogTransport, error)
 func (m *MailhogTransport) SendEmail(ctx context.Context, to, subject, htmlBody, textBody string) error {
 	fromEmail := m.fromEmail
 
-	// Build email message as MIME format
-	var buf bytes.Buffer
-
-	// Write headers
-	buf.WriteString(fmt.Sprintf("From: %s\r\n", fromEmail))
-	buf.WriteString(fmt.Sprintf("To: %s\r\n", to))
-	buf.WriteString(fmt.Sprintf("Subject: %s\r\n", subject))
-	buf.WriteString("MIME-Version: 1.0\r\n")
-	buf.WriteString("Content-Type: multipart/alternative; boundary=boundary123\r\n\r\n")
-
-	// Text part
-	if textBody != "" {
-		buf.WriteString("--boundary123\r\n")
-		buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
-		buf.WriteString(textBody)
-		buf.WriteString("\r\n")
+	msg, err := buildMIMEMessage(fromEmail, m.fromName, to, replyToFromContext(ctx, ""), subject, htmlBody, textBody)
+	if err != nil {
+		return err
 	}
 
-	// HTML part
-	buf.WriteString("--boundary123\r\n")
-	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n\r\n")
-	buf.WriteString(htmlBody)
-	buf.WriteString("\r\n--boundary123--\r\n")
-
 	// Connect to Mailhog SMTP and send
 	addr := fmt.Sprintf("%s:%s", m.host, m.port)
-	return smtp.SendMail(addr, nil, fromEmail, []string{to}, buf.Bytes())
+	return smtp.SendMail(addr, nil, fromEmail, []string{to}, msg)
 }
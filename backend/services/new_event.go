@@ -36,11 +36,11 @@ func (s *EventService) createSingleEvent(ctx context.Context, newEvent models.Ne
 	// good DB practice, DO NOT RETURN while inside of a transaction.
 
 	query = `
-		INSERT INTO events (event_name, description, event_is_virtual, staff_contact_id, venue_id, timezone, funding_entity_id)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO events (event_name, description, event_is_virtual, staff_contact_id, venue_id, timezone, funding_entity_id, status, publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, ` + newEventStatus(8) + `, $8)
 		RETURNING event_id
 	`
-	err = tx.QueryRowContext(ctx, query, newEvent.Name, newEvent.Description, virtualEvent, contactIdPtr, venueIdPtr, newEvent.Timezone, newEvent.FundingEntityID).Scan(&eventInt)
+	err = tx.QueryRowContext(ctx, query, newEvent.Name, newEvent.Description, virtualEvent, contactIdPtr, venueIdPtr, newEvent.Timezone, newEvent.FundingEntityID, newEvent.PublishAt).Scan(&eventInt)
 
 	if err == nil {
 		// Event was inserted. Add the dates.
@@ -90,8 +90,10 @@ func (s *EventService) createEventRecurrence(ctx context.Context, tx *sql.Tx, ev
 			timezone, 
 			funding_entity_id,
 			recurrence_group_id,
-			recurrence_order)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			recurrence_order,
+			status,
+			publish_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, ` + newEventStatus(10) + `, $10)
 		RETURNING event_id
 	`
 	err := tx.QueryRowContext(ctx, query,
@@ -103,7 +105,8 @@ func (s *EventService) createEventRecurrence(ctx context.Context, tx *sql.Tx, ev
 		ev.Timezone,
 		ev.FundingEntityID,
 		groupId.String(),
-		groupOrder).Scan(&eventInt)
+		groupOrder,
+		ev.PublishAt).Scan(&eventInt)
 
 	if err != nil {
 		return nil, friendlyDBError(err)
@@ -124,6 +127,12 @@ func (s *EventService) createEventRecurrence(ctx context.Context, tx *sql.Tx, ev
 	}, nil
 }
 
+// newEventStatus is the status of a new event published at $arg (UTC, or
+// NULL for a draft): published if that time has come, else a draft.
+func newEventStatus(arg int) string {
+	return fmt.Sprintf("CASE WHEN $%d::timestamp <= NOW() THEN 'PUBLISHED' ELSE 'DRAFT' END", arg)
+}
+
 // These helpers are used to create a new event - whether one-time events or recurring events.
 
 type timeTuple struct {
@@ -148,7 +157,7 @@ func createDatesForPattern(evDates []*models.NewEventDateInput, timezone string,
 	// so we can use go's time package to manipulate the dates.
 	ogDates, err := eventDatesToTimes(evDates, timezone)
 	if err != nil {
-		return nil, fmt.Errorf("Invalid datetimes in EventDates.")
+		return nil, models.NewFieldError("eventDates", "Invalid datetimes in EventDates.")
 	}
 	loc, err := time.LoadLocation(timezone)
 	if err != nil {
@@ -197,7 +206,7 @@ func createDatesForPattern(evDates []*models.NewEventDateInput, timezone string,
 			}
 
 			if recur.WeekdayOrdinal == nil {
-				return nil, fmt.Errorf("Weeday recurring events require the weekday ordinal parameter.")
+				return nil, models.NewFieldError("weekdayOrdinal", "Weekday recurring events require the weekday ordinal parameter.")
 			}
 			switch *recur.WeekdayOrdinal {
 			case models.WeekdayOrdinalFirst:
@@ -214,20 +223,20 @@ func createDatesForPattern(evDates []*models.NewEventDateInput, timezone string,
 				// Treat last weekday as the 5th. Backs up if there are only 4.
 				evDatesMap = createDatesForMonths(ogDates, 4, loc, max)
 			default:
-				return nil, fmt.Errorf("invalid weekday ordinal.")
+				return nil, models.NewFieldError("weekdayOrdinal", "invalid weekday ordinal.")
 			}
 		}
 	case models.RecurrencePatternYearly:
 		{
 			if recur.MaxOccurrences == nil {
-				return nil, fmt.Errorf("Yearly occurrences requires a maximum number.")
+				return nil, models.NewFieldError("maxOccurrences", "Yearly occurrences requires a maximum number.")
 			}
 			evDatesMap = createDatesForYears(ogDates, *recur.MaxOccurrences)
 
 		}
 	default:
 		{
-			return nil, fmt.Errorf("Invalid recurrence pattern for create event.")
+			return nil, models.NewFieldError("pattern", "Invalid recurrence pattern for create event.")
 		}
 	}
 	return evDatesMap, nil
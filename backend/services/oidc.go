@@ -0,0 +1,547 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Sign-in failures the SSO callback reports back to the login page.
+var (
+	ErrOIDCInvalidState     = errors.New("sign-in attempt expired or was started in another browser")
+	ErrOIDCEmailUnverified  = errors.New("identity provider did not return a verified email address")
+	ErrOIDCDomainNotAllowed = errors.New("single sign-on is not enabled for this email domain")
+	ErrOIDCNoAccount        = errors.New("no active volunteer account for this email")
+)
+
+// oidcStateLifetime bounds how long a user may spend at the identity provider.
+const oidcStateLifetime = 10 * time.Minute
+
+// OIDCConfig configures single sign-on against one OpenID Connect provider
+// (Google Workspace, Microsoft Entra, ...).
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string // this API's /auth/oidc/callback, as registered with the provider
+	Scopes       []string
+
+	// Email domains allowed to sign in this way; empty allows any.
+	AllowedDomains []string
+
+	// Accept the email claim without email_verified. Only for providers that
+	// never send it but only issue addresses they own (e.g. Entra work accounts).
+	TrustEmail bool
+}
+
+// OIDCConfigFromEnv reads OIDC_* settings. It returns nil when OIDC_ISSUER is
+// unset, which leaves SSO disabled. clientSecret overrides OIDC_CLIENT_SECRET
+// when non-empty (e.g. read from a Docker secret).
+func OIDCConfigFromEnv(clientSecret string) *OIDCConfig {
+	issuer := strings.TrimSpace(os.Getenv("OIDC_ISSUER"))
+	if issuer == "" {
+		return nil
+	}
+	if clientSecret == "" {
+		clientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	}
+	scopes := strings.Fields(os.Getenv("OIDC_SCOPES"))
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	var domains []string
+	for _, d := range strings.Split(os.Getenv("OIDC_ALLOWED_DOMAINS"), ",") {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, strings.TrimPrefix(d, "@"))
+		}
+	}
+	return &OIDCConfig{
+		Issuer:         issuer,
+		ClientID:       os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret:   clientSecret,
+		RedirectURL:    os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:         scopes,
+		AllowedDomains: domains,
+		TrustEmail:     os.Getenv("OIDC_TRUST_EMAIL") == "true",
+	}
+}
+
+// OIDCService runs the authorization-code flow with PKCE and maps the
+// verified email in the ID token to an existing volunteer. Session creation
+// is left to MagicLinkService.CreateSessionToken, so SSO and magic-link
+// sessions are indistinguishable.
+type OIDCService struct {
+	DB     *sql.DB
+	config OIDCConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *oidcDiscovery
+	keys      map[string]crypto.PublicKey
+}
+
+func NewOIDCService(db *sql.DB, config OIDCConfig) *OIDCService {
+	return &OIDCService{
+		DB:     db,
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// ============================================================================
+// Flow
+// ============================================================================
+
+// BeginLogin records a new sign-in attempt and returns the provider URL to
+// redirect the browser to, plus the state value the caller must also bind to
+// the browser (a cookie) and compare on the callback.
+func (s *OIDCService) BeginLogin(ctx context.Context) (authURL, state string, err error) {
+	disc, err := s.getDiscovery(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	state, err = randomURLSafe(32)
+	if err != nil {
+		return "", "", err
+	}
+	nonce, err := randomURLSafe(32)
+	if err != nil {
+		return "", "", err
+	}
+	verifier, err := randomURLSafe(32) // 43 characters, within RFC 7636's 43-128
+	if err != nil {
+		return "", "", err
+	}
+
+	_, err = s.DB.ExecContext(ctx, `
+		INSERT INTO oidc_login_states (state, code_verifier, nonce, expires_at)
+		VALUES ($1, $2, $3, $4)
+	`, hashSessionToken(state), verifier, nonce, time.Now().UTC().Add(oidcStateLifetime))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to store sign-in state: %w", err)
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {s.config.ClientID},
+		"redirect_uri":          {s.config.RedirectURL},
+		"scope":                 {strings.Join(s.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+	sep := "?"
+	if strings.Contains(disc.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return disc.AuthorizationEndpoint + sep + q.Encode(), state, nil
+}
+
+// CompleteLogin finishes a sign-in: it consumes the state, redeems the code,
+// verifies the ID token and returns the email of the matching active
+// volunteer, as stored in volunteers.email.
+func (s *OIDCService) CompleteLogin(ctx context.Context, state, code string) (string, error) {
+	var verifier, nonce string
+	err := s.DB.QueryRowContext(ctx, `
+		DELETE FROM oidc_login_states
+		WHERE state = $1 AND expires_at > NOW()
+		RETURNING code_verifier, nonce
+	`, hashSessionToken(state)).Scan(&verifier, &nonce)
+	if err == sql.ErrNoRows {
+		return "", ErrOIDCInvalidState
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading sign-in state: %w", err)
+	}
+
+	rawIDToken, err := s.exchangeCode(ctx, code, verifier)
+	if err != nil {
+		return "", err
+	}
+	claims, err := s.verifyIDToken(ctx, rawIDToken, nonce, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	email, err := s.emailFromClaims(claims)
+	if err != nil {
+		return "", err
+	}
+
+	var stored string
+	err = s.DB.QueryRowContext(ctx, `
+		SELECT email FROM volunteers
+		WHERE LOWER(email) = LOWER($1) AND is_active = TRUE
+	`, email).Scan(&stored)
+	if err == sql.ErrNoRows {
+		return "", ErrOIDCNoAccount
+	}
+	if err != nil {
+		return "", fmt.Errorf("error looking up volunteer: %w", err)
+	}
+	return stored, nil
+}
+
+// Cleanup removes sign-in attempts that were never completed.
+func (s *OIDCService) Cleanup(ctx context.Context) error {
+	_, err := s.DB.ExecContext(ctx, "DELETE FROM oidc_login_states WHERE expires_at < NOW()")
+	return err
+}
+
+// emailFromClaims applies the verified-email and domain rules.
+func (s *OIDCService) emailFromClaims(claims map[string]any) (string, error) {
+	email, _ := claims["email"].(string)
+	email = strings.TrimSpace(email)
+	if email == "" || !strings.Contains(email, "@") {
+		return "", ErrOIDCEmailUnverified
+	}
+	// Some providers send email_verified as the string "true".
+	verified := claims["email_verified"] == true || claims["email_verified"] == "true"
+	if !verified && !s.config.TrustEmail {
+		return "", ErrOIDCEmailUnverified
+	}
+	if !emailDomainAllowed(email, s.config.AllowedDomains) {
+		return "", ErrOIDCDomainNotAllowed
+	}
+	return email, nil
+}
+
+// emailDomainAllowed reports whether email's domain is in allowed; an empty
+// list allows every domain.
+func emailDomainAllowed(email string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+	for _, d := range allowed {
+		if domain == d {
+			return true
+		}
+	}
+	return false
+}
+
+// ============================================================================
+// Provider calls
+// ============================================================================
+
+// getDiscovery fetches and caches the provider's openid-configuration.
+func (s *OIDCService) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.discovery != nil {
+		return s.discovery, nil
+	}
+
+	wellKnown := strings.TrimSuffix(s.config.Issuer, "/") + "/.well-known/openid-configuration"
+	var disc oidcDiscovery
+	if err := s.getJSON(ctx, wellKnown, &disc); err != nil {
+		return nil, fmt.Errorf("OIDC discovery failed: %w", err)
+	}
+	if disc.Issuer != s.config.Issuer {
+		return nil, fmt.Errorf("OIDC discovery: issuer %q does not match configured %q", disc.Issuer, s.config.Issuer)
+	}
+	if disc.AuthorizationEndpoint == "" || disc.TokenEndpoint == "" || disc.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery: incomplete provider metadata")
+	}
+	s.discovery = &disc
+	return s.discovery, nil
+}
+
+// exchangeCode redeems an authorization code and returns the raw ID token.
+func (s *OIDCService) exchangeCode(ctx context.Context, code, verifier string) (string, error) {
+	disc, err := s.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {s.config.RedirectURL},
+		"client_id":     {s.config.ClientID},
+		"code_verifier": {verifier},
+	}
+	if s.config.ClientSecret != "" {
+		form.Set("client_secret", s.config.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, disc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("OIDC token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("OIDC token request failed: %s: %s", resp.Status, body)
+	}
+
+	var tok struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.Unmarshal(body, &tok); err != nil || tok.IDToken == "" {
+		return "", fmt.Errorf("OIDC token response has no id_token")
+	}
+	return tok.IDToken, nil
+}
+
+func (s *OIDCService) getJSON(ctx context.Context, u string, dest any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", u, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(dest)
+}
+
+// ============================================================================
+// ID token verification
+// ============================================================================
+
+// verifyIDToken checks the signature (RS256 or ES256, against the provider's
+// JWKS), issuer, audience, expiry and nonce, and returns the claims.
+func (s *OIDCService) verifyIDToken(ctx context.Context, raw, nonce string, now time.Time) (map[string]any, error) {
+	parts := strings.Split(raw, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed ID token")
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("malformed ID token header: %w", err)
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token signature: %w", err)
+	}
+
+	key, err := s.signingKey(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyJWTSignature(header.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]any
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %w", err)
+	}
+	if err := s.checkClaims(claims, nonce, now); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// checkClaims validates the registered claims of a signature-checked token.
+func (s *OIDCService) checkClaims(claims map[string]any, nonce string, now time.Time) error {
+	const skew = time.Minute
+
+	if iss, _ := claims["iss"].(string); iss != s.config.Issuer {
+		return fmt.Errorf("ID token issuer %q is not %q", iss, s.config.Issuer)
+	}
+
+	var audiences []string
+	switch aud := claims["aud"].(type) {
+	case string:
+		audiences = []string{aud}
+	case []any:
+		for _, a := range aud {
+			if str, ok := a.(string); ok {
+				audiences = append(audiences, str)
+			}
+		}
+	}
+	found := false
+	for _, a := range audiences {
+		if a == s.config.ClientID {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("ID token was not issued for this client")
+	}
+	if azp, ok := claims["azp"].(string); ok && len(audiences) > 1 && azp != s.config.ClientID {
+		return fmt.Errorf("ID token authorized party %q is not this client", azp)
+	}
+
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(skew)) {
+		return fmt.Errorf("ID token has expired")
+	}
+	if iat, ok := claims["iat"].(float64); ok && time.Unix(int64(iat), 0).After(now.Add(skew)) {
+		return fmt.Errorf("ID token issued in the future")
+	}
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return fmt.Errorf("ID token nonce does not match")
+	}
+	return nil
+}
+
+// signingKey returns the JWKS key with the given id, refetching the key set
+// once when the id is unknown (the provider may have rotated keys).
+func (s *OIDCService) signingKey(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	disc, err := s.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+
+	var jwks struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := s.getJSON(ctx, disc.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC signing keys: %w", err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for _, k := range jwks.Keys {
+		if pub, err := k.publicKey(); err == nil {
+			keys[k.Kid] = pub
+		}
+	}
+	s.keys = keys
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown ID token signing key %q", kid)
+	}
+	return key, nil
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	if k.Use != "" && k.Use != "sig" {
+		return nil, fmt.Errorf("key %q is not a signing key", k.Kid)
+	}
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// verifyJWTSignature checks an RS256 or ES256 signature over signingInput.
+// Any other alg (notably "none" and HMAC) is refused.
+func verifyJWTSignature(alg string, key crypto.PublicKey, signingInput string, sig []byte) error {
+	digest := sha256.Sum256([]byte(signingInput))
+	switch alg {
+	case "RS256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("ID token key does not match alg %s", alg)
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sig); err != nil {
+			return fmt.Errorf("invalid ID token signature")
+		}
+		return nil
+	case "ES256":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok || len(sig) != 64 {
+			return fmt.Errorf("ID token key does not match alg %s", alg)
+		}
+		r := new(big.Int).SetBytes(sig[:32])
+		sVal := new(big.Int).SetBytes(sig[32:])
+		if !ecdsa.Verify(pub, digest[:], r, sVal) {
+			return fmt.Errorf("invalid ID token signature")
+		}
+		return nil
+	}
+	return fmt.Errorf("unsupported ID token alg %q", alg)
+}
+
+func decodeJWTPart(part string, dest any) error {
+	b, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
+// randomURLSafe returns n random bytes, base64url-encoded without padding.
+func randomURLSafe(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestOIDCService serves discovery and a one-key JWKS for key.
+func newTestOIDCService(t *testing.T, key *rsa.PrivateKey) *OIDCService {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 srv.URL,
+			"authorization_endpoint": srv.URL + "/authorize",
+			"token_endpoint":         srv.URL + "/token",
+			"jwks_uri":               srv.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   "AQAB",
+		}}})
+	})
+
+	return NewOIDCService(nil, OIDCConfig{Issuer: srv.URL, ClientID: "client-1"})
+}
+
+func signTestJWT(t *testing.T, key *rsa.PrivateKey, header, claims map[string]any) string {
+	t.Helper()
+	h, _ := json.Marshal(header)
+	c, _ := json.Marshal(claims)
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newTestOIDCService(t, key)
+	now := time.Now()
+
+	claims := func(edit func(map[string]any)) map[string]any {
+		c := map[string]any{
+			"iss":   s.config.Issuer,
+			"aud":   "client-1",
+			"sub":   "user-1",
+			"exp":   now.Add(5 * time.Minute).Unix(),
+			"iat":   now.Unix(),
+			"nonce": "n-1",
+		}
+		if edit != nil {
+			edit(c)
+		}
+		return c
+	}
+	rs256 := map[string]any{"alg": "RS256", "kid": "k1"}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"valid", signTestJWT(t, key, rs256, claims(nil)), false},
+		{"audience list", signTestJWT(t, key, rs256, claims(func(c map[string]any) {
+			c["aud"] = []string{"other", "client-1"}
+			c["azp"] = "client-1"
+		})), false},
+		{"wrong key", signTestJWT(t, otherKey, rs256, claims(nil)), true},
+		{"unknown kid", signTestJWT(t, key, map[string]any{"alg": "RS256", "kid": "k2"}, claims(nil)), true},
+		{"alg none", func() string {
+			tok := signTestJWT(t, key, map[string]any{"alg": "none", "kid": "k1"}, claims(nil))
+			return tok[:strings.LastIndex(tok, ".")+1]
+		}(), true},
+		{"wrong issuer", signTestJWT(t, key, rs256, claims(func(c map[string]any) { c["iss"] = "https://evil.example" })), true},
+		{"wrong audience", signTestJWT(t, key, rs256, claims(func(c map[string]any) { c["aud"] = "client-2" })), true},
+		{"expired", signTestJWT(t, key, rs256, claims(func(c map[string]any) { c["exp"] = now.Add(-5 * time.Minute).Unix() })), true},
+		{"wrong nonce", signTestJWT(t, key, rs256, claims(func(c map[string]any) { c["nonce"] = "n-2" })), true},
+		{"malformed", "not-a-jwt", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.verifyIDToken(context.Background(), tt.token, "n-1", now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailFromClaims(t *testing.T) {
+	s := &OIDCService{config: OIDCConfig{AllowedDomains: []string{"example.org"}}}
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		trust   bool
+		wantErr error
+	}{
+		{"verified", map[string]any{"email": "Ann@Example.org", "email_verified": true}, false, nil},
+		{"verified as string", map[string]any{"email": "ann@example.org", "email_verified": "true"}, false, nil},
+		{"unverified", map[string]any{"email": "ann@example.org", "email_verified": false}, false, ErrOIDCEmailUnverified},
+		{"missing verified, trusted", map[string]any{"email": "ann@example.org"}, true, nil},
+		{"no email", map[string]any{"email_verified": true}, false, ErrOIDCEmailUnverified},
+		{"other domain", map[string]any{"email": "ann@example.com", "email_verified": true}, false, ErrOIDCDomainNotAllowed},
+		{"subdomain", map[string]any{"email": "ann@mail.example.org", "email_verified": true}, false, ErrOIDCDomainNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s.config.TrustEmail = tt.trust
+			if _, err := s.emailFromClaims(tt.claims); err != tt.wantErr {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
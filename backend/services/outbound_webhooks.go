@@ -0,0 +1,964 @@
+package services
+
+// outbound_webhooks.go
+//
+// Admin-managed webhook subscriptions. When a volunteer signs up for or
+// cancels a shift, or an event is created, updated, cancelled or deleted,
+// one delivery per matching subscription is queued in webhook_deliveries,
+// and RunWebhookDispatcher POSTs it:
+//
+//	POST <url>
+//	Content-Type: application/json
+//	X-Webhook-Id: <delivery envelope id, the same for every attempt>
+//	X-Webhook-Timestamp: <unix seconds>
+//	X-Webhook-Signature: sha256=<hex HMAC-SHA256(secret, timestamp + "." + body)>
+//
+//	{"id": "...", "type": "SHIFT_ASSIGNED", "createdAt": "...", "data": {...}}
+//
+// The signature is the scheme our own inbound webhooks check (see
+// webhooks.Sign), so receivers can verify it the same way and reject stale
+// timestamps. Any 2xx response is a delivery; anything else is retried with
+// exponential backoff until maxWebhookAttempts, after which the delivery is
+// FAILED and can be retried by hand from the delivery log.
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"volunteer-scheduler/models"
+)
+
+const (
+	maxWebhookAttempts   = 8
+	webhookBaseBackoff   = 30 * time.Second
+	webhookMaxBackoff    = 6 * time.Hour
+	webhookTimeout       = 10 * time.Second
+	webhookBatchSize     = 20
+	maxWebhookErrorBytes = 500
+
+	// webhookClaimLease is how long a claimed batch is reserved. The batch is
+	// sent one delivery at a time, so it must outlast every send timing out,
+	// with one timeout to spare for recording the results.
+	webhookClaimLease = (webhookBatchSize + 1) * webhookTimeout
+)
+
+// webhookPollInterval is how often the dispatcher looks for due deliveries.
+// WEBHOOK_POLL_SECONDS overrides it.
+func webhookPollInterval() time.Duration {
+	if val, err := strconv.Atoi(os.Getenv("WEBHOOK_POLL_SECONDS")); err == nil && val > 0 {
+		return time.Duration(val) * time.Second
+	}
+	return 10 * time.Second
+}
+
+// webhookBackoff is the wait after the given number of failed attempts:
+// 30s, 1m, 2m, ... capped at 6h.
+func webhookBackoff(attempts int) time.Duration {
+	d := webhookBaseBackoff
+	for i := 1; i < attempts && d < webhookMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, webhookMaxBackoff)
+}
+
+// signWebhook matches webhooks.Sign, which this package cannot import.
+func signWebhook(secret string, ts time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(ts.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookService manages webhook subscriptions and delivers their payloads.
+type WebhookService struct {
+	DB     *sql.DB
+	client *http.Client
+}
+
+func NewWebhookService(db *sql.DB) *WebhookService {
+	return &WebhookService{DB: db, client: newWebhookClient()}
+}
+
+// newWebhookClient does not follow redirects, and in production refuses to
+// connect to loopback, private or link-local addresses, so a subscription
+// cannot be pointed at internal services.
+func newWebhookClient() *http.Client {
+	dialer := &net.Dialer{Timeout: webhookTimeout}
+	if os.Getenv("APP_ENV") == "production" {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+				return fmt.Errorf("webhook address %s is not allowed", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// ============================================================================
+// Subscriptions
+// ============================================================================
+
+// FetchWebhookSubscriptions returns every subscription, active ones first.
+func (s *WebhookService) FetchWebhookSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	rows, err := s.DB.QueryContext(ctx, webhookSubscriptionSelect+`
+		ORDER BY w.is_active DESC, w.created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := []*models.WebhookSubscription{}
+	for rows.Next() {
+		sub, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// CreateWebhookSubscription adds a subscription with a new signing secret,
+// which is returned once.
+func (s *WebhookService) CreateWebhookSubscription(ctx context.Context, adminId int, input models.NewWebhookSubscriptionInput) (*models.WebhookSubscriptionResult, error) {
+	webhookURL, verr := validateWebhookURL(input.URL)
+	if verr != nil {
+		return nil, verr
+	}
+	eventTypes, verr := validateWebhookEventTypes(input.EventTypes)
+	if verr != nil {
+		return nil, verr
+	}
+	description, verr := validateWebhookDescription(input.Description)
+	if verr != nil {
+		return nil, verr
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO webhook_subscriptions (url, secret, event_types, description, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`, webhookURL, secret, pq.Array(eventTypes), description, adminId).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("error creating webhook subscription: %w", err)
+	}
+
+	sub, err := s.fetchWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WebhookSubscriptionResult{
+		Success:      true,
+		Message:      ptrString("Webhook created. Copy the signing secret now; it will not be shown again."),
+		Subscription: sub,
+		Secret:       &secret,
+	}, nil
+}
+
+// UpdateWebhookSubscription changes the fields that are set. Deactivating a
+// subscription stops new deliveries being queued for it; ones already queued
+// are still sent.
+func (s *WebhookService) UpdateWebhookSubscription(ctx context.Context, input models.UpdateWebhookSubscriptionInput) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, models.InvalidID("id")
+	}
+
+	sets := []string{"updated_at = NOW()"}
+	args := []any{idInt}
+	if input.URL != nil {
+		webhookURL, verr := validateWebhookURL(*input.URL)
+		if verr != nil {
+			return nil, verr
+		}
+		args = append(args, webhookURL)
+		sets = append(sets, fmt.Sprintf("url = $%d", len(args)))
+	}
+	if input.EventTypes != nil {
+		eventTypes, verr := validateWebhookEventTypes(input.EventTypes)
+		if verr != nil {
+			return nil, verr
+		}
+		args = append(args, pq.Array(eventTypes))
+		sets = append(sets, fmt.Sprintf("event_types = $%d", len(args)))
+	}
+	if input.Description != nil {
+		description, verr := validateWebhookDescription(input.Description)
+		if verr != nil {
+			return nil, verr
+		}
+		args = append(args, description)
+		sets = append(sets, fmt.Sprintf("description = $%d", len(args)))
+	}
+	if input.IsActive != nil {
+		args = append(args, *input.IsActive)
+		sets = append(sets, fmt.Sprintf("is_active = $%d", len(args)))
+	}
+
+	res, err := s.DB.ExecContext(ctx,
+		"UPDATE webhook_subscriptions SET "+strings.Join(sets, ", ")+" WHERE id = $1", args...)
+	if err != nil {
+		return nil, fmt.Errorf("error updating webhook subscription: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Webhook not found.")
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Webhook updated."),
+		ID:      &input.ID,
+	}, nil
+}
+
+// DeleteWebhookSubscription removes a subscription and its delivery log.
+func (s *WebhookService) DeleteWebhookSubscription(ctx context.Context, subscriptionId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(subscriptionId)
+	if err != nil {
+		return nil, models.InvalidID("subscriptionId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, "DELETE FROM webhook_subscriptions WHERE id = $1", idInt)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting webhook subscription: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Webhook not found.")
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Webhook deleted."),
+		ID:      &subscriptionId,
+	}, nil
+}
+
+// RotateWebhookSecret replaces a subscription's signing secret and returns
+// the new one once. Deliveries sent from now on, including retries, use it.
+func (s *WebhookService) RotateWebhookSecret(ctx context.Context, subscriptionId string) (*models.WebhookSubscriptionResult, error) {
+	idInt, err := strconv.Atoi(subscriptionId)
+	if err != nil {
+		return nil, models.InvalidID("subscriptionId")
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE webhook_subscriptions SET secret = $2, updated_at = NOW() WHERE id = $1
+	`, idInt, secret)
+	if err != nil {
+		return nil, fmt.Errorf("error rotating webhook secret: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Webhook not found.")
+	}
+
+	sub, err := s.fetchWebhookSubscription(ctx, idInt)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WebhookSubscriptionResult{
+		Success:      true,
+		Message:      ptrString("Signing secret rotated. Copy it now; it will not be shown again."),
+		Subscription: sub,
+		Secret:       &secret,
+	}, nil
+}
+
+// ============================================================================
+// Delivery log
+// ============================================================================
+
+var webhookDeliveryOrder = keysetOrder{
+	expr:    "d.created_at",
+	sqlType: "timestamp",
+	idExpr:  "d.id",
+	desc:    true,
+}
+
+// FetchWebhookDeliveriesPage returns one page of the delivery log, newest
+// first.
+func (s *WebhookService) FetchWebhookDeliveriesPage(ctx context.Context, filter *models.WebhookDeliveryFilterInput, page *models.PageInput) (*models.WebhookDeliveryConnection, error) {
+	req, err := newPageRequest(page)
+	if err != nil {
+		return nil, err
+	}
+	order := webhookDeliveryOrder
+
+	var conds []string
+	var args []any
+	if filter != nil {
+		if filter.SubscriptionID != nil {
+			subId, err := strconv.Atoi(*filter.SubscriptionID)
+			if err != nil {
+				return nil, models.InvalidID("subscriptionId")
+			}
+			args = append(args, subId)
+			conds = append(conds, fmt.Sprintf("d.subscription_id = $%d", len(args)))
+		}
+		if filter.Status != nil {
+			args = append(args, string(*filter.Status))
+			conds = append(conds, fmt.Sprintf("d.status = $%d", len(args)))
+		}
+		if filter.EventType != nil {
+			args = append(args, string(*filter.EventType))
+			conds = append(conds, fmt.Sprintf("d.event_type = $%d", len(args)))
+		}
+	}
+
+	conn := &models.WebhookDeliveryConnection{}
+	err = s.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM webhook_deliveries d`+whereClause(conds), args...).Scan(&conn.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("error counting webhook deliveries: %w", err)
+	}
+
+	if req.cursor != nil {
+		conds = append(conds, order.after(req.cursor, &args))
+	}
+	args = append(args, req.limit+1)
+	query := `
+		SELECT
+			d.id,
+			d.subscription_id,
+			d.event_type,
+			d.payload::text,
+			d.status,
+			d.attempts,
+			d.next_attempt_at,
+			d.last_attempt_at,
+			d.last_status_code,
+			d.last_error,
+			d.created_at,
+			d.delivered_at,
+			` + order.selectKey() + `
+		FROM webhook_deliveries d` +
+		whereClause(conds) + `
+		ORDER BY ` + order.orderBy() + fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	var deliveries []*models.WebhookDelivery
+	var keys []string
+	var ids []int
+	for rows.Next() {
+		var d models.WebhookDelivery
+		var id, subId int
+		var eventType, status, key string
+		var nextAttemptAt, lastAttemptAt, lastError, deliveredAt sql.NullString
+		var lastStatusCode sql.NullInt64
+		err := rows.Scan(
+			&id,
+			&subId,
+			&eventType,
+			&d.Payload,
+			&status,
+			&d.Attempts,
+			&nextAttemptAt,
+			&lastAttemptAt,
+			&lastStatusCode,
+			&lastError,
+			&d.CreatedAt,
+			&deliveredAt,
+			&key,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+		d.ID = strconv.Itoa(id)
+		d.SubscriptionID = strconv.Itoa(subId)
+		d.EventType = models.WebhookEventType(eventType)
+		d.Status = models.WebhookDeliveryStatus(status)
+		if nextAttemptAt.Valid && d.Status == models.WebhookDeliveryStatusPending {
+			d.NextAttemptAt = &nextAttemptAt.String
+		}
+		if lastAttemptAt.Valid {
+			d.LastAttemptAt = &lastAttemptAt.String
+		}
+		if lastStatusCode.Valid {
+			code := int(lastStatusCode.Int64)
+			d.LastStatusCode = &code
+		}
+		if lastError.Valid {
+			d.LastError = &lastError.String
+		}
+		if deliveredAt.Valid {
+			d.DeliveredAt = &deliveredAt.String
+		}
+		deliveries = append(deliveries, &d)
+		keys = append(keys, key)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading webhook deliveries: %w", err)
+	}
+
+	info, cursors := req.pageInfo(keys, ids)
+	conn.PageInfo = info
+	conn.Edges = make([]*models.WebhookDeliveryEdge, len(cursors))
+	for i, c := range cursors {
+		conn.Edges[i] = &models.WebhookDeliveryEdge{Cursor: c, Node: deliveries[i]}
+	}
+	return conn, nil
+}
+
+// RetryWebhookDelivery queues a FAILED delivery to be sent again on the
+// dispatcher's next pass, with a fresh set of attempts.
+func (s *WebhookService) RetryWebhookDelivery(ctx context.Context, deliveryId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(deliveryId)
+	if err != nil {
+		return nil, models.InvalidID("deliveryId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = NOW()
+		WHERE id = $1 AND status = 'FAILED'
+	`, idInt)
+	if err != nil {
+		return nil, fmt.Errorf("error retrying webhook delivery: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Delivery not found, or it has not failed.")
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Delivery queued for retry."),
+		ID:      &deliveryId,
+	}, nil
+}
+
+// ============================================================================
+// Queueing
+// ============================================================================
+
+// webhookEnvelope is the body of every delivery. ID is shared by the
+// deliveries of one change to different subscriptions, and stays the same
+// across retries, so receivers can drop duplicates.
+type webhookEnvelope struct {
+	ID        string                  `json:"id"`
+	Type      models.WebhookEventType `json:"type"`
+	CreatedAt string                  `json:"createdAt"`
+	Data      any                     `json:"data"`
+}
+
+// enqueueWebhook queues a delivery of data to every active subscription to
+// eventType. It is called after the change has been committed, so a failure
+// here is logged rather than failing the mutation.
+func enqueueWebhook(ctx context.Context, db *sql.DB, eventType models.WebhookEventType, data any) {
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		log.Printf("webhooks: encoding %s payload: %v", eventType, err)
+		return
+	}
+	_, err = db.ExecContext(context.WithoutCancel(ctx), `
+		INSERT INTO webhook_deliveries (subscription_id, event_type, payload)
+		SELECT id, $1, $2 FROM webhook_subscriptions
+		WHERE is_active AND $1 = ANY(event_types)
+	`, string(eventType), body)
+	if err != nil {
+		log.Printf("webhooks: queueing %s: %v", eventType, err)
+	}
+}
+
+// hasWebhookSubscribers reports whether any active subscription wants
+// eventType, so callers can skip building a payload nobody is sent.
+func hasWebhookSubscribers(ctx context.Context, db *sql.DB, eventType models.WebhookEventType) bool {
+	var exists bool
+	err := db.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM webhook_subscriptions WHERE is_active AND $1 = ANY(event_types))
+	`, string(eventType)).Scan(&exists)
+	if err != nil {
+		log.Printf("webhooks: checking %s subscribers: %v", eventType, err)
+	}
+	return exists
+}
+
+type webhookVolunteer struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+}
+
+type webhookShiftData struct {
+	ShiftID       string           `json:"shiftId"`
+	StartDateTime string           `json:"startDateTime"`
+	EndDateTime   string           `json:"endDateTime"`
+	JobName       string           `json:"jobName"`
+	EventID       string           `json:"eventId"`
+	EventName     string           `json:"eventName"`
+	Volunteer     webhookVolunteer `json:"volunteer"`
+}
+
+// enqueueShiftWebhook queues a SHIFT_ASSIGNED or SHIFT_CANCELLED delivery for
+// one volunteer's signup.
+func enqueueShiftWebhook(ctx context.Context, db *sql.DB, eventType models.WebhookEventType, shiftId, volId int) {
+	if !hasWebhookSubscribers(ctx, db, eventType) {
+		return
+	}
+
+	data := webhookShiftData{
+		ShiftID:   strconv.Itoa(shiftId),
+		Volunteer: webhookVolunteer{ID: strconv.Itoa(volId)},
+	}
+	var eventId int
+	err := db.QueryRowContext(ctx, `
+		SELECT s.shift_start, s.shift_end, jt.name, e.event_id, e.event_name,
+			v.first_name, v.last_name, v.email
+		FROM shifts s
+		JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+		JOIN job_types jt ON jt.job_type_id = o.job_type_id
+		JOIN events e ON e.event_id = o.event_id
+		JOIN volunteers v ON v.volunteer_id = $2
+		WHERE s.shift_id = $1
+	`, shiftId, volId).Scan(&data.StartDateTime, &data.EndDateTime, &data.JobName, &eventId, &data.EventName,
+		&data.Volunteer.FirstName, &data.Volunteer.LastName, &data.Volunteer.Email)
+	if err != nil {
+		log.Printf("webhooks: loading shift %d for %s: %v", shiftId, eventType, err)
+		return
+	}
+	data.EventID = strconv.Itoa(eventId)
+	enqueueWebhook(ctx, db, eventType, data)
+}
+
+type webhookEvent struct {
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	EventType         string  `json:"eventType"`
+	FundingEntityID   string  `json:"fundingEntityId"`
+	RecurrenceGroupID *string `json:"recurrenceGroupId"`
+	Timezone          string  `json:"timezone"`
+	StartDateTime     *string `json:"startDateTime"`
+	EndDateTime       *string `json:"endDateTime"`
+}
+
+type webhookEventData struct {
+	Events []webhookEvent `json:"events"`
+}
+
+// eventWebhookData loads the events an EVENT_* delivery is about. A change
+// to a recurring series is one delivery listing every event it touched.
+// Returns nil when nobody is subscribed to eventType. For deletes it must be
+// called before the rows go.
+func eventWebhookData(ctx context.Context, db *sql.DB, eventType models.WebhookEventType, eventIds []int) *webhookEventData {
+	if len(eventIds) == 0 || !hasWebhookSubscribers(ctx, db, eventType) {
+		return nil
+	}
+
+	rows, err := db.QueryContext(ctx, `
+		SELECT e.event_id, e.event_name, COALESCE(e.event_is_virtual, FALSE), e.venue_id IS NOT NULL,
+			e.funding_entity_id, e.recurrence_group_id::text, e.timezone,
+			MIN(ed.start_date_time)::text, MAX(ed.end_date_time)::text
+		FROM events e
+		LEFT JOIN event_dates ed ON ed.event_id = e.event_id
+		WHERE e.event_id = ANY($1)
+		GROUP BY e.event_id
+		ORDER BY MIN(ed.start_date_time), e.event_id
+	`, pq.Array(eventIds))
+	if err != nil {
+		log.Printf("webhooks: loading events for %s: %v", eventType, err)
+		return nil
+	}
+	defer rows.Close()
+
+	data := &webhookEventData{Events: []webhookEvent{}}
+	for rows.Next() {
+		var ev webhookEvent
+		var id, fundingEntityId int
+		var isVirtual, hasVenue bool
+		var recurrenceGroupId, start, end sql.NullString
+		if err := rows.Scan(&id, &ev.Name, &isVirtual, &hasVenue, &fundingEntityId, &recurrenceGroupId,
+			&ev.Timezone, &start, &end); err != nil {
+			log.Printf("webhooks: scanning event for %s: %v", eventType, err)
+			return nil
+		}
+		ev.ID = strconv.Itoa(id)
+		ev.EventType = string(GetEventType(isVirtual, hasVenue))
+		ev.FundingEntityID = strconv.Itoa(fundingEntityId)
+		if recurrenceGroupId.Valid {
+			ev.RecurrenceGroupID = &recurrenceGroupId.String
+		}
+		if start.Valid {
+			ev.StartDateTime = &start.String
+		}
+		if end.Valid {
+			ev.EndDateTime = &end.String
+		}
+		data.Events = append(data.Events, ev)
+	}
+	if err := rows.Err(); err != nil {
+		log.Printf("webhooks: reading events for %s: %v", eventType, err)
+		return nil
+	}
+	return data
+}
+
+// recurringEventIds returns the events of a recurring series from order
+// fromOrder on, for payloads about THIS_AND_FUTURE changes. Errors are
+// logged and yield no IDs.
+func recurringEventIds(ctx context.Context, db *sql.DB, groupId string, fromOrder int) []int {
+	rows, err := db.QueryContext(ctx, `
+		SELECT event_id FROM events
+		WHERE recurrence_group_id = $1::uuid AND recurrence_order >= $2
+	`, groupId, fromOrder)
+	if err != nil {
+		log.Printf("webhooks: loading recurring events: %v", err)
+		return nil
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Printf("webhooks: scanning recurring event: %v", err)
+			return nil
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// enqueueEventWebhook queues an EVENT_CREATED or EVENT_UPDATED delivery for
+// the given events.
+func enqueueEventWebhook(ctx context.Context, db *sql.DB, eventType models.WebhookEventType, eventIds []int) {
+	if data := eventWebhookData(ctx, db, eventType, eventIds); data != nil {
+		enqueueWebhook(ctx, db, eventType, data)
+	}
+}
+
+// ============================================================================
+// Dispatcher
+// ============================================================================
+
+// RunWebhookDispatcher sends due deliveries until ctx is cancelled. Several
+// servers may run it at once: each claims its batch with SKIP LOCKED.
+func (s *WebhookService) RunWebhookDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(webhookPollInterval())
+	defer ticker.Stop()
+	for {
+		if err := s.DispatchDueWebhooks(ctx); err != nil {
+			log.Printf("Webhook dispatcher error: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// DispatchDueWebhooks sends every delivery that is due, in batches, and
+// returns once none are left.
+func (s *WebhookService) DispatchDueWebhooks(ctx context.Context) error {
+	for {
+		n, err := s.dispatchBatch(ctx)
+		if err != nil || n < webhookBatchSize {
+			return err
+		}
+	}
+}
+
+type dueWebhook struct {
+	id       int
+	url      string
+	secret   string
+	payload  []byte
+	attempts int
+}
+
+// dispatchBatch claims up to webhookBatchSize due deliveries and sends them.
+// A claim pushes next_attempt_at past webhookClaimLease, so no other server
+// sends a delivery while this one is still working through the batch, and if
+// this server dies mid-batch another picks the rest up later.
+func (s *WebhookService) dispatchBatch(ctx context.Context) (int, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + make_interval(secs => $2), attempts = d.attempts + 1
+		FROM webhook_subscriptions w
+		WHERE w.id = d.subscription_id
+		  AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		  )
+		RETURNING d.id, w.url, w.secret, d.payload::text, d.attempts
+	`, webhookBatchSize, webhookClaimLease.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("error claiming webhook deliveries: %w", err)
+	}
+
+	var due []dueWebhook
+	for rows.Next() {
+		var d dueWebhook
+		var payload string
+		if err := rows.Scan(&d.id, &d.url, &d.secret, &payload, &d.attempts); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("error scanning webhook delivery: %w", err)
+		}
+		d.payload = []byte(payload)
+		due = append(due, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error reading webhook deliveries: %w", err)
+	}
+
+	for _, d := range due {
+		statusCode, sendErr := s.send(ctx, d)
+		if err := s.recordAttempt(ctx, d, statusCode, sendErr); err != nil {
+			log.Printf("webhooks: recording delivery %d: %v", d.id, err)
+		}
+	}
+	return len(due), nil
+}
+
+// send POSTs one delivery. A non-2xx response is an error carrying the start
+// of the response body.
+func (s *WebhookService) send(ctx context.Context, d dueWebhook) (int, error) {
+	var envelope struct {
+		ID string `json:"id"`
+	}
+	_ = json.Unmarshal(d.payload, &envelope)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "volunteer-scheduler-webhooks/1")
+	req.Header.Set("X-Webhook-Id", envelope.ID)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set("X-Webhook-Signature", signWebhook(d.secret, ts, d.payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxWebhookErrorBytes))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg := "HTTP " + resp.Status
+		if body := strings.TrimSpace(string(snippet)); body != "" {
+			msg += ": " + body
+		}
+		return resp.StatusCode, errors.New(msg)
+	}
+	return resp.StatusCode, nil
+}
+
+// recordAttempt stores the outcome of one attempt: DELIVERED, PENDING again
+// after a backoff, or FAILED once attempts run out.
+func (s *WebhookService) recordAttempt(ctx context.Context, d dueWebhook, statusCode int, sendErr error) error {
+	ctx = context.WithoutCancel(ctx)
+	var code sql.NullInt64
+	if statusCode != 0 {
+		code = sql.NullInt64{Int64: int64(statusCode), Valid: true}
+	}
+
+	if sendErr == nil {
+		_, err := s.DB.ExecContext(ctx, `
+			UPDATE webhook_deliveries
+			SET status = 'DELIVERED', last_attempt_at = NOW(), delivered_at = NOW(),
+				last_status_code = $2, last_error = NULL
+			WHERE id = $1
+		`, d.id, code)
+		return err
+	}
+
+	status := models.WebhookDeliveryStatusPending
+	if d.attempts >= maxWebhookAttempts {
+		status = models.WebhookDeliveryStatusFailed
+	}
+	msg := sendErr.Error()
+	if len(msg) > maxWebhookErrorBytes {
+		msg = msg[:maxWebhookErrorBytes]
+	}
+	_, err := s.DB.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET status = $2, last_attempt_at = NOW(), last_status_code = $3, last_error = $4,
+			next_attempt_at = NOW() + make_interval(secs => $5)
+		WHERE id = $1
+	`, d.id, string(status), code, msg, webhookBackoff(d.attempts).Seconds())
+	return err
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// validateWebhookURL requires an absolute http(s) URL, and https in
+// production.
+func validateWebhookURL(raw string) (string, *models.Error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", models.NewFieldError("url", "URL must be an absolute http or https URL.")
+	}
+	if u.Scheme != "https" && os.Getenv("APP_ENV") == "production" {
+		return "", models.NewFieldError("url", "URL must use https.")
+	}
+	if u.User != nil {
+		return "", models.NewFieldError("url", "URL must not contain credentials.")
+	}
+	return raw, nil
+}
+
+func validateWebhookEventTypes(types []models.WebhookEventType) ([]string, *models.Error) {
+	if len(types) == 0 {
+		return nil, models.NewFieldError("eventTypes", "Choose at least one event type.")
+	}
+	seen := map[models.WebhookEventType]bool{}
+	var out []string
+	for _, t := range types {
+		switch t {
+		case models.WebhookEventShiftAssigned, models.WebhookEventShiftCancelled,
+			models.WebhookEventEventCreated, models.WebhookEventEventUpdated, models.WebhookEventEventDeleted,
+			models.WebhookEventEventCancelled:
+		default:
+			return nil, models.NewFieldError("eventTypes", "Unknown event type %s.", t)
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, string(t))
+		}
+	}
+	return out, nil
+}
+
+func validateWebhookDescription(description *string) (*string, *models.Error) {
+	if description == nil {
+		return nil, nil
+	}
+	d := strings.TrimSpace(*description)
+	if d == "" {
+		return nil, nil
+	}
+	if len(d) > 200 {
+		return nil, models.NewFieldError("description", "Description must be 200 characters or fewer.")
+	}
+	return &d, nil
+}
+
+const webhookSubscriptionSelect = `
+	SELECT
+		w.id,
+		w.url,
+		w.description,
+		w.event_types,
+		w.is_active,
+		w.created_by,
+		c.first_name || ' ' || c.last_name,
+		w.created_at,
+		w.updated_at
+	FROM webhook_subscriptions w
+	LEFT JOIN volunteers c ON c.volunteer_id = w.created_by
+`
+
+func (s *WebhookService) fetchWebhookSubscription(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	row := s.DB.QueryRowContext(ctx, webhookSubscriptionSelect+" WHERE w.id = $1", id)
+	return scanWebhookSubscription(row)
+}
+
+func scanWebhookSubscription(row interface{ Scan(...any) error }) (*models.WebhookSubscription, error) {
+	var sub models.WebhookSubscription
+	var id int
+	var eventTypes []string
+	var createdBy sql.NullInt64
+	var description, createdByName, updatedAt sql.NullString
+	err := row.Scan(
+		&id,
+		&sub.URL,
+		&description,
+		pq.Array(&eventTypes),
+		&sub.IsActive,
+		&createdBy,
+		&createdByName,
+		&sub.CreatedAt,
+		&updatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning webhook subscription: %w", err)
+	}
+	sub.ID = strconv.Itoa(id)
+	for _, t := range eventTypes {
+		sub.EventTypes = append(sub.EventTypes, models.WebhookEventType(t))
+	}
+	if description.Valid {
+		sub.Description = &description.String
+	}
+	if createdBy.Valid {
+		s := strconv.FormatInt(createdBy.Int64, 10)
+		sub.CreatedByID = &s
+	}
+	if createdByName.Valid {
+		sub.CreatedByName = &createdByName.String
+	}
+	if updatedAt.Valid {
+		sub.UpdatedAt = &updatedAt.String
+	}
+	return &sub, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"volunteer-scheduler/models"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  30 * time.Second,
+		2:  time.Minute,
+		5:  8 * time.Minute,
+		8:  64 * time.Minute,
+		20: 6 * time.Hour,
+	}
+	for attempts, want := range cases {
+		if got := webhookBackoff(attempts); got != want {
+			t.Errorf("webhookBackoff(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+}
+
+// TestWebhookClaimLease guards against a claim expiring while the batch is
+// still being sent, which would let a second server send it again.
+func TestWebhookClaimLease(t *testing.T) {
+	client := newWebhookClient()
+	if worst := webhookBatchSize * client.Timeout; webhookClaimLease <= worst {
+		t.Errorf("claim lease %v must outlast a batch of timed-out sends (%v)", webhookClaimLease, worst)
+	}
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	for _, raw := range []string{"https://crm.example.org/hooks/vs", " http://localhost:8080/hook "} {
+		if _, err := validateWebhookURL(raw); err != nil {
+			t.Errorf("validateWebhookURL(%q) = %v, want ok", raw, err)
+		}
+	}
+	for _, raw := range []string{"", "crm.example.org/hook", "ftp://example.org", "https://user:pw@example.org"} {
+		if _, err := validateWebhookURL(raw); err == nil || err.Code != models.ErrorCodeValidation {
+			t.Errorf("validateWebhookURL(%q) = %v, want a validation error", raw, err)
+		}
+	}
+
+	t.Setenv("APP_ENV", "production")
+	if _, err := validateWebhookURL("http://crm.example.org/hook"); err == nil {
+		t.Error("expected plain http to be refused in production")
+	}
+}
+
+func TestValidateWebhookEventTypes(t *testing.T) {
+	got, err := validateWebhookEventTypes([]models.WebhookEventType{
+		models.WebhookEventShiftAssigned, models.WebhookEventEventDeleted, models.WebhookEventShiftAssigned,
+	})
+	if err != nil || len(got) != 2 {
+		t.Errorf("got %v, %v; want the two distinct types", got, err)
+	}
+	if _, err := validateWebhookEventTypes(nil); err == nil {
+		t.Error("expected an empty list to be refused")
+	}
+	if _, err := validateWebhookEventTypes([]models.WebhookEventType{"SHIFT_SWAPPED"}); err == nil {
+		t.Error("expected an unknown type to be refused")
+	}
+}
@@ -0,0 +1,139 @@
+package services
+
+// pagination.go
+//
+// Cursor (keyset) pagination for the admin connection fields. A page is read
+// with ORDER BY <sort key>, <id> LIMIT first+1 and the next one continues from
+// the last row's (sort key, id) pair, so paging stays cheap however deep the
+// client goes and rows inserted meanwhile don't shift the pages.
+//
+// Cursors are opaque to clients: base64 of the row's sort key and id. A cursor
+// is only meaningful with the sort order it was issued for.
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"volunteer-scheduler/models"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
+// pageCursor is the position of one row: its sort key (as text) and id.
+type pageCursor struct {
+	Key string `json:"k"`
+	ID  int    `json:"id"`
+}
+
+func encodeCursor(key string, id int) string {
+	b, _ := json.Marshal(pageCursor{Key: key, ID: id})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (*pageCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, models.NewFieldError("after", "invalid cursor")
+	}
+	var c pageCursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, models.NewFieldError("after", "invalid cursor")
+	}
+	return &c, nil
+}
+
+// keysetOrder is one sort option. expr must never be NULL (wrap nullable
+// columns in COALESCE) and has SQL type sqlType, which cursor keys are cast
+// back to; idExpr breaks ties and must be unique.
+type keysetOrder struct {
+	expr    string
+	sqlType string
+	idExpr  string
+	desc    bool
+}
+
+// withDirection returns the order sorted the given way; nil keeps the
+// option's default.
+func (k keysetOrder) withDirection(dir *models.SortDirection) keysetOrder {
+	if dir != nil {
+		k.desc = *dir == models.SortDirectionDesc
+	}
+	return k
+}
+
+// selectKey is the select-list expression that yields a row's cursor key.
+func (k keysetOrder) selectKey() string {
+	return "(" + k.expr + ")::text"
+}
+
+// orderBy is the ORDER BY clause (without the keywords).
+func (k keysetOrder) orderBy() string {
+	dir := "ASC"
+	if k.desc {
+		dir = "DESC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", k.expr, dir, k.idExpr, dir)
+}
+
+// after returns the condition selecting rows past c, appending its
+// arguments to args.
+func (k keysetOrder) after(c *pageCursor, args *[]any) string {
+	op := ">"
+	if k.desc {
+		op = "<"
+	}
+	*args = append(*args, c.Key, c.ID)
+	return fmt.Sprintf("(%s, %s) %s ($%d::%s, $%d)",
+		k.expr, k.idExpr, op, len(*args)-1, k.sqlType, len(*args))
+}
+
+// pageRequest is a validated models.PageInput.
+type pageRequest struct {
+	limit  int
+	cursor *pageCursor
+}
+
+func newPageRequest(page *models.PageInput) (pageRequest, error) {
+	req := pageRequest{limit: defaultPageSize}
+	if page == nil {
+		return req, nil
+	}
+	if page.First != nil {
+		if *page.First < 0 || *page.First > maxPageSize {
+			return req, models.NewFieldError("first", "first must be between 0 and %d", maxPageSize)
+		}
+		req.limit = *page.First
+	}
+	if page.After != nil && *page.After != "" {
+		c, err := decodeCursor(*page.After)
+		if err != nil {
+			return req, err
+		}
+		req.cursor = c
+	}
+	return req, nil
+}
+
+// pageInfo trims the extra row a page query reads (LIMIT limit+1) and
+// describes the page. keys and ids are the cursor parts of the rows read.
+func (req pageRequest) pageInfo(keys []string, ids []int) (models.PageInfo, []string) {
+	info := models.PageInfo{HasPreviousPage: req.cursor != nil}
+	n := len(ids)
+	if n > req.limit {
+		info.HasNextPage = true
+		n = req.limit
+	}
+	cursors := make([]string, n)
+	for i := 0; i < n; i++ {
+		cursors[i] = encodeCursor(keys[i], ids[i])
+	}
+	if n > 0 {
+		info.StartCursor = &cursors[0]
+		info.EndCursor = &cursors[n-1]
+	}
+	return info, cursors
+}
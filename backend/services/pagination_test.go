@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"volunteer-scheduler/models"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	s := encodeCursor("2026-03-01 09:00:00", 42)
+	c, err := decodeCursor(s)
+	if err != nil {
+		t.Fatalf("decodeCursor: %v", err)
+	}
+	if c.Key != "2026-03-01 09:00:00" || c.ID != 42 {
+		t.Errorf("cursor = %+v", c)
+	}
+	if _, err := decodeCursor("not a cursor!"); err == nil {
+		t.Error("expected an error for a malformed cursor")
+	}
+}
+
+func TestNewPageRequest(t *testing.T) {
+	req, err := newPageRequest(nil)
+	if err != nil || req.limit != defaultPageSize || req.cursor != nil {
+		t.Errorf("nil page = %+v, %v", req, err)
+	}
+
+	for _, first := range []int{-1, maxPageSize + 1} {
+		if _, err := newPageRequest(&models.PageInput{First: &first}); err == nil {
+			t.Errorf("first = %d: expected an error", first)
+		}
+	}
+
+	bad := "%%%"
+	if _, err := newPageRequest(&models.PageInput{After: &bad}); err == nil {
+		t.Error("expected an error for a malformed after cursor")
+	}
+}
+
+func TestPageInfo(t *testing.T) {
+	req := pageRequest{limit: 2}
+	info, cursors := req.pageInfo([]string{"a", "b", "c"}, []int{1, 2, 3})
+	if !info.HasNextPage || info.HasPreviousPage || len(cursors) != 2 {
+		t.Fatalf("info = %+v, %d cursors", info, len(cursors))
+	}
+	if *info.EndCursor != encodeCursor("b", 2) {
+		t.Errorf("end cursor is not the last row on the page")
+	}
+
+	req.cursor = &pageCursor{Key: "b", ID: 2}
+	info, cursors = req.pageInfo([]string{"c"}, []int{3})
+	if info.HasNextPage || !info.HasPreviousPage || len(cursors) != 1 {
+		t.Errorf("last page info = %+v", info)
+	}
+
+	info, _ = req.pageInfo(nil, nil)
+	if info.StartCursor != nil || info.EndCursor != nil {
+		t.Errorf("empty page has cursors: %+v", info)
+	}
+}
+
+func TestKeysetOrderAfter(t *testing.T) {
+	order := feedbackSortOrders[models.FeedbackSortCreatedAt]
+	args := []any{"OPEN"}
+	got := order.after(&pageCursor{Key: "k", ID: 7}, &args)
+	if want := "(f.created_at, f.feedback_id) < ($2::timestamp, $3)"; got != want {
+		t.Errorf("after = %q, want %q", got, want)
+	}
+	if len(args) != 3 || args[1] != "k" || args[2] != 7 {
+		t.Errorf("args = %v", args)
+	}
+
+	asc := models.SortDirectionAsc
+	if got := order.withDirection(&asc).orderBy(); got != "f.created_at ASC, f.feedback_id ASC" {
+		t.Errorf("orderBy = %q", got)
+	}
+}
@@ -0,0 +1,422 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
+)
+
+// dataExportVersion is bumped whenever the shape of the export changes, so
+// tools reading older exports can tell them apart.
+const dataExportVersion = 1
+
+// Placeholders written over a volunteer's personal data on erasure. The
+// email keeps the column unique and uses the reserved .invalid TLD, so
+// nothing can ever be delivered to it.
+const (
+	erasedFirstName = "Erased"
+	erasedLastName  = "Volunteer"
+	erasedText      = "[erased]"
+)
+
+func erasedEmail(volId int) string {
+	return fmt.Sprintf("erased-%d@erased.invalid", volId)
+}
+
+// PrivacyService answers volunteers' questions about the data we hold on
+// them: a machine-readable export of it, and requests to be forgotten, which
+// an admin approves or denies.
+type PrivacyService struct {
+	DB     *sql.DB
+	mailer *Mailer
+}
+
+func NewPrivacyService(db *sql.DB, mailer *Mailer) *PrivacyService {
+	return &PrivacyService{DB: db, mailer: mailer}
+}
+
+// dataExportSections build the export, one JSON value per top-level key.
+// Each takes the volunteer's ID as $1. Secrets (session and token hashes)
+// are left out.
+var dataExportSections = []struct {
+	key   string
+	query string
+}{
+	{"profile", `
+		SELECT jsonb_build_object(
+			'id', v.volunteer_id,
+			'firstName', v.first_name,
+			'lastName', v.last_name,
+			'email', v.email,
+			'phone', v.phone,
+			'zipCode', v.zip_code,
+			'latitude', v.latitude,
+			'longitude', v.longitude,
+			'distance', v.default_distance_miles,
+			'isActive', v.is_active,
+			'createdAt', v.created_at,
+			'lastLoginAt', v.last_login_at,
+			'roles', ARRAY(
+				SELECT r.role_name FROM volunteer_roles vr JOIN roles r ON r.role_id = vr.role_id
+				WHERE vr.volunteer_id = v.volunteer_id ORDER BY 1),
+			'fundingEntities', ARRAY(
+				SELECT f.name FROM coordinator_funding_entities c
+				JOIN funding_entities f ON f.id = c.funding_entity_id
+				WHERE c.volunteer_id = v.volunteer_id ORDER BY 1),
+			'emailSuppression', (
+				SELECT jsonb_build_object('reason', es.reason, 'detail', es.detail, 'createdAt', es.created_at)
+				FROM email_suppressions es WHERE LOWER(es.email) = LOWER(v.email)))
+		FROM volunteers v WHERE v.volunteer_id = $1`},
+	{"shifts", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'shiftId', vs.shift_id,
+			'eventId', e.event_id,
+			'eventName', e.event_name,
+			'jobName', jt.name,
+			'start', s.shift_start,
+			'end', s.shift_end,
+			'timezone', e.timezone,
+			'assignedAt', vs.assigned_at,
+			'cancelledAt', vs.cancelled_at,
+			'reminderSentAt', vs.reminder_sent_at) ORDER BY s.shift_start), '[]')
+		FROM volunteer_shifts vs
+		JOIN shifts s ON s.shift_id = vs.shift_id
+		JOIN opportunities o ON o.opportunity_id = s.opportunity_id
+		LEFT JOIN job_types jt ON jt.job_type_id = o.job_type_id
+		JOIN events e ON e.event_id = o.event_id
+		WHERE vs.volunteer_id = $1`},
+	{"feedback", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'id', f.feedback_id,
+			'type', f.feedback_type,
+			'status', f.status,
+			'subject', f.subject,
+			'appPageName', f.app_page_name,
+			'text', f.text,
+			'createdAt', f.created_at,
+			'lastUpdatedAt', f.last_updated_at,
+			'resolvedAt', f.resolved_at,
+			'notes', (
+				SELECT COALESCE(jsonb_agg(jsonb_build_object(
+					'id', n.note_id, 'type', n.note_type, 'note', n.note, 'createdAt', n.created_at)
+					ORDER BY n.created_at), '[]')
+				FROM feedback_notes n
+				WHERE n.feedback_id = f.feedback_id AND n.note_type != 'ADMIN_NOTE'),
+			'attachments', (
+				SELECT COALESCE(jsonb_agg(jsonb_build_object(
+					'id', a.attachment_id,
+					'filename', a.filename,
+					'mimeType', a.mime_type,
+					'size', a.file_size,
+					'createdAt', a.created_at,
+					'data', replace(encode(a.file_data, 'base64'), E'\n', ''))
+					ORDER BY a.created_at), '[]')
+				FROM feedback_attachments a WHERE a.feedback_id = f.feedback_id)
+			) ORDER BY f.created_at), '[]')
+		FROM feedback f WHERE f.volunteer_id = $1`},
+	{"notesWritten", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'id', n.note_id,
+			'feedbackId', n.feedback_id,
+			'type', n.note_type,
+			'note', n.note,
+			'createdAt', n.created_at) ORDER BY n.created_at), '[]')
+		FROM feedback_notes n WHERE n.volunteer_id = $1`},
+	{"sessions", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'createdAt', s.created_at,
+			'lastActivityAt', s.last_activity_at,
+			'expiresAt', s.expires_at,
+			'ipAddress', s.ip_address,
+			'userAgent', s.user_agent) ORDER BY s.created_at), '[]')
+		FROM sessions s WHERE s.volunteer_id = $1`},
+	{"impersonations", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'startedAt', i.started_at,
+			'endedAt', i.ended_at,
+			'reason', i.reason) ORDER BY i.started_at), '[]')
+		FROM impersonation_sessions i WHERE i.volunteer_id = $1`},
+	{"erasureRequests", `
+		SELECT COALESCE(jsonb_agg(jsonb_build_object(
+			'status', r.status,
+			'reason', r.reason,
+			'createdAt', r.created_at,
+			'decidedAt', r.decided_at,
+			'decisionMessage', r.decision_message) ORDER BY r.created_at), '[]')
+		FROM erasure_requests r WHERE r.volunteer_id = $1`},
+}
+
+// ExportVolunteerData returns everything we hold about a volunteer as one
+// JSON document: profile, shift history (cancelled signups included),
+// feedback with its notes and attachments (base64), notes they wrote,
+// sessions, impersonations of their account and erasure requests. Notes
+// admins keep among themselves are left out, as in ownFeedback.
+func (s *PrivacyService) ExportVolunteerData(ctx context.Context, volId int) ([]byte, error) {
+	export := map[string]any{
+		"version":    dataExportVersion,
+		"exportedAt": time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, section := range dataExportSections {
+		var raw []byte
+		err := s.DB.QueryRowContext(ctx, section.query, volId).Scan(&raw)
+		if err == sql.ErrNoRows {
+			return nil, models.NewError(models.ErrorCodeNotFound, "volunteer not found")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error exporting %s for volunteer %d: %w", section.key, volId, err)
+		}
+		export[section.key] = json.RawMessage(raw)
+	}
+	return json.MarshalIndent(export, "", "  ")
+}
+
+// Volunteer side.
+
+// RequestErasure asks for the volunteer's personal data to be erased and
+// notifies the admins. Asking again while a request is open changes nothing.
+func (s *PrivacyService) RequestErasure(ctx context.Context, volId int, reason *string) (*models.VolunteerMutationResult, error) {
+	var why string
+	if reason != nil {
+		why = strings.TrimSpace(*reason)
+	}
+
+	var id int
+	err := s.DB.QueryRowContext(ctx, `
+		INSERT INTO erasure_requests (volunteer_id, reason)
+		VALUES ($1, NULLIF($2, ''))
+		ON CONFLICT (volunteer_id) WHERE status = 'PENDING' DO NOTHING
+		RETURNING id
+	`, volId, why).Scan(&id)
+	if err == sql.ErrNoRows {
+		return &models.VolunteerMutationResult{
+			Success: true,
+			Message: ptrString("Your erasure request is already waiting for an administrator."),
+		}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error storing erasure request: %w", err)
+	}
+
+	var firstName, lastName string
+	err = s.DB.QueryRowContext(ctx,
+		"SELECT first_name, last_name FROM volunteers WHERE volunteer_id = $1", volId).Scan(&firstName, &lastName)
+	if err != nil {
+		log.Printf("Warning: erasure request %d stored but volunteer %d could not be read: %v", id, volId, err)
+	} else if adminEmails, err := fetchAdminEmails(ctx, s.DB); err != nil {
+		log.Printf("Warning: erasure request %d stored but admins could not be notified: %v", id, err)
+	} else {
+		sendErasureRequested(ctx, s.mailer, adminEmails, firstName, lastName, volId, why)
+	}
+
+	return &models.VolunteerMutationResult{
+		Success: true,
+		Message: ptrString("Your erasure request has been sent to an administrator."),
+	}, nil
+}
+
+// Queries.
+
+// FetchErasureRequests returns requests with the given status: pending ones
+// oldest first, decided ones most recently decided first.
+func (s *PrivacyService) FetchErasureRequests(ctx context.Context, status models.ErasureRequestStatus) ([]*models.ErasureRequest, error) {
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT
+			r.id,
+			r.volunteer_id,
+			v.first_name || ' ' || v.last_name,
+			r.reason,
+			r.status,
+			r.created_at,
+			r.decided_by,
+			d.first_name || ' ' || d.last_name,
+			r.decided_at,
+			r.decision_message
+		FROM erasure_requests r
+		JOIN volunteers v ON v.volunteer_id = r.volunteer_id
+		LEFT JOIN volunteers d ON d.volunteer_id = r.decided_by
+		WHERE r.status = $1
+		ORDER BY r.decided_at DESC NULLS LAST, r.created_at
+	`, string(status))
+	if err != nil {
+		return nil, fmt.Errorf("error querying erasure requests: %w", err)
+	}
+	defer rows.Close()
+
+	requests := []*models.ErasureRequest{}
+	for rows.Next() {
+		var r models.ErasureRequest
+		var id, volId int
+		var decidedBy sql.NullInt64
+		var reason, decidedByName, decidedAt, message sql.NullString
+		if err := rows.Scan(&id, &volId, &r.VolunteerName, &reason, &r.Status, &r.CreatedAt,
+			&decidedBy, &decidedByName, &decidedAt, &message); err != nil {
+			return nil, fmt.Errorf("error scanning erasure request: %w", err)
+		}
+		r.ID = strconv.Itoa(id)
+		r.VolunteerID = strconv.Itoa(volId)
+		r.Reason = nullStringPtr(reason)
+		r.DecidedByID = nullIntString(decidedBy)
+		r.DecidedByName = nullStringPtr(decidedByName)
+		r.DecidedAt = nullStringPtr(decidedAt)
+		r.DecisionMessage = nullStringPtr(message)
+		requests = append(requests, &r)
+	}
+	return requests, rows.Err()
+}
+
+// Mutations.
+
+// ApproveErasureRequest anonymizes the volunteer in one transaction. The
+// volunteer row is kept so their shift history still counts in reports and
+// the ON DELETE RESTRICT links from feedback and feedback_notes hold:
+//   - name, email, phone, location and distance are overwritten and the
+//     account is deactivated and marked erased;
+//   - their roles, sessions, API tokens, sign-in links, email suppression
+//     and account requests are deleted;
+//   - their feedback keeps its type, status and dates, but its subject,
+//     text and page, every note on it and its attachments are redacted;
+//   - notes they wrote on other feedback keep their text, now attributed to
+//     the erased volunteer;
+//   - outbound webhook payloads about their shifts keep only their ID.
+//
+// The volunteer is sent a last email confirming the erasure. An admin cannot
+// approve their own request.
+func (s *PrivacyService) ApproveErasureRequest(ctx context.Context, adminId int, requestId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(requestId)
+	if err != nil {
+		return nil, models.InvalidID("requestId")
+	}
+
+	tx, err := s.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error starting erasure: %w", err)
+	}
+	defer tx.Rollback()
+
+	var volId int
+	err = tx.QueryRowContext(ctx, `
+		UPDATE erasure_requests
+		SET status = 'APPROVED', decided_by = $2, decided_at = NOW()
+		WHERE id = $1 AND status = 'PENDING' AND volunteer_id != $2
+		RETURNING volunteer_id
+	`, idInt, adminId).Scan(&volId)
+	if err == sql.ErrNoRows {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Erasure request not found, already decided, or your own.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error approving erasure request: %w", err)
+	}
+
+	var firstName, email string
+	err = tx.QueryRowContext(ctx,
+		"SELECT first_name, email FROM volunteers WHERE volunteer_id = $1 FOR UPDATE",
+		volId).Scan(&firstName, &email)
+	if err != nil {
+		return nil, fmt.Errorf("error reading volunteer %d for erasure: %w", volId, err)
+	}
+
+	steps := []struct {
+		what  string
+		query string
+		args  []any
+	}{
+		{"sign-in links", "DELETE FROM magic_links WHERE LOWER(email) = LOWER($1)", []any{email}},
+		{"email suppression", "DELETE FROM email_suppressions WHERE LOWER(email) = LOWER($1)", []any{email}},
+		{"account requests", "DELETE FROM account_requests WHERE LOWER(email) = LOWER($1) OR volunteer_id = $2", []any{email, volId}},
+		{"sessions", "DELETE FROM sessions WHERE volunteer_id = $1", []any{volId}},
+		{"API tokens", "DELETE FROM api_tokens WHERE owner_id = $1", []any{volId}},
+		{"roles", "DELETE FROM volunteer_roles WHERE volunteer_id = $1", []any{volId}},
+		{"funding entities", "DELETE FROM coordinator_funding_entities WHERE volunteer_id = $1", []any{volId}},
+		{"impersonations", `
+			UPDATE impersonation_sessions SET ended_at = NOW()
+			WHERE volunteer_id = $1 AND ended_at IS NULL`, []any{volId}},
+		{"attachments", `
+			DELETE FROM feedback_attachments
+			WHERE feedback_id IN (SELECT feedback_id FROM feedback WHERE volunteer_id = $1)`, []any{volId}},
+		{"feedback notes", `
+			UPDATE feedback_notes SET note = $2
+			WHERE feedback_id IN (SELECT feedback_id FROM feedback WHERE volunteer_id = $1)`, []any{volId, erasedText}},
+		{"feedback", `
+			UPDATE feedback SET subject = $2, text = $2, app_page_name = ''
+			WHERE volunteer_id = $1`, []any{volId, erasedText}},
+		{"webhook deliveries", `
+			UPDATE webhook_deliveries
+			SET payload = jsonb_set(payload, '{data,volunteer}', jsonb_build_object('id', $1::text))
+			WHERE payload->'data'->'volunteer'->>'id' = $1::text`, []any{strconv.Itoa(volId)}},
+		// The only sanctioned change to audit_log (see migration 000025).
+		{"audit log", "SELECT redact_erased_volunteer_audit($1, $2, $3)",
+			[]any{volId, erasedFirstName + " " + erasedLastName, pq.Array(auditRedactedFields["Volunteer"])}},
+		{"profile", `
+			UPDATE volunteers SET
+				first_name = $2, last_name = $3, email = $4,
+				phone = NULL, zip_code = NULL, latitude = NULL, longitude = NULL,
+				default_distance_miles = NULL, last_login_at = NULL,
+				is_active = FALSE, erased_at = NOW()
+			WHERE volunteer_id = $1`, []any{volId, erasedFirstName, erasedLastName, erasedEmail(volId)}},
+	}
+	for _, step := range steps {
+		if _, err := tx.ExecContext(ctx, step.query, step.args...); err != nil {
+			return nil, fmt.Errorf("error erasing %s of volunteer %d: %w", step.what, volId, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("error committing erasure of volunteer %d: %w", volId, err)
+	}
+
+	if err := sendErasureDecision(ctx, s.mailer, firstName, email, true, ""); err != nil {
+		log.Printf("Warning: volunteer %d erased but the confirmation email failed: %v", volId, err)
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Volunteer's personal data erased."),
+		ID:      &requestId,
+	}, nil
+}
+
+// DenyErasureRequest closes a pending request and tells the volunteer why,
+// with message if one is given.
+func (s *PrivacyService) DenyErasureRequest(ctx context.Context, adminId int, requestId string, message *string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(requestId)
+	if err != nil {
+		return nil, models.InvalidID("requestId")
+	}
+	var msg string
+	if message != nil {
+		msg = strings.TrimSpace(*message)
+	}
+
+	var firstName, email string
+	err = s.DB.QueryRowContext(ctx, `
+		UPDATE erasure_requests r
+		SET status = 'DENIED', decided_by = $2, decided_at = NOW(), decision_message = NULLIF($3, '')
+		FROM volunteers v
+		WHERE r.id = $1 AND r.status = 'PENDING' AND v.volunteer_id = r.volunteer_id
+		RETURNING v.first_name, v.email
+	`, idInt, adminId, msg).Scan(&firstName, &email)
+	if err == sql.ErrNoRows {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Erasure request not found or already decided.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error denying erasure request: %w", err)
+	}
+
+	if err := sendErasureDecision(ctx, s.mailer, firstName, email, false, msg); err != nil {
+		log.Printf("Warning: failed to send erasure denial to %s: %v", email, err)
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Erasure request denied."),
+		ID:      &requestId,
+	}, nil
+}
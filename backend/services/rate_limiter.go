@@ -0,0 +1,56 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+)
+
+// RateLimiter counts requests per key in fixed time windows. Counters live in
+// Postgres so a limit holds across every replica of the server.
+type RateLimiter struct {
+	DB *sql.DB
+}
+
+func NewRateLimiter(db *sql.DB) *RateLimiter {
+	return &RateLimiter{DB: db}
+}
+
+// Allow records a hit for key and reports whether it is within limit for the
+// current window. When it is not, the returned duration is how long until
+// the window resets.
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, time.Duration, error) {
+	now := time.Now().UTC()
+	windowStart := now.Truncate(window)
+	windowEnd := windowStart.Add(window)
+
+	query := `
+		INSERT INTO rate_limits (key, window_start, window_end, hits)
+		VALUES ($1, $2, $3, 1)
+		ON CONFLICT (key, window_start) DO UPDATE SET hits = rate_limits.hits + 1
+		RETURNING hits
+	`
+	var hits int
+	if err := l.DB.QueryRowContext(ctx, query, key, windowStart, windowEnd).Scan(&hits); err != nil {
+		return true, 0, fmt.Errorf("error recording rate limit hit: %w", err)
+	}
+
+	if hits > limit {
+		return false, windowEnd.Sub(now), nil
+	}
+	return true, 0, nil
+}
+
+// Cleanup removes counters for windows that have already ended.
+func (l *RateLimiter) Cleanup(ctx context.Context) error {
+	result, err := l.DB.ExecContext(ctx, "DELETE FROM rate_limits WHERE window_end < NOW()")
+	if err != nil {
+		return fmt.Errorf("error deleting expired rate limits: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected > 0 {
+		log.Printf("Cleaned up %d expired rate limit windows", rowsAffected)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrOutOfScope is returned when a coordinator touches a record that belongs
+// to a funding entity they do not manage. Records that do not exist are
+// reported the same way, so coordinators cannot probe other regions.
+var ErrOutOfScope error = models.NewError(models.ErrorCodeForbidden, "forbidden: outside your assigned regions")
+
+// ScopeService answers "which funding entities may this caller manage?" and
+// checks individual records against that answer.
+type ScopeService struct {
+	DB *sql.DB
+}
+
+func NewScopeService(db *sql.DB) *ScopeService {
+	return &ScopeService{DB: db}
+}
+
+// FundingScope is the set of funding entities a caller may manage.
+// Administrators manage all of them.
+type FundingScope struct {
+	All bool
+	IDs []int
+}
+
+// Allows reports whether the scope covers the given funding entity.
+func (fs FundingScope) Allows(fundingEntityId int) bool {
+	if fs.All {
+		return true
+	}
+	for _, id := range fs.IDs {
+		if id == fundingEntityId {
+			return true
+		}
+	}
+	return false
+}
+
+// FundingScopeFor returns the caller's scope. ADMINISTRATOR wins over
+// COORDINATOR; anybody else gets an empty scope.
+func (s *ScopeService) FundingScopeFor(ctx context.Context, volId int, roles []string) (FundingScope, error) {
+	if hasRoleName(roles, models.RoleAdministrator) {
+		return FundingScope{All: true}, nil
+	}
+	if !hasRoleName(roles, models.RoleCoordinator) {
+		return FundingScope{}, nil
+	}
+
+	rows, err := s.DB.QueryContext(ctx, `
+		SELECT funding_entity_id
+		FROM coordinator_funding_entities
+		WHERE volunteer_id = $1
+		ORDER BY funding_entity_id
+	`, volId)
+	if err != nil {
+		return FundingScope{}, fmt.Errorf("error querying coordinator scope: %w", err)
+	}
+	defer rows.Close()
+
+	var scope FundingScope
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return FundingScope{}, fmt.Errorf("error scanning coordinator scope: %w", err)
+		}
+		scope.IDs = append(scope.IDs, id)
+	}
+	return scope, rows.Err()
+}
+
+// CheckFundingEntity returns ErrOutOfScope unless the scope covers the entity.
+func (s *ScopeService) CheckFundingEntity(scope FundingScope, fundingEntityId int) error {
+	if !scope.Allows(fundingEntityId) {
+		return ErrOutOfScope
+	}
+	return nil
+}
+
+// CheckEvent checks the funding entity of an event.
+func (s *ScopeService) CheckEvent(ctx context.Context, scope FundingScope, eventId string) error {
+	return s.checkRecord(ctx, scope, `
+		SELECT e.funding_entity_id
+		FROM events e
+		WHERE e.event_id = $1
+	`, eventId)
+}
+
+// CheckEventDate checks the funding entity of the event a date belongs to.
+func (s *ScopeService) CheckEventDate(ctx context.Context, scope FundingScope, eventDateId string) error {
+	return s.checkRecord(ctx, scope, `
+		SELECT e.funding_entity_id
+		FROM event_dates ed
+		JOIN events e ON e.event_id = ed.event_id
+		WHERE ed.event_date_id = $1
+	`, eventDateId)
+}
+
+// CheckOpportunity checks the funding entity of the event an opportunity belongs to.
+func (s *ScopeService) CheckOpportunity(ctx context.Context, scope FundingScope, oppId string) error {
+	return s.checkRecord(ctx, scope, `
+		SELECT e.funding_entity_id
+		FROM opportunities o
+		JOIN events e ON e.event_id = o.event_id
+		WHERE o.opportunity_id = $1
+	`, oppId)
+}
+
+// CheckShift checks the funding entity of the event a shift belongs to.
+func (s *ScopeService) CheckShift(ctx context.Context, scope FundingScope, shiftId string) error {
+	return s.checkRecord(ctx, scope, `
+		SELECT e.funding_entity_id
+		FROM shifts sh
+		JOIN opportunities o ON o.opportunity_id = sh.opportunity_id
+		JOIN events e        ON e.event_id = o.event_id
+		WHERE sh.shift_id = $1
+	`, shiftId)
+}
+
+// CheckVolunteer allows a coordinator to see a volunteer who has signed up
+// for at least one shift in their regions.
+func (s *ScopeService) CheckVolunteer(ctx context.Context, scope FundingScope, volunteerId string) error {
+	if scope.All {
+		return nil
+	}
+	volInt, err := strconv.Atoi(volunteerId)
+	if err != nil {
+		return ErrOutOfScope
+	}
+	args := []any{volInt}
+	var rostered bool
+	err = s.DB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM volunteers v WHERE v.volunteer_id = $1 AND `+
+		volunteerScopeCondition(scope, &args)+`)`, args...).Scan(&rostered)
+	if err != nil {
+		return fmt.Errorf("error checking scope: %w", err)
+	}
+	if !rostered {
+		return ErrOutOfScope
+	}
+	return nil
+}
+
+// eventScopeCondition returns a condition on events e limiting them to the
+// scope's funding entities, adding its argument to args, or "" when the scope
+// covers everything.
+func eventScopeCondition(scope FundingScope, args *[]any) string {
+	if scope.All {
+		return ""
+	}
+	*args = append(*args, pq.Array(scope.IDs))
+	return fmt.Sprintf("e.funding_entity_id = ANY($%d)", len(*args))
+}
+
+// volunteerScopeCondition returns a condition on volunteers v limiting them to
+// those who have signed up for a shift in one of the scope's funding
+// entities, adding its argument to args, or "" when the scope covers
+// everything.
+func volunteerScopeCondition(scope FundingScope, args *[]any) string {
+	if scope.All {
+		return ""
+	}
+	*args = append(*args, pq.Array(scope.IDs))
+	return fmt.Sprintf(`v.volunteer_id IN (
+		SELECT vs.volunteer_id
+		FROM volunteer_shifts vs
+		JOIN shifts sh       ON sh.shift_id = vs.shift_id
+		JOIN opportunities o ON o.opportunity_id = sh.opportunity_id
+		JOIN events e        ON e.event_id = o.event_id
+		WHERE e.funding_entity_id = ANY($%d))`, len(*args))
+}
+
+// checkRecord runs a query returning one record's funding_entity_id and
+// checks it against the scope.
+func (s *ScopeService) checkRecord(ctx context.Context, scope FundingScope, query string, id string) error {
+	if scope.All {
+		return nil
+	}
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return ErrOutOfScope
+	}
+	var fundingEntityId int
+	err = s.DB.QueryRowContext(ctx, query, idInt).Scan(&fundingEntityId)
+	if err == sql.ErrNoRows {
+		return ErrOutOfScope
+	}
+	if err != nil {
+		return fmt.Errorf("error checking scope: %w", err)
+	}
+	return s.CheckFundingEntity(scope, fundingEntityId)
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"os"
+	"strconv"
+	"time"
+	"volunteer-scheduler/models"
+)
+
+// SessionPolicy bounds how long a session lives.
+//
+// MaxAge is the sliding lifetime: a session used when less than half of it
+// remains is extended to a full MaxAge again (and its cookie refreshed), so
+// active volunteers are not signed out mid-task. IdleTimeout expires a
+// session that has not been used for that long, whatever its expiry.
+// AbsoluteMaxAge caps renewal: however active, a session ends that long
+// after sign-in.
+type SessionPolicy struct {
+	MaxAge         time.Duration
+	IdleTimeout    time.Duration
+	AbsoluteMaxAge time.Duration
+}
+
+// sessionPolicyFor returns the policy for a session held by someone with
+// roles. Admin and coordinator sessions use the stricter ADMIN_SESSION_*
+// limits and ignore remember-device, since an unattended browser with either
+// role can change events for everybody.
+//
+// Environment (all in seconds):
+//
+//	SESSION_MAX_AGE                    default 28800 (8 hours)
+//	SESSION_IDLE_TIMEOUT               default 7200 (2 hours)
+//	SESSION_ABSOLUTE_MAX_AGE           default 86400 (24 hours)
+//	SESSION_REMEMBER_MAX_AGE           default 7776000 (90 days)
+//	SESSION_REMEMBER_IDLE_TIMEOUT      default 2592000 (30 days)
+//	SESSION_REMEMBER_ABSOLUTE_MAX_AGE  default 31536000 (365 days)
+//	ADMIN_SESSION_MAX_AGE              default 28800 (8 hours)
+//	ADMIN_SESSION_IDLE_TIMEOUT         default 3600 (1 hour)
+//	ADMIN_SESSION_ABSOLUTE_MAX_AGE     default 43200 (12 hours)
+func sessionPolicyFor(roles []string, remember bool) SessionPolicy {
+	var p SessionPolicy
+	switch {
+	case hasRoleName(roles, models.RoleAdministrator), hasRoleName(roles, models.RoleCoordinator):
+		p = SessionPolicy{
+			MaxAge:         envSeconds("ADMIN_SESSION_MAX_AGE", 8*time.Hour),
+			IdleTimeout:    envSeconds("ADMIN_SESSION_IDLE_TIMEOUT", time.Hour),
+			AbsoluteMaxAge: envSeconds("ADMIN_SESSION_ABSOLUTE_MAX_AGE", 12*time.Hour),
+		}
+	case remember:
+		p = SessionPolicy{
+			MaxAge:         envSeconds("SESSION_REMEMBER_MAX_AGE", 90*24*time.Hour),
+			IdleTimeout:    envSeconds("SESSION_REMEMBER_IDLE_TIMEOUT", 30*24*time.Hour),
+			AbsoluteMaxAge: envSeconds("SESSION_REMEMBER_ABSOLUTE_MAX_AGE", 365*24*time.Hour),
+		}
+	default:
+		p = SessionPolicy{
+			MaxAge:         envSeconds("SESSION_MAX_AGE", 8*time.Hour),
+			IdleTimeout:    envSeconds("SESSION_IDLE_TIMEOUT", 2*time.Hour),
+			AbsoluteMaxAge: envSeconds("SESSION_ABSOLUTE_MAX_AGE", 24*time.Hour),
+		}
+	}
+	// Neither a sliding lifetime nor an idle spell can outlast the session.
+	if p.MaxAge > p.AbsoluteMaxAge {
+		p.MaxAge = p.AbsoluteMaxAge
+	}
+	if p.IdleTimeout > p.MaxAge {
+		p.IdleTimeout = p.MaxAge
+	}
+	return p
+}
+
+// shouldRenew reports whether a session created at createdAt and expiring
+// at expiresAt is past the half-way point of its lifetime and can still be
+// extended before its absolute deadline.
+func (p SessionPolicy) shouldRenew(createdAt, expiresAt, now time.Time) bool {
+	return expiresAt.Sub(now) < p.MaxAge/2 && expiresAt.Before(createdAt.Add(p.AbsoluteMaxAge))
+}
+
+// renewedExpiry is the expiry a session created at createdAt gets when it is
+// renewed (or issued) at now: a full MaxAge, but no later than its absolute
+// deadline.
+func (p SessionPolicy) renewedExpiry(createdAt, now time.Time) time.Time {
+	expiry := now.Add(p.MaxAge)
+	if deadline := createdAt.Add(p.AbsoluteMaxAge); expiry.After(deadline) {
+		return deadline
+	}
+	return expiry
+}
+
+// envSeconds reads a positive number of seconds from key, or returns
+// fallback.
+func envSeconds(key string, fallback time.Duration) time.Duration {
+	if val, err := strconv.Atoi(os.Getenv(key)); err == nil && val > 0 {
+		return time.Duration(val) * time.Second
+	}
+	return fallback
+}
+
+func hasRoleName(roles []string, role models.Role) bool {
+	for _, r := range roles {
+		if r == string(role) {
+			return true
+		}
+	}
+	return false
+}
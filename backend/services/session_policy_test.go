@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionPolicyFor(t *testing.T) {
+	t.Setenv("SESSION_MAX_AGE", "3600")
+	t.Setenv("SESSION_IDLE_TIMEOUT", "7200") // longer than MaxAge: clamped
+	t.Setenv("SESSION_ABSOLUTE_MAX_AGE", "86400")
+	t.Setenv("SESSION_REMEMBER_MAX_AGE", "86400")
+	t.Setenv("SESSION_REMEMBER_IDLE_TIMEOUT", "43200")
+	t.Setenv("SESSION_REMEMBER_ABSOLUTE_MAX_AGE", "172800")
+	t.Setenv("ADMIN_SESSION_MAX_AGE", "1800")
+	t.Setenv("ADMIN_SESSION_IDLE_TIMEOUT", "600")
+	t.Setenv("ADMIN_SESSION_ABSOLUTE_MAX_AGE", "7200")
+
+	admin := SessionPolicy{MaxAge: 30 * time.Minute, IdleTimeout: 10 * time.Minute, AbsoluteMaxAge: 2 * time.Hour}
+	tests := []struct {
+		name     string
+		roles    []string
+		remember bool
+		want     SessionPolicy
+	}{
+		{"volunteer", []string{"VOLUNTEER"}, false, SessionPolicy{MaxAge: time.Hour, IdleTimeout: time.Hour, AbsoluteMaxAge: 24 * time.Hour}},
+		{"volunteer remembered", []string{"VOLUNTEER"}, true, SessionPolicy{MaxAge: 24 * time.Hour, IdleTimeout: 12 * time.Hour, AbsoluteMaxAge: 48 * time.Hour}},
+		{"admin", []string{"ADMINISTRATOR", "VOLUNTEER"}, false, admin},
+		{"admin ignores remember", []string{"ADMINISTRATOR"}, true, admin},
+		{"coordinator", []string{"COORDINATOR", "VOLUNTEER"}, true, admin},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sessionPolicyFor(tt.roles, tt.remember); got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionPolicyFor_Defaults(t *testing.T) {
+	for _, key := range []string{
+		"SESSION_MAX_AGE", "SESSION_IDLE_TIMEOUT", "SESSION_ABSOLUTE_MAX_AGE",
+		"ADMIN_SESSION_MAX_AGE", "ADMIN_SESSION_IDLE_TIMEOUT", "ADMIN_SESSION_ABSOLUTE_MAX_AGE",
+	} {
+		t.Setenv(key, "")
+	}
+	want := SessionPolicy{MaxAge: 8 * time.Hour, IdleTimeout: 2 * time.Hour, AbsoluteMaxAge: 24 * time.Hour}
+	if got := sessionPolicyFor(nil, false); got != want {
+		t.Errorf("volunteer defaults: got %+v, want %+v", got, want)
+	}
+	want = SessionPolicy{MaxAge: 8 * time.Hour, IdleTimeout: time.Hour, AbsoluteMaxAge: 12 * time.Hour}
+	if got := sessionPolicyFor([]string{"ADMINISTRATOR"}, false); got != want {
+		t.Errorf("admin defaults: got %+v, want %+v", got, want)
+	}
+}
+
+func TestSessionPolicy_ShouldRenew(t *testing.T) {
+	p := SessionPolicy{MaxAge: 10 * time.Hour, AbsoluteMaxAge: 24 * time.Hour}
+	now := time.Now()
+	created := now.Add(-time.Hour)
+	if p.shouldRenew(created, now.Add(6*time.Hour), now) {
+		t.Error("should not renew with more than half the lifetime left")
+	}
+	if !p.shouldRenew(created, now.Add(4*time.Hour), now) {
+		t.Error("should renew with less than half the lifetime left")
+	}
+
+	// Renewal never passes the absolute deadline, and stops once it is reached.
+	created = now.Add(-20 * time.Hour)
+	if got, want := p.renewedExpiry(created, now), created.Add(24*time.Hour); !got.Equal(want) {
+		t.Errorf("renewedExpiry = %v, want the absolute deadline %v", got, want)
+	}
+	if p.shouldRenew(created, created.Add(24*time.Hour), now) {
+		t.Error("should not renew a session already expiring at its absolute deadline")
+	}
+}
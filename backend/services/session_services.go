@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"volunteer-scheduler/models"
+)
+
+// SessionService lets volunteers see and revoke their signed-in devices, and
+// lets admins sign a volunteer out everywhere. Sessions themselves are created
+// and validated by MagicLinkService.
+type SessionService struct {
+	DB *sql.DB
+}
+
+func NewSessionService(db *sql.DB) *SessionService {
+	return &SessionService{DB: db}
+}
+
+// hashSessionToken returns the representation stored in sessions.token. The
+// raw token only ever lives in the volunteer's cookie.
+func hashSessionToken(token string) string {
+	hashToken := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hashToken[:])
+}
+
+// Queries.
+
+// FetchOwnSessions lists the volunteer's unexpired sessions, most recently
+// active first. currentToken marks the session making the request.
+func (s *SessionService) FetchOwnSessions(ctx context.Context, volId int, currentToken string) ([]*models.Session, error) {
+	query := `
+		SELECT
+			id,
+			user_agent,
+			ip_address,
+			created_at,
+			last_activity_at,
+			expires_at,
+			token = $2 AS current
+		FROM sessions
+		WHERE volunteer_id = $1 AND expires_at > NOW()
+		ORDER BY last_activity_at DESC NULLS LAST, created_at DESC
+	`
+	rows, err := s.DB.QueryContext(ctx, query, volId, hashSessionToken(currentToken))
+	if err != nil {
+		return nil, fmt.Errorf("error querying sessions: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []*models.Session{}
+	for rows.Next() {
+		var sess models.Session
+		var id int
+		var userAgent, ipAddress, lastActivity sql.NullString
+
+		err := rows.Scan(
+			&id,
+			&userAgent,
+			&ipAddress,
+			&sess.CreatedAt,
+			&lastActivity,
+			&sess.ExpiresAt,
+			&sess.Current)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning session: %w", err)
+		}
+
+		sess.ID = strconv.Itoa(id)
+		if userAgent.Valid {
+			sess.UserAgent = &userAgent.String
+		}
+		if ipAddress.Valid {
+			sess.IPAddress = &ipAddress.String
+		}
+		if lastActivity.Valid {
+			sess.LastActivityAt = &lastActivity.String
+		}
+		sessions = append(sessions, &sess)
+	}
+
+	return sessions, nil
+}
+
+// Mutations.
+
+// RevokeOwnSession signs out one of the volunteer's own sessions.
+func (s *SessionService) RevokeOwnSession(ctx context.Context, volId int, sessionId string) (*models.MutationResult, error) {
+	sessionInt, err := strconv.Atoi(sessionId)
+	if err != nil {
+		return nil, models.InvalidID("sessionId")
+	}
+
+	res, err := s.DB.ExecContext(ctx,
+		"DELETE FROM sessions WHERE id = $1 AND volunteer_id = $2", sessionInt, volId)
+	if err != nil {
+		return nil, fmt.Errorf("unable to revoke session: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Session not found.")
+	}
+
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Session signed out."),
+		ID:      &sessionId,
+	}, nil
+}
+
+// RevokeOtherSessions signs out every session except the one making the
+// request ("sign out everywhere else").
+func (s *SessionService) RevokeOtherSessions(ctx context.Context, volId int, currentToken string) (*models.MutationResult, error) {
+	res, err := s.DB.ExecContext(ctx,
+		"DELETE FROM sessions WHERE volunteer_id = $1 AND token <> $2", volId, hashSessionToken(currentToken))
+	if err != nil {
+		return nil, fmt.Errorf("unable to revoke sessions: %w", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString(fmt.Sprintf("Signed out %d other session(s).", n)),
+	}, nil
+}
+
+// RevokeAllSessions signs a volunteer out on every device. Admin only.
+func (s *SessionService) RevokeAllSessions(ctx context.Context, volunteerId string) (*models.MutationResult, error) {
+	volInt, err := strconv.Atoi(volunteerId)
+	if err != nil {
+		return nil, models.InvalidID("volunteerId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, "DELETE FROM sessions WHERE volunteer_id = $1", volInt)
+	if err != nil {
+		return nil, fmt.Errorf("unable to revoke sessions: %w", err)
+	}
+
+	n, _ := res.RowsAffected()
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString(fmt.Sprintf("Signed out %d session(s).", n)),
+		ID:      &volunteerId,
+	}, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+// shift_capacity_feed.go
+//
+// Pushes shift capacity changes to GraphQL subscribers. Migration 000018
+// has Postgres NOTIFY the shift_capacity channel whenever a shift's signups
+// or limit change; every replica LISTENs on it and fans each change out to
+// the subscribers of that shift's event, so a signup on one replica reaches
+// browsers connected to another.
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
+)
+
+const shiftCapacityChannel = "shift_capacity"
+
+// subscriberBuffer is how many changes a slow subscriber may fall behind
+// before the oldest are dropped. Each change carries the shift's full count,
+// so a dropped one is superseded by a later one for the same shift.
+const subscriberBuffer = 16
+
+type ShiftCapacityFeed struct {
+	dbURL string
+
+	mu          sync.Mutex
+	subscribers map[string]map[chan *models.ShiftCapacity]struct{} // by event id
+}
+
+func NewShiftCapacityFeed(dbURL string) *ShiftCapacityFeed {
+	return &ShiftCapacityFeed{
+		dbURL:       dbURL,
+		subscribers: make(map[string]map[chan *models.ShiftCapacity]struct{}),
+	}
+}
+
+// RunShiftCapacityFeed listens for capacity notifications until ctx is
+// cancelled. The listener reconnects by itself; changes made while it is
+// disconnected are not replayed.
+func (f *ShiftCapacityFeed) RunShiftCapacityFeed(ctx context.Context) {
+	listener := pq.NewListener(f.dbURL, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Shift capacity listener: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(shiftCapacityChannel); err != nil {
+		log.Printf("Shift capacity listener: LISTEN failed: %v", err)
+		return
+	}
+
+	// Ping now and then so a dead connection is noticed and re-established.
+	ticker := time.NewTicker(90 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case n := <-listener.Notify:
+			// nil after a reconnect.
+			if n != nil {
+				f.publish(n.Extra)
+			}
+		case <-ticker.C:
+			go listener.Ping()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// SubscribeToEvent returns a channel of the capacity changes to eventId's
+// shifts. The subscription ends, and the channel is closed, when ctx is done.
+func (f *ShiftCapacityFeed) SubscribeToEvent(ctx context.Context, eventId string) (<-chan *models.ShiftCapacity, error) {
+	if _, err := strconv.Atoi(eventId); err != nil {
+		return nil, models.InvalidID("eventId")
+	}
+
+	ch := make(chan *models.ShiftCapacity, subscriberBuffer)
+	f.mu.Lock()
+	if f.subscribers[eventId] == nil {
+		f.subscribers[eventId] = make(map[chan *models.ShiftCapacity]struct{})
+	}
+	f.subscribers[eventId][ch] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		f.mu.Lock()
+		delete(f.subscribers[eventId], ch)
+		if len(f.subscribers[eventId]) == 0 {
+			delete(f.subscribers, eventId)
+		}
+		close(ch)
+		f.mu.Unlock()
+	}()
+	return ch, nil
+}
+
+// capacityNotification is the NOTIFY payload sent by notify_shift_capacity().
+type capacityNotification struct {
+	EventId  int  `json:"eventId"`
+	ShiftId  int  `json:"shiftId"`
+	Assigned int  `json:"assigned"`
+	Max      *int `json:"max"`
+}
+
+// publish hands one notification payload to the event's subscribers.
+func (f *ShiftCapacityFeed) publish(payload string) {
+	var n capacityNotification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil {
+		log.Printf("Shift capacity listener: bad payload %q: %v", payload, err)
+		return
+	}
+	change := &models.ShiftCapacity{
+		EventId:            strconv.Itoa(n.EventId),
+		ShiftId:            strconv.Itoa(n.ShiftId),
+		AssignedVolunteers: n.Assigned,
+		MaxVolunteers:      n.Max,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subscribers[change.EventId] {
+		select {
+		case ch <- change:
+		default:
+			// Full: drop the oldest change to make room for this one.
+			select {
+			case <-ch:
+			default:
+			}
+			select {
+			case ch <- change:
+			default:
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestShiftCapacityFeed_FansOutByEvent(t *testing.T) {
+	feed := NewShiftCapacityFeed("")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mine, err := feed.SubscribeToEvent(ctx, "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, _ := feed.SubscribeToEvent(ctx, "8")
+
+	feed.publish(`{"eventId": 7, "shiftId": 21, "assigned": 3, "max": 5}`)
+
+	select {
+	case c := <-mine:
+		if c.ShiftId != "21" || c.AssignedVolunteers != 3 || c.MaxVolunteers == nil || *c.MaxVolunteers != 5 {
+			t.Errorf("change = %+v", c)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscriber to event 7 got nothing")
+	}
+	select {
+	case c := <-other:
+		t.Errorf("subscriber to event 8 got %+v", c)
+	default:
+	}
+}
+
+func TestShiftCapacityFeed_SlowSubscriberKeepsLatest(t *testing.T) {
+	feed := NewShiftCapacityFeed("")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, _ := feed.SubscribeToEvent(ctx, "1")
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		feed.publish(fmt.Sprintf(`{"eventId": 1, "shiftId": 2, "assigned": %d, "max": null}`, i))
+	}
+	var last int
+	for i := 0; i < subscriberBuffer; i++ {
+		last = (<-ch).AssignedVolunteers
+	}
+	if last != subscriberBuffer+4 {
+		t.Errorf("last change = %d, want %d", last, subscriberBuffer+4)
+	}
+}
+
+func TestShiftCapacityFeed_UnsubscribesOnCancel(t *testing.T) {
+	feed := NewShiftCapacityFeed("")
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, _ := feed.SubscribeToEvent(ctx, "3")
+
+	cancel()
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected the channel to be closed")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+
+	feed.mu.Lock()
+	defer feed.mu.Unlock()
+	if len(feed.subscribers) != 0 {
+		t.Errorf("subscribers left: %v", feed.subscribers)
+	}
+}
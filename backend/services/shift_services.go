@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -67,7 +68,7 @@ func (s *ShiftService) FetchOpportunitiesForEvent(ctx context.Context, eventId s
 
 	eventInt, err := strconv.Atoi(eventId)
 	if err != nil {
-		return nil, fmt.Errorf("event id is not valid: %s", err)
+		return nil, models.InvalidID("eventId")
 	}
 
 	query := `
@@ -128,7 +129,7 @@ func (s *ShiftService) FetchShiftsForOpportunity(ctx context.Context, oppId stri
 
 	oppInt, err := strconv.Atoi(oppId)
 	if err != nil {
-		return nil, fmt.Errorf("opportunity id is not valid: %w", err)
+		return nil, models.InvalidID("oppId")
 	}
 
 	query := `
@@ -180,7 +181,7 @@ func (s *ShiftService) FetchEventShiftViews(ctx context.Context, eventId string)
 
 	eventInt, err := strconv.Atoi(eventId)
 	if err != nil {
-		return nil, fmt.Errorf("event id is not valid: %w", err)
+		return nil, models.InvalidID("eventId")
 	}
 
 	query := `
@@ -194,8 +195,9 @@ func (s *ShiftService) FetchEventShiftViews(ctx context.Context, eventId string)
 		o.opportunity_is_virtual
 	FROM shifts s
 	JOIN opportunities o ON s.opportunity_id = o.opportunity_id
+	JOIN events e ON e.event_id = o.event_id
 	LEFT JOIN job_types jt ON jt.job_type_id = o.job_type_id
-	WHERE o.event_id = $1
+	WHERE o.event_id = $1 AND ` + eventVisibleCondition + `
 	ORDER by o.opportunity_id, s.shift_id
 	`
 	rows, err := s.DB.QueryContext(ctx, query, eventInt)
@@ -264,11 +266,7 @@ func (s *ShiftService) CreateJobType(ctx context.Context, newJob models.NewJobTy
 	err := s.DB.QueryRowContext(ctx, query, newJob.Code, newJob.Name, newJob.SortOrder).Scan(&JobId)
 
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Failed to create new Job."),
-			ID:      nil,
-		}, err
+		return nil, friendlyDBError(err)
 	}
 
 	return &models.MutationResult{
@@ -286,7 +284,7 @@ func (s *ShiftService) CreateJobType(ctx context.Context, newJob models.NewJobTy
 func (s *ShiftService) CreateOpportunity(ctx context.Context, opp models.NewOpportunityInput) (*models.MutationResult, error) {
 	eventInt, err := strconv.Atoi(opp.EventId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid event id: %w", err)
+		return nil, models.InvalidID("eventId")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -304,12 +302,12 @@ func (s *ShiftService) CreateOpportunity(ctx context.Context, opp models.NewOppo
 		opp.EventId, opp.JobId, opp.IsVirtual, opp.PreEventInstructions,
 	).Scan(&oppInt)
 	if err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("error creating opportunity")}, err
+		return nil, friendlyDBError(err)
 	}
 
 	// Add initial shifts to the base opportunity.
 	if err = addNewOpportunityShifts(ctx, opp.Shifts, oppInt, tx); err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("error adding shifts to opportunity")}, err
+		return nil, err
 	}
 
 	// Check whether the event belongs to a recurrence group.
@@ -437,7 +435,7 @@ func (s *ShiftService) CreateOpportunity(ctx context.Context, opp models.NewOppo
 	}
 
 	if err = tx.Commit(); err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("error committing transaction")}, err
+		return nil, err
 	}
 
 	oppStr := strconv.Itoa(oppInt)
@@ -456,7 +454,7 @@ func (s *ShiftService) CreateOpportunity(ctx context.Context, opp models.NewOppo
 func (s *ShiftService) CreateShift(ctx context.Context, shift models.AddShiftInput) (*models.MutationResult, error) {
 	oppInt, err := strconv.Atoi(shift.OppId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid opportunity id: %w", err)
+		return nil, models.InvalidID("opportunityId")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -487,7 +485,7 @@ func (s *ShiftService) CreateShift(ctx context.Context, shift models.AddShiftInp
 		return nil, err
 	}
 	if *endUTC <= *startUTC {
-		return nil, fmt.Errorf("A shift must end after it starts.")
+		return nil, models.NewFieldError("endDateTime", "A shift must end after it starts.")
 	}
 
 	var maxVols interface{}
@@ -604,11 +602,7 @@ func (s *ShiftService) UpdateJobType(ctx context.Context, job models.UpdateJobTy
 	_, err := s.DB.ExecContext(ctx, update, job.Code, job.Name, job.SortOrder, job.ID)
 
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Failed to update job type."),
-			ID:      &jobStr,
-		}, err
+		return nil, friendlyDBError(err)
 	}
 
 	return &models.MutationResult{
@@ -625,7 +619,7 @@ func (s *ShiftService) UpdateJobType(ctx context.Context, job models.UpdateJobTy
 func (s *ShiftService) UpdateOpportunity(ctx context.Context, opp models.UpdateOpportunityInput) (*models.MutationResult, error) {
 	oppInt, err := strconv.Atoi(opp.ID)
 	if err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Invalid opp.ID."), ID: &opp.ID}, err
+		return nil, models.InvalidID("id")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -641,7 +635,7 @@ func (s *ShiftService) UpdateOpportunity(ctx context.Context, opp models.UpdateO
 		WHERE opportunity_id = $4`,
 		opp.JobId, opp.IsVirtual, opp.PreEventInstructions, oppInt,
 	); err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Failed to update opportunity."), ID: &opp.ID}, err
+		return nil, friendlyDBError(err)
 	}
 
 	// Look up recurrence info to decide whether to propagate.
@@ -693,7 +687,7 @@ func (s *ShiftService) UpdateOpportunity(ctx context.Context, opp models.UpdateO
 func (s *ShiftService) UpdateShift(ctx context.Context, shift models.UpdateShiftInput) (*models.MutationResult, error) {
 	shiftInt, err := strconv.Atoi(shift.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid shift id: %w", err)
+		return nil, models.InvalidID("id")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -730,7 +724,7 @@ func (s *ShiftService) UpdateShift(ctx context.Context, shift models.UpdateShift
 		return nil, err
 	}
 	if *endUTC <= *startUTC {
-		return nil, fmt.Errorf("A shift must end after it starts.")
+		return nil, models.NewFieldError("endDateTime", "A shift must end after it starts.")
 	}
 
 	// Update the base shift.
@@ -785,21 +779,17 @@ func (s *ShiftService) UpdateShift(ctx context.Context, shift models.UpdateShift
 }
 
 func (s *ShiftService) AssignSelfToShift(ctx context.Context, shiftId string, volId int) (*models.MutationResult, error) {
-	return assignVolToShift(ctx, s.DB, s.mailer, shiftId, volId)
+	return assignVolToShift(ctx, s.DB, s.mailer, shiftId, volId, false)
 }
 
 func (s *ShiftService) AssignVolunteerToShift(ctx context.Context, shiftId string, volunteerId string) (*models.MutationResult, error) {
 
 	volInt, err := strconv.Atoi(volunteerId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Invalid volunteerId."),
-			ID:      &volunteerId,
-		}, err
+		return nil, models.InvalidID("volunteerId")
 	}
 
-	return assignVolToShift(ctx, s.DB, s.mailer, shiftId, volInt)
+	return assignVolToShift(ctx, s.DB, s.mailer, shiftId, volInt, true)
 }
 
 // ============================================================================
@@ -818,7 +808,7 @@ func (s *ShiftService) DeleteJobType(ctx context.Context, jobTypeId int) (*model
 	`
 	_, err := s.DB.ExecContext(ctx, update, jobTypeId)
 	if err != nil {
-		return nil, fmt.Errorf("unable to delete job type: %w", err)
+		return nil, friendlyDBError(err)
 	}
 
 	jobTypeStr := strconv.Itoa(jobTypeId)
@@ -835,7 +825,7 @@ func (s *ShiftService) DeleteJobType(ctx context.Context, jobTypeId int) (*model
 func (s *ShiftService) DeleteOpportunity(ctx context.Context, oppId string) (*models.MutationResult, error) {
 	oppInt, err := strconv.Atoi(oppId)
 	if err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Failed to delete opportunity."), ID: &oppId}, err
+		return nil, models.InvalidID("oppId")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -856,13 +846,16 @@ func (s *ShiftService) DeleteOpportunity(ctx context.Context, oppId string) (*mo
 		WHERE o.opportunity_id = $1`,
 		oppInt,
 	).Scan(&tmplID, &groupID, &order)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "opportunity not found")
+	}
 	if err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Failed to delete opportunity."), ID: &oppId}, err
+		return nil, err
 	}
 
 	// Delete the base opportunity (DB cascades to its shifts).
 	if _, err = tx.ExecContext(ctx, `DELETE FROM opportunities WHERE opportunity_id = $1`, oppInt); err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Failed to delete opportunity."), ID: &oppId}, err
+		return nil, err
 	}
 
 	// Propagate deletion to sibling opps on future instances.
@@ -901,7 +894,7 @@ func (s *ShiftService) DeleteOpportunity(ctx context.Context, oppId string) (*mo
 func (s *ShiftService) DeleteShift(ctx context.Context, shiftId string) (*models.MutationResult, error) {
 	shiftInt, err := strconv.Atoi(shiftId)
 	if err != nil {
-		return nil, fmt.Errorf("invalid shiftId, %s: %w", shiftId, err)
+		return nil, models.InvalidID("shiftId")
 	}
 
 	tx, err := s.DB.BeginTx(ctx, nil)
@@ -926,13 +919,16 @@ func (s *ShiftService) DeleteShift(ctx context.Context, shiftId string) (*models
 		WHERE s.shift_id = $1`,
 		shiftInt,
 	).Scan(&oppInt, &numShifts, &shiftTmplID, &groupID, &order, &eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, models.NewError(models.ErrorCodeNotFound, "shift not found")
+	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to find shift %d: %w", shiftInt, err)
 	}
 
 	// The base opportunity must keep at least one shift.
 	if numShifts < 2 {
-		return nil, fmt.Errorf("cannot delete the last shift associated with opportunity %d", oppInt)
+		return nil, models.NewError(models.ErrorCodeConflict, "cannot delete the last shift associated with opportunity %d", oppInt)
 	}
 
 	// For recurring shifts: ensure no sibling opp would be left with zero shifts.
@@ -954,7 +950,7 @@ func (s *ShiftService) DeleteShift(ctx context.Context, shiftId string) (*models
 			return nil, fmt.Errorf("error checking sibling shift counts: %w", err)
 		}
 		if wouldEmpty > 0 {
-			return nil, fmt.Errorf(
+			return nil, models.NewError(models.ErrorCodeConflict,
 				"cannot delete: %d future event(s) would have no shifts remaining on this opportunity",
 				wouldEmpty,
 			)
@@ -963,7 +959,7 @@ func (s *ShiftService) DeleteShift(ctx context.Context, shiftId string) (*models
 
 	// Delete the base shift.
 	if _, err = tx.ExecContext(ctx, `DELETE FROM shifts WHERE shift_id = $1`, shiftInt); err != nil {
-		return &models.MutationResult{Success: false, Message: ptrString("Failed to delete shift."), ID: &shiftId}, err
+		return nil, err
 	}
 
 	// Propagate deletion to sibling shifts on future instances.
@@ -1000,11 +996,7 @@ func (s *ShiftService) CancelShiftAssignment(ctx context.Context, shiftId string
 
 	volInt, err := strconv.Atoi(volId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("VolunteerId is not valid."),
-			ID:      nil,
-		}, err
+		return nil, models.InvalidID("volunteerId")
 	}
 
 	return cancelShiftAssignment(ctx, s.DB, s.mailer, shiftId, volInt)
@@ -0,0 +1,354 @@
+package services
+
+// smtp_transport.go
+//
+// SMTPTransport sends mail through an authenticated SMTP relay (Microsoft 365,
+// Postfix, etc.). It is selected with EMAIL_TRANSPORT=smtp; see useSMTP in
+// mailer.go. The MIME builder at the bottom of this file is shared with
+// MailhogTransport.
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TLS modes for SMTP_TLS.
+const (
+	smtpTLSStartTLS = "starttls" // plain connect, then upgrade (port 587)
+	smtpTLSImplicit = "tls"      // TLS from the first byte (port 465)
+	smtpTLSNone     = "none"     // no encryption; only for trusted local relays
+)
+
+// Auth mechanisms for SMTP_AUTH.
+const (
+	smtpAuthPlain = "plain"
+	smtpAuthLogin = "login"
+	smtpAuthNone  = "none"
+)
+
+const defaultSMTPTimeout = 30 * time.Second
+
+type SMTPTransport struct {
+	host       string
+	port       string
+	username   string
+	password   string
+	authMethod string
+	tlsMode    string
+	timeout    time.Duration
+	fromEmail  string
+	fromName   string
+	replyTo    string
+}
+
+// NewSMTPTransport creates an SMTP transport from environment configuration:
+//
+//	SMTP_HOST             relay host name (required)
+//	SMTP_PORT             defaults to 587 (starttls), 465 (tls) or 25 (none)
+//	SMTP_TLS              starttls (default), tls or none
+//	SMTP_AUTH             plain (default when a username is set), login or none
+//	SMTP_USERNAME         account used to authenticate
+//	SMTP_PASSWORD         password; falls back to the file named by SMTP_PASSWORD_FILE
+//	SMTP_TIMEOUT_SECONDS  dial and per-message I/O timeout (default 30)
+func NewSMTPTransport(fromEmail, fromName string) (*SMTPTransport, error) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is required for SMTP transport")
+	}
+
+	tlsMode := strings.ToLower(os.Getenv("SMTP_TLS"))
+	if tlsMode == "" {
+		tlsMode = smtpTLSStartTLS
+	}
+	if tlsMode != smtpTLSStartTLS && tlsMode != smtpTLSImplicit && tlsMode != smtpTLSNone {
+		return nil, fmt.Errorf("SMTP_TLS must be one of starttls, tls or none (got %q)", tlsMode)
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		switch tlsMode {
+		case smtpTLSImplicit:
+			port = "465"
+		case smtpTLSNone:
+			port = "25"
+		default:
+			port = "587"
+		}
+	}
+
+	username := os.Getenv("SMTP_USERNAME")
+	password := os.Getenv("SMTP_PASSWORD")
+	if password == "" && os.Getenv("SMTP_PASSWORD_FILE") != "" {
+		data, err := os.ReadFile(os.Getenv("SMTP_PASSWORD_FILE"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read SMTP_PASSWORD_FILE: %w", err)
+		}
+		password = strings.TrimSpace(string(data))
+	}
+
+	authMethod := strings.ToLower(os.Getenv("SMTP_AUTH"))
+	if authMethod == "" {
+		authMethod = smtpAuthNone
+		if username != "" {
+			authMethod = smtpAuthPlain
+		}
+	}
+	if authMethod != smtpAuthPlain && authMethod != smtpAuthLogin && authMethod != smtpAuthNone {
+		return nil, fmt.Errorf("SMTP_AUTH must be one of plain, login or none (got %q)", authMethod)
+	}
+	if authMethod != smtpAuthNone && (username == "" || password == "") {
+		return nil, fmt.Errorf("SMTP_USERNAME and SMTP_PASSWORD are required when SMTP_AUTH is %s", authMethod)
+	}
+
+	timeout := defaultSMTPTimeout
+	if s := os.Getenv("SMTP_TIMEOUT_SECONDS"); s != "" {
+		secs, err := strconv.Atoi(s)
+		if err != nil || secs <= 0 {
+			return nil, fmt.Errorf("SMTP_TIMEOUT_SECONDS must be a positive integer (got %q)", s)
+		}
+		timeout = time.Duration(secs) * time.Second
+	}
+
+	return &SMTPTransport{
+		host:       host,
+		port:       port,
+		username:   username,
+		password:   password,
+		authMethod: authMethod,
+		tlsMode:    tlsMode,
+		timeout:    timeout,
+		fromEmail:  fromEmail,
+		fromName:   fromName,
+		replyTo:    os.Getenv("MAIL_REPLY_TO"),
+	}, nil
+}
+
+// SendEmail delivers one message through the configured relay. The whole
+// conversation (dial, TLS handshake, auth and DATA) is bounded by the
+// transport timeout or the context deadline, whichever comes first.
+func (s *SMTPTransport) SendEmail(ctx context.Context, to, subject, htmlBody, textBody string) error {
+	msg, err := buildMIMEMessage(s.fromEmail, s.fromName, to, replyToFromContext(ctx, s.replyTo), subject, htmlBody, textBody)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.host, s.port)
+	dialer := &net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not connect to SMTP server %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	tlsConfig := &tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}
+	if s.tlsMode == smtpTLSImplicit {
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err = tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return fmt.Errorf("TLS handshake with %s failed: %w", addr, err)
+		}
+		conn = tlsConn
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("SMTP handshake with %s failed: %w", addr, err)
+	}
+	defer client.Close()
+
+	if s.tlsMode == smtpTLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("SMTP server %s does not support STARTTLS", addr)
+		}
+		if err = client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS with %s failed: %w", addr, err)
+		}
+	}
+
+	switch s.authMethod {
+	case smtpAuthPlain:
+		err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host))
+	case smtpAuthLogin:
+		err = client.Auth(&loginAuth{username: s.username, password: s.password, host: s.host})
+	}
+	if err != nil {
+		return fmt.Errorf("SMTP authentication failed: %w", err)
+	}
+
+	if err = client.Mail(s.fromEmail); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected: %w", err)
+	}
+	if err = client.Rcpt(to); err != nil {
+		return fmt.Errorf("SMTP RCPT TO rejected for %s: %w", to, err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA rejected: %w", err)
+	}
+	if _, err = w.Write(msg); err != nil {
+		w.Close()
+		return fmt.Errorf("error writing message body: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("SMTP server did not accept message: %w", err)
+	}
+
+	if err = client.Quit(); err != nil {
+		// The message has already been accepted; a failed QUIT is harmless.
+		log.Printf("SMTP QUIT to %s failed: %v", addr, err)
+	}
+
+	log.Printf("Email sent via SMTP to %s", to)
+	return nil
+}
+
+// ============================================================================
+// LOGIN auth
+// net/smtp only ships PLAIN and CRAM-MD5; Microsoft 365 and some older relays
+// only advertise LOGIN.
+// ============================================================================
+
+type loginAuth struct {
+	username string
+	password string
+	host     string
+}
+
+func (a *loginAuth) Start(server *smtp.ServerInfo) (string, []byte, error) {
+	// Same rule as smtp.PlainAuth: never send credentials in the clear,
+	// except to localhost.
+	if !server.TLS && !isLocalhost(server.Name) {
+		return "", nil, errors.New("unencrypted connection")
+	}
+	if server.Name != a.host {
+		return "", nil, errors.New("wrong host name")
+	}
+	return "LOGIN", nil, nil
+}
+
+func (a *loginAuth) Next(fromServer []byte, more bool) ([]byte, error) {
+	if !more {
+		return nil, nil
+	}
+	prompt := strings.ToLower(strings.TrimSpace(string(fromServer)))
+	switch {
+	case strings.HasPrefix(prompt, "username"):
+		return []byte(a.username), nil
+	case strings.HasPrefix(prompt, "password"):
+		return []byte(a.password), nil
+	default:
+		return nil, fmt.Errorf("unexpected LOGIN prompt from server: %q", fromServer)
+	}
+}
+
+func isLocalhost(name string) bool {
+	return name == "localhost" || name == "127.0.0.1" || name == "::1"
+}
+
+// ============================================================================
+// MIME message building
+// ============================================================================
+
+// buildMIMEMessage renders a multipart/alternative message with a random
+// boundary, RFC 2047-encoded headers, Date and Message-ID. Both parts are
+// quoted-printable so long lines and non-ASCII text survive any relay.
+func buildMIMEMessage(fromEmail, fromName, to, replyTo, subject, htmlBody, textBody string) ([]byte, error) {
+	for _, h := range []string{fromEmail, fromName, to, replyTo, subject} {
+		if strings.ContainsAny(h, "\r\n") {
+			return nil, fmt.Errorf("email header contains a line break")
+		}
+	}
+
+	boundary, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate MIME boundary: %w", err)
+	}
+	msgID, err := newMessageID(fromEmail)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate Message-ID: %w", err)
+	}
+
+	from := (&mail.Address{Name: fromName, Address: fromEmail}).String()
+
+	var buf bytes.Buffer
+	buf.WriteString("From: " + from + "\r\n")
+	buf.WriteString("To: " + to + "\r\n")
+	if replyTo != "" {
+		buf.WriteString("Reply-To: " + replyTo + "\r\n")
+	}
+	buf.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	buf.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	buf.WriteString("Message-ID: " + msgID + "\r\n")
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: multipart/alternative; boundary=\"" + boundary + "\"\r\n\r\n")
+
+	if textBody != "" {
+		if err = writeQPPart(&buf, boundary, "text/plain", textBody); err != nil {
+			return nil, err
+		}
+	}
+	if err = writeQPPart(&buf, boundary, "text/html", htmlBody); err != nil {
+		return nil, err
+	}
+	buf.WriteString("--" + boundary + "--\r\n")
+
+	return buf.Bytes(), nil
+}
+
+func writeQPPart(buf *bytes.Buffer, boundary, contentType, body string) error {
+	buf.WriteString("--" + boundary + "\r\n")
+	buf.WriteString("Content-Type: " + contentType + "; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	qp := quotedprintable.NewWriter(buf)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return fmt.Errorf("error encoding %s part: %w", contentType, err)
+	}
+	if err := qp.Close(); err != nil {
+		return fmt.Errorf("error encoding %s part: %w", contentType, err)
+	}
+	buf.WriteString("\r\n")
+	return nil
+}
+
+// newMessageID returns a globally unique Message-ID using the sender's domain.
+func newMessageID(fromEmail string) (string, error) {
+	domain := "localhost"
+	if at := strings.LastIndex(fromEmail, "@"); at >= 0 && at < len(fromEmail)-1 {
+		domain = fromEmail[at+1:]
+	}
+	id, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), id, domain), nil
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
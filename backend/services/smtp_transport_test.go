@@ -0,0 +1,194 @@
+package services
+
+// ============================================================================
+// Unit tests for smtp_transport.go helpers.
+//
+// buildMIMEMessage and loginAuth are pure — no SMTP server needed.
+//
+// Rules under test:
+//   1. The message parses as RFC 5322 with Date, Message-ID and Reply-To.
+//   2. Non-ASCII subjects are RFC 2047 encoded and decode back intact.
+//   3. Boundaries are random per message.
+//   4. Both parts decode back to the original bodies.
+//   5. CR/LF in header values is rejected.
+//   6. LOGIN auth answers the username/password prompts and refuses
+//      unencrypted non-local connections.
+// ============================================================================
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"testing"
+)
+
+// ============================================================================
+// buildMIMEMessage
+// ============================================================================
+
+func parseBuiltMessage(t *testing.T, raw []byte) *mail.Message {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("message does not parse: %v\n%s", err, raw)
+	}
+	return msg
+}
+
+func TestBuildMIMEMessage_Headers(t *testing.T) {
+	raw, err := buildMIMEMessage("noreply@example.org", "Volunteer Scheduler", "vol@example.org",
+		"help@example.org", "Shift reminder", "<p>Hi</p>", "Hi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := parseBuiltMessage(t, raw)
+
+	if _, err := msg.Header.Date(); err != nil {
+		t.Errorf("Date header missing or invalid: %v", err)
+	}
+	id := msg.Header.Get("Message-ID")
+	if !strings.HasPrefix(id, "<") || !strings.HasSuffix(id, "@example.org>") {
+		t.Errorf("unexpected Message-ID %q", id)
+	}
+	if got := msg.Header.Get("Reply-To"); got != "help@example.org" {
+		t.Errorf("Reply-To: want help@example.org, got %q", got)
+	}
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil || from.Name != "Volunteer Scheduler" || from.Address != "noreply@example.org" {
+		t.Errorf("unexpected From %q (%v)", msg.Header.Get("From"), err)
+	}
+}
+
+func TestBuildMIMEMessage_NoReplyToWhenEmpty(t *testing.T) {
+	raw, err := buildMIMEMessage("noreply@example.org", "", "vol@example.org", "", "Hi", "<p>x</p>", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg := parseBuiltMessage(t, raw); msg.Header.Get("Reply-To") != "" {
+		t.Error("Reply-To should be omitted when empty")
+	}
+}
+
+func TestBuildMIMEMessage_UTF8Subject(t *testing.T) {
+	subject := "Café volunteers — ¡gracias!"
+	raw, err := buildMIMEMessage("noreply@example.org", "", "vol@example.org", "", subject, "<p>x</p>", "x")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := parseBuiltMessage(t, raw)
+
+	encoded := msg.Header.Get("Subject")
+	if encoded == subject {
+		t.Fatal("subject was not encoded")
+	}
+	decoded, err := new(mime.WordDecoder).DecodeHeader(encoded)
+	if err != nil {
+		t.Fatalf("subject does not decode: %v", err)
+	}
+	if decoded != subject {
+		t.Errorf("subject: want %q, got %q", subject, decoded)
+	}
+}
+
+func TestBuildMIMEMessage_RandomBoundary(t *testing.T) {
+	boundaryOf := func() string {
+		raw, err := buildMIMEMessage("noreply@example.org", "", "vol@example.org", "", "Hi", "<p>x</p>", "x")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, params, err := mime.ParseMediaType(parseBuiltMessage(t, raw).Header.Get("Content-Type"))
+		if err != nil {
+			t.Fatalf("bad Content-Type: %v", err)
+		}
+		return params["boundary"]
+	}
+
+	a, b := boundaryOf(), boundaryOf()
+	if a == "" || a == b {
+		t.Errorf("expected distinct non-empty boundaries, got %q and %q", a, b)
+	}
+}
+
+func TestBuildMIMEMessage_PartsRoundTrip(t *testing.T) {
+	text := "Hello Zoë,\n" + strings.Repeat("long line ", 40)
+	html := "<p>Hello Zoë,</p><p>" + strings.Repeat("long line ", 40) + "</p>"
+
+	raw, err := buildMIMEMessage("noreply@example.org", "", "vol@example.org", "", "Hi", html, text)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	msg := parseBuiltMessage(t, raw)
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected Content-Type %q (%v)", msg.Header.Get("Content-Type"), err)
+	}
+
+	// multipart.Reader transparently decodes quoted-printable parts.
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	want := map[string]string{"text/plain": text, "text/html": html}
+	seen := 0
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("reading part: %v", err)
+		}
+		ct, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(part)
+		// Quoted-printable text mode canonicalises line endings to CRLF.
+		if got := strings.ReplaceAll(string(body), "\r\n", "\n"); got != want[ct] {
+			t.Errorf("%s body mismatch:\nwant %q\ngot  %q", ct, want[ct], got)
+		}
+		seen++
+	}
+	if seen != 2 {
+		t.Errorf("expected 2 parts, got %d", seen)
+	}
+}
+
+func TestBuildMIMEMessage_RejectsHeaderInjection(t *testing.T) {
+	_, err := buildMIMEMessage("noreply@example.org", "", "vol@example.org", "",
+		"Hi\r\nBcc: victim@example.org", "<p>x</p>", "x")
+	if err == nil {
+		t.Error("expected error for subject containing CRLF")
+	}
+}
+
+// ============================================================================
+// loginAuth
+// ============================================================================
+
+func TestLoginAuth_Exchange(t *testing.T) {
+	a := &loginAuth{username: "user@example.org", password: "secret", host: "smtp.example.org"}
+
+	proto, _, err := a.Start(&smtp.ServerInfo{Name: "smtp.example.org", TLS: true})
+	if err != nil || proto != "LOGIN" {
+		t.Fatalf("Start: want LOGIN, got %q (%v)", proto, err)
+	}
+
+	resp, err := a.Next([]byte("Username:"), true)
+	if err != nil || string(resp) != "user@example.org" {
+		t.Errorf("username prompt: got %q (%v)", resp, err)
+	}
+	resp, err = a.Next([]byte("Password:"), true)
+	if err != nil || string(resp) != "secret" {
+		t.Errorf("password prompt: got %q (%v)", resp, err)
+	}
+	if _, err = a.Next([]byte("Something else:"), true); err == nil {
+		t.Error("expected error for unknown prompt")
+	}
+}
+
+func TestLoginAuth_RefusesUnencrypted(t *testing.T) {
+	a := &loginAuth{username: "u", password: "p", host: "smtp.example.org"}
+	if _, _, err := a.Start(&smtp.ServerInfo{Name: "smtp.example.org", TLS: false}); err == nil {
+		t.Error("expected LOGIN auth to refuse an unencrypted connection")
+	}
+}
@@ -87,12 +87,7 @@ func (s *StaffService) CreateStaff(ctx context.Context, staff models.NewStaffInp
 	`
 	err := s.DB.QueryRowContext(ctx, insert, staff.FirstName, staff.LastName, staff.Email, staff.Phone, staff.Position).Scan(&staffInt)
 	if err != nil {
-		friendly := friendlyDBError(err)
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString(friendly.Error()),
-			ID:      nil,
-		}, friendly
+		return nil, friendlyDBError(err)
 	}
 
 	id := strconv.Itoa(staffInt)
@@ -126,7 +121,7 @@ func (s *StaffService) UpdateStaff(ctx context.Context, staff models.UpdateStaff
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return nil, fmt.Errorf("staff member %s not found", staff.ID)
+		return nil, models.NewError(models.ErrorCodeNotFound, "staff member %s not found", staff.ID)
 	}
 
 	return &models.MutationResult{
@@ -152,7 +147,7 @@ func (s *StaffService) DeleteStaff(ctx context.Context, staffID string) (*models
 
 	rows, _ := result.RowsAffected()
 	if rows == 0 {
-		return nil, fmt.Errorf("staff member %s not found", staffID)
+		return nil, models.NewError(models.ErrorCodeNotFound, "staff member %s not found", staffID)
 	}
 
 	return &models.MutationResult{
@@ -165,22 +165,13 @@ func (s *VenueService) DeleteVenue(ctx context.Context, venueId string) (*models
 
 	venueInt, err := strconv.Atoi(venueId)
 	if err != nil {
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString("Invalid venue ID."),
-			ID:      &venueId,
-		}, err
+		return nil, models.InvalidID("venueId")
 	}
 
 	_, err = s.DB.ExecContext(ctx, "DELETE FROM venues WHERE venue_id = $1", venueInt)
 
 	if err != nil {
-		friendly := friendlyDBError(err)
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString(friendly.Error()),
-			ID:      &venueId,
-		}, friendly
+		return nil, friendlyDBError(err)
 	}
 
 	return &models.MutationResult{
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"volunteer-scheduler/models"
+
+	"github.com/lib/pq"
+)
+
+// maxVolunteerDistanceMiles bounds the near filter.
+const maxVolunteerDistanceMiles = 500
+
+// volunteerStatsJoin adds each volunteer's service record as stats: their
+// signups that are not cancelled, and the hours and latest end of the
+// shifts among them that have ended (served).
+const volunteerStatsJoin = `
+	LEFT JOIN LATERAL (
+		SELECT
+			COUNT(*) AS signups,
+			COALESCE(SUM(EXTRACT(EPOCH FROM sh.shift_end - sh.shift_start) / 3600)
+				FILTER (WHERE sh.shift_end < NOW()), 0)::float8 AS hours,
+			MAX(sh.shift_end) FILTER (WHERE sh.shift_end < NOW()) AS last_served
+		FROM volunteer_shifts vs
+		JOIN shifts sh ON sh.shift_id = vs.shift_id
+		WHERE vs.volunteer_id = v.volunteer_id AND vs.cancelled_at IS NULL
+	) stats ON TRUE`
+
+// validateVolunteerFilter checks what it can without the database, so a
+// segment is not saved with a filter that can never run.
+func validateVolunteerFilter(filter *models.VolunteerFilterInput) *models.Error {
+	if filter == nil {
+		return nil
+	}
+	if near := filter.Near; near != nil {
+		hasVenue := near.VenueID != nil && strings.TrimSpace(*near.VenueID) != ""
+		hasZip := near.ZipCode != nil && strings.TrimSpace(*near.ZipCode) != ""
+		if hasVenue == hasZip {
+			return models.NewFieldError("near", "give either a venue or a zip code to measure distance from")
+		}
+		if near.Miles < 1 || near.Miles > maxVolunteerDistanceMiles {
+			return models.NewFieldError("near", "miles must be between 1 and %d", maxVolunteerDistanceMiles)
+		}
+	}
+	for _, bound := range []struct {
+		field string
+		val   *string
+	}{{"lastServedFrom", filter.LastServedFrom}, {"lastServedTo", filter.LastServedTo}} {
+		if bound.val != nil && *bound.val != "" {
+			if _, err := parseAuditTime(*bound.val, false); err != nil {
+				return models.NewFieldError(bound.field, "invalid date %q: use YYYY-MM-DD or RFC 3339", *bound.val)
+			}
+		}
+	}
+	if (filter.MinHours != nil && *filter.MinHours < 0) || (filter.MaxHours != nil && *filter.MaxHours < 0) {
+		return models.NewFieldError("minHours", "hours cannot be negative")
+	}
+	if filter.MinHours != nil && filter.MaxHours != nil && *filter.MinHours > *filter.MaxHours {
+		return models.NewFieldError("minHours", "minHours is more than maxHours")
+	}
+	if (filter.MinSignups != nil && *filter.MinSignups < 0) || (filter.MaxSignups != nil && *filter.MaxSignups < 0) {
+		return models.NewFieldError("minSignups", "signup counts cannot be negative")
+	}
+	if filter.MinSignups != nil && filter.MaxSignups != nil && *filter.MinSignups > *filter.MaxSignups {
+		return models.NewFieldError("minSignups", "minSignups is more than maxSignups")
+	}
+	return nil
+}
+
+// volunteerFilterConditions turns filter into WHERE conditions over
+// volunteers v and volunteerStatsJoin, appending their arguments to args.
+// Only active volunteers match unless filter.IsActive is false.
+func (s *VolunteerService) volunteerFilterConditions(ctx context.Context, filter *models.VolunteerFilterInput, args *[]any) ([]string, error) {
+	if verr := validateVolunteerFilter(filter); verr != nil {
+		return nil, verr
+	}
+	conds := []string{}
+	add := func(cond string, val any) {
+		*args = append(*args, val)
+		conds = append(conds, fmt.Sprintf(cond, len(*args)))
+	}
+
+	if filter == nil || filter.IsActive == nil {
+		conds = append(conds, "v.is_active = TRUE")
+	} else {
+		add("v.is_active = $%d", *filter.IsActive)
+	}
+	if filter == nil {
+		return conds, nil
+	}
+
+	like := func(col string, val *string) {
+		if val == nil || strings.TrimSpace(*val) == "" {
+			return
+		}
+		add(col+" ILIKE $%d", "%"+strings.TrimSpace(*val)+"%")
+	}
+	like("v.first_name", filter.FirstName)
+	like("v.last_name", filter.LastName)
+	like("v.email", filter.Email)
+
+	if len(filter.Roles) > 0 {
+		roles := make([]string, len(filter.Roles))
+		for i, r := range filter.Roles {
+			roles[i] = string(r)
+		}
+		add(`EXISTS (
+			SELECT 1 FROM volunteer_roles fvr
+			JOIN roles fr ON fr.role_id = fvr.role_id
+			WHERE fvr.volunteer_id = v.volunteer_id AND fr.role_name = ANY($%d))`, pq.Array(roles))
+	}
+
+	if filter.Near != nil {
+		lat, lng, err := s.distanceOrigin(ctx, filter.Near)
+		if err != nil {
+			return nil, err
+		}
+		*args = append(*args, lat, lng, filter.Near.Miles)
+		n := len(*args)
+		// Haversine, with the Earth's radius in miles as in fetchDistance.
+		conds = append(conds, fmt.Sprintf(`v.latitude IS NOT NULL AND v.longitude IS NOT NULL
+			AND 3958.8 * 2 * ASIN(LEAST(1, SQRT(
+				POWER(SIN(RADIANS(v.latitude::float8 - $%[1]d::float8) / 2), 2) +
+				COS(RADIANS($%[1]d::float8)) * COS(RADIANS(v.latitude::float8)) *
+				POWER(SIN(RADIANS(v.longitude::float8 - $%[2]d::float8) / 2), 2)))) <= $%[3]d`, n-2, n-1, n))
+	}
+
+	if filter.LastServedFrom != nil && *filter.LastServedFrom != "" {
+		from, _ := parseAuditTime(*filter.LastServedFrom, false)
+		add("stats.last_served >= $%d", from)
+	}
+	if filter.LastServedTo != nil && *filter.LastServedTo != "" {
+		to, _ := parseAuditTime(*filter.LastServedTo, true)
+		add("stats.last_served <= $%d", to)
+	}
+
+	if len(filter.JobTypeIDs) > 0 {
+		add(`EXISTS (
+			SELECT 1 FROM volunteer_shifts fvs
+			JOIN shifts fsh       ON fsh.shift_id = fvs.shift_id
+			JOIN opportunities fo ON fo.opportunity_id = fsh.opportunity_id
+			WHERE fvs.volunteer_id = v.volunteer_id AND fvs.cancelled_at IS NULL
+			  AND fsh.shift_end < NOW() AND fo.job_type_id = ANY($%d))`, pq.Array(filter.JobTypeIDs))
+	}
+
+	if filter.MinHours != nil {
+		add("stats.hours >= $%d", *filter.MinHours)
+	}
+	if filter.MaxHours != nil {
+		add("stats.hours <= $%d", *filter.MaxHours)
+	}
+	if filter.MinSignups != nil {
+		add("stats.signups >= $%d", *filter.MinSignups)
+	}
+	if filter.MaxSignups != nil {
+		add("stats.signups <= $%d", *filter.MaxSignups)
+	}
+	return conds, nil
+}
+
+// distanceOrigin is where near measures from: the venue's stored
+// coordinates, or for a zip code the coordinates already stored for venues
+// and volunteers there, geocoding it only if there are none.
+func (s *VolunteerService) distanceOrigin(ctx context.Context, near *models.VolunteerDistanceInput) (float64, float64, error) {
+	var lat, lng sql.NullFloat64
+	if near.VenueID != nil && strings.TrimSpace(*near.VenueID) != "" {
+		venueInt, err := strconv.Atoi(strings.TrimSpace(*near.VenueID))
+		if err != nil {
+			return 0, 0, models.InvalidID("near.venueId")
+		}
+		err = s.DB.QueryRowContext(ctx,
+			"SELECT latitude, longitude FROM venues WHERE venue_id = $1", venueInt).Scan(&lat, &lng)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, models.NewError(models.ErrorCodeNotFound, "venue not found")
+		}
+		if err != nil {
+			return 0, 0, fmt.Errorf("error getting venue coordinates: %w", err)
+		}
+		if !lat.Valid || !lng.Valid {
+			return 0, 0, models.NewFieldError("near", "that venue has no stored location; use a zip code instead")
+		}
+		return lat.Float64, lng.Float64, nil
+	}
+
+	zip := strings.TrimSpace(*near.ZipCode)
+	err := s.DB.QueryRowContext(ctx, `
+		SELECT AVG(latitude)::float8, AVG(longitude)::float8 FROM (
+			SELECT latitude, longitude FROM venues
+			WHERE zip_code = $1 AND latitude IS NOT NULL AND longitude IS NOT NULL
+			UNION ALL
+			SELECT latitude, longitude FROM volunteers
+			WHERE zip_code = $1 AND latitude IS NOT NULL AND longitude IS NOT NULL
+		) known
+	`, zip).Scan(&lat, &lng)
+	if err != nil {
+		return 0, 0, fmt.Errorf("error getting zip code coordinates: %w", err)
+	}
+	if lat.Valid && lng.Valid {
+		return lat.Float64, lng.Float64, nil
+	}
+	geoLat, geoLng, err := GeocodeZip(zip)
+	if err != nil || geoLat == nil || geoLng == nil {
+		return 0, 0, models.NewFieldError("near", "could not find zip code %q", zip)
+	}
+	return *geoLat, *geoLng, nil
+}
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"testing"
+
+	"volunteer-scheduler/models"
+)
+
+func TestValidateVolunteerFilter(t *testing.T) {
+	venue, zip := "12", "98101"
+	day, bad := "2026-01-31", "last tuesday"
+	one, two := 1.0, 2.0
+	neg, five, three := -1, 5, 3
+
+	valid := []*models.VolunteerFilterInput{
+		nil,
+		{},
+		{Near: &models.VolunteerDistanceInput{VenueID: &venue, Miles: 25}},
+		{Near: &models.VolunteerDistanceInput{ZipCode: &zip, Miles: maxVolunteerDistanceMiles}},
+		{LastServedFrom: &day, LastServedTo: &day},
+		{MinHours: &one, MaxHours: &two, MinSignups: &three, MaxSignups: &five},
+	}
+	for _, f := range valid {
+		if err := validateVolunteerFilter(f); err != nil {
+			t.Errorf("validateVolunteerFilter(%+v) = %v, want ok", f, err)
+		}
+	}
+
+	invalid := map[string]*models.VolunteerFilterInput{
+		"near":           {Near: &models.VolunteerDistanceInput{Miles: 10}},
+		"near both":      {Near: &models.VolunteerDistanceInput{VenueID: &venue, ZipCode: &zip, Miles: 10}},
+		"near miles":     {Near: &models.VolunteerDistanceInput{ZipCode: &zip, Miles: 0}},
+		"lastServedTo":   {LastServedTo: &bad},
+		"minHours":       {MinHours: &two, MaxHours: &one},
+		"minSignups":     {MinSignups: &neg},
+		"minSignups max": {MinSignups: &five, MaxSignups: &three},
+	}
+	for name, f := range invalid {
+		err := validateVolunteerFilter(f)
+		if err == nil || err.Code != models.ErrorCodeValidation {
+			t.Errorf("%s: validateVolunteerFilter = %v, want a validation error", name, err)
+		}
+	}
+}
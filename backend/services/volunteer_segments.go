@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"volunteer-scheduler/models"
+)
+
+const maxSegmentNameLength = 100
+
+const volunteerSegmentSelect = `
+	SELECT
+		g.id,
+		g.name,
+		g.filter,
+		g.created_by,
+		c.first_name || ' ' || c.last_name,
+		g.created_at,
+		g.updated_at
+	FROM volunteer_segments g
+	LEFT JOIN volunteers c ON c.volunteer_id = g.created_by`
+
+// FetchVolunteerSegments returns every saved segment, by name.
+func (s *VolunteerService) FetchVolunteerSegments(ctx context.Context) ([]*models.VolunteerSegment, error) {
+	rows, err := s.DB.QueryContext(ctx, volunteerSegmentSelect+`
+		ORDER BY LOWER(g.name)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("error querying volunteer segments: %w", err)
+	}
+	defer rows.Close()
+
+	segments := []*models.VolunteerSegment{}
+	for rows.Next() {
+		var seg models.VolunteerSegment
+		var id int
+		var filter []byte
+		var createdBy *int
+		err := rows.Scan(&id, &seg.Name, &filter, &createdBy, &seg.CreatedByName, &seg.CreatedAt, &seg.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning volunteer segment: %w", err)
+		}
+		seg.ID = strconv.Itoa(id)
+		if createdBy != nil {
+			creator := strconv.Itoa(*createdBy)
+			seg.CreatedByID = &creator
+		}
+		if err := json.Unmarshal(filter, &seg.Filter); err != nil {
+			return nil, fmt.Errorf("error reading filter of volunteer segment %d: %w", id, err)
+		}
+		segments = append(segments, &seg)
+	}
+	return segments, rows.Err()
+}
+
+// CreateVolunteerSegment saves filter under a new name.
+func (s *VolunteerService) CreateVolunteerSegment(ctx context.Context, adminId int, input models.NewVolunteerSegmentInput) (*models.MutationResult, error) {
+	name, verr := validateSegmentName(input.Name)
+	if verr == nil {
+		verr = validateVolunteerFilter(&input.Filter)
+	}
+	if verr != nil {
+		return nil, verr
+	}
+	filter, err := json.Marshal(input.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding volunteer filter: %w", err)
+	}
+
+	var id int
+	err = s.DB.QueryRowContext(ctx, `
+		INSERT INTO volunteer_segments (name, filter, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING id
+	`, name, filter, adminId).Scan(&id)
+	if err != nil {
+		return nil, friendlyDBError(err)
+	}
+	idStr := strconv.Itoa(id)
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Segment saved."),
+		ID:      &idStr,
+	}, nil
+}
+
+// UpdateVolunteerSegment renames a segment or replaces its filter.
+func (s *VolunteerService) UpdateVolunteerSegment(ctx context.Context, input models.UpdateVolunteerSegmentInput) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, models.InvalidID("id")
+	}
+
+	sets := []string{"updated_at = NOW()"}
+	args := []any{idInt}
+	if input.Name != nil {
+		name, verr := validateSegmentName(*input.Name)
+		if verr != nil {
+			return nil, verr
+		}
+		args = append(args, name)
+		sets = append(sets, fmt.Sprintf("name = $%d", len(args)))
+	}
+	if input.Filter != nil {
+		if verr := validateVolunteerFilter(input.Filter); verr != nil {
+			return nil, verr
+		}
+		filter, err := json.Marshal(input.Filter)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding volunteer filter: %w", err)
+		}
+		args = append(args, filter)
+		sets = append(sets, fmt.Sprintf("filter = $%d", len(args)))
+	}
+
+	res, err := s.DB.ExecContext(ctx,
+		"UPDATE volunteer_segments SET "+strings.Join(sets, ", ")+" WHERE id = $1", args...)
+	if err != nil {
+		return nil, friendlyDBError(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Segment not found.")
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Segment updated."),
+		ID:      &input.ID,
+	}, nil
+}
+
+// DeleteVolunteerSegment removes a saved segment.
+func (s *VolunteerService) DeleteVolunteerSegment(ctx context.Context, segmentId string) (*models.MutationResult, error) {
+	idInt, err := strconv.Atoi(segmentId)
+	if err != nil {
+		return nil, models.InvalidID("segmentId")
+	}
+
+	res, err := s.DB.ExecContext(ctx, "DELETE FROM volunteer_segments WHERE id = $1", idInt)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting volunteer segment: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Segment not found.")
+	}
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Segment deleted."),
+		ID:      &segmentId,
+	}, nil
+}
+
+func validateSegmentName(name string) (string, *models.Error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", models.NewFieldError("name", "name is required")
+	}
+	if len(name) > maxSegmentNameLength {
+		return "", models.NewFieldError("name", "name must be at most %d characters", maxSegmentNameLength)
+	}
+	return name, nil
+}
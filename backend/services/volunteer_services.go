@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"volunteer-scheduler/models"
 
@@ -26,31 +27,53 @@ func NewVolunteerService(db *sql.DB, mailer *Mailer) *VolunteerService {
 
 // Queries.
 
-// FetchVolunteers retrieves all volunteers with optional filtering
-func (s *VolunteerService) FetchVolunteers(ctx context.Context, filter *models.VolunteerFilterInput) ([]*models.Volunteer, error) {
-	// Get all volunteers for now.
-	// We may need a filter here, so it's in the input, but
-	// we'll deal with that when we know if we are looking
-	// up by email or name or ?? Darrell is working on that.
-
-	query := `
-		SELECT
-			v.volunteer_id,
-			v.first_name,
-			v.last_name,
-			v.email,
-			v.phone,
-			v.zip_code,
-			v.default_distance_miles,
-			COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles
-		FROM volunteers v
-		LEFT JOIN volunteer_roles vr ON vr.volunteer_id = v.volunteer_id
-		LEFT JOIN roles r            ON r.role_id = vr.role_id
-		WHERE v.is_active = TRUE
-		GROUP BY v.volunteer_id
+// volunteerListColumns and volunteerListFrom make up the admin volunteer list
+// query; rows are grouped by volunteerListGroupBy and read with
+// scanVolunteerRow.
+const volunteerListColumns = `
+	v.volunteer_id,
+	v.first_name,
+	v.last_name,
+	v.email,
+	v.phone,
+	v.zip_code,
+	v.default_distance_miles,
+	COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles,
+	ARRAY(
+		SELECT cfe.funding_entity_id FROM coordinator_funding_entities cfe
+		WHERE cfe.volunteer_id = v.volunteer_id
+		ORDER BY cfe.funding_entity_id
+	) AS funding_entity_ids,
+	es.reason,
+	stats.hours,
+	stats.signups,
+	stats.last_served`
+
+const volunteerListFrom = `
+	FROM volunteers v
+	LEFT JOIN volunteer_roles vr    ON vr.volunteer_id = v.volunteer_id
+	LEFT JOIN roles r               ON r.role_id = vr.role_id
+	LEFT JOIN email_suppressions es ON LOWER(es.email) = LOWER(v.email)` + volunteerStatsJoin
+
+const volunteerListGroupBy = `
+	GROUP BY v.volunteer_id, es.reason, stats.hours, stats.signups, stats.last_served`
+
+// FetchVolunteers retrieves the volunteers matching filter (see
+// volunteerFilterConditions), active ones only by default. Coordinators get
+// only the volunteers rostered on their funding entities' events.
+func (s *VolunteerService) FetchVolunteers(ctx context.Context, filter *models.VolunteerFilterInput, scope FundingScope) ([]*models.Volunteer, error) {
+	var args []any
+	conds, err := s.volunteerFilterConditions(ctx, filter, &args)
+	if err != nil {
+		return nil, err
+	}
+	if cond := volunteerScopeCondition(scope, &args); cond != "" {
+		conds = append(conds, cond)
+	}
+	query := `SELECT` + volunteerListColumns + volunteerListFrom + whereClause(conds) + volunteerListGroupBy + `
 		ORDER BY v.last_name, v.first_name
 	`
-	rows, err := s.DB.QueryContext(ctx, query)
+	rows, err := s.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("error querying volunteers: %w", err)
 	}
@@ -58,44 +81,199 @@ func (s *VolunteerService) FetchVolunteers(ctx context.Context, filter *models.V
 
 	var volunteers []*models.Volunteer
 	for rows.Next() {
-		var v models.Volunteer
-		var volInt int
-		var phone, zip sql.NullString
-		var ddm sql.NullInt32
-		var roleNames pq.StringArray
-
-		err := rows.Scan(
-			&volInt,
-			&v.FirstName,
-			&v.LastName,
-			&v.Email,
-			&phone,
-			&zip,
-			&ddm,
-			&roleNames)
+		v, err := scanVolunteerRow(rows.Scan)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning volunteer: %w", err)
+			return nil, err
 		}
-		if phone.Valid {
-			v.Phone = &phone.String
-		} else {
-			v.Phone = nil
+		volunteers = append(volunteers, v)
+	}
+
+	return volunteers, nil
+}
+
+// FetchAssignableVolunteers returns the active volunteers whose name contains
+// search (all of them when it is empty), with only their id and name filled
+// in. It is not scoped: coordinators use it to find volunteers who are not yet
+// on any of their rosters.
+func (s *VolunteerService) FetchAssignableVolunteers(ctx context.Context, search string) ([]*models.Volunteer, error) {
+	query := `
+		SELECT volunteer_id, first_name, last_name
+		FROM volunteers
+		WHERE is_active = TRUE
+		  AND ($1 = '' OR first_name || ' ' || last_name ILIKE '%' || $1 || '%')
+		ORDER BY last_name, first_name, volunteer_id
+	`
+	rows, err := s.DB.QueryContext(ctx, query, strings.TrimSpace(search))
+	if err != nil {
+		return nil, fmt.Errorf("error querying assignable volunteers: %w", err)
+	}
+	defer rows.Close()
+
+	var vols []*models.Volunteer
+	for rows.Next() {
+		var v models.Volunteer
+		var id int
+		if err := rows.Scan(&id, &v.FirstName, &v.LastName); err != nil {
+			return nil, fmt.Errorf("error scanning assignable volunteer: %w", err)
 		}
-		if zip.Valid {
-			v.ZipCode = &zip.String
-		} else {
-			v.ZipCode = nil
+		v.ID = strconv.Itoa(id)
+		vols = append(vols, &v)
+	}
+	return vols, rows.Err()
+}
+
+// volunteerSortOrders are the sort options of the admin volunteer connection.
+var volunteerSortOrders = map[models.VolunteerSortField]keysetOrder{
+	models.VolunteerSortLastName: {
+		expr:    "LOWER(v.last_name || ', ' || v.first_name)",
+		sqlType: "text",
+		idExpr:  "v.volunteer_id",
+	},
+	models.VolunteerSortFirstName: {
+		expr:    "LOWER(v.first_name || ' ' || v.last_name)",
+		sqlType: "text",
+		idExpr:  "v.volunteer_id",
+	},
+	models.VolunteerSortEmail: {
+		expr:    "LOWER(COALESCE(v.email, ''))",
+		sqlType: "text",
+		idExpr:  "v.volunteer_id",
+	},
+	models.VolunteerSortCreatedAt: {
+		expr:    "COALESCE(v.created_at, '-infinity'::timestamp)",
+		sqlType: "timestamp",
+		idExpr:  "v.volunteer_id",
+	},
+}
+
+// FetchVolunteersPage returns one page of the volunteers matching filter (see
+// volunteerFilterConditions). Coordinators get only the volunteers rostered
+// on their funding entities' events.
+func (s *VolunteerService) FetchVolunteersPage(ctx context.Context, filter *models.VolunteerFilterInput, scope FundingScope, sort *models.VolunteerSort, page *models.PageInput) (*models.VolunteerConnection, error) {
+	req, err := newPageRequest(page)
+	if err != nil {
+		return nil, err
+	}
+	order := volunteerSortOrders[models.VolunteerSortLastName]
+	if sort != nil {
+		o, ok := volunteerSortOrders[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown volunteer sort field %s", sort.Field)
 		}
-		if ddm.Valid {
-			dist := int(ddm.Int32)
-			v.Distance = &dist
+		order = o.withDirection(sort.Direction)
+	}
+
+	var args []any
+	conds, err := s.volunteerFilterConditions(ctx, filter, &args)
+	if err != nil {
+		return nil, err
+	}
+	if cond := volunteerScopeCondition(scope, &args); cond != "" {
+		conds = append(conds, cond)
+	}
+
+	conn := &models.VolunteerConnection{}
+	err = s.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM volunteers v`+volunteerStatsJoin+whereClause(conds), args...).Scan(&conn.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("error counting volunteers: %w", err)
+	}
+
+	if req.cursor != nil {
+		conds = append(conds, order.after(req.cursor, &args))
+	}
+	args = append(args, req.limit+1)
+	query := `SELECT` + volunteerListColumns + `, ` + order.selectKey() + volunteerListFrom +
+		whereClause(conds) + volunteerListGroupBy + `
+		ORDER BY ` + order.orderBy() + fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying volunteers: %w", err)
+	}
+	defer rows.Close()
+
+	var vols []*models.Volunteer
+	var keys []string
+	var ids []int
+	for rows.Next() {
+		var key string
+		v, err := scanVolunteerRow(rows.Scan, &key)
+		if err != nil {
+			return nil, err
 		}
-		v.ID = strconv.Itoa(volInt)
-		v.Roles = toModelRoles(roleNames)
-		volunteers = append(volunteers, &v)
+		id, _ := strconv.Atoi(v.ID)
+		vols = append(vols, v)
+		keys = append(keys, key)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading volunteers: %w", err)
 	}
 
-	return volunteers, nil
+	info, cursors := req.pageInfo(keys, ids)
+	conn.PageInfo = info
+	conn.Edges = make([]*models.VolunteerEdge, len(cursors))
+	for i, c := range cursors {
+		conn.Edges[i] = &models.VolunteerEdge{Cursor: c, Node: vols[i]}
+	}
+	return conn, nil
+}
+
+// scanVolunteerRow reads one row selected with volunteerListColumns, followed
+// by any extra columns.
+func scanVolunteerRow(scan func(...any) error, extra ...any) (*models.Volunteer, error) {
+	var v models.Volunteer
+	var volInt int
+	var phone, zip sql.NullString
+	var ddm sql.NullInt32
+	var roleNames pq.StringArray
+	var fundingEntityIDs pq.Int64Array
+	var suppression, lastServed sql.NullString
+	var hours float64
+	var signups int
+
+	dest := []any{
+		&volInt,
+		&v.FirstName,
+		&v.LastName,
+		&v.Email,
+		&phone,
+		&zip,
+		&ddm,
+		&roleNames,
+		&fundingEntityIDs,
+		&suppression,
+		&hours,
+		&signups,
+		&lastServed,
+	}
+	if err := scan(append(dest, extra...)...); err != nil {
+		return nil, fmt.Errorf("error scanning volunteer: %w", err)
+	}
+	v.HoursServed = &hours
+	v.SignupCount = &signups
+	if lastServed.Valid {
+		v.LastServedAt = &lastServed.String
+	}
+	if phone.Valid {
+		v.Phone = &phone.String
+	}
+	if zip.Valid {
+		v.ZipCode = &zip.String
+	}
+	if ddm.Valid {
+		dist := int(ddm.Int32)
+		v.Distance = &dist
+	}
+	v.ID = strconv.Itoa(volInt)
+	v.Roles = toModelRoles(roleNames)
+	v.FundingEntityIDs = toInts(fundingEntityIDs)
+	if suppression.Valid {
+		reason := models.EmailSuppressionReason(suppression.String)
+		v.EmailUndeliverable = &reason
+	}
+	return &v, nil
 }
 
 func (s *VolunteerService) FetchOwnProfile(ctx context.Context, volId int) (*models.VolunteerView, error) {
@@ -129,7 +307,7 @@ func (s *VolunteerService) FetchOwnProfile(ctx context.Context, volId int) (*mod
 		&roleNames)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("volunteer not found")
+		return nil, models.NewError(models.ErrorCodeNotFound, "volunteer not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error querying volunteer: %w", err)
@@ -164,17 +342,26 @@ func (s *VolunteerService) FetchVolunteer(ctx context.Context, volId int) (*mode
 			v.phone,
 			v.zip_code,
 			v.default_distance_miles,
-			COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles
+			COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}') AS roles,
+			ARRAY(
+				SELECT cfe.funding_entity_id FROM coordinator_funding_entities cfe
+				WHERE cfe.volunteer_id = v.volunteer_id
+				ORDER BY cfe.funding_entity_id
+			) AS funding_entity_ids,
+			es.reason
 		FROM volunteers v
-		LEFT JOIN volunteer_roles vr ON vr.volunteer_id = v.volunteer_id
-		LEFT JOIN roles r            ON r.role_id = vr.role_id
+		LEFT JOIN volunteer_roles vr    ON vr.volunteer_id = v.volunteer_id
+		LEFT JOIN roles r               ON r.role_id = vr.role_id
+		LEFT JOIN email_suppressions es ON LOWER(es.email) = LOWER(v.email)
 		WHERE v.volunteer_id = $1
-		GROUP BY v.volunteer_id
+		GROUP BY v.volunteer_id, es.reason
 	`
 	var profile models.Volunteer
 	var phone, zip sql.NullString
 	var ddm sql.NullInt32
 	var roleNames pq.StringArray
+	var fundingEntityIDs pq.Int64Array
+	var suppression sql.NullString
 
 	err := s.DB.QueryRowContext(ctx, query, volId).Scan(
 		&profile.FirstName,
@@ -183,10 +370,12 @@ func (s *VolunteerService) FetchVolunteer(ctx context.Context, volId int) (*mode
 		&phone,
 		&zip,
 		&ddm,
-		&roleNames)
+		&roleNames,
+		&fundingEntityIDs,
+		&suppression)
 
 	if err == sql.ErrNoRows {
-		return nil, fmt.Errorf("volunteer not found")
+		return nil, models.NewError(models.ErrorCodeNotFound, "volunteer not found")
 	}
 	if err != nil {
 		return nil, fmt.Errorf("error querying volunteer: %w", err)
@@ -207,6 +396,11 @@ func (s *VolunteerService) FetchVolunteer(ctx context.Context, volId int) (*mode
 		profile.Distance = &dist
 	}
 	profile.Roles = toModelRoles(roleNames)
+	profile.FundingEntityIDs = toInts(fundingEntityIDs)
+	if suppression.Valid {
+		reason := models.EmailSuppressionReason(suppression.String)
+		profile.EmailUndeliverable = &reason
+	}
 
 	return &profile, nil
 }
@@ -360,53 +554,70 @@ func (s *VolunteerService) FetchOwnShifts(ctx context.Context, volId int, filter
 	return shifts, nil
 }
 
-func (s *VolunteerService) FetchVolunteerShifts(ctx context.Context, volId string, filter models.ShiftsTimeFilter) ([]*models.VolunteerShift, error) {
+// volunteerShiftColumns and volunteerShiftFrom make up the admin query for a
+// volunteer's shifts; rows are read with scanVolunteerShiftRow.
+const volunteerShiftColumns = `
+	sv.shift_id,
+	sv.assigned_at,
+	sv.cancelled_at,
+	s.shift_start,
+	s.shift_end,
+	s.max_volunteers,
+	jt.name,
+	opp.opportunity_is_virtual,
+	opp.pre_event_instructions,
+	e.event_id,
+	e.event_name,
+	e.description,
+	v.venue_name,
+	v.street_address,
+	v.city,
+	v.state,
+	v.zip_code,
+	e.timezone`
+
+const volunteerShiftFrom = `
+	FROM volunteer_shifts sv
+	JOIN shifts s ON s.shift_id = sv.shift_id
+	JOIN opportunities opp ON opp.opportunity_id = s.opportunity_id
+	LEFT JOIN job_types jt ON jt.job_type_id = opp.job_type_id
+	JOIN events e ON e.event_id = opp.event_id
+	LEFT JOIN venues v ON e.venue_id = v.venue_id`
+
+// shiftTimeCondition restricts volunteer shifts to the filter's time frame.
+func shiftTimeCondition(filter models.ShiftsTimeFilter) string {
+	switch filter {
+	case "UPCOMING":
+		return "s.shift_start >= NOW()"
+	case "PAST":
+		return "s.shift_start < NOW()"
+	}
+	return ""
+}
+
+// FetchVolunteerShifts returns a volunteer's shifts in filter's time window,
+// limited to the scope's funding entities.
+func (s *VolunteerService) FetchVolunteerShifts(ctx context.Context, volId string, filter models.ShiftsTimeFilter, scope FundingScope) ([]*models.VolunteerShift, error) {
 
 	volInt, err := strconv.Atoi(volId)
 	if err != nil {
 		return nil, fmt.Errorf("volunteer id is not valid: %w", err)
 	}
 
-	query := `
-        SELECT
-			sv.shift_id,
-			sv.assigned_at,
-			sv.cancelled_at,
-			s.shift_start,
-			s.shift_end,
-			s.max_volunteers,
-			jt.name,
-			opp.opportunity_is_virtual,
-			opp.pre_event_instructions,
-            e.event_id,
-            e.event_name,
-            e.description,
-            v.venue_name,
-            v.street_address,
-            v.city,
-            v.state,
-            v.zip_code,
-			e.timezone
-    	FROM volunteer_shifts sv
-		JOIN shifts s ON s.shift_id = sv.shift_id
-		JOIN opportunities opp ON opp.opportunity_id = s.opportunity_id
-		LEFT JOIN job_types jt ON jt.job_type_id = opp.job_type_id
-		JOIN events e ON e.event_id = opp.event_id
-		LEFT JOIN venues v ON e.venue_id = v.venue_id
+	args := []any{volInt}
+	query := `SELECT` + volunteerShiftColumns + volunteerShiftFrom + `
 		WHERE sv.volunteer_id = $1
 		AND sv.cancelled_at IS NULL
     `
-	switch filter {
-	case "UPCOMING":
-		query += " AND s.shift_start >= NOW()"
-	case "PAST":
-		query += " AND s.shift_start < NOW()"
-	case "ALL":
-		// no filter
+	if cond := shiftTimeCondition(filter); cond != "" {
+		query += " AND " + cond
+	}
+	if cond := eventScopeCondition(scope, &args); cond != "" {
+		query += " AND " + cond
 	}
 	query += " ORDER BY s.shift_start"
 
-	shiftRows, err := s.DB.QueryContext(ctx, query, volInt)
+	shiftRows, err := s.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -415,84 +626,15 @@ func (s *VolunteerService) FetchVolunteerShifts(ctx context.Context, volId strin
 	shiftsMap := make(map[int]*models.VolunteerShift)
 
 	for shiftRows.Next() {
-		var volShift models.VolunteerShift
-		var shiftInt, eventInt int
-		var cancelledAt, preEventInst, eventDesc, timezone sql.NullString
-		var venueName, streetAddress, city, state, zip sql.NullString
-		var maxVols sql.NullInt64
-
-		err := shiftRows.Scan(
-			&shiftInt,
-			&volShift.AssignedAt,
-			&cancelledAt,
-			&volShift.StartDateTime,
-			&volShift.EndDateTime,
-			&maxVols,
-			&volShift.JobName,
-			&volShift.IsVirtual,
-			&preEventInst,
-			&eventInt,
-			&volShift.EventName,
-			&eventDesc,
-			&venueName,
-			&streetAddress,
-			&city,
-			&state,
-			&zip,
-			&timezone,
-		)
+		volShift, err := scanVolunteerShiftRow(shiftRows.Scan)
 		if err != nil {
-			return nil, fmt.Errorf("error scanning shift row: %w", err)
-		}
-
-		volShift.ShiftId = strconv.Itoa(shiftInt)
-		volShift.EventId = strconv.Itoa(eventInt)
-		if cancelledAt.Valid {
-			volShift.CancelledAt = &cancelledAt.String
-		} else {
-			volShift.CancelledAt = nil
-		}
-		if maxVols.Valid {
-			maxVolInt := int(maxVols.Int64)
-			volShift.MaxVolunteers = &maxVolInt
-		} else {
-			volShift.MaxVolunteers = nil
-		}
-		if preEventInst.Valid {
-			volShift.PreEventInstructions = &preEventInst.String
-		} else {
-			volShift.PreEventInstructions = nil
-		}
-		if eventDesc.Valid {
-			volShift.EventDescription = &eventDesc.String
-		} else {
-			volShift.EventDescription = nil
-		}
-		if streetAddress.Valid {
-			// If we have one of these, we must have them all.
-			volShift.Venue = &models.Venue{
-				Address: streetAddress.String,
-				City:    city.String,
-				State:   state.String,
-			}
-			// Name and zip are optional.
-			if venueName.Valid {
-				volShift.Venue.Name = &venueName.String
-			} else {
-				volShift.Venue.Name = nil
-			}
-			if zip.Valid {
-				volShift.Venue.ZipCode = &zip.String
-			} else {
-				volShift.Venue.ZipCode = nil
-			}
-		} else {
-			volShift.Venue = nil
+			return nil, err
 		}
 
+		shiftInt, _ := strconv.Atoi(volShift.ShiftId)
 		_, exists := shiftsMap[shiftInt]
 		if !exists {
-			shiftsMap[shiftInt] = &volShift
+			shiftsMap[shiftInt] = volShift
 		}
 	}
 
@@ -505,6 +647,165 @@ func (s *VolunteerService) FetchVolunteerShifts(ctx context.Context, volId strin
 	return shifts, nil
 }
 
+// volunteerShiftSortOrders are the sort options of the admin volunteer shift
+// connection. A volunteer has at most one row per shift, so the shift id
+// breaks ties.
+var volunteerShiftSortOrders = map[models.VolunteerShiftSortField]keysetOrder{
+	models.VolunteerShiftSortStart: {
+		expr:    "s.shift_start",
+		sqlType: "timestamp",
+		idExpr:  "sv.shift_id",
+	},
+	models.VolunteerShiftSortAssignedAt: {
+		expr:    "COALESCE(sv.assigned_at, '-infinity'::timestamp)",
+		sqlType: "timestamp",
+		idExpr:  "sv.shift_id",
+	},
+}
+
+// FetchVolunteerShiftsPage returns one page of a volunteer's current shifts
+// in the filter's time frame, limited to the events in scope.
+func (s *VolunteerService) FetchVolunteerShiftsPage(ctx context.Context, volId string, filter models.ShiftsTimeFilter, scope FundingScope, sort *models.VolunteerShiftSort, page *models.PageInput) (*models.VolunteerShiftConnection, error) {
+	volInt, err := strconv.Atoi(volId)
+	if err != nil {
+		return nil, fmt.Errorf("volunteer id is not valid: %w", err)
+	}
+	req, err := newPageRequest(page)
+	if err != nil {
+		return nil, err
+	}
+	order := volunteerShiftSortOrders[models.VolunteerShiftSortStart]
+	if sort != nil {
+		o, ok := volunteerShiftSortOrders[sort.Field]
+		if !ok {
+			return nil, fmt.Errorf("unknown volunteer shift sort field %s", sort.Field)
+		}
+		order = o.withDirection(sort.Direction)
+	}
+
+	conds := []string{"sv.volunteer_id = $1", "sv.cancelled_at IS NULL"}
+	args := []any{volInt}
+	if cond := shiftTimeCondition(filter); cond != "" {
+		conds = append(conds, cond)
+	}
+	if cond := eventScopeCondition(scope, &args); cond != "" {
+		conds = append(conds, cond)
+	}
+
+	conn := &models.VolunteerShiftConnection{}
+	err = s.DB.QueryRowContext(ctx,
+		`SELECT COUNT(*)`+volunteerShiftFrom+whereClause(conds), args...).Scan(&conn.TotalCount)
+	if err != nil {
+		return nil, fmt.Errorf("error counting volunteer shifts: %w", err)
+	}
+
+	if req.cursor != nil {
+		conds = append(conds, order.after(req.cursor, &args))
+	}
+	args = append(args, req.limit+1)
+	query := `SELECT` + volunteerShiftColumns + `, ` + order.selectKey() + volunteerShiftFrom +
+		whereClause(conds) + `
+		ORDER BY ` + order.orderBy() + fmt.Sprintf(" LIMIT $%d", len(args))
+
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error querying volunteer shifts: %w", err)
+	}
+	defer rows.Close()
+
+	var shifts []*models.VolunteerShift
+	var keys []string
+	var ids []int
+	for rows.Next() {
+		var key string
+		sh, err := scanVolunteerShiftRow(rows.Scan, &key)
+		if err != nil {
+			return nil, err
+		}
+		id, _ := strconv.Atoi(sh.ShiftId)
+		shifts = append(shifts, sh)
+		keys = append(keys, key)
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error reading volunteer shifts: %w", err)
+	}
+
+	info, cursors := req.pageInfo(keys, ids)
+	conn.PageInfo = info
+	conn.Edges = make([]*models.VolunteerShiftEdge, len(cursors))
+	for i, c := range cursors {
+		conn.Edges[i] = &models.VolunteerShiftEdge{Cursor: c, Node: shifts[i]}
+	}
+	return conn, nil
+}
+
+// scanVolunteerShiftRow reads one row selected with volunteerShiftColumns,
+// followed by any extra columns.
+func scanVolunteerShiftRow(scan func(...any) error, extra ...any) (*models.VolunteerShift, error) {
+	var volShift models.VolunteerShift
+	var shiftInt, eventInt int
+	var cancelledAt, preEventInst, eventDesc, timezone sql.NullString
+	var venueName, streetAddress, city, state, zip sql.NullString
+	var maxVols sql.NullInt64
+
+	dest := []any{
+		&shiftInt,
+		&volShift.AssignedAt,
+		&cancelledAt,
+		&volShift.StartDateTime,
+		&volShift.EndDateTime,
+		&maxVols,
+		&volShift.JobName,
+		&volShift.IsVirtual,
+		&preEventInst,
+		&eventInt,
+		&volShift.EventName,
+		&eventDesc,
+		&venueName,
+		&streetAddress,
+		&city,
+		&state,
+		&zip,
+		&timezone,
+	}
+	if err := scan(append(dest, extra...)...); err != nil {
+		return nil, fmt.Errorf("error scanning shift row: %w", err)
+	}
+
+	volShift.ShiftId = strconv.Itoa(shiftInt)
+	volShift.EventId = strconv.Itoa(eventInt)
+	if cancelledAt.Valid {
+		volShift.CancelledAt = &cancelledAt.String
+	}
+	if maxVols.Valid {
+		maxVolInt := int(maxVols.Int64)
+		volShift.MaxVolunteers = &maxVolInt
+	}
+	if preEventInst.Valid {
+		volShift.PreEventInstructions = &preEventInst.String
+	}
+	if eventDesc.Valid {
+		volShift.EventDescription = &eventDesc.String
+	}
+	if streetAddress.Valid {
+		// If we have one of these, we must have them all.
+		volShift.Venue = &models.Venue{
+			Address: streetAddress.String,
+			City:    city.String,
+			State:   state.String,
+		}
+		// Name and zip are optional.
+		if venueName.Valid {
+			volShift.Venue.Name = &venueName.String
+		}
+		if zip.Valid {
+			volShift.Venue.ZipCode = &zip.String
+		}
+	}
+	return &volShift, nil
+}
+
 // Mutations.
 
 func (s *VolunteerService) UpdateOwnProfile(ctx context.Context, volId int, profile models.UpdateOwnProfileInput) (*models.VolunteerMutationResult, error) {
@@ -547,6 +848,10 @@ func (s *VolunteerService) UpdateOwnProfile(ctx context.Context, volId int, prof
 // CreateVolunteer is the resolver for the createVolunteer field.
 func (s *VolunteerService) CreateVolunteer(ctx context.Context, creatorId int, newVol models.NewVolunteerInput) (*models.MutationResult, error) {
 
+	if err := validateRoleAssignment(newVol.Role, newVol.FundingEntityIDs); err != nil {
+		return nil, err
+	}
+
 	var lat, lng *float64
 	var err error
 	if newVol.ZipCode != nil {
@@ -575,37 +880,65 @@ func (s *VolunteerService) CreateVolunteer(ctx context.Context, creatorId int, n
 	var volInt int
 	err = s.DB.QueryRowContext(ctx, query, newVol.FirstName, newVol.LastName, newVol.Email, newVol.Phone, newVol.ZipCode, newVol.Distance, lat, lng).Scan(&volInt)
 	if err != nil {
-		friendly := friendlyDBError(err)
-		return &models.MutationResult{
-			Success: false,
-			Message: ptrString(friendly.Error()),
-			ID:      nil,
-		}, friendly
+		return nil, friendlyDBError(err)
 	}
 
-	// Insert roles into the junction table.
-	// ADMINISTRATOR always implies VOLUNTEER (business rule).
-	rolesToInsert := []models.Role{models.RoleVolunteer}
-	if newVol.Role == models.RoleAdministrator {
-		rolesToInsert = append(rolesToInsert, models.RoleAdministrator)
+	// Insert roles (and a coordinator's funding entities) into the junction tables.
+	if err := assignRoles(ctx, s.DB, volInt, newVol.Role, newVol.FundingEntityIDs); err != nil {
+		log.Printf("Warning: could not assign role %s to volunteer %d: %v", newVol.Role, volInt, err)
 	}
-	for _, r := range rolesToInsert {
-		_, err = s.DB.ExecContext(ctx, `
-			INSERT INTO volunteer_roles (volunteer_id, role_id)
-			SELECT $1, role_id FROM roles WHERE role_name = $2
-		`, volInt, string(r))
-		if err != nil {
-			log.Printf("Warning: could not assign role %s to volunteer %d: %v", r, volInt, err)
-		}
+
+	s.notifyAccountCreated(ctx, creatorId, newVol.FirstName, newVol.LastName, newVol.Email, newVol.Role)
+
+	volStr := strconv.Itoa(volInt)
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Successfullly created volunteer."),
+		ID:      &volStr,
+	}, nil
+}
+
+// ReactivateVolunteer restores a deactivated volunteer with the given role,
+// keeping their shift history, and welcomes them back as CreateVolunteer
+// welcomes a new one.
+func (s *VolunteerService) ReactivateVolunteer(ctx context.Context, creatorId int, volId int, role models.Role, fundingEntityIDs []int) (*models.MutationResult, error) {
+
+	if err := validateRoleAssignment(role, fundingEntityIDs); err != nil {
+		return nil, err
 	}
 
-	var role string
-	if newVol.Role == models.RoleVolunteer {
-		role = "volunteer"
-	} else {
-		role = "administrator"
+	var firstName, lastName, email string
+	err := s.DB.QueryRowContext(ctx, `
+		UPDATE volunteers SET is_active = TRUE
+		WHERE volunteer_id = $1 AND is_active = FALSE
+		RETURNING first_name, last_name, email
+	`, volId).Scan(&firstName, &lastName, &email)
+	if err == sql.ErrNoRows {
+		return nil, models.NewError(models.ErrorCodeNotFound, "Volunteer not found or already active.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to reactivate volunteer %d: %w", volId, err)
+	}
+
+	if err := assignRoles(ctx, s.DB, volId, role, fundingEntityIDs); err != nil {
+		log.Printf("Warning: could not assign role %s to volunteer %d: %v", role, volId, err)
 	}
 
+	s.notifyAccountCreated(ctx, creatorId, firstName, lastName, email, role)
+
+	volStr := strconv.Itoa(volId)
+	return &models.MutationResult{
+		Success: true,
+		Message: ptrString("Volunteer successfully reactivated."),
+		ID:      &volStr,
+	}, nil
+}
+
+// notifyAccountCreated sends the welcome email to a new (or reactivated)
+// volunteer and tells all admins who created the account. Failures are logged.
+func (s *VolunteerService) notifyAccountCreated(ctx context.Context, creatorId int, firstName, lastName, email string, role models.Role) {
+	roleName := strings.ToLower(string(role))
+
 	// Get the creating admin's email for the notification.
 	createdByEmail, err := fetchEmailByVolId(ctx, s.DB, creatorId)
 	if err != nil {
@@ -614,23 +947,16 @@ func (s *VolunteerService) CreateVolunteer(ctx context.Context, creatorId int, n
 	}
 
 	// Welcome email to the new volunteer.
-	err = sendAccountCreated(ctx, s.mailer, newVol.FirstName, newVol.LastName, newVol.Email, role)
+	err = sendAccountCreated(ctx, s.mailer, firstName, lastName, email, roleName)
 	if err != nil {
-		log.Printf("Warning: failed to send welcome email to %s: %v", newVol.Email, err)
+		log.Printf("Warning: failed to send welcome email to %s: %v", email, err)
 	}
 
 	// Notification to all admins.
-	err = sendAccountCreatedAdminNotification(ctx, s.DB, s.mailer, newVol.FirstName, newVol.LastName, newVol.Email, role, createdByEmail)
+	err = sendAccountCreatedAdminNotification(ctx, s.DB, s.mailer, firstName, lastName, email, roleName, createdByEmail)
 	if err != nil {
-		log.Printf("Warning: failed to send admin notification for %s: %v", newVol.Email, err)
+		log.Printf("Warning: failed to send admin notification for %s: %v", email, err)
 	}
-
-	volStr := strconv.Itoa(volInt)
-	return &models.MutationResult{
-		Success: true,
-		Message: ptrString("Successfullly created volunteer."),
-		ID:      &volStr,
-	}, nil
 }
 
 // UpdateVolunteerProfile
@@ -642,7 +968,11 @@ func (s *VolunteerService) UpdateVolunteerProfile(ctx context.Context, profile m
 
 	volInt, err := strconv.Atoi(profile.ID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update profile - invalid id %s: %w", profile.ID, err)
+		return nil, models.InvalidID("id")
+	}
+
+	if err := validateRoleAssignment(profile.Role, profile.FundingEntityIDs); err != nil {
+		return nil, err
 	}
 
 	var lat, lng *float64
@@ -672,25 +1002,9 @@ func (s *VolunteerService) UpdateVolunteerProfile(ctx context.Context, profile m
 		return nil, friendlyDBError(err)
 	}
 
-	// Replace the volunteer's roles in the junction table.
-	// Delete all current roles, then re-insert the requested set.
-	// ADMINISTRATOR always implies VOLUNTEER.
-	_, err = s.DB.ExecContext(ctx, "DELETE FROM volunteer_roles WHERE volunteer_id = $1", volInt)
-	if err != nil {
-		return nil, fmt.Errorf("could not clear volunteer roles: %w", err)
-	}
-	rolesToInsert := []models.Role{models.RoleVolunteer}
-	if profile.Role == models.RoleAdministrator {
-		rolesToInsert = append(rolesToInsert, models.RoleAdministrator)
-	}
-	for _, r := range rolesToInsert {
-		_, err = s.DB.ExecContext(ctx, `
-			INSERT INTO volunteer_roles (volunteer_id, role_id)
-			SELECT $1, role_id FROM roles WHERE role_name = $2
-		`, volInt, string(r))
-		if err != nil {
-			return nil, fmt.Errorf("could not assign role %s: %w", r, err)
-		}
+	// Replace the volunteer's roles (and funding entity scope) in the junction tables.
+	if err := assignRoles(ctx, s.DB, volInt, profile.Role, profile.FundingEntityIDs); err != nil {
+		return nil, err
 	}
 
 	return &models.MutationResult{
@@ -732,3 +1046,74 @@ func toModelRoles(names pq.StringArray) []models.Role {
 	}
 	return roles
 }
+
+// toInts converts a pq.Int64Array into a []int slice.
+func toInts(a pq.Int64Array) []int {
+	ints := make([]int, len(a))
+	for i, n := range a {
+		ints[i] = int(n)
+	}
+	return ints
+}
+
+// validateRoleAssignment checks that a coordinator is given at least one
+// funding entity to manage, and that nobody else is given any.
+func validateRoleAssignment(role models.Role, fundingEntityIDs []int) error {
+	switch role {
+	case models.RoleCoordinator:
+		if len(fundingEntityIDs) == 0 {
+			return models.NewFieldError("fundingEntityIds", "a coordinator must be assigned at least one funding entity")
+		}
+	case models.RoleVolunteer, models.RoleAdministrator:
+		if len(fundingEntityIDs) > 0 {
+			return models.NewFieldError("fundingEntityIds", "funding entities can only be assigned to coordinators")
+		}
+	default:
+		return models.NewFieldError("role", "unknown role %s", role)
+	}
+	return nil
+}
+
+// assignRoles replaces a volunteer's roles and coordinator funding entities.
+// ADMINISTRATOR and COORDINATOR always imply VOLUNTEER (business rule).
+func assignRoles(ctx context.Context, db *sql.DB, volId int, role models.Role, fundingEntityIDs []int) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not begin role update: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM volunteer_roles WHERE volunteer_id = $1", volId); err != nil {
+		return fmt.Errorf("could not clear volunteer roles: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM coordinator_funding_entities WHERE volunteer_id = $1", volId); err != nil {
+		return fmt.Errorf("could not clear coordinator funding entities: %w", err)
+	}
+
+	rolesToInsert := []models.Role{models.RoleVolunteer}
+	if role != models.RoleVolunteer {
+		rolesToInsert = append(rolesToInsert, role)
+	}
+	for _, r := range rolesToInsert {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO volunteer_roles (volunteer_id, role_id)
+			SELECT $1, role_id FROM roles WHERE role_name = $2
+		`, volId, string(r))
+		if err != nil {
+			return fmt.Errorf("could not assign role %s: %w", r, err)
+		}
+	}
+
+	for _, fe := range fundingEntityIDs {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO coordinator_funding_entities (volunteer_id, funding_entity_id)
+			VALUES ($1, $2)
+			ON CONFLICT DO NOTHING
+		`, volId, fe)
+		if err != nil {
+			return friendlyDBError(err)
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,135 @@
+// Package sso serves the browser-facing endpoints of OpenID Connect single
+// sign-on. It sits next to the magic-link flow: a successful sign-in ends
+// with the same "session" cookie the login mutation sets.
+package sso
+
+import (
+	"crypto/subtle"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/services"
+)
+
+// stateCookie binds a sign-in attempt to the browser that started it, so a
+// callback URL cannot be replayed in (or forced onto) another browser.
+const stateCookie = "oidc_state"
+
+// Handlers serves GET /auth/oidc/login and GET /auth/oidc/callback.
+type Handlers struct {
+	OIDC      *services.OIDCService
+	MagicLink *services.MagicLinkService
+
+	// FrontendURL is where the browser lands afterwards: the app root on
+	// success, /login?sso_error=<code> on failure.
+	FrontendURL string
+	IsProd      bool
+}
+
+// Login starts a sign-in and redirects the browser to the identity provider.
+func (h *Handlers) Login() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		authURL, state, err := h.OIDC.BeginLogin(r.Context())
+		if err != nil {
+			log.Printf("sso: begin login: %v", err)
+			h.redirectError(w, r, "unavailable")
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     stateCookie,
+			Value:    state,
+			Path:     "/auth/oidc",
+			MaxAge:   600,
+			HttpOnly: true,
+			Secure:   h.IsProd,
+			// Lax is sent on the provider's top-level redirect back to us.
+			SameSite: http.SameSiteLaxMode,
+		})
+		http.Redirect(w, r, authURL, http.StatusFound)
+	})
+}
+
+// Callback receives the provider's redirect, signs the volunteer in and sends
+// the browser on to the frontend.
+func (h *Handlers) Callback() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		// The state cookie is single-use whatever happens next.
+		http.SetCookie(w, &http.Cookie{
+			Name:     stateCookie,
+			Path:     "/auth/oidc",
+			MaxAge:   -1,
+			HttpOnly: true,
+			Secure:   h.IsProd,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		q := r.URL.Query()
+		if q.Get("error") != "" {
+			// e.g. access_denied when the user cancels at the provider.
+			h.redirectError(w, r, "cancelled")
+			return
+		}
+
+		state := q.Get("state")
+		cookie, err := r.Cookie(stateCookie)
+		if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(state)) != 1 {
+			h.redirectError(w, r, "expired")
+			return
+		}
+
+		email, err := h.OIDC.CompleteLogin(r.Context(), state, q.Get("code"))
+		if err != nil {
+			h.redirectError(w, r, errorCode(err))
+			return
+		}
+
+		token, expiresAt, err := h.MagicLink.CreateSessionToken(r.Context(), email,
+			middleware.ClientIP(r), r.UserAgent(), false)
+		if err != nil {
+			log.Printf("sso: create session for %s: %v", email, err)
+			h.redirectError(w, r, "unavailable")
+			return
+		}
+		middleware.SetSessionCookie(w, token, expiresAt, h.IsProd)
+
+		http.Redirect(w, r, h.frontend("/"), http.StatusFound)
+	})
+}
+
+// errorCode maps a sign-in failure to the sso_error value the login page shows.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, services.ErrOIDCInvalidState):
+		return "expired"
+	case errors.Is(err, services.ErrOIDCEmailUnverified):
+		return "unverified_email"
+	case errors.Is(err, services.ErrOIDCDomainNotAllowed):
+		return "domain_not_allowed"
+	case errors.Is(err, services.ErrOIDCNoAccount):
+		return "no_account"
+	}
+	log.Printf("sso: complete login: %v", err)
+	return "unavailable"
+}
+
+func (h *Handlers) redirectError(w http.ResponseWriter, r *http.Request, code string) {
+	http.Redirect(w, r, h.frontend("/login?sso_error="+url.QueryEscape(code)), http.StatusFound)
+}
+
+func (h *Handlers) frontend(path string) string {
+	return strings.TrimSuffix(h.FrontendURL, "/") + path
+}
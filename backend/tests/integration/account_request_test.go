@@ -0,0 +1,316 @@
+package integration
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const (
+	mutVerifyAccountRequest = `
+		mutation Verify($token: String!) {
+			verifyAccountRequest(token: $token) { success message }
+		}`
+
+	qryAccountRequests = `
+		query AccountRequests($status: AccountRequestStatus) {
+			accountRequests(status: $status) {
+				id email firstName lastName status existingVolunteerId
+				decidedById decisionMessage volunteerId
+			}
+		}`
+
+	mutApproveAccountRequest = `
+		mutation Approve($id: ID!, $input: ApproveAccountRequestInput) {
+			approveAccountRequest(requestId: $id, input: $input) { success message id }
+		}`
+
+	mutDenyAccountRequest = `
+		mutation Deny($id: ID!, $message: String) {
+			denyAccountRequest(requestId: $id, message: $message) { success message }
+		}`
+)
+
+type accountRequest struct {
+	ID                  string  `json:"id"`
+	Email               string  `json:"email"`
+	FirstName           string  `json:"firstName"`
+	Status              string  `json:"status"`
+	ExistingVolunteerID *string `json:"existingVolunteerId"`
+	DecidedByID         *string `json:"decidedById"`
+	DecisionMessage     *string `json:"decisionMessage"`
+	VolunteerID         *string `json:"volunteerId"`
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// submitAccountRequest calls requestAccount for email and returns a token
+// that verifies it. The emailed token is stored hashed, so a known one is
+// swapped in.
+func submitAccountRequest(t *testing.T, email, firstName, lastName string) string {
+	t.Helper()
+	t.Cleanup(func() { testDB.Exec("DELETE FROM account_requests WHERE LOWER(email) = LOWER($1)", email) })
+
+	resp := gqlPost(t, "/graphql/auth", "", mutRequestAccount, map[string]any{
+		"email": email, "firstName": firstName, "lastName": lastName,
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("requestAccount: unexpected errors: %v", resp.Errors)
+	}
+
+	token := "verify-" + email
+	testDB.Exec("UPDATE account_requests SET verify_token = $1 WHERE LOWER(email) = LOWER($2) AND verified_at IS NULL",
+		hashSessionToken(token), email)
+	return token
+}
+
+// verifyAccountRequest submits token and reports whether it was accepted.
+func verifyAccountRequest(t *testing.T, token string) bool {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/auth", "", mutVerifyAccountRequest, map[string]any{"token": token})
+	if hasGQLErrors(resp) {
+		t.Fatalf("verifyAccountRequest: unexpected errors: %v", resp.Errors)
+	}
+	var result struct {
+		Success bool `json:"success"`
+	}
+	unmarshalField(t, resp, "verifyAccountRequest", &result)
+	return result.Success
+}
+
+// queuedRequest returns email's request from the admin queue with the given
+// status, or nil.
+func queuedRequest(t *testing.T, adminToken, email, status string) *accountRequest {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryAccountRequests, map[string]any{"status": status})
+	if hasGQLErrors(resp) {
+		t.Fatalf("accountRequests: unexpected errors: %v", resp.Errors)
+	}
+	var requests []accountRequest
+	unmarshalField(t, resp, "accountRequests", &requests)
+	for _, r := range requests {
+		if r.Email == email {
+			return &r
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Requesting and verifying
+// ============================================================================
+
+// TestAccountRequest_QueuedOnceVerified verifies a request is stored but kept
+// from admins until the requester confirms their address.
+func TestAccountRequest_QueuedOnceVerified(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	email := uniqueEmail(t)
+	token := submitAccountRequest(t, email, "Queued", "Requester")
+
+	if !rowExists(t, "SELECT COUNT(*) FROM account_requests WHERE email = $1 AND status = 'PENDING' AND verified_at IS NULL", email) {
+		t.Fatal("expected an unverified request to be stored")
+	}
+	if queuedRequest(t, adminToken, email, "PENDING") != nil {
+		t.Error("an unverified request must not be shown to admins")
+	}
+
+	if !verifyAccountRequest(t, token) {
+		t.Fatal("expected verification to succeed")
+	}
+	r := queuedRequest(t, adminToken, email, "PENDING")
+	if r == nil || r.FirstName != "Queued" {
+		t.Fatalf("expected the verified request in the queue, got %+v", r)
+	}
+
+	if verifyAccountRequest(t, token) {
+		t.Error("a verification link must work only once")
+	}
+}
+
+// TestAccountRequest_RepeatRefreshes verifies asking again keeps one request.
+func TestAccountRequest_RepeatRefreshes(t *testing.T) {
+	email := uniqueEmail(t)
+	submitAccountRequest(t, email, "First", "Try")
+	token := submitAccountRequest(t, email, "Second", "Try")
+
+	var count int
+	testDB.QueryRow("SELECT COUNT(*) FROM account_requests WHERE email = $1", email).Scan(&count)
+	if count != 1 {
+		t.Fatalf("expected one request, got %d", count)
+	}
+	if !verifyAccountRequest(t, token) {
+		t.Fatal("expected the refreshed link to verify")
+	}
+
+	// Once verified, another request leaves the queued one alone.
+	submitAccountRequest(t, email, "Third", "Try")
+	if !rowExists(t, "SELECT COUNT(*) FROM account_requests WHERE email = $1 AND first_name = 'Second' AND verified_at IS NOT NULL", email) {
+		t.Error("expected the verified request to be unchanged")
+	}
+}
+
+// TestAccountRequest_ExpiredLink verifies an expired link is refused.
+func TestAccountRequest_ExpiredLink(t *testing.T) {
+	email := uniqueEmail(t)
+	token := submitAccountRequest(t, email, "Late", "Requester")
+	testDB.Exec("UPDATE account_requests SET verify_expires_at = NOW() - INTERVAL '1 minute' WHERE email = $1", email)
+
+	if verifyAccountRequest(t, token) {
+		t.Error("expected an expired link to be refused")
+	}
+	if verifyAccountRequest(t, "not-a-real-token") {
+		t.Error("expected an unknown token to be refused")
+	}
+}
+
+// TestAccountRequest_ActiveAccountIgnored verifies nothing is queued for an
+// address that already has an account, without telling the requester.
+func TestAccountRequest_ActiveAccountIgnored(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Already", "Here", "VOLUNTEER")
+
+	resp := gqlPost(t, "/graphql/auth", "", mutRequestAccount, map[string]any{
+		"email": email, "firstName": "Already", "lastName": "Here",
+	})
+	var result struct {
+		Success bool `json:"success"`
+	}
+	unmarshalField(t, resp, "requestAccount", &result)
+	if !result.Success {
+		t.Error("expected the same success response as for a new address")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM account_requests WHERE email = $1", email) {
+		t.Error("no request should be stored for an active account")
+	}
+}
+
+// ============================================================================
+// Approving and denying
+// ============================================================================
+
+// TestAccountRequest_ApproveCreatesOnce verifies approval creates the
+// volunteer with the chosen role, and a second approval cannot repeat it.
+func TestAccountRequest_ApproveCreatesOnce(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	email := uniqueEmail(t)
+	verifyAccountRequest(t, submitAccountRequest(t, email, "Approved", "Requester"))
+	t.Cleanup(func() { testDB.Exec("DELETE FROM volunteers WHERE email = $1", email) })
+	req := queuedRequest(t, adminToken, email, "PENDING")
+	if req == nil {
+		t.Fatal("expected the request in the queue")
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutApproveAccountRequest, map[string]any{"id": req.ID})
+	if hasGQLErrors(resp) {
+		t.Fatalf("approveAccountRequest: unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "approveAccountRequest", &result)
+	if !result.Success || result.ID == nil {
+		t.Fatalf("expected success with the volunteer's id, got %+v", result)
+	}
+	if !rowExists(t, `SELECT COUNT(*) FROM volunteers v JOIN volunteer_roles vr ON vr.volunteer_id = v.volunteer_id
+		JOIN roles r ON r.role_id = vr.role_id
+		WHERE v.volunteer_id = $1 AND v.email = $2 AND v.is_active AND r.role_name = 'VOLUNTEER'`, *result.ID, email) {
+		t.Error("expected an active volunteer with the VOLUNTEER role")
+	}
+
+	approved := queuedRequest(t, adminToken, email, "APPROVED")
+	if approved == nil || approved.VolunteerID == nil || *approved.VolunteerID != *result.ID ||
+		approved.DecidedByID == nil || *approved.DecidedByID != strconv.Itoa(adminID) {
+		t.Errorf("expected the request linked to the volunteer and the deciding admin, got %+v", approved)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutApproveAccountRequest, map[string]any{"id": req.ID})
+	if !hasGQLErrors(resp) {
+		t.Error("expected a second approval to fail")
+	}
+	var count int
+	testDB.QueryRow("SELECT COUNT(*) FROM volunteers WHERE email = $1", email).Scan(&count)
+	if count != 1 {
+		t.Errorf("expected exactly one volunteer, got %d", count)
+	}
+}
+
+// TestAccountRequest_ApproveReactivates verifies a request from a former
+// volunteer's address brings back their account.
+func TestAccountRequest_ApproveReactivates(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	email := uniqueEmail(t)
+	formerID := seedInactiveVolunteer(t, email, "Former", "Volunteer", "VOLUNTEER")
+	verifyAccountRequest(t, submitAccountRequest(t, email, "Former", "Volunteer"))
+
+	req := queuedRequest(t, adminToken, email, "PENDING")
+	if req == nil || req.ExistingVolunteerID == nil || *req.ExistingVolunteerID != strconv.Itoa(formerID) {
+		t.Fatalf("expected the request to point at volunteer %d, got %+v", formerID, req)
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutApproveAccountRequest, map[string]any{
+		"id": req.ID, "input": map[string]any{"role": "VOLUNTEER"},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("approveAccountRequest: unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "approveAccountRequest", &result)
+	if result.ID == nil || *result.ID != strconv.Itoa(formerID) {
+		t.Fatalf("expected volunteer %d to be reactivated, got %+v", formerID, result)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE volunteer_id = $1 AND is_active", formerID) {
+		t.Error("expected the volunteer to be active again")
+	}
+}
+
+// TestAccountRequest_Deny verifies a denial is recorded with its message.
+func TestAccountRequest_Deny(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	email := uniqueEmail(t)
+	verifyAccountRequest(t, submitAccountRequest(t, email, "Denied", "Requester"))
+	req := queuedRequest(t, adminToken, email, "PENDING")
+	if req == nil {
+		t.Fatal("expected the request in the queue")
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutDenyAccountRequest, map[string]any{
+		"id": req.ID, "message": "We are not taking new volunteers this season.",
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("denyAccountRequest: unexpected errors: %v", resp.Errors)
+	}
+
+	denied := queuedRequest(t, adminToken, email, "DENIED")
+	if denied == nil || denied.DecisionMessage == nil || *denied.DecisionMessage != "We are not taking new volunteers this season." {
+		t.Fatalf("expected the denial and its message, got %+v", denied)
+	}
+	if queuedRequest(t, adminToken, email, "PENDING") != nil {
+		t.Error("a denied request must leave the pending queue")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE email = $1", email) {
+		t.Error("no volunteer should have been created")
+	}
+
+	// The requester may ask again later.
+	submitAccountRequest(t, email, "Denied", "Requester")
+	if !rowExists(t, "SELECT COUNT(*) FROM account_requests WHERE email = $1 AND status = 'PENDING'", email) {
+		t.Error("expected a new request to be accepted after a denial")
+	}
+}
+
+// TestAccountRequest_AdministratorOnly verifies coordinators cannot see or
+// decide requests.
+func TestAccountRequest_AdministratorOnly(t *testing.T) {
+	fe := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, fe)
+
+	if resp := gqlPost(t, "/graphql/admin", token, qryAccountRequests, nil); !hasGQLErrors(resp) {
+		t.Error("expected a coordinator to be refused the queue")
+	}
+	if resp := gqlPost(t, "/graphql/admin", token, mutDenyAccountRequest, map[string]any{"id": "1"}); !hasGQLErrors(resp) {
+		t.Error("expected a coordinator to be refused denyAccountRequest")
+	}
+}
@@ -0,0 +1,254 @@
+package integration
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const (
+	mutCreateAPIToken = `
+		mutation Create($input: NewApiTokenInput!) {
+			createApiToken(input: $input) {
+				success
+				message
+				token
+				apiToken { id prefix ownerId scope expiresAt }
+			}
+		}`
+
+	mutRevokeAPIToken = `
+		mutation Revoke($id: ID!) {
+			revokeApiToken(tokenId: $id) { success message }
+		}`
+
+	qryAPITokens = `query { apiTokens { id name ownerId scope lastUsedAt lastUsedIp revokedAt } }`
+
+	qryVolunteerIDs = `query { volunteers { id } }`
+)
+
+type apiTokenResult struct {
+	Success  bool    `json:"success"`
+	Message  *string `json:"message"`
+	Token    *string `json:"token"`
+	APIToken *struct {
+		ID      string `json:"id"`
+		Prefix  string `json:"prefix"`
+		OwnerID string `json:"ownerId"`
+		Scope   string `json:"scope"`
+	} `json:"apiToken"`
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// createAPIToken has adminToken issue a token owned by ownerID (0 for the
+// admin) and returns the token and its id.
+func createAPIToken(t *testing.T, adminToken string, ownerID int, scope string) (string, string) {
+	t.Helper()
+	input := map[string]any{"name": "Test integration", "scope": scope}
+	if ownerID != 0 {
+		input["ownerId"] = strconv.Itoa(ownerID)
+	}
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateAPIToken, map[string]any{"input": input})
+	if hasGQLErrors(resp) {
+		t.Fatalf("createApiToken: unexpected errors: %v", resp.Errors)
+	}
+	var result apiTokenResult
+	unmarshalField(t, resp, "createApiToken", &result)
+	if !result.Success || result.Token == nil || result.APIToken == nil {
+		t.Fatal("createApiToken: expected success with a token")
+	}
+	t.Cleanup(func() { testDB.Exec("DELETE FROM api_tokens WHERE id = $1", result.APIToken.ID) })
+	return *result.Token, result.APIToken.ID
+}
+
+// ============================================================================
+// Creation
+// ============================================================================
+
+// TestCreateAPIToken_StoredHashed verifies the token is returned once and
+// only its hash is stored.
+func TestCreateAPIToken_StoredHashed(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	token, id := createAPIToken(t, adminToken, 0, "REPORTING")
+
+	if rowExists(t, "SELECT COUNT(*) FROM api_tokens WHERE token = $1", token) {
+		t.Error("the raw token must not be stored")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM api_tokens WHERE id = $1 AND token = $2 AND owner_id = $3 AND created_by = $3",
+		id, hashSessionToken(token), adminID) {
+		t.Error("expected the hashed token owned by the creating admin")
+	}
+}
+
+// TestCreateAPIToken_AdminScopeNeedsAdminOwner verifies admin-side scopes
+// cannot be given to a plain volunteer.
+func TestCreateAPIToken_AdminScopeNeedsAdminOwner(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateAPIToken, map[string]any{
+		"input": map[string]any{"name": "Nope", "scope": "ADMIN_READ_ONLY", "ownerId": strconv.Itoa(volID)},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected an error for a volunteer-owned admin token")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM api_tokens WHERE owner_id = $1", volID) {
+		t.Error("no token should have been created")
+	}
+}
+
+// ============================================================================
+// Scopes
+// ============================================================================
+
+// TestAPIToken_ReadOnlyAdmin verifies ADMIN_READ_ONLY can query but not
+// mutate, and use is tracked.
+func TestAPIToken_ReadOnlyAdmin(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	token, id := createAPIToken(t, adminToken, 0, "ADMIN_READ_ONLY")
+
+	resp := gqlPost(t, "/graphql/admin", token, qryVolunteerIDs, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("expected queries to be allowed: %v", resp.Errors)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM api_tokens WHERE id = $1 AND last_used_at IS NOT NULL AND last_used_ip IS NOT NULL", id) {
+		t.Error("expected last use to be recorded")
+	}
+
+	name := uniqueCode(t, "Token Venue ")
+	resp = gqlPost(t, "/graphql/admin", token, mutCreateVenue, map[string]any{
+		"input": map[string]any{"name": name, "address": "1 Main St", "city": "Portland", "state": "OR", "zipCode": "97204"},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected mutations to be refused")
+	}
+	if code, _ := resp.Errors[0].Extensions["code"].(string); code != "FORBIDDEN" {
+		t.Errorf("expected FORBIDDEN, got %q", code)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM venues WHERE venue_name = $1", name) {
+		t.Error("no venue should have been created")
+	}
+}
+
+// TestAPIToken_ReportingLimited verifies REPORTING cannot read feedback or
+// manage tokens.
+func TestAPIToken_ReportingLimited(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	token, _ := createAPIToken(t, adminToken, 0, "REPORTING")
+
+	if resp := gqlPost(t, "/graphql/admin", token, qryVolunteerIDs, nil); hasGQLErrors(resp) {
+		t.Fatalf("expected volunteers to be readable: %v", resp.Errors)
+	}
+	if resp := gqlPost(t, "/graphql/admin", token, `query { feedback { id } }`, nil); !hasGQLErrors(resp) {
+		t.Error("expected feedback to be out of scope")
+	}
+	if resp := gqlPost(t, "/graphql/admin", token, qryAPITokens, nil); !hasGQLErrors(resp) {
+		t.Error("expected an API token not to list API tokens")
+	}
+}
+
+// TestAPIToken_VolunteerSignup verifies VOLUNTEER_SIGNUP lets a volunteer's
+// token sign them up, but not read elsewhere.
+func TestAPIToken_VolunteerSignup(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	token, _ := createAPIToken(t, adminToken, volID, "VOLUNTEER_SIGNUP")
+
+	eventID := seedEvent(t, "Token Signup Event", true, nil)
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	shiftID := seedShift(t, oppID, "2028-08-12T09:00:00Z", "2028-08-12T12:00:00Z", 5)
+
+	resp := gqlPost(t, "/graphql/volunteer", token, `mutation($id: ID!) { assignSelfToShift(shiftId: $id) { success } }`,
+		map[string]any{"id": strconv.Itoa(shiftID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("expected signup to be allowed: %v", resp.Errors)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM volunteer_shifts WHERE shift_id = $1 AND volunteer_id = $2", shiftID, volID) {
+		t.Error("expected the volunteer to be signed up")
+	}
+
+	if resp := gqlPost(t, "/graphql/volunteer", token, `query { ownSessions { id } }`, nil); !hasGQLErrors(resp) {
+		t.Error("expected sessions to be out of scope")
+	}
+	if resp := gqlPost(t, "/graphql/admin", token, qryVolunteerIDs, nil); !hasGQLErrors(resp) {
+		t.Error("expected a volunteer's token to be refused on the admin endpoint")
+	}
+}
+
+// TestAPIToken_SignupCannotCreateAdmins verifies a VOLUNTEER_SIGNUP token
+// issued by an admin can create volunteers but not escalate their role.
+func TestAPIToken_SignupCannotCreateAdmins(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	token, _ := createAPIToken(t, adminToken, 0, "VOLUNTEER_SIGNUP")
+
+	for name, extra := range map[string]map[string]any{
+		"administrator":    {"role": "ADMINISTRATOR"},
+		"coordinator":      {"role": "COORDINATOR", "fundingEntityIds": []int{seedFundingEntity(t, "Token Region")}},
+		"funding entities": {"role": "VOLUNTEER", "fundingEntityIds": []int{seedFundingEntity(t, "Token Region 2")}},
+	} {
+		email := uniqueEmail(t)
+		input := map[string]any{"firstName": "Token", "lastName": "Escalation", "email": email}
+		for k, v := range extra {
+			input[k] = v
+		}
+		resp := gqlPost(t, "/graphql/admin", token, mutCreateVolunteer, map[string]any{"input": input})
+		if code := errorCode(resp); code != "FORBIDDEN" {
+			t.Errorf("%s: error code = %q, want FORBIDDEN (errors: %v)", name, code, resp.Errors)
+		}
+		if rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE email = $1", email) {
+			t.Errorf("%s: volunteer was created", name)
+		}
+	}
+
+	email := uniqueEmail(t)
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateVolunteer, map[string]any{"input": map[string]any{
+		"firstName": "Token", "lastName": "Signup", "email": email, "role": "VOLUNTEER",
+	}})
+	t.Cleanup(func() { testDB.Exec("DELETE FROM volunteers WHERE email = $1", email) })
+	if hasGQLErrors(resp) {
+		t.Fatalf("expected a plain volunteer to be created: %v", resp.Errors)
+	}
+}
+
+// ============================================================================
+// Revocation and expiry
+// ============================================================================
+
+// TestAPIToken_RevokedAndExpired verifies tokens stop working at once.
+func TestAPIToken_RevokedAndExpired(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+
+	token, id := createAPIToken(t, adminToken, 0, "ADMIN_READ_ONLY")
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutRevokeAPIToken, map[string]any{"id": id})
+	if hasGQLErrors(resp) {
+		t.Fatalf("revokeApiToken: unexpected errors: %v", resp.Errors)
+	}
+	if resp := gqlPost(t, "/graphql/admin", token, qryVolunteerIDs, nil); !hasGQLErrors(resp) {
+		t.Error("expected a revoked token to be rejected")
+	}
+
+	token, id = createAPIToken(t, adminToken, 0, "ADMIN_READ_ONLY")
+	testDB.Exec("UPDATE api_tokens SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", id)
+	if resp := gqlPost(t, "/graphql/admin", token, qryVolunteerIDs, nil); !hasGQLErrors(resp) {
+		t.Error("expected an expired token to be rejected")
+	}
+}
+
+// TestAPIToken_CannotImpersonate verifies the X-Impersonate header is refused
+// for API tokens.
+func TestAPIToken_CannotImpersonate(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	impToken, _ := startImpersonation(t, adminToken, volID, false)
+	token, _ := createAPIToken(t, adminToken, 0, "ADMIN_READ_ONLY")
+
+	if status, _ := gqlPostImpersonating(t, token, impToken, qryOwnProfileEmail); status != 403 {
+		t.Errorf("expected 403 for an API token, got %d", status)
+	}
+}
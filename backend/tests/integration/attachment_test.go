@@ -240,15 +240,8 @@ func TestAttachFileToFeedback_TooLarge(t *testing.T) {
 		oversize,
 	)
 
-	if hasGQLErrors(resp) {
-		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
-	}
-
-	var result mutationResult
-	unmarshalField(t, resp, "attachFileToFeedback", &result)
-
-	if result.Success {
-		t.Error("expected success=false for oversized file, got true")
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("oversized file: error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
 	}
 }
 
@@ -373,15 +366,8 @@ func TestAttachFile_DisallowedMIMEType(t *testing.T) {
 		"application/javascript",
 		[]byte(`alert("xss")`),
 	)
-	if hasGQLErrors(resp) {
-		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
-	}
-
-	var result mutationResult
-	unmarshalField(t, resp, "attachFileToFeedback", &result)
-
-	if result.Success {
-		t.Error("expected success=false for disallowed MIME type, got true")
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("disallowed MIME type: error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
 	}
 }
 
@@ -404,14 +390,7 @@ func TestAttachFile_ContentMismatch(t *testing.T) {
 		"image/png",
 		[]byte("this is definitely not a PNG"),
 	)
-	if hasGQLErrors(resp) {
-		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
-	}
-
-	var result mutationResult
-	unmarshalField(t, resp, "attachFileToFeedback", &result)
-
-	if result.Success {
-		t.Error("expected success=false for MIME type mismatch, got true")
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("MIME type mismatch: error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
 	}
 }
@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const qryAuditLog = `
+	query AuditLog($filter: AuditLogFilterInput) {
+		auditLog(filter: $filter) {
+			id actorId operation entity entityId success ipAddress
+			changes { field before after }
+		}
+	}`
+
+type auditEntry struct {
+	ID        string  `json:"id"`
+	ActorID   *string `json:"actorId"`
+	Operation string  `json:"operation"`
+	Entity    *string `json:"entity"`
+	EntityID  *string `json:"entityId"`
+	Success   bool    `json:"success"`
+	IPAddress *string `json:"ipAddress"`
+	Changes   []struct {
+		Field  string  `json:"field"`
+		Before *string `json:"before"`
+		After  *string `json:"after"`
+	} `json:"changes"`
+}
+
+// fetchAuditLog returns the entries matching filter.
+func fetchAuditLog(t *testing.T, adminToken string, filter map[string]any) []auditEntry {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryAuditLog, map[string]any{"filter": filter})
+	if hasGQLErrors(resp) {
+		t.Fatalf("auditLog: unexpected errors: %v", resp.Errors)
+	}
+	var entries []auditEntry
+	unmarshalField(t, resp, "auditLog", &entries)
+	return entries
+}
+
+// ============================================================================
+// Recording
+// ============================================================================
+
+// TestAudit_RoleChangeRecorded verifies promoting a volunteer records who did
+// it and the roles before and after.
+func TestAudit_RoleChangeRecorded(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	email := uniqueEmail(t)
+	volID := seedVolunteer(t, email, "Promoted", "Volunteer", "VOLUNTEER")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutUpdateVolunteer, map[string]any{
+		"input": map[string]any{
+			"id":        strconv.Itoa(volID),
+			"firstName": "Promoted",
+			"lastName":  "Volunteer",
+			"email":     email,
+			"role":      "ADMINISTRATOR",
+		},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("updateVolunteer: unexpected errors: %v", resp.Errors)
+	}
+
+	entries := fetchAuditLog(t, adminToken, map[string]any{
+		"actorId": strconv.Itoa(adminID), "entity": "Volunteer", "entityId": strconv.Itoa(volID),
+	})
+	if len(entries) != 1 {
+		t.Fatalf("expected one audit entry, got %d", len(entries))
+	}
+	e := entries[0]
+	if e.Operation != "updateVolunteer" || !e.Success || e.IPAddress == nil {
+		t.Errorf("unexpected entry: %+v", e)
+	}
+	var roles *struct{ Before, After *string }
+	for _, c := range e.Changes {
+		if c.Field == "roles" {
+			roles = &struct{ Before, After *string }{c.Before, c.After}
+		}
+	}
+	if roles == nil || roles.Before == nil || roles.After == nil {
+		t.Fatalf("expected a roles change, got %+v", e.Changes)
+	}
+	if *roles.Before != `["VOLUNTEER"]` || *roles.After != `["ADMINISTRATOR","VOLUNTEER"]` {
+		t.Errorf("unexpected roles change %s -> %s", *roles.Before, *roles.After)
+	}
+}
+
+// TestAudit_CreateRecordsNewRecord verifies a create is logged against the
+// new record's ID with no before values.
+func TestAudit_CreateRecordsNewRecord(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	name := uniqueCode(t, "Audited Venue ")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateVenue, map[string]any{
+		"input": map[string]any{"name": name, "address": "1 Main St", "city": "Portland", "state": "OR", "zipCode": "97204"},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("createVenue: unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "createVenue", &result)
+	t.Cleanup(func() { testDB.Exec("DELETE FROM venues WHERE venue_id = $1", *result.ID) })
+
+	entries := fetchAuditLog(t, adminToken, map[string]any{"actorId": strconv.Itoa(adminID), "entity": "Venue"})
+	if len(entries) != 1 || entries[0].EntityID == nil || *entries[0].EntityID != *result.ID {
+		t.Fatalf("expected one entry for venue %s, got %+v", *result.ID, entries)
+	}
+	for _, c := range entries[0].Changes {
+		if c.Before != nil {
+			t.Errorf("field %s: expected no before value on create", c.Field)
+		}
+		if c.Field == "venue_name" && (c.After == nil || *c.After != strconv.Quote(name)) {
+			t.Errorf("expected venue_name %q, got %v", name, c.After)
+		}
+	}
+}
+
+// TestAudit_FailedMutationRecorded verifies failures are logged without a diff.
+func TestAudit_FailedMutationRecorded(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutStartImpersonation, map[string]any{
+		"input": map[string]any{"volunteerId": strconv.Itoa(volID), "reason": ""},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected startImpersonation to fail without a reason")
+	}
+
+	entries := fetchAuditLog(t, adminToken, map[string]any{"actorId": strconv.Itoa(adminID)})
+	if len(entries) != 1 || entries[0].Operation != "startImpersonation" {
+		t.Fatalf("expected the failed call to be logged, got %+v", entries)
+	}
+	if entries[0].Success || len(entries[0].Changes) != 0 {
+		t.Errorf("expected success=false and no changes, got %+v", entries[0])
+	}
+}
+
+// ============================================================================
+// Querying and integrity
+// ============================================================================
+
+// TestAudit_DateRangeFilter verifies from/to bound the results.
+func TestAudit_DateRangeFilter(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	gqlPost(t, "/graphql/admin", adminToken, `mutation($id: ID!) { revokeAllSessions(volunteerId: $id) { success } }`,
+		map[string]any{"id": strconv.Itoa(volID)})
+
+	today := time.Now().UTC().Format("2006-01-02")
+	tomorrow := time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02")
+	actor := strconv.Itoa(adminID)
+
+	if n := len(fetchAuditLog(t, adminToken, map[string]any{"actorId": actor, "from": today, "to": today})); n != 1 {
+		t.Errorf("expected today's entry, got %d", n)
+	}
+	if n := len(fetchAuditLog(t, adminToken, map[string]any{"actorId": actor, "from": tomorrow})); n != 0 {
+		t.Errorf("expected nothing from tomorrow on, got %d", n)
+	}
+}
+
+// TestAudit_AppendOnly verifies entries cannot be edited or deleted.
+func TestAudit_AppendOnly(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	gqlPost(t, "/graphql/admin", adminToken, `mutation($id: ID!) { revokeAllSessions(volunteerId: $id) { success } }`,
+		map[string]any{"id": strconv.Itoa(volID)})
+
+	if _, err := testDB.Exec("UPDATE audit_log SET operation = 'nothing' WHERE actor_id = $1", adminID); err == nil {
+		t.Error("expected UPDATE on audit_log to be refused")
+	}
+	if _, err := testDB.Exec("DELETE FROM audit_log WHERE actor_id = $1", adminID); err == nil {
+		t.Error("expected DELETE on audit_log to be refused")
+	}
+}
+
+// TestAudit_AdministratorOnly verifies coordinators cannot read the log.
+func TestAudit_AdministratorOnly(t *testing.T) {
+	fe := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, fe)
+
+	resp := gqlPost(t, "/graphql/admin", token, qryAuditLog, nil)
+	if !hasGQLErrors(resp) {
+		t.Error("expected a coordinator to be refused the audit log")
+	}
+}
@@ -1,6 +1,8 @@
 package integration
 
 import (
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 )
@@ -19,11 +21,12 @@ const mutRequestMagicLink = `
 	}`
 
 const mutConsumeMagicLink = `
-	mutation ConsumeMagicLink($token: String!) {
-		consumeMagicLink(token: $token) {
+	mutation ConsumeMagicLink($token: String!, $confirmDevice: Boolean) {
+		consumeMagicLink(token: $token, confirmDevice: $confirmDevice) {
 			success
 			message
 			email
+			confirmationRequired
 		}
 	}`
 
@@ -503,3 +506,291 @@ func TestLogout_NoCookie(t *testing.T) {
 		t.Error("expected logout with no cookie to return success=true")
 	}
 }
+
+// ============================================================================
+// consumeSignInCode
+// ============================================================================
+
+const mutConsumeSignInCode = `
+	mutation ConsumeSignInCode($email: String!, $code: String!) {
+		consumeSignInCode(email: $email, code: $code) {
+			success
+			message
+			email
+		}
+	}`
+
+type authResult struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Email   string `json:"email"`
+}
+
+func consumeSignInCode(t *testing.T, email, code string) (authResult, []*http.Cookie) {
+	t.Helper()
+	resp, cookies := gqlPostFull(t, "/graphql/auth", "", mutConsumeSignInCode, map[string]any{
+		"email": email,
+		"code":  code,
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GraphQL errors: %v", resp.Errors)
+	}
+	var result authResult
+	unmarshalField(t, resp, "consumeSignInCode", &result)
+	return result, cookies
+}
+
+// TestConsumeSignInCode_Valid verifies that the right code signs the volunteer
+// in exactly like the magic link: session cookie set, link marked used.
+func TestConsumeSignInCode_Valid(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	token := seedSignInCode(t, email, "123456", time.Now().Add(15*time.Minute))
+
+	result, cookies := consumeSignInCode(t, email, "123456")
+	if !result.Success {
+		t.Fatalf("expected success=true, got false; message: %s", result.Message)
+	}
+
+	sessionCookie := findCookie(cookies, "session")
+	if sessionCookie == nil || !sessionExists(t, sessionCookie.Value) {
+		t.Fatal("expected a session cookie backed by a session row")
+	}
+
+	// The code and the link are single use together.
+	if !magicLinkUsed(t, token) {
+		t.Error("expected magic link to be marked used")
+	}
+	resp := gqlPost(t, "/graphql/auth", "", mutConsumeMagicLink, map[string]any{"token": token})
+	var link authResult
+	unmarshalField(t, resp, "consumeMagicLink", &link)
+	if link.Success {
+		t.Error("magic link must not work after its code was used")
+	}
+}
+
+// TestConsumeSignInCode_EmailCaseInsensitive verifies the code works however
+// the email is capitalised or padded when typed back in.
+func TestConsumeSignInCode_EmailCaseInsensitive(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	seedSignInCode(t, email, "135790", time.Now().Add(15*time.Minute))
+
+	result, _ := consumeSignInCode(t, "  "+strings.ToUpper(email)+" ", "135790")
+	if !result.Success {
+		t.Fatalf("expected success=true, got false; message: %s", result.Message)
+	}
+	if result.Email != email {
+		t.Errorf("expected the account's email %q back, got %q", email, result.Email)
+	}
+}
+
+// TestConsumeSignInCode_SingleUse verifies a code cannot be replayed.
+func TestConsumeSignInCode_SingleUse(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	seedSignInCode(t, email, "246810", time.Now().Add(15*time.Minute))
+
+	if first, _ := consumeSignInCode(t, email, "246810"); !first.Success {
+		t.Fatalf("first use failed: %s", first.Message)
+	}
+	if second, _ := consumeSignInCode(t, email, "246810"); second.Success {
+		t.Error("expected second use to fail")
+	}
+}
+
+// TestConsumeSignInCode_Expired verifies expired codes are rejected.
+func TestConsumeSignInCode_Expired(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	seedSignInCode(t, email, "135790", time.Now().Add(-1*time.Minute))
+
+	if result, _ := consumeSignInCode(t, email, "135790"); result.Success {
+		t.Error("expected success=false for expired code")
+	}
+}
+
+// TestConsumeSignInCode_WrongEmail verifies a code only works for the address
+// it was sent to.
+func TestConsumeSignInCode_WrongEmail(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	other := uniqueEmail(t)
+	seedVolunteer(t, other, "Other", "Test", "VOLUNTEER")
+	seedSignInCode(t, email, "112233", time.Now().Add(15*time.Minute))
+
+	if result, _ := consumeSignInCode(t, other, "112233"); result.Success {
+		t.Error("expected success=false when the code belongs to another email")
+	}
+}
+
+// TestConsumeSignInCode_Lockout verifies that after five wrong guesses the
+// code stops working, even when the right code is then supplied.
+func TestConsumeSignInCode_Lockout(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Code", "Test", "VOLUNTEER")
+	token := seedSignInCode(t, email, "654321", time.Now().Add(15*time.Minute))
+
+	for i := 0; i < 5; i++ {
+		if result, _ := consumeSignInCode(t, email, "000000"); result.Success {
+			t.Fatal("wrong code unexpectedly accepted")
+		}
+	}
+
+	if result, _ := consumeSignInCode(t, email, "654321"); result.Success {
+		t.Error("expected the code to be locked after too many wrong attempts")
+	}
+
+	// The locked link must still count toward the request rate limit.
+	if !rowExists(t, "SELECT COUNT(*) FROM magic_links WHERE token = $1 AND used_at IS NULL AND code_attempts = 5", hashSessionToken(token)) {
+		t.Error("expected locked link to keep used_at NULL with 5 recorded attempts")
+	}
+}
+
+// ============================================================================
+// Magic-link hardening: hashed storage, browser binding, consumer details
+// ============================================================================
+
+// TestRequestMagicLink_StoresHashAndNonce verifies that a requested link is
+// stored as a SHA-256 digest with the requester's details, and that the
+// browser receives the nonce cookie the link is bound to.
+func TestRequestMagicLink_StoresHashAndNonce(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Hash", "Test", "VOLUNTEER")
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM magic_links WHERE email = $1", email)
+	})
+
+	resp, cookies := gqlPostFull(t, "/graphql/auth", "", mutRequestMagicLink, map[string]any{"email": email})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GraphQL errors: %v", resp.Errors)
+	}
+
+	nonce := findCookie(cookies, "magic_link_nonce")
+	if nonce == nil || nonce.Value == "" || !nonce.HttpOnly {
+		t.Fatal("expected an HttpOnly magic_link_nonce cookie")
+	}
+
+	var token, nonceHash string
+	var ip *string
+	err := testDB.QueryRow(
+		"SELECT token, browser_nonce_hash, ip_address FROM magic_links WHERE email = $1", email,
+	).Scan(&token, &nonceHash, &ip)
+	if err != nil {
+		t.Fatalf("query magic link: %v", err)
+	}
+	if len(token) != 64 {
+		t.Errorf("expected a 64-char SHA-256 hex digest, got %q", token)
+	}
+	if nonceHash != hashSessionToken(nonce.Value) {
+		t.Error("expected browser_nonce_hash to be the hash of the nonce cookie")
+	}
+	if ip == nil {
+		t.Error("expected the requesting IP address to be recorded")
+	}
+}
+
+// TestConsumeMagicLink_RecordsConsumer verifies the consuming IP and user
+// agent are stored alongside the requesting ones.
+func TestConsumeMagicLink_RecordsConsumer(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Consumer", "Test", "VOLUNTEER")
+	token := "consumer-token-" + email
+	seedMagicLink(t, email, token, time.Now().Add(15*time.Minute))
+
+	resp := gqlPost(t, "/graphql/auth", "", mutConsumeMagicLink, map[string]any{"token": token})
+	var result authResult
+	unmarshalField(t, resp, "consumeMagicLink", &result)
+	if !result.Success {
+		t.Fatalf("expected success=true, got false; message: %s", result.Message)
+	}
+
+	if !rowExists(t, `SELECT COUNT(*) FROM magic_links
+		WHERE token = $1 AND consumed_ip IS NOT NULL AND consumed_user_agent IS NOT NULL`, hashSessionToken(token)) {
+		t.Error("expected consumed_ip and consumed_user_agent to be recorded")
+	}
+}
+
+// TestConsumeMagicLink_RawTokenInDBRejected verifies that the stored value
+// cannot itself be used as a link.
+func TestConsumeMagicLink_RawTokenInDBRejected(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Leak", "Test", "VOLUNTEER")
+	token := "leak-token-" + email
+	seedMagicLink(t, email, token, time.Now().Add(15*time.Minute))
+
+	resp := gqlPost(t, "/graphql/auth", "", mutConsumeMagicLink, map[string]any{"token": hashSessionToken(token)})
+	var result authResult
+	unmarshalField(t, resp, "consumeMagicLink", &result)
+	if result.Success {
+		t.Error("the stored hash must not be accepted as a token")
+	}
+}
+
+// TestConsumeMagicLink_BrowserBinding verifies that, with binding enabled, a
+// link opened without the requesting browser's nonce asks for confirmation
+// and stays usable, and that confirming signs the volunteer in.
+func TestConsumeMagicLink_BrowserBinding(t *testing.T) {
+	t.Setenv("MAGIC_LINK_BROWSER_BINDING", "true")
+
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Bound", "Test", "VOLUNTEER")
+	token := "bound-token-" + email
+	seedBoundMagicLink(t, email, token, "nonce-"+email)
+
+	// Different browser: no nonce cookie.
+	resp := gqlPost(t, "/graphql/auth", "", mutConsumeMagicLink, map[string]any{"token": token})
+	var result struct {
+		authResult
+		ConfirmationRequired bool `json:"confirmationRequired"`
+	}
+	unmarshalField(t, resp, "consumeMagicLink", &result)
+	if result.Success || !result.ConfirmationRequired {
+		t.Fatalf("expected confirmationRequired without the nonce, got %+v", result)
+	}
+	if magicLinkUsed(t, token) {
+		t.Fatal("link must stay unused while awaiting confirmation")
+	}
+
+	// Explicit confirmation on this device.
+	resp, cookies := gqlPostFull(t, "/graphql/auth", "", mutConsumeMagicLink, map[string]any{
+		"token":         token,
+		"confirmDevice": true,
+	})
+	unmarshalField(t, resp, "consumeMagicLink", &result)
+	if !result.Success {
+		t.Fatalf("expected confirmed sign-in to succeed; message: %s", result.Message)
+	}
+	if findCookie(cookies, "session") == nil {
+		t.Error("expected a session cookie after confirming")
+	}
+}
+
+// TestConsumeMagicLink_BrowserBindingSameBrowser verifies that the requesting
+// browser signs straight in and has its nonce cookie cleared.
+func TestConsumeMagicLink_BrowserBindingSameBrowser(t *testing.T) {
+	t.Setenv("MAGIC_LINK_BROWSER_BINDING", "true")
+
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Same", "Test", "VOLUNTEER")
+	token := "same-token-" + email
+	nonce := "nonce-" + email
+	seedBoundMagicLink(t, email, token, nonce)
+
+	resp, cookies := gqlPostWithCookies(t, "/graphql/auth",
+		[]*http.Cookie{{Name: "magic_link_nonce", Value: nonce}},
+		mutConsumeMagicLink, map[string]any{"token": token})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GraphQL errors: %v", resp.Errors)
+	}
+	var result authResult
+	unmarshalField(t, resp, "consumeMagicLink", &result)
+	if !result.Success {
+		t.Fatalf("expected success=true, got false; message: %s", result.Message)
+	}
+
+	cleared := findCookie(cookies, "magic_link_nonce")
+	if cleared == nil || cleared.MaxAge >= 0 {
+		t.Error("expected the nonce cookie to be cleared after sign-in")
+	}
+}
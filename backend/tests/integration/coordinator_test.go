@@ -0,0 +1,315 @@
+package integration
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// makeCoordinator creates a COORDINATOR scoped to the given funding entities
+// and a session, returning (sessionToken, volunteerID).
+func makeCoordinator(t *testing.T, fundingEntityIDs ...int) (string, int) {
+	t.Helper()
+	email := uniqueEmail(t)
+	id := seedVolunteer(t, email, "Coord", "Test", "COORDINATOR")
+	for _, fe := range fundingEntityIDs {
+		_, err := testDB.Exec(`
+			INSERT INTO coordinator_funding_entities (volunteer_id, funding_entity_id)
+			VALUES ($1, $2)
+		`, id, fe)
+		if err != nil {
+			t.Fatalf("makeCoordinator: %v", err)
+		}
+	}
+	token := seedSession(t, email, id, "COORDINATOR", "crd-"+email)
+	return token, id
+}
+
+// seedEventInRegion seeds an event and moves it to the given funding entity.
+func seedEventInRegion(t *testing.T, name string, fundingEntityID int) int {
+	t.Helper()
+	eventID := seedEvent(t, name, true, nil)
+	if _, err := testDB.Exec(
+		"UPDATE events SET funding_entity_id = $1 WHERE event_id = $2", fundingEntityID, eventID,
+	); err != nil {
+		t.Fatalf("seedEventInRegion: %v", err)
+	}
+	return eventID
+}
+
+// ============================================================================
+// Per-operation permissions
+// ============================================================================
+
+// TestCoordinator_CanManageEventsInRegion verifies a coordinator can add a
+// date to an event in their own region.
+func TestCoordinator_CanManageEventsInRegion(t *testing.T) {
+	fe := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, fe)
+	eventID := seedEventInRegion(t, "Coordinator Own Event", fe)
+
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateEventDate, map[string]any{
+		"newDate": map[string]any{
+			"eventId":       fmt.Sprintf("%d", eventID),
+			"startDateTime": "2028-08-12 09:00:00",
+			"endDateTime":   "2028-08-12 17:00:00",
+		},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+
+	var result mutationResult
+	unmarshalField(t, resp, "createEventDate", &result)
+	if !result.Success {
+		t.Fatal("expected success=true for an event in the coordinator's region")
+	}
+}
+
+// TestCoordinator_ForbiddenOutsideRegion verifies that events belonging to
+// another funding entity cannot be changed.
+func TestCoordinator_ForbiddenOutsideRegion(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	other := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	eventID := seedEventInRegion(t, "Coordinator Other Event", other)
+
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateEventDate, map[string]any{
+		"newDate": map[string]any{
+			"eventId":       fmt.Sprintf("%d", eventID),
+			"startDateTime": "2028-08-12 09:00:00",
+			"endDateTime":   "2028-08-12 17:00:00",
+		},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected an error for an event outside the coordinator's region")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM event_dates WHERE event_id = $1", eventID) {
+		t.Error("no event date should have been created")
+	}
+}
+
+// TestCoordinator_CannotCreateEventInOtherRegion verifies the funding entity
+// of a new event is checked.
+func TestCoordinator_CannotCreateEventInOtherRegion(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	other := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	name := uniqueCode(t, "Coordinator Event ")
+
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateEvent, map[string]any{
+		"newEvent": map[string]any{
+			"name":            name,
+			"eventType":       "VIRTUAL",
+			"timezone":        "America/Los_Angeles",
+			"fundingEntityId": other,
+			"serviceTypes":    []int{},
+			"eventDates": []map[string]any{
+				{"startDateTime": "2028-08-12 09:00:00", "endDateTime": "2028-08-12 17:00:00"},
+			},
+		},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected an error creating an event in another region")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM events WHERE event_name = $1", name) {
+		t.Error("no event should have been created")
+	}
+}
+
+// TestCoordinator_CannotManageStaff verifies that fields without @hasRole
+// stay ADMINISTRATOR-only.
+func TestCoordinator_CannotManageStaff(t *testing.T) {
+	fe := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, fe)
+	email := uniqueEmail(t)
+
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateStaff, map[string]any{
+		"input": map[string]any{
+			"firstName": "Not",
+			"lastName":  "Allowed",
+			"email":     email,
+		},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected createStaff to be forbidden for a coordinator")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM staff WHERE email = $1", email) {
+		t.Error("no staff row should have been created")
+	}
+}
+
+// TestCoordinator_VolunteerOnlyForbidden verifies a plain volunteer still
+// cannot reach the admin endpoint.
+func TestCoordinator_VolunteerOnlyForbidden(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	resp := gqlPost(t, "/graphql/admin", token, queryAdminFilteredEvents, nil)
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected a volunteer to be forbidden from /graphql/admin")
+	}
+}
+
+// ============================================================================
+// Query filtering
+// ============================================================================
+
+// TestCoordinator_EventsFilteredToRegion verifies the events query only
+// returns the coordinator's regions.
+func TestCoordinator_EventsFilteredToRegion(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	other := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	ownName := uniqueCode(t, "Own Event ")
+	otherName := uniqueCode(t, "Other Event ")
+	seedEventDate(t, seedEventInRegion(t, ownName, own), "2028-05-01T09:00:00Z", "2028-05-01T17:00:00Z")
+	seedEventDate(t, seedEventInRegion(t, otherName, other), "2028-05-01T09:00:00Z", "2028-05-01T17:00:00Z")
+
+	resp := gqlPost(t, "/graphql/admin", token, queryAdminFilteredEvents, map[string]any{
+		"filter": map[string]any{"timeFrame": "ALL"},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	names := adminEventNamesFromResponse(t, resp)
+	if !names[ownName] {
+		t.Errorf("expected %q in the coordinator's events", ownName)
+	}
+	if names[otherName] {
+		t.Errorf("did not expect %q from another region", otherName)
+	}
+}
+
+// TestCoordinator_VolunteersFilteredToRoster verifies that only volunteers
+// signed up for the coordinator's events are listed.
+func TestCoordinator_VolunteersFilteredToRoster(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	eventID := seedEventInRegion(t, "Coordinator Roster Event", own)
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	shiftID := seedShift(t, oppID, "2028-08-12T09:00:00Z", "2028-08-12T12:00:00Z", 5)
+
+	_, rostered := makeVolunteer(t)
+	_, unrelated := makeVolunteer(t)
+	seedVolunteerShift(t, shiftID, rostered)
+
+	resp := gqlPost(t, "/graphql/admin", token, `query { volunteers { id } }`, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var vols []struct {
+		ID string `json:"id"`
+	}
+	unmarshalField(t, resp, "volunteers", &vols)
+	ids := map[string]bool{}
+	for _, v := range vols {
+		ids[v.ID] = true
+	}
+	if !ids[strconv.Itoa(rostered)] {
+		t.Error("expected the rostered volunteer to be listed")
+	}
+	if ids[strconv.Itoa(unrelated)] {
+		t.Error("did not expect a volunteer with no shifts in the region")
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, `query($id: Int!) { volunteer(volId: $id) { id } }`,
+		map[string]any{"id": unrelated})
+	if !hasGQLErrors(resp) {
+		t.Error("expected an error fetching a volunteer outside the coordinator's scope")
+	}
+}
+
+// TestCoordinator_AssignsVolunteerFromOutsideRoster verifies a coordinator can
+// find a volunteer not yet on any of their rosters by name, assign them, and
+// then see them like any other rostered volunteer.
+func TestCoordinator_AssignsVolunteerFromOutsideRoster(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	eventID := seedEventInRegion(t, "Coordinator Assign Event", own)
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	shiftID := seedShift(t, oppID, "2028-08-13T09:00:00Z", "2028-08-13T12:00:00Z", 5)
+
+	lastName := uniqueCode(t, "Newcomer")
+	volID := seedVolunteer(t, uniqueEmail(t), "Found", lastName, "VOLUNTEER")
+
+	resp := gqlPost(t, "/graphql/admin", token, `query($q: String) { assignableVolunteers(search: $q) { id firstName lastName } }`,
+		map[string]any{"q": lastName})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var found []struct {
+		ID       string `json:"id"`
+		LastName string `json:"lastName"`
+	}
+	unmarshalField(t, resp, "assignableVolunteers", &found)
+	if len(found) != 1 || found[0].ID != strconv.Itoa(volID) {
+		t.Fatalf("expected to find volunteer %d by name, got %v", volID, found)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, `mutation($s: ID!, $v: ID!) { assignVolunteerToShift(shiftId: $s, volunteerId: $v) { success message } }`,
+		map[string]any{"s": strconv.Itoa(shiftID), "v": found[0].ID})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors assigning: %v", resp.Errors)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, `query($id: Int!) { volunteer(volId: $id) { id } }`,
+		map[string]any{"id": volID})
+	if hasGQLErrors(resp) {
+		t.Errorf("expected the newly rostered volunteer to be visible: %v", resp.Errors)
+	}
+}
+
+// ============================================================================
+// Role assignment
+// ============================================================================
+
+// TestCreateVolunteer_Coordinator verifies that creating a coordinator
+// stores their funding entities, and that they are required.
+func TestCreateVolunteer_Coordinator(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	fe := seedFundingEntity(t, uniqueCode(t, "Region "))
+
+	email := uniqueEmail(t)
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateVolunteer, map[string]any{
+		"input": map[string]any{
+			"firstName": "No",
+			"lastName":  "Regions",
+			"email":     email,
+			"role":      "COORDINATOR",
+		},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected an error creating a coordinator without funding entities")
+	}
+
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutCreateVolunteer, map[string]any{
+		"input": map[string]any{
+			"firstName":        "With",
+			"lastName":         "Regions",
+			"email":            email,
+			"role":             "COORDINATOR",
+			"fundingEntityIds": []int{fe},
+		},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "createVolunteer", &result)
+	if !result.Success || result.ID == nil {
+		t.Fatal("expected createVolunteer to succeed")
+	}
+	t.Cleanup(func() { testDB.Exec("DELETE FROM volunteers WHERE volunteer_id = $1", *result.ID) })
+
+	if !rowExists(t, "SELECT COUNT(*) FROM coordinator_funding_entities WHERE volunteer_id = $1 AND funding_entity_id = $2", *result.ID, fe) {
+		t.Error("expected the coordinator's funding entity to be stored")
+	}
+	if !rowExists(t, `
+		SELECT COUNT(*) FROM volunteer_roles vr JOIN roles r ON r.role_id = vr.role_id
+		WHERE vr.volunteer_id = $1 AND r.role_name = 'VOLUNTEER'`, *result.ID) {
+		t.Error("expected COORDINATOR to imply VOLUNTEER")
+	}
+}
@@ -0,0 +1,195 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/services"
+	"volunteer-scheduler/webhooks"
+)
+
+// ============================================================================
+// Delivery events — /webhooks/email-events
+//
+// Events are signed the way Resend (via Svix) signs them, using
+// testSvixSecret from setup_test.go.
+// ============================================================================
+
+const qryVolunteerUndeliverable = `query Volunteer($id: Int!) {
+	volunteer(volId: $id) { id email emailUndeliverable }
+}`
+
+const mutClearEmailSuppression = `mutation Clear($id: ID!) {
+	clearEmailSuppression(volunteerId: $id) { success message id }
+}`
+
+// postEmailEvent sends a Svix-signed delivery event and returns the status.
+func postEmailEvent(t *testing.T, event map[string]any) int {
+	t.Helper()
+
+	b, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("postEmailEvent: marshal: %v", err)
+	}
+	now := time.Now()
+	msgID := "msg_" + strconv.FormatInt(now.UnixNano(), 10)
+	sig, err := webhooks.SvixSign(testSvixSecret, msgID, now, b)
+	if err != nil {
+		t.Fatalf("postEmailEvent: sign: %v", err)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/webhooks/email-events", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.SvixIDHeader, msgID)
+	req.Header.Set(webhooks.SvixTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhooks.SvixSignatureHeader, sig)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("postEmailEvent: do request: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func cleanupSuppression(t *testing.T, email string) {
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM email_suppressions WHERE LOWER(email) = LOWER($1)", email)
+	})
+}
+
+// TestEmailEvents_HardBounceSuppresses verifies that a permanent bounce puts
+// the address on the suppression list, flags the volunteer for admins, and
+// stops further sends.
+func TestEmailEvents_HardBounceSuppresses(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	email := volunteerEmail(t, volID)
+	cleanupSuppression(t, email)
+
+	status := postEmailEvent(t, map[string]any{
+		"type": "email.bounced",
+		"data": map[string]any{
+			"to":     []string{email},
+			"bounce": map[string]any{"type": "Permanent", "subType": "General", "message": "mailbox does not exist"},
+		},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+
+	if !rowExists(t, "SELECT COUNT(*) FROM email_suppressions WHERE LOWER(email) = LOWER($1) AND reason = 'BOUNCE'", email) {
+		t.Fatal("expected a BOUNCE suppression for the volunteer's address")
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryVolunteerUndeliverable, map[string]any{"id": volID})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var vol struct {
+		EmailUndeliverable *string `json:"emailUndeliverable"`
+	}
+	unmarshalField(t, resp, "volunteer", &vol)
+	if vol.EmailUndeliverable == nil || *vol.EmailUndeliverable != "BOUNCE" {
+		t.Errorf("expected emailUndeliverable=BOUNCE, got %v", vol.EmailUndeliverable)
+	}
+
+	// The mailer must refuse to send to the suppressed address.
+	mailer := services.NewTestMailer()
+	mailer.UseSuppressionList(services.NewSuppressionList(testDB))
+	err := mailer.SendEmail(context.Background(), email, "Hello", "<p>hi</p>", "hi")
+	if !errors.Is(err, services.ErrEmailSuppressed) {
+		t.Errorf("expected ErrEmailSuppressed, got %v", err)
+	}
+}
+
+// TestEmailEvents_SoftBounceIgnored verifies that a transient bounce (full
+// mailbox, greylisting) does not suppress the address.
+func TestEmailEvents_SoftBounceIgnored(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	email := volunteerEmail(t, volID)
+	cleanupSuppression(t, email)
+
+	status := postEmailEvent(t, map[string]any{
+		"type": "email.bounced",
+		"data": map[string]any{
+			"to":     []string{email},
+			"bounce": map[string]any{"type": "Transient", "subType": "MailboxFull"},
+		},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM email_suppressions WHERE LOWER(email) = LOWER($1)", email) {
+		t.Error("a transient bounce must not suppress the address")
+	}
+}
+
+// TestEmailEvents_ComplaintSuppresses verifies that spam complaints are
+// recorded as COMPLAINT.
+func TestEmailEvents_ComplaintSuppresses(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	email := volunteerEmail(t, volID)
+	cleanupSuppression(t, email)
+
+	status := postEmailEvent(t, map[string]any{
+		"type": "email.complained",
+		"data": map[string]any{"to": []string{email}},
+	})
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM email_suppressions WHERE LOWER(email) = LOWER($1) AND reason = 'COMPLAINT'", email) {
+		t.Error("expected a COMPLAINT suppression")
+	}
+}
+
+// TestEmailEvents_BadSignature verifies unsigned events are rejected.
+func TestEmailEvents_BadSignature(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/webhooks/email-events",
+		bytes.NewReader([]byte(`{"type":"email.complained","data":{"to":["x@example.com"]}}`)))
+	req.Header.Set(webhooks.SvixIDHeader, "msg_1")
+	req.Header.Set(webhooks.SvixTimestampHeader, strconv.FormatInt(time.Now().Unix(), 10))
+	req.Header.Set(webhooks.SvixSignatureHeader, "v1,bm90LWEtc2lnbmF0dXJl")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+// TestClearEmailSuppression verifies that an admin can lift a suppression.
+func TestClearEmailSuppression(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	email := volunteerEmail(t, volID)
+	cleanupSuppression(t, email)
+
+	if _, err := testDB.Exec("INSERT INTO email_suppressions (email, reason) VALUES ($1, 'BOUNCE')", email); err != nil {
+		t.Fatalf("seed suppression: %v", err)
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutClearEmailSuppression, map[string]any{"id": strconv.Itoa(volID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "clearEmailSuppression", &result)
+	if !result.Success {
+		t.Errorf("expected success=true, got false (message: %v)", result.Message)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM email_suppressions WHERE LOWER(email) = LOWER($1)", email) {
+		t.Error("expected suppression to be removed")
+	}
+}
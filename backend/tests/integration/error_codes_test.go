@@ -0,0 +1,138 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/models"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// errorFields returns the field names in extensions.fields of the response's
+// first error.
+func errorFields(r gqlResponse) []string {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	fields, _ := r.Errors[0].Extensions["fields"].([]any)
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if m, ok := f.(map[string]any); ok {
+			name, _ := m["field"].(string)
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestErrorCode_InvalidID verifies a malformed ID is a VALIDATION error that
+// names the argument.
+func TestErrorCode_InvalidID(t *testing.T) {
+	token := makeAdminToken(t)
+	resp := gqlPost(t, "/graphql/admin", token,
+		`query { opportunitiesForEvent(eventId: "abc") { id } }`, nil)
+
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Fatalf("error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
+	}
+	if fields := errorFields(resp); len(fields) != 1 || fields[0] != "eventId" {
+		t.Errorf("fields = %v, want [eventId]", fields)
+	}
+}
+
+// TestErrorCode_DuplicateEmail verifies a unique violation is a CONFLICT on
+// the offending field, with a message that does not leak the constraint.
+func TestErrorCode_DuplicateEmail(t *testing.T) {
+	token := makeAdminToken(t)
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "First", "Vol", "VOLUNTEER")
+
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateVolunteer, map[string]any{
+		"input": map[string]any{
+			"firstName": "Second",
+			"lastName":  "Vol",
+			"email":     email,
+			"role":      "VOLUNTEER",
+		},
+	})
+
+	if code := errorCode(resp); code != "CONFLICT" {
+		t.Fatalf("error code = %q, want CONFLICT (errors: %v)", code, resp.Errors)
+	}
+	if fields := errorFields(resp); len(fields) != 1 || fields[0] != "email" {
+		t.Errorf("fields = %v, want [email]", fields)
+	}
+	if strings.Contains(resp.Errors[0].Message, "volunteers_email_key") {
+		t.Errorf("message leaks the constraint name: %q", resp.Errors[0].Message)
+	}
+}
+
+// TestErrorCode_NotFound verifies deleting a shift that does not exist is
+// NOT_FOUND.
+func TestErrorCode_NotFound(t *testing.T) {
+	token := makeAdminToken(t)
+	resp := gqlPost(t, "/graphql/admin", token,
+		`mutation { deleteShift(shiftId: "999999999") { success } }`, nil)
+
+	if code := errorCode(resp); code != "NOT_FOUND" {
+		t.Errorf("error code = %q, want NOT_FOUND (errors: %v)", code, resp.Errors)
+	}
+}
+
+// TestErrorCode_CapacityFull verifies a full shift is reported in the
+// mutation result's code rather than as a GraphQL error.
+func TestErrorCode_CapacityFull(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	_, otherID := makeVolunteer(t)
+	_, shiftID := seedEventWithShift(t, 1)
+	seedVolunteerShift(t, shiftID, otherID)
+
+	resp := gqlPost(t, "/graphql/volunteer", token,
+		`mutation($shiftId: ID!) { assignSelfToShift(shiftId: $shiftId) { success code } }`,
+		map[string]any{"shiftId": fmt.Sprintf("%d", shiftID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+
+	var result struct {
+		Success bool    `json:"success"`
+		Code    *string `json:"code"`
+	}
+	unmarshalField(t, resp, "assignSelfToShift", &result)
+	if result.Success || result.Code == nil || *result.Code != "CAPACITY_FULL" {
+		t.Errorf("result = %+v, want success=false code=CAPACITY_FULL", result)
+	}
+}
+
+// TestErrorPresenter_Internal verifies unexpected errors are hidden behind a
+// correlation ID, while typed errors keep their message.
+func TestErrorPresenter_Internal(t *testing.T) {
+	ctx := context.Background()
+
+	gqlErr := middleware.ErrorPresenter(ctx, fmt.Errorf("query failed: %w", errors.New(`pq: relation "secrets" does not exist`)))
+	if gqlErr.Message != "internal server error" {
+		t.Errorf("message = %q, want the generic message", gqlErr.Message)
+	}
+	if gqlErr.Extensions["code"] != models.ErrorCodeInternal {
+		t.Errorf("code = %v, want INTERNAL", gqlErr.Extensions["code"])
+	}
+	if id, _ := gqlErr.Extensions["correlationId"].(string); id == "" {
+		t.Error("expected a correlationId")
+	}
+
+	gqlErr = middleware.ErrorPresenter(ctx, fmt.Errorf("wrapped: %w", models.NewError(models.ErrorCodeForbidden, "not yours")))
+	if gqlErr.Message != "not yours" || gqlErr.Extensions["code"] != models.ErrorCodeForbidden {
+		t.Errorf("typed error presented as %q %v", gqlErr.Message, gqlErr.Extensions)
+	}
+}
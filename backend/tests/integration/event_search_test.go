@@ -0,0 +1,184 @@
+package integration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Keyword search — the search field of VolunteerEventFilterInput and
+// EventFilterInput
+// ============================================================================
+
+const (
+	qrySearchEventViews = `
+		query Search($filter: VolunteerEventFilterInput) {
+			eventViews(filter: $filter) { id name searchRank searchSnippet }
+		}`
+
+	qrySearchAdminEvents = `
+		query Search($filter: EventFilterInput) {
+			events(filter: $filter) { id name searchRank searchSnippet }
+		}`
+
+	qrySearchAdminEventsConnection = `
+		query Search($filter: EventFilterInput) {
+			eventsConnection(filter: $filter) { totalCount edges { node { id searchSnippet } } }
+		}`
+)
+
+type searchedEvent struct {
+	ID            string   `json:"id"`
+	Name          string   `json:"name"`
+	SearchRank    *float64 `json:"searchRank"`
+	SearchSnippet *string  `json:"searchSnippet"`
+}
+
+// searchFixture seeds an event for each place a keyword can appear and
+// returns the keyword with the events' IDs by where it appears.
+func searchFixture(t *testing.T) (string, map[string]int) {
+	t.Helper()
+	// A made-up word, so no other test's events match.
+	word := fmt.Sprintf("quokka%d", time.Now().UnixNano())
+	city := uniqueCode(t, "Searchville")
+	jobTypeID := seedJobType(t, uniqueCode(t, "jt"), "Helper")
+
+	ids := map[string]int{}
+	ids["name"], _ = seedPublicEvent(t, "The "+word+" Gala", city, jobTypeID, 2)
+	ids["description"], _ = seedPublicEvent(t, "Description Event", city, jobTypeID, 2)
+	ids["instructions"], _ = seedPublicEvent(t, "Instructions Event", city, jobTypeID, 2)
+	ids["venue"], _ = seedPublicEvent(t, "Venue Event", city, jobTypeID, 2)
+	seedPublicEvent(t, "Unrelated Event", city, jobTypeID, 2)
+
+	// Each update goes through a different trigger of migration 000020.
+	mustExec(t, "UPDATE events SET description = $1 WHERE event_id = $2",
+		"Bring <gloves> & help sort the "+word+" donations.", ids["description"])
+	mustExec(t, "UPDATE opportunities SET pre_event_instructions = $1 WHERE event_id = $2",
+		"Ask for the "+word+" coordinator at the door.", ids["instructions"])
+	mustExec(t, "UPDATE venues SET venue_name = $1 WHERE venue_id = (SELECT venue_id FROM events WHERE event_id = $2)",
+		word+" Community Hall", ids["venue"])
+	return word, ids
+}
+
+func mustExec(t *testing.T, query string, args ...any) {
+	t.Helper()
+	if _, err := testDB.Exec(query, args...); err != nil {
+		t.Fatalf("exec %q: %v", query, err)
+	}
+}
+
+func checkSearchResults(t *testing.T, events []searchedEvent, word string, ids map[string]int) {
+	t.Helper()
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want the 4 mentioning %s: %+v", len(events), word, events)
+	}
+	// The name carries the most weight.
+	if events[0].ID != strconv.Itoa(ids["name"]) {
+		t.Errorf("first result = %s, want the event named for the keyword", events[0].Name)
+	}
+	for i, e := range events {
+		if e.SearchRank == nil || e.SearchSnippet == nil {
+			t.Fatalf("%s: rank or snippet missing", e.Name)
+		}
+		if i > 0 && *e.SearchRank > *events[i-1].SearchRank {
+			t.Errorf("%s ranks above the result before it", e.Name)
+		}
+		if !strings.Contains(*e.SearchSnippet, "<mark>"+word+"</mark>") {
+			t.Errorf("%s: snippet %q does not mark the keyword", e.Name, *e.SearchSnippet)
+		}
+		if e.ID == strconv.Itoa(ids["description"]) && !strings.Contains(*e.SearchSnippet, "&lt;gloves&gt; &amp;") {
+			t.Errorf("snippet %q is not HTML-escaped", *e.SearchSnippet)
+		}
+	}
+}
+
+// TestEventSearch_Volunteer verifies eventViews finds a keyword in each
+// searched field, best match first, with highlighted snippets.
+func TestEventSearch_Volunteer(t *testing.T) {
+	word, ids := searchFixture(t)
+	token, _ := makeVolunteer(t)
+
+	resp := gqlPost(t, "/graphql/volunteer", token, qrySearchEventViews, map[string]any{
+		"filter": map[string]any{"search": word},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("eventViews: unexpected errors: %v", resp.Errors)
+	}
+	var events []searchedEvent
+	unmarshalField(t, resp, "eventViews", &events)
+	checkSearchResults(t, events, word, ids)
+
+	// Without a search there is no rank or snippet.
+	resp = gqlPost(t, "/graphql/volunteer", token, qrySearchEventViews, map[string]any{
+		"filter": map[string]any{"search": "  "},
+	})
+	unmarshalField(t, resp, "eventViews", &events)
+	for _, e := range events {
+		if e.SearchRank != nil || e.SearchSnippet != nil {
+			t.Fatalf("%s: rank or snippet set without a search", e.Name)
+		}
+	}
+}
+
+// TestEventSearch_Admin verifies events and eventsConnection search, and
+// that search combines with the other filters.
+func TestEventSearch_Admin(t *testing.T) {
+	word, ids := searchFixture(t)
+	adminToken := makeAdminToken(t)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, qrySearchAdminEvents, map[string]any{
+		"filter": map[string]any{"search": word},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("events: unexpected errors: %v", resp.Errors)
+	}
+	var events []searchedEvent
+	unmarshalField(t, resp, "events", &events)
+	checkSearchResults(t, events, word, ids)
+
+	// Excluding a word and filtering by type narrow the matches.
+	resp = gqlPost(t, "/graphql/admin", adminToken, qrySearchAdminEvents, map[string]any{
+		"filter": map[string]any{"search": word + " -gala", "eventType": "IN_PERSON"},
+	})
+	unmarshalField(t, resp, "events", &events)
+	if len(events) != 3 {
+		t.Errorf("search %q: got %d events, want 3", word+" -gala", len(events))
+	}
+	resp = gqlPost(t, "/graphql/admin", adminToken, qrySearchAdminEvents, map[string]any{
+		"filter": map[string]any{"search": word, "eventType": "VIRTUAL"},
+	})
+	unmarshalField(t, resp, "events", &events)
+	if len(events) != 0 {
+		t.Errorf("search with eventType VIRTUAL: got %d events, want 0", len(events))
+	}
+
+	resp = gqlPost(t, "/graphql/admin", adminToken, qrySearchAdminEventsConnection, map[string]any{
+		"filter": map[string]any{"search": word},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("eventsConnection: unexpected errors: %v", resp.Errors)
+	}
+	var conn struct {
+		TotalCount int `json:"totalCount"`
+		Edges      []struct {
+			Node searchedEvent `json:"node"`
+		} `json:"edges"`
+	}
+	unmarshalField(t, resp, "eventsConnection", &conn)
+	if conn.TotalCount != 4 || len(conn.Edges) != 4 || conn.Edges[0].Node.SearchSnippet == nil {
+		t.Errorf("eventsConnection = %+v, want 4 matches with snippets", conn)
+	}
+}
+
+// TestEventSearch_TooLong verifies an overlong search is a validation error.
+func TestEventSearch_TooLong(t *testing.T) {
+	resp := gqlPost(t, "/graphql/admin", makeAdminToken(t), qrySearchAdminEvents, map[string]any{
+		"filter": map[string]any{"search": strings.Repeat("food ", 100)},
+	})
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("code = %q, want VALIDATION", code)
+	}
+}
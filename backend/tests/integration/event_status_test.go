@@ -0,0 +1,181 @@
+package integration
+
+// ============================================================================
+// Integration tests — event lifecycle (draft, published, cancelled)
+// ============================================================================
+//
+//   - Draft events are hidden from eventViews and eventView
+//   - publishEvent makes a draft visible; a future publishAt keeps it hidden
+//   - cancelEvent keeps the event, cancels upcoming signups and blocks new ones
+//   - The admin statuses filter narrows the event list
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+const (
+	mutPublishEvent = `mutation($eventId: ID!, $publishAt: String) {
+		publishEvent(eventId: $eventId, publishAt: $publishAt) { success message code }
+	}`
+
+	mutCancelEvent = `mutation($eventId: ID!) {
+		cancelEvent(eventId: $eventId) { success message code }
+	}`
+
+	qryEventViewStatus = `query($eventId: ID!) {
+		eventView(eventId: $eventId) { id name status }
+	}`
+)
+
+// seedStatusEvent seeds an upcoming event with one shift and sets its status.
+func seedStatusEvent(t *testing.T, status string) (eventID, shiftID int, name string) {
+	t.Helper()
+	name = fmt.Sprintf("Status-%s-%d", status, time.Now().UnixNano())
+	eventID = seedEvent(t, name, true, nil)
+	seedEventDate(t, eventID, "2031-05-01T16:00:00Z", "2031-05-01T20:00:00Z")
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	shiftID = seedShift(t, oppID, "2031-05-01T16:00:00Z", "2031-05-01T20:00:00Z", 5)
+	mustExec(t, "UPDATE events SET status = $1 WHERE event_id = $2", status, eventID)
+	return eventID, shiftID, name
+}
+
+func eventStatus(t *testing.T, eventID int) string {
+	t.Helper()
+	var status string
+	if err := testDB.QueryRow("SELECT status FROM events WHERE event_id = $1", eventID).Scan(&status); err != nil {
+		t.Fatalf("eventStatus: %v", err)
+	}
+	return status
+}
+
+func TestEventStatus_DraftHiddenUntilPublished(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	volToken, _ := makeVolunteer(t)
+	eventID, _, name := seedStatusEvent(t, "DRAFT")
+	id := fmt.Sprintf("%d", eventID)
+
+	resp := gqlPost(t, "/graphql/volunteer", volToken, queryFilteredEvents, nil)
+	if eventNamesFromResponse(t, resp)[name] {
+		t.Fatal("draft event listed in eventViews")
+	}
+	resp = gqlPost(t, "/graphql/volunteer", volToken, qryEventViewStatus, map[string]any{"eventId": id})
+	if code := errorCode(resp); code != "NOT_FOUND" {
+		t.Fatalf("eventView of a draft: code = %q, want NOT_FOUND (errors: %v)", code, resp.Errors)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutPublishEvent, map[string]any{"eventId": id})
+	if hasGQLErrors(resp) {
+		t.Fatalf("publishEvent errors: %v", resp.Errors)
+	}
+	if got := eventStatus(t, eventID); got != "PUBLISHED" {
+		t.Fatalf("status = %q, want PUBLISHED", got)
+	}
+
+	resp = gqlPost(t, "/graphql/volunteer", volToken, queryFilteredEvents, nil)
+	if !eventNamesFromResponse(t, resp)[name] {
+		t.Error("published event missing from eventViews")
+	}
+	resp = gqlPost(t, "/graphql/volunteer", volToken, qryEventViewStatus, map[string]any{"eventId": id})
+	var view struct {
+		Status string `json:"status"`
+	}
+	unmarshalField(t, resp, "eventView", &view)
+	if view.Status != "PUBLISHED" {
+		t.Errorf("eventView status = %q, want PUBLISHED", view.Status)
+	}
+
+	// Publishing twice is a conflict.
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutPublishEvent, map[string]any{"eventId": id})
+	if code := errorCode(resp); code != "CONFLICT" {
+		t.Errorf("second publishEvent: code = %q, want CONFLICT (errors: %v)", code, resp.Errors)
+	}
+}
+
+func TestEventStatus_ScheduledPublish(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	volToken, _ := makeVolunteer(t)
+	eventID, _, name := seedStatusEvent(t, "DRAFT")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutPublishEvent, map[string]any{
+		"eventId":   fmt.Sprintf("%d", eventID),
+		"publishAt": "2030-01-01 09:00:00",
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("publishEvent errors: %v", resp.Errors)
+	}
+	if got := eventStatus(t, eventID); got != "DRAFT" {
+		t.Fatalf("status = %q, want DRAFT until publishAt", got)
+	}
+	resp = gqlPost(t, "/graphql/volunteer", volToken, queryFilteredEvents, nil)
+	if eventNamesFromResponse(t, resp)[name] {
+		t.Error("scheduled event listed before its publish time")
+	}
+
+	// Once publish_at has passed the event shows without waiting for the scheduler.
+	mustExec(t, "UPDATE events SET publish_at = NOW() - INTERVAL '1 minute' WHERE event_id = $1", eventID)
+	resp = gqlPost(t, "/graphql/volunteer", volToken, queryFilteredEvents, nil)
+	if !eventNamesFromResponse(t, resp)[name] {
+		t.Error("event missing from eventViews after its publish time")
+	}
+}
+
+func TestEventStatus_CancelKeepsHistoryAndBlocksSignups(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	volToken, volID := makeVolunteer(t)
+	_, otherID := makeVolunteer(t)
+	eventID, shiftID, _ := seedStatusEvent(t, "PUBLISHED")
+	seedVolunteerShift(t, shiftID, otherID)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCancelEvent, map[string]any{
+		"eventId": fmt.Sprintf("%d", eventID),
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("cancelEvent errors: %v", resp.Errors)
+	}
+	if got := eventStatus(t, eventID); got != "CANCELLED" {
+		t.Fatalf("status = %q, want CANCELLED", got)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM shifts WHERE shift_id = $1", shiftID) {
+		t.Error("cancelEvent removed the event's shifts")
+	}
+	if !rowExists(t, `
+		SELECT COUNT(*) FROM volunteer_shifts
+		WHERE volunteer_id = $1 AND shift_id = $2 AND cancelled_at IS NOT NULL
+	`, otherID, shiftID) {
+		t.Error("expected the existing signup to be kept and marked cancelled")
+	}
+
+	resp = gqlPost(t, "/graphql/volunteer", volToken,
+		`mutation($shiftId: ID!) { assignSelfToShift(shiftId: $shiftId) { success code } }`,
+		map[string]any{"shiftId": fmt.Sprintf("%d", shiftID)})
+	var result struct {
+		Success bool    `json:"success"`
+		Code    *string `json:"code"`
+	}
+	unmarshalField(t, resp, "assignSelfToShift", &result)
+	if result.Success || result.Code == nil || *result.Code != "CONFLICT" {
+		t.Errorf("assignSelfToShift on a cancelled event = %+v, want success=false code=CONFLICT", result)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM volunteer_shifts WHERE volunteer_id = $1", volID) {
+		t.Error("volunteer was signed up for a cancelled event")
+	}
+}
+
+func TestEventStatus_AdminStatusesFilter(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	_, _, draftName := seedStatusEvent(t, "DRAFT")
+	_, _, publishedName := seedStatusEvent(t, "PUBLISHED")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, queryAdminFilteredEvents, map[string]any{
+		"filter": map[string]any{"statuses": []string{"DRAFT"}},
+	})
+	names := adminEventNamesFromResponse(t, resp)
+	if !names[draftName] {
+		t.Error("statuses=[DRAFT] missing the draft event")
+	}
+	if names[publishedName] {
+		t.Error("statuses=[DRAFT] returned a published event")
+	}
+}
@@ -2,25 +2,42 @@ package integration
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/webhooks"
 )
 
 // hashSessionToken computes the SHA-256 hash of the token string, matching
 // the storage representation used by CreateSessionToken, ValidateSessionToken,
-// and Logout in auth_magiclink.go.  The DB always stores the hash; callers
-// pass the raw (plaintext) token as cookie / Bearer value.
+// and Logout in auth_magiclink.go. The DB always stores the hash; callers
+// pass the raw (plaintext) token as cookie / Bearer value. Magic-link tokens
+// are stored the same way.
 func hashSessionToken(token string) string {
 	h := sha256.Sum256([]byte(token))
 	return hex.EncodeToString(h[:])
 }
 
+// hashSignInCode mirrors the magic_links.code_hash representation used by
+// GenerateMagicLink and ConsumeSignInCode, keyed with the SIGN_IN_CODE_SECRET
+// set in setup_test.go.
+func hashSignInCode(email, code string) string {
+	mac := hmac.New(sha256.New, []byte(os.Getenv("SIGN_IN_CODE_SECRET")))
+	mac.Write([]byte(strings.ToLower(strings.TrimSpace(email)) + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // ============================================================================
 // GraphQL request helper
 // ============================================================================
@@ -29,7 +46,8 @@ func hashSessionToken(token string) string {
 type gqlResponse struct {
 	Data   map[string]json.RawMessage `json:"data"`
 	Errors []struct {
-		Message string `json:"message"`
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
 	} `json:"errors"`
 }
 
@@ -142,6 +160,7 @@ func gqlPostCookie(t *testing.T, path, cookieValue, query string, variables map[
 		t.Fatalf("gqlPostCookie: create request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.CSRFHeader, "fetch") // as the frontend sends it
 	if cookieValue != "" {
 		req.AddCookie(&http.Cookie{Name: "session", Value: cookieValue})
 	}
@@ -184,6 +203,7 @@ func gqlPostFullCookie(t *testing.T, path, cookieValue, query string, variables
 		t.Fatalf("gqlPostFullCookie: create request: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.CSRFHeader, "fetch") // as the frontend sends it
 	if cookieValue != "" {
 		req.AddCookie(&http.Cookie{Name: "session", Value: cookieValue})
 	}
@@ -206,6 +226,49 @@ func gqlPostFullCookie(t *testing.T, path, cookieValue, query string, variables
 	return result, resp.Cookies()
 }
 
+// gqlPostWithCookies sends a GraphQL POST carrying arbitrary cookies (e.g.
+// the magic-link browser nonce) and returns the parsed response and any
+// Set-Cookie headers.
+func gqlPostWithCookies(t *testing.T, path string, cookies []*http.Cookie, query string, variables map[string]any) (gqlResponse, []*http.Cookie) {
+	t.Helper()
+
+	body := map[string]any{"query": query}
+	if variables != nil {
+		body["variables"] = variables
+	}
+	b, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("gqlPostWithCookies: marshal request: %v", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+path, bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("gqlPostWithCookies: create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.CSRFHeader, "fetch")
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gqlPostWithCookies: do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("gqlPostWithCookies: read response: %v", err)
+	}
+
+	var result gqlResponse
+	if err := json.Unmarshal(respBytes, &result); err != nil {
+		t.Fatalf("gqlPostWithCookies: unmarshal response: %v\nbody: %s", err, respBytes)
+	}
+	return result, resp.Cookies()
+}
+
 // findCookie returns the named cookie from a Set-Cookie response, or nil.
 func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
 	for _, c := range cookies {
@@ -233,8 +296,9 @@ func unmarshalField(t *testing.T, r gqlResponse, field string, dest any) {
 // ============================================================================
 
 // seedVolunteer inserts an active volunteer directly into the DB and returns the ID.
-// The role argument ("VOLUNTEER" or "ADMINISTRATOR") is inserted into volunteer_roles.
-// ADMINISTRATOR automatically also gets the VOLUNTEER role (business rule).
+// The role argument ("VOLUNTEER", "ADMINISTRATOR" or "COORDINATOR") is inserted
+// into volunteer_roles. ADMINISTRATOR and COORDINATOR automatically also get the
+// VOLUNTEER role (business rule).
 func seedVolunteer(t *testing.T, email, firstName, lastName, role string) int {
 	t.Helper()
 	var id int
@@ -273,13 +337,13 @@ func seedInactiveVolunteer(t *testing.T, email, firstName, lastName, role string
 	return id
 }
 
-// seedVolunteerRoles inserts the given role (and VOLUNTEER if role is ADMINISTRATOR)
-// into the volunteer_roles junction table for the given volunteer ID.
+// seedVolunteerRoles inserts the given role (and VOLUNTEER if role is ADMINISTRATOR
+// or COORDINATOR) into the volunteer_roles junction table for the given volunteer ID.
 func seedVolunteerRoles(t *testing.T, volunteerID int, role string) {
 	t.Helper()
 	rolesToInsert := []string{"VOLUNTEER"}
-	if role == "ADMINISTRATOR" {
-		rolesToInsert = append(rolesToInsert, "ADMINISTRATOR")
+	if role == "ADMINISTRATOR" || role == "COORDINATOR" {
+		rolesToInsert = append(rolesToInsert, role)
 	}
 	for _, r := range rolesToInsert {
 		_, err := testDB.Exec(`
@@ -293,7 +357,8 @@ func seedVolunteerRoles(t *testing.T, volunteerID int, role string) {
 	}
 }
 
-// seedMagicLink inserts a magic link token directly into the DB.
+// seedMagicLink inserts a magic link token directly into the DB. As in
+// GenerateMagicLink, only the SHA-256 hash of the token is stored.
 // Use a future expiresAt for a valid token; a past time for an expired one.
 // Times are converted to UTC before storage to match the TIMESTAMP column type
 // in the database (which stores without timezone and compares against UTC NOW()).
@@ -302,21 +367,54 @@ func seedMagicLink(t *testing.T, email, token string, expiresAt time.Time) {
 	_, err := testDB.Exec(`
 		INSERT INTO magic_links (email, token, created_at, expires_at)
 		VALUES ($1, $2, NOW(), $3)
-	`, email, token, expiresAt.UTC())
+	`, email, hashSessionToken(token), expiresAt.UTC())
 	if err != nil {
 		t.Fatalf("seedMagicLink: %v", err)
 	}
 	t.Cleanup(func() {
-		testDB.Exec("DELETE FROM magic_links WHERE token = $1", token)
+		testDB.Exec("DELETE FROM magic_links WHERE token = $1", hashSessionToken(token))
+	})
+}
+
+// seedBoundMagicLink inserts a magic link tied to the browser holding nonce.
+func seedBoundMagicLink(t *testing.T, email, token, nonce string) {
+	t.Helper()
+	_, err := testDB.Exec(`
+		INSERT INTO magic_links (email, token, browser_nonce_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, NOW(), NOW() + INTERVAL '15 minutes')
+	`, email, hashSessionToken(token), hashSessionToken(nonce))
+	if err != nil {
+		t.Fatalf("seedBoundMagicLink: %v", err)
+	}
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM magic_links WHERE token = $1", hashSessionToken(token))
+	})
+}
+
+// seedSignInCode inserts a magic link that also carries the 6-digit sign-in
+// code, as GenerateMagicLink does, and returns the link token.
+func seedSignInCode(t *testing.T, email, code string, expiresAt time.Time) string {
+	t.Helper()
+	token := "code-" + code + "-" + email
+	_, err := testDB.Exec(`
+		INSERT INTO magic_links (email, token, code_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, NOW(), $4)
+	`, email, hashSessionToken(token), hashSignInCode(email, code), expiresAt.UTC())
+	if err != nil {
+		t.Fatalf("seedSignInCode: %v", err)
+	}
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM magic_links WHERE token = $1", hashSessionToken(token))
 	})
+	return token
 }
 
 // seedSession inserts a session token directly into the DB and returns the
 // plaintext token.  The DB stores the SHA-256 hash of the token (matching
 // what CreateSessionToken does), while the plaintext is used by callers as
 // a cookie / Bearer value.
-// email must match the volunteer's email — the sessions table has a NOT NULL
-// unique constraint on email.
+// email must match the volunteer's email. A volunteer may hold any number of
+// sessions, so this can be called repeatedly to simulate several devices.
 // The role parameter is accepted for backwards-compatibility but is no longer
 // stored in the sessions table; roles live in volunteer_roles instead.
 func seedSession(t *testing.T, email string, volunteerID int, role, token string) string {
@@ -377,7 +475,7 @@ func magicLinkUsed(t *testing.T, token string) bool {
 	t.Helper()
 	var count int
 	err := testDB.QueryRow(
-		"SELECT COUNT(*) FROM magic_links WHERE token = $1 AND used_at IS NOT NULL", token,
+		"SELECT COUNT(*) FROM magic_links WHERE token = $1 AND used_at IS NOT NULL", hashSessionToken(token),
 	).Scan(&count)
 	if err != nil {
 		t.Fatalf("magicLinkUsed: %v", err)
@@ -681,3 +779,34 @@ func seedVolunteerShift(t *testing.T, shiftID, volID int) {
 		testDB.Exec("DELETE FROM volunteer_shifts WHERE volunteer_id = $1 AND shift_id = $2", volID, shiftID)
 	})
 }
+
+// postSignedWebhook POSTs payload as JSON to a /webhooks/* path, signed with
+// testWebhookSecret the way a mail provider would. Returns the status code and
+// decoded JSON body.
+func postSignedWebhook(t *testing.T, path string, payload any) (int, map[string]any) {
+	t.Helper()
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("postSignedWebhook: marshal payload: %v", err)
+	}
+	now := time.Now()
+
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+path, bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("postSignedWebhook: create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign(testWebhookSecret, now, b))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("postSignedWebhook: do request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]any
+	json.NewDecoder(resp.Body).Decode(&result)
+	return resp.StatusCode, result
+}
@@ -0,0 +1,269 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const (
+	mutStartImpersonation = `
+		mutation Start($input: StartImpersonationInput!) {
+			startImpersonation(input: $input) {
+				success
+				message
+				token
+				impersonation { id volunteerId reason volunteerNotified }
+			}
+		}`
+
+	mutEndImpersonation = `
+		mutation End($id: ID!) {
+			endImpersonation(impersonationId: $id) { success message }
+		}`
+
+	qryImpersonations = `
+		query Impersonations($volunteerId: ID) {
+			impersonations(volunteerId: $volunteerId) { id adminId volunteerId reason requestCount }
+		}`
+
+	qryOwnProfileEmail = `query { ownProfile { email } }`
+)
+
+type impersonationResult struct {
+	Success       bool    `json:"success"`
+	Message       *string `json:"message"`
+	Token         *string `json:"token"`
+	Impersonation *struct {
+		ID                string `json:"id"`
+		VolunteerID       string `json:"volunteerId"`
+		Reason            string `json:"reason"`
+		VolunteerNotified bool   `json:"volunteerNotified"`
+	} `json:"impersonation"`
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// startImpersonation has adminToken start viewing as volID and returns the
+// impersonation token and id.
+func startImpersonation(t *testing.T, adminToken string, volID int, notify bool) (string, string) {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutStartImpersonation, map[string]any{
+		"input": map[string]any{
+			"volunteerId":     strconv.Itoa(volID),
+			"reason":          "Volunteer reports a missing event",
+			"notifyVolunteer": notify,
+		},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("startImpersonation: unexpected errors: %v", resp.Errors)
+	}
+	var result impersonationResult
+	unmarshalField(t, resp, "startImpersonation", &result)
+	if !result.Success || result.Token == nil || result.Impersonation == nil {
+		t.Fatal("startImpersonation: expected success with a token")
+	}
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM impersonation_sessions WHERE id = $1", result.Impersonation.ID)
+	})
+	return *result.Token, result.Impersonation.ID
+}
+
+// gqlPostImpersonating posts to /graphql/volunteer with the caller's session
+// and an X-Impersonate header, returning the HTTP status and parsed body.
+func gqlPostImpersonating(t *testing.T, sessionToken, impToken, query string) (int, gqlResponse) {
+	t.Helper()
+	b, _ := json.Marshal(map[string]any{"query": query})
+	req, err := http.NewRequest(http.MethodPost, testServer.URL+"/graphql/volunteer", bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("gqlPostImpersonating: create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+sessionToken)
+	req.Header.Set("X-Impersonate", impToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("gqlPostImpersonating: do request: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	var result gqlResponse
+	json.Unmarshal(body, &result)
+	return resp.StatusCode, result
+}
+
+// ============================================================================
+// Viewing as a volunteer
+// ============================================================================
+
+// TestImpersonation_SeesVolunteerData verifies the volunteer schema answers
+// as the impersonated volunteer and each request is counted.
+func TestImpersonation_SeesVolunteerData(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	volEmail := uniqueEmail(t)
+	volID := seedVolunteer(t, volEmail, "Viewed", "Volunteer", "VOLUNTEER")
+
+	impToken, impID := startImpersonation(t, adminToken, volID, false)
+
+	status, resp := gqlPostImpersonating(t, adminToken, impToken, qryOwnProfileEmail)
+	if status != http.StatusOK || hasGQLErrors(resp) {
+		t.Fatalf("expected 200 without errors, got %d: %v", status, resp.Errors)
+	}
+	var profile struct {
+		Email string `json:"email"`
+	}
+	unmarshalField(t, resp, "ownProfile", &profile)
+	if profile.Email != volEmail {
+		t.Errorf("expected the volunteer's profile (%s), got %s", volEmail, profile.Email)
+	}
+
+	if !rowExists(t, "SELECT COUNT(*) FROM impersonation_sessions WHERE id = $1 AND request_count = 1 AND last_used_at IS NOT NULL", impID) {
+		t.Error("expected the request to be counted on the audit record")
+	}
+}
+
+// TestImpersonation_MutationsBlocked verifies every write is refused.
+func TestImpersonation_MutationsBlocked(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	impToken, _ := startImpersonation(t, adminToken, volID, false)
+
+	status, resp := gqlPostImpersonating(t, adminToken, impToken, `mutation { revokeOtherSessions { success } }`)
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d", status)
+	}
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected the mutation to be refused while impersonating")
+	}
+	if code, _ := resp.Errors[0].Extensions["code"].(string); code != "READ_ONLY" {
+		t.Errorf("expected READ_ONLY error code, got %q", code)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM sessions WHERE volunteer_id = $1", volID) {
+		t.Error("the volunteer's sessions must be untouched")
+	}
+}
+
+// TestImpersonation_SessionsHidden verifies the volunteer's sign-in devices,
+// IP addresses and user agents are not shown while viewing as them.
+func TestImpersonation_SessionsHidden(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	impToken, _ := startImpersonation(t, adminToken, volID, false)
+
+	_, resp := gqlPostImpersonating(t, adminToken, impToken, qryOwnSessions)
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected ownSessions to be refused while impersonating")
+	}
+	if code, _ := resp.Errors[0].Extensions["code"].(string); code != "READ_ONLY" {
+		t.Errorf("expected READ_ONLY error code, got %q", code)
+	}
+}
+
+// TestImpersonation_RequiresAdmin verifies a volunteer cannot use the header.
+func TestImpersonation_RequiresAdmin(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	volToken, _ := makeVolunteer(t)
+	_, otherID := makeVolunteer(t)
+	impToken, _ := startImpersonation(t, adminToken, otherID, false)
+
+	status, _ := gqlPostImpersonating(t, volToken, impToken, qryOwnProfileEmail)
+	if status != http.StatusForbidden {
+		t.Errorf("expected 403 for a volunteer, got %d", status)
+	}
+}
+
+// TestImpersonation_TokenBoundToAdmin verifies another admin cannot reuse a token.
+func TestImpersonation_TokenBoundToAdmin(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	otherAdminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	impToken, _ := startImpersonation(t, adminToken, volID, false)
+
+	status, _ := gqlPostImpersonating(t, otherAdminToken, impToken, qryOwnProfileEmail)
+	if status != http.StatusForbidden {
+		t.Errorf("expected 403 for another admin, got %d", status)
+	}
+}
+
+// TestImpersonation_EndedAndExpired verifies a token stops working once
+// ended or past its expiry.
+func TestImpersonation_EndedAndExpired(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+
+	impToken, impID := startImpersonation(t, adminToken, volID, false)
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutEndImpersonation, map[string]any{"id": impID})
+	if hasGQLErrors(resp) {
+		t.Fatalf("endImpersonation: unexpected errors: %v", resp.Errors)
+	}
+	if status, _ := gqlPostImpersonating(t, adminToken, impToken, qryOwnProfileEmail); status != http.StatusForbidden {
+		t.Errorf("expected 403 after ending, got %d", status)
+	}
+
+	impToken, impID = startImpersonation(t, adminToken, volID, false)
+	testDB.Exec("UPDATE impersonation_sessions SET expires_at = NOW() - INTERVAL '1 minute' WHERE id = $1", impID)
+	if status, _ := gqlPostImpersonating(t, adminToken, impToken, qryOwnProfileEmail); status != http.StatusForbidden {
+		t.Errorf("expected 403 after expiry, got %d", status)
+	}
+}
+
+// ============================================================================
+// Audit trail
+// ============================================================================
+
+// TestStartImpersonation_ReasonRequired verifies a reason must be given.
+func TestStartImpersonation_ReasonRequired(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutStartImpersonation, map[string]any{
+		"input": map[string]any{"volunteerId": strconv.Itoa(volID), "reason": "   "},
+	})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected an error without a reason")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM impersonation_sessions WHERE volunteer_id = $1", volID) {
+		t.Error("no impersonation should have been recorded")
+	}
+}
+
+// TestImpersonations_AuditTrail verifies who, whom, why and notification are
+// recorded and listed.
+func TestImpersonations_AuditTrail(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	_, impID := startImpersonation(t, adminToken, volID, true)
+
+	if !rowExists(t, "SELECT COUNT(*) FROM impersonation_sessions WHERE id = $1 AND volunteer_notified", impID) {
+		t.Error("expected volunteer_notified to be recorded")
+	}
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryImpersonations, map[string]any{"volunteerId": strconv.Itoa(volID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var imps []struct {
+		ID          string `json:"id"`
+		AdminID     string `json:"adminId"`
+		VolunteerID string `json:"volunteerId"`
+		Reason      string `json:"reason"`
+	}
+	unmarshalField(t, resp, "impersonations", &imps)
+	if len(imps) != 1 || imps[0].ID != impID {
+		t.Fatalf("expected exactly the one impersonation, got %+v", imps)
+	}
+	if imps[0].AdminID != strconv.Itoa(adminID) || imps[0].Reason == "" {
+		t.Errorf("unexpected audit record: %+v", imps[0])
+	}
+}
@@ -0,0 +1,194 @@
+package integration
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/services"
+	"volunteer-scheduler/webhooks"
+)
+
+// ============================================================================
+// Inbound email replies — /webhooks/inbound-email
+//
+// The webhook stands in for the mail provider's inbound parser. Replies are
+// matched to feedback by the signed Reply-To address (INBOUND_REPLY_DOMAIN and
+// INBOUND_EMAIL_SECRET are set in setup_test.go).
+// ============================================================================
+
+// volunteerEmail returns the email address on record for volID.
+func volunteerEmail(t *testing.T, volID int) string {
+	t.Helper()
+	var email string
+	if err := testDB.QueryRow("SELECT email FROM volunteers WHERE volunteer_id = $1", volID).Scan(&email); err != nil {
+		t.Fatalf("volunteerEmail: %v", err)
+	}
+	return email
+}
+
+func feedbackReplyAddress(t *testing.T, feedbackID int) string {
+	t.Helper()
+	addr, ok := services.FeedbackReplyAddress(feedbackID)
+	if !ok {
+		t.Fatal("inbound replies are not configured in the test environment")
+	}
+	return addr
+}
+
+// TestInboundEmail_ReplyStoredAsVolunteerNote verifies the happy path: a reply
+// to a QUESTION_SENT thread is stored (without the quoted original) and the
+// feedback is reopened.
+func TestInboundEmail_ReplyStoredAsVolunteerNote(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	_, adminID := makeAdmin(t)
+	feedbackID := seedFeedback(t, volID)
+	seedFeedbackNote(t, feedbackID, adminID, "QUESTION", "Which browser were you using?")
+	if _, err := testDB.Exec("UPDATE feedback SET status = 'QUESTION_SENT' WHERE feedback_id = $1", feedbackID); err != nil {
+		t.Fatalf("failed to seed QUESTION_SENT status: %v", err)
+	}
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM feedback_notes WHERE feedback_id = $1", feedbackID)
+	})
+
+	status, body := postSignedWebhook(t, "/webhooks/inbound-email", map[string]any{
+		"from":    "Vol Test <" + volunteerEmail(t, volID) + ">",
+		"to":      []string{feedbackReplyAddress(t, feedbackID)},
+		"subject": "re: Test subject",
+		"text":    "Firefox on Linux.\n\nOn Tue, Jan 2, 2035 at 9:00 AM Admin <a@example.com> wrote:\n> Which browser were you using?",
+	})
+
+	if status != http.StatusOK {
+		t.Fatalf("expected 200, got %d (%v)", status, body)
+	}
+	if body["accepted"] != true {
+		t.Fatalf("expected accepted=true, got %v", body)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = $1 AND note_type = 'VOLUNTEER_NOTE' AND note = $2",
+		feedbackID, "Firefox on Linux.") {
+		t.Error("expected the reply (without quoted text) stored as a VOLUNTEER_NOTE")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM feedback WHERE feedback_id = $1 AND status = 'OPEN'", feedbackID) {
+		t.Error("expected status to reset to OPEN after an emailed reply")
+	}
+}
+
+// TestInboundEmail_RedeliveryIgnored verifies that a message delivered twice
+// (same Message-ID) is acknowledged both times but stored once, and the
+// repeat does not reopen the thread or notify the admin again.
+func TestInboundEmail_RedeliveryIgnored(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	feedbackID := seedFeedback(t, volID)
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM feedback_notes WHERE feedback_id = $1", feedbackID)
+	})
+
+	payload := map[string]any{
+		"messageId": "<reply-" + strconv.FormatInt(time.Now().UnixNano(), 10) + "@mail.example.com>",
+		"from":      volunteerEmail(t, volID),
+		"to":        []string{feedbackReplyAddress(t, feedbackID)},
+		"text":      "Still happening on Safari.",
+	}
+	for i := 1; i <= 2; i++ {
+		status, body := postSignedWebhook(t, "/webhooks/inbound-email", payload)
+		if status != http.StatusOK || body["accepted"] != true {
+			t.Fatalf("delivery %d: expected 200 accepted=true, got %d %v", i, status, body)
+		}
+		if i == 1 {
+			mustExec(t, "UPDATE feedback SET status = 'QUESTION_SENT' WHERE feedback_id = $1", feedbackID)
+		}
+	}
+
+	var notes int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = $1", feedbackID).Scan(&notes); err != nil {
+		t.Fatalf("count notes: %v", err)
+	}
+	if notes != 1 {
+		t.Errorf("expected the reply stored once, got %d notes", notes)
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM feedback WHERE feedback_id = $1 AND status = 'QUESTION_SENT'", feedbackID) {
+		t.Error("a redelivered reply must not reopen the thread")
+	}
+}
+
+// TestInboundEmail_WrongSender verifies that a reply from anyone other than
+// the feedback's submitter is acknowledged but not stored.
+func TestInboundEmail_WrongSender(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	feedbackID := seedFeedback(t, volID)
+
+	status, body := postSignedWebhook(t, "/webhooks/inbound-email", map[string]any{
+		"from": "someone.else@example.com",
+		"to":   []string{feedbackReplyAddress(t, feedbackID)},
+		"text": "I was forwarded this.",
+	})
+
+	if status != http.StatusOK || body["accepted"] != false {
+		t.Fatalf("expected 200 accepted=false, got %d %v", status, body)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = $1", feedbackID) {
+		t.Error("no note should be stored for a reply from another sender")
+	}
+}
+
+// TestInboundEmail_DeletedFeedback verifies a reply to a thread that has since
+// been deleted is acknowledged rather than failed, so the provider stops
+// retrying it.
+func TestInboundEmail_DeletedFeedback(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	feedbackID := seedFeedback(t, volID)
+	mustExec(t, "DELETE FROM feedback WHERE feedback_id = $1", feedbackID)
+
+	status, body := postSignedWebhook(t, "/webhooks/inbound-email", map[string]any{
+		"from": volunteerEmail(t, volID),
+		"to":   []string{feedbackReplyAddress(t, feedbackID)},
+		"text": "Any news?",
+	})
+
+	if status != http.StatusOK || body["accepted"] != false {
+		t.Fatalf("expected 200 accepted=false, got %d %v", status, body)
+	}
+}
+
+// TestInboundEmail_ForgedAddress verifies that a reply address with a bad
+// signature does not match any thread.
+func TestInboundEmail_ForgedAddress(t *testing.T) {
+	_, volID := makeVolunteer(t)
+	feedbackID := seedFeedback(t, volID)
+
+	status, body := postSignedWebhook(t, "/webhooks/inbound-email", map[string]any{
+		"from": volunteerEmail(t, volID),
+		"to":   []string{"feedback+" + strconv.Itoa(feedbackID) + ".0000000000000000@reply.example.com"},
+		"text": "Forged.",
+	})
+
+	if status != http.StatusOK || body["accepted"] != false {
+		t.Fatalf("expected 200 accepted=false, got %d %v", status, body)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = $1", feedbackID) {
+		t.Error("no note should be stored for a forged reply address")
+	}
+}
+
+// TestInboundEmail_BadSignature verifies that unsigned or wrongly signed
+// webhook requests are rejected with 401.
+func TestInboundEmail_BadSignature(t *testing.T) {
+	body := []byte(`{"from":"x@example.com","to":[],"text":"hi"}`)
+
+	req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/webhooks/inbound-email", bytes.NewReader(body))
+	now := time.Now()
+	req.Header.Set(webhooks.TimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	req.Header.Set(webhooks.SignatureHeader, webhooks.Sign("wrong-secret", now, body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a bad signature, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,304 @@
+package integration
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/services"
+	"volunteer-scheduler/sso"
+)
+
+// ssoFrontend is where the SSO handlers send the browser afterwards. Nothing
+// listens there; the test client stops at the redirect.
+const ssoFrontend = "http://frontend.test"
+
+// ============================================================================
+// Mock identity provider
+// ============================================================================
+
+// mockIdP is a minimal OpenID Connect provider: discovery, an authorize
+// endpoint that signs the user in immediately, a PKCE-checking token endpoint
+// and a JWKS. email and emailVerified are the claims it issues.
+type mockIdP struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu            sync.Mutex
+	email         string
+	emailVerified bool
+	codes         map[string]mockAuthorization
+}
+
+type mockAuthorization struct {
+	nonce     string
+	challenge string
+}
+
+func newMockIdP(t *testing.T, email string, emailVerified bool) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("newMockIdP: %v", err)
+	}
+	idp := &mockIdP{key: key, email: email, emailVerified: emailVerified, codes: map[string]mockAuthorization{}}
+
+	mux := http.NewServeMux()
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.URL,
+			"authorization_endpoint": idp.URL + "/authorize",
+			"token_endpoint":         idp.URL + "/token",
+			"jwks_uri":               idp.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "mock-key",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   "AQAB",
+		}}})
+	})
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+			http.Error(w, "PKCE required", http.StatusBadRequest)
+			return
+		}
+		code := uniqueCode(t, "code-")
+		idp.mu.Lock()
+		idp.codes[code] = mockAuthorization{nonce: q.Get("nonce"), challenge: q.Get("code_challenge")}
+		idp.mu.Unlock()
+
+		back, _ := url.Parse(q.Get("redirect_uri"))
+		back.RawQuery = url.Values{"code": {code}, "state": {q.Get("state")}}.Encode()
+		http.Redirect(w, r, back.String(), http.StatusFound)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		idp.mu.Lock()
+		authz, ok := idp.codes[r.PostForm.Get("code")]
+		delete(idp.codes, r.PostForm.Get("code"))
+		idp.mu.Unlock()
+
+		sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+		if !ok || base64.RawURLEncoding.EncodeToString(sum[:]) != authz.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "mock-access-token",
+			"token_type":   "Bearer",
+			"id_token":     idp.idToken(t, r.PostForm.Get("client_id"), authz.nonce),
+		})
+	})
+	return idp
+}
+
+// idToken signs an RS256 ID token for the configured user.
+func (idp *mockIdP) idToken(t *testing.T, clientID, nonce string) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "mock-key", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iss":            idp.URL,
+		"aud":            clientID,
+		"sub":            "mock-user",
+		"email":          idp.email,
+		"email_verified": idp.emailVerified,
+		"nonce":          nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	})
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Errorf("mockIdP: sign: %v", err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// newSSOServer serves the SSO endpoints against idp, restricted to domains.
+func newSSOServer(t *testing.T, idp *mockIdP, domains ...string) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	oidc := services.NewOIDCService(testDB, services.OIDCConfig{
+		Issuer:         idp.URL,
+		ClientID:       "volunteer-scheduler",
+		ClientSecret:   "mock-secret",
+		RedirectURL:    srv.URL + "/auth/oidc/callback",
+		Scopes:         []string{"openid", "email"},
+		AllowedDomains: domains,
+	})
+	h := &sso.Handlers{OIDC: oidc, MagicLink: testMagicLinkService, FrontendURL: ssoFrontend}
+	mux.Handle("/auth/oidc/login", h.Login())
+	mux.Handle("/auth/oidc/callback", h.Callback())
+	return srv
+}
+
+// ssoClient is a browser stand-in: it keeps cookies and follows redirects
+// until it is sent to the frontend.
+func ssoClient(t *testing.T) *http.Client {
+	t.Helper()
+	jar, _ := cookiejar.New(nil)
+	return &http.Client{
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host == "frontend.test" {
+				return http.ErrUseLastResponse
+			}
+			return nil
+		},
+	}
+}
+
+// ssoSignIn runs the flow from /auth/oidc/login and returns where the browser
+// lands on the frontend and the "session" cookie, if any.
+func ssoSignIn(t *testing.T, client *http.Client, srv *httptest.Server) (string, *http.Cookie) {
+	t.Helper()
+	resp, err := client.Get(srv.URL + "/auth/oidc/login")
+	if err != nil {
+		t.Fatalf("ssoSignIn: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("ssoSignIn: expected a redirect to the frontend, got %d", resp.StatusCode)
+	}
+	return resp.Header.Get("Location"), findCookie(resp.Cookies(), "session")
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestOIDC_SignsInExistingVolunteer verifies a verified email signs in the
+// matching volunteer with a normal session cookie.
+func TestOIDC_SignsInExistingVolunteer(t *testing.T) {
+	email := uniqueEmail(t)
+	volID := seedVolunteer(t, email, "Single", "SignOn", "VOLUNTEER")
+	idp := newMockIdP(t, email, true)
+	srv := newSSOServer(t, idp)
+
+	location, session := ssoSignIn(t, ssoClient(t), srv)
+	if location != ssoFrontend+"/" {
+		t.Fatalf("expected redirect to the frontend root, got %s", location)
+	}
+	if session == nil || !session.HttpOnly {
+		t.Fatal("expected an HttpOnly session cookie")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM sessions WHERE token = $1 AND volunteer_id = $2",
+		hashSessionToken(session.Value), volID) {
+		t.Error("expected the session to belong to the volunteer")
+	}
+
+	resp := gqlPostCookie(t, "/graphql/volunteer", session.Value, qryOwnProfileEmail, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("the SSO session should work on the volunteer endpoint: %v", resp.Errors)
+	}
+}
+
+// TestOIDC_DomainNotAllowed verifies the domain allowlist.
+func TestOIDC_DomainNotAllowed(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Wrong", "Domain", "VOLUNTEER")
+	srv := newSSOServer(t, newMockIdP(t, email, true), "staff.example.org")
+
+	location, session := ssoSignIn(t, ssoClient(t), srv)
+	if location != ssoFrontend+"/login?sso_error=domain_not_allowed" {
+		t.Errorf("unexpected redirect %s", location)
+	}
+	if session != nil {
+		t.Error("no session cookie should be set")
+	}
+}
+
+// TestOIDC_UnverifiedEmail verifies an unverified email is refused.
+func TestOIDC_UnverifiedEmail(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "Not", "Verified", "VOLUNTEER")
+	srv := newSSOServer(t, newMockIdP(t, email, false))
+
+	location, session := ssoSignIn(t, ssoClient(t), srv)
+	if location != ssoFrontend+"/login?sso_error=unverified_email" {
+		t.Errorf("unexpected redirect %s", location)
+	}
+	if session != nil {
+		t.Error("no session cookie should be set")
+	}
+}
+
+// TestOIDC_NoAccount verifies SSO does not create volunteers.
+func TestOIDC_NoAccount(t *testing.T) {
+	email := uniqueEmail(t)
+	srv := newSSOServer(t, newMockIdP(t, email, true))
+
+	location, _ := ssoSignIn(t, ssoClient(t), srv)
+	if location != ssoFrontend+"/login?sso_error=no_account" {
+		t.Errorf("unexpected redirect %s", location)
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE email = $1", email) {
+		t.Error("no volunteer should have been created")
+	}
+}
+
+// TestOIDC_StateBoundToBrowser verifies a callback URL is refused in a
+// browser that did not start the sign-in, and cannot be replayed.
+func TestOIDC_StateBoundToBrowser(t *testing.T) {
+	email := uniqueEmail(t)
+	seedVolunteer(t, email, "State", "Bound", "VOLUNTEER")
+	srv := newSSOServer(t, newMockIdP(t, email, true))
+
+	// Capture the callback URL instead of following it.
+	var callback string
+	starter := ssoClient(t)
+	starter.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if req.URL.Path == "/auth/oidc/callback" {
+			callback = req.URL.String()
+			return http.ErrUseLastResponse
+		}
+		return nil
+	}
+	resp, err := starter.Get(srv.URL + "/auth/oidc/login")
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	resp.Body.Close()
+	if callback == "" {
+		t.Fatal("expected the provider to redirect to the callback")
+	}
+
+	// Another browser, without the state cookie.
+	resp, err = ssoClient(t).Get(callback)
+	if err != nil {
+		t.Fatalf("callback: %v", err)
+	}
+	resp.Body.Close()
+	if loc := resp.Header.Get("Location"); loc != ssoFrontend+"/login?sso_error=expired" {
+		t.Errorf("expected the foreign browser to be refused, got %s", loc)
+	}
+	if findCookie(resp.Cookies(), "session") != nil {
+		t.Error("no session cookie should be set")
+	}
+}
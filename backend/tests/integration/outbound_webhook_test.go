@@ -0,0 +1,325 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/services"
+	"volunteer-scheduler/webhooks"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const (
+	mutCreateWebhookSubscription = `
+		mutation Create($input: NewWebhookSubscriptionInput!) {
+			createWebhookSubscription(input: $input) {
+				success
+				message
+				code
+				secret
+				subscription { id url eventTypes isActive }
+			}
+		}`
+
+	mutRetryWebhookDelivery = `
+		mutation Retry($id: ID!) {
+			retryWebhookDelivery(deliveryId: $id) { success message code }
+		}`
+
+	qryWebhookDeliveries = `
+		query Deliveries($filter: WebhookDeliveryFilterInput) {
+			webhookDeliveries(filter: $filter) {
+				totalCount
+				edges { node { id eventType payload status attempts lastStatusCode lastError nextAttemptAt deliveredAt } }
+			}
+		}`
+)
+
+type webhookDeliveryNode struct {
+	ID             string  `json:"id"`
+	EventType      string  `json:"eventType"`
+	Payload        string  `json:"payload"`
+	Status         string  `json:"status"`
+	Attempts       int     `json:"attempts"`
+	LastStatusCode *int    `json:"lastStatusCode"`
+	LastError      *string `json:"lastError"`
+	NextAttemptAt  *string `json:"nextAttemptAt"`
+	DeliveredAt    *string `json:"deliveredAt"`
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// webhookReceiver is a partner endpoint that records what it is sent and
+// answers with status.
+type webhookReceiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	status   int
+	received []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookReceiver(t *testing.T, status int) *webhookReceiver {
+	t.Helper()
+	rcv := &webhookReceiver{status: status}
+	rcv.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rcv.mu.Lock()
+		defer rcv.mu.Unlock()
+		rcv.received = append(rcv.received, r)
+		rcv.bodies = append(rcv.bodies, body)
+		w.WriteHeader(rcv.status)
+		fmt.Fprint(w, http.StatusText(rcv.status))
+	}))
+	t.Cleanup(rcv.Close)
+	return rcv
+}
+
+func (rcv *webhookReceiver) setStatus(status int) {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	rcv.status = status
+}
+
+func (rcv *webhookReceiver) count() int {
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	return len(rcv.received)
+}
+
+// createWebhookSubscription subscribes url to eventTypes and returns the
+// subscription's id and signing secret. The subscription (and its delivery
+// log) is deleted when the test ends.
+func createWebhookSubscription(t *testing.T, adminToken, url string, eventTypes ...string) (string, string) {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateWebhookSubscription, map[string]any{
+		"input": map[string]any{"url": url, "eventTypes": eventTypes},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("createWebhookSubscription: unexpected errors: %v", resp.Errors)
+	}
+	var result struct {
+		Success      bool    `json:"success"`
+		Secret       *string `json:"secret"`
+		Subscription *struct {
+			ID string `json:"id"`
+		} `json:"subscription"`
+	}
+	unmarshalField(t, resp, "createWebhookSubscription", &result)
+	if !result.Success || result.Secret == nil || result.Subscription == nil {
+		t.Fatalf("createWebhookSubscription: result = %+v", result)
+	}
+	id := result.Subscription.ID
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	})
+	return id, *result.Secret
+}
+
+func fetchWebhookDeliveries(t *testing.T, adminToken, subscriptionID string) []webhookDeliveryNode {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryWebhookDeliveries, map[string]any{
+		"filter": map[string]any{"subscriptionId": subscriptionID},
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("webhookDeliveries: unexpected errors: %v", resp.Errors)
+	}
+	var conn struct {
+		Edges []struct {
+			Node webhookDeliveryNode `json:"node"`
+		} `json:"edges"`
+	}
+	unmarshalField(t, resp, "webhookDeliveries", &conn)
+	nodes := make([]webhookDeliveryNode, len(conn.Edges))
+	for i, e := range conn.Edges {
+		nodes[i] = e.Node
+	}
+	return nodes
+}
+
+func dispatchWebhooks(t *testing.T) {
+	t.Helper()
+	if err := services.NewWebhookService(testDB).DispatchDueWebhooks(context.Background()); err != nil {
+		t.Fatalf("DispatchDueWebhooks: %v", err)
+	}
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestWebhook_ShiftAssignedDelivered verifies a volunteer signing up is
+// POSTed to a subscriber, signed with the subscription's secret, and logged
+// as delivered.
+func TestWebhook_ShiftAssignedDelivered(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	subID, secret := createWebhookSubscription(t, adminToken, rcv.URL, "SHIFT_ASSIGNED")
+
+	volToken, volID := makeVolunteer(t)
+	_, shiftID := seedEventWithShift(t, 2)
+	resp := gqlPost(t, "/graphql/volunteer", volToken, mutAssignSelfToShift, map[string]any{
+		"shiftId": strconv.Itoa(shiftID),
+	})
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM volunteer_shifts WHERE volunteer_id = $1 AND shift_id = $2", volID, shiftID)
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("assignSelfToShift: unexpected errors: %v", resp.Errors)
+	}
+
+	dispatchWebhooks(t)
+	if rcv.count() != 1 {
+		t.Fatalf("receiver got %d requests, want 1", rcv.count())
+	}
+
+	req, body := rcv.received[0], rcv.bodies[0]
+	secs, err := strconv.ParseInt(req.Header.Get(webhooks.TimestampHeader), 10, 64)
+	if err != nil {
+		t.Fatalf("invalid %s header: %v", webhooks.TimestampHeader, err)
+	}
+	if got, want := req.Header.Get(webhooks.SignatureHeader), webhooks.Sign(secret, time.Unix(secs, 0), body); got != want {
+		t.Errorf("signature = %q, want %q", got, want)
+	}
+
+	var payload struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			ShiftID   string `json:"shiftId"`
+			Volunteer struct {
+				ID string `json:"id"`
+			} `json:"volunteer"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if payload.Type != "SHIFT_ASSIGNED" || payload.Data.ShiftID != strconv.Itoa(shiftID) ||
+		payload.Data.Volunteer.ID != strconv.Itoa(volID) {
+		t.Errorf("payload = %s", body)
+	}
+	if payload.ID == "" || req.Header.Get("X-Webhook-Id") != payload.ID {
+		t.Errorf("X-Webhook-Id = %q, payload id = %q", req.Header.Get("X-Webhook-Id"), payload.ID)
+	}
+
+	deliveries := fetchWebhookDeliveries(t, adminToken, subID)
+	if len(deliveries) != 1 || deliveries[0].Status != "DELIVERED" || deliveries[0].Attempts != 1 ||
+		deliveries[0].DeliveredAt == nil {
+		t.Errorf("deliveries = %+v, want one DELIVERED after 1 attempt", deliveries)
+	}
+}
+
+// TestWebhook_FailedDeliveryRetried verifies a failing endpoint is retried
+// later, marked FAILED once attempts run out, and can be retried by hand.
+func TestWebhook_FailedDeliveryRetried(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	rcv := newWebhookReceiver(t, http.StatusInternalServerError)
+	subID, _ := createWebhookSubscription(t, adminToken, rcv.URL, "EVENT_DELETED")
+
+	eventID, _ := seedEventWithShift(t, 1)
+	resp := gqlPost(t, "/graphql/admin", adminToken,
+		`mutation($id: ID!) { deleteEvent(eventId: $id) { success } }`,
+		map[string]any{"id": strconv.Itoa(eventID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("deleteEvent: unexpected errors: %v", resp.Errors)
+	}
+
+	dispatchWebhooks(t)
+	deliveries := fetchWebhookDeliveries(t, adminToken, subID)
+	if len(deliveries) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.Status != "PENDING" || d.Attempts != 1 || d.LastStatusCode == nil || *d.LastStatusCode != 500 ||
+		d.NextAttemptAt == nil || d.LastError == nil {
+		t.Fatalf("after a failure: delivery = %+v, want PENDING with a 500 and a next attempt", d)
+	}
+
+	// Not due yet: a second pass sends nothing.
+	dispatchWebhooks(t)
+	if rcv.count() != 1 {
+		t.Fatalf("receiver got %d requests before the backoff elapsed, want 1", rcv.count())
+	}
+
+	// Skip ahead to the last attempt.
+	if _, err := testDB.Exec(
+		"UPDATE webhook_deliveries SET attempts = 7, next_attempt_at = NOW() WHERE id = $1::int", d.ID,
+	); err != nil {
+		t.Fatalf("fast-forward delivery: %v", err)
+	}
+	dispatchWebhooks(t)
+	if d = fetchWebhookDeliveries(t, adminToken, subID)[0]; d.Status != "FAILED" || d.NextAttemptAt != nil {
+		t.Fatalf("after the last attempt: delivery = %+v, want FAILED", d)
+	}
+
+	rcv.setStatus(http.StatusNoContent)
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutRetryWebhookDelivery, map[string]any{"id": d.ID})
+	var result struct {
+		Success bool `json:"success"`
+	}
+	unmarshalField(t, resp, "retryWebhookDelivery", &result)
+	if !result.Success {
+		t.Fatalf("retryWebhookDelivery: result = %+v, errors = %v", result, resp.Errors)
+	}
+	dispatchWebhooks(t)
+	if d = fetchWebhookDeliveries(t, adminToken, subID)[0]; d.Status != "DELIVERED" || d.Attempts != 1 {
+		t.Errorf("after a manual retry: delivery = %+v, want DELIVERED", d)
+	}
+
+	var payload struct {
+		Data struct {
+			Events []struct {
+				ID string `json:"id"`
+			} `json:"events"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(d.Payload), &payload); err != nil || len(payload.Data.Events) != 1 ||
+		payload.Data.Events[0].ID != strconv.Itoa(eventID) {
+		t.Errorf("payload = %s, want the deleted event", d.Payload)
+	}
+}
+
+// TestWebhook_UnsubscribedTypeNotQueued verifies only subscribed event types
+// are queued.
+func TestWebhook_UnsubscribedTypeNotQueued(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	rcv := newWebhookReceiver(t, http.StatusOK)
+	subID, _ := createWebhookSubscription(t, adminToken, rcv.URL, "SHIFT_CANCELLED")
+
+	eventID, _ := seedEventWithShift(t, 1)
+	gqlPost(t, "/graphql/admin", adminToken,
+		`mutation($id: ID!) { deleteEvent(eventId: $id) { success } }`,
+		map[string]any{"id": strconv.Itoa(eventID)})
+
+	if deliveries := fetchWebhookDeliveries(t, adminToken, subID); len(deliveries) != 0 {
+		t.Errorf("deliveries = %+v, want none", deliveries)
+	}
+}
+
+// TestWebhook_InvalidURL verifies a subscription URL must be absolute http(s).
+func TestWebhook_InvalidURL(t *testing.T) {
+	adminToken := makeAdminToken(t)
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutCreateWebhookSubscription, map[string]any{
+		"input": map[string]any{"url": "ftp://example.com/hook", "eventTypes": []string{"EVENT_CREATED"}},
+	})
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
+	}
+	if fields := errorFields(resp); len(fields) != 1 || fields[0] != "url" {
+		t.Errorf("fields = %v, want [url]", fields)
+	}
+}
@@ -0,0 +1,298 @@
+package integration
+
+import (
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+type pageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+type idConnection struct {
+	Edges []struct {
+		Cursor string `json:"cursor"`
+		Node   struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"node"`
+	} `json:"edges"`
+	PageInfo   pageInfo `json:"pageInfo"`
+	TotalCount int      `json:"totalCount"`
+}
+
+func (c idConnection) ids() []string {
+	ids := make([]string, len(c.Edges))
+	for i, e := range c.Edges {
+		ids[i] = e.Node.ID
+	}
+	return ids
+}
+
+const queryVolunteersConnection = `
+	query($filter: VolunteerFilterInput, $sort: VolunteerSort, $first: Int, $after: String) {
+		volunteersConnection(filter: $filter, sort: $sort, first: $first, after: $after) {
+			edges { cursor node { id } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+			totalCount
+		}
+	}`
+
+const queryEventsConnection = `
+	query($filter: EventFilterInput, $sort: EventSort, $first: Int, $after: String) {
+		eventsConnection(filter: $filter, sort: $sort, first: $first, after: $after) {
+			edges { cursor node { id name } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+			totalCount
+		}
+	}`
+
+const queryFeedbackConnection = `
+	query($first: Int, $after: String) {
+		feedbackConnection(first: $first, after: $after) {
+			edges { cursor node { id } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+			totalCount
+		}
+	}`
+
+const queryVolunteerShiftsConnection = `
+	query($volunteerId: ID!, $sort: VolunteerShiftSort, $first: Int, $after: String) {
+		volunteerShiftsConnection(volunteerId: $volunteerId, filter: ALL, sort: $sort, first: $first, after: $after) {
+			edges { cursor node { shiftId } }
+			pageInfo { hasNextPage hasPreviousPage endCursor }
+			totalCount
+		}
+	}`
+
+// fetchConnection runs a connection query and decodes the named field.
+func fetchConnection(t *testing.T, token, query, field string, vars map[string]any) idConnection {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/admin", token, query, vars)
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var conn idConnection
+	unmarshalField(t, resp, field, &conn)
+	return conn
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestVolunteersConnection_Pages verifies that the filter is applied in the
+// query, pages follow the sort order and the cursor continues where the
+// previous page ended.
+func TestVolunteersConnection_Pages(t *testing.T) {
+	token, _ := makeAdmin(t)
+	lastName := uniqueCode(t, "Pager")
+	c := seedVolunteer(t, uniqueEmail(t), "Carla", lastName, "VOLUNTEER")
+	a := seedVolunteer(t, uniqueEmail(t), "Ana", lastName, "VOLUNTEER")
+	b := seedVolunteer(t, uniqueEmail(t), "Ben", lastName, "VOLUNTEER")
+
+	vars := map[string]any{
+		"filter": map[string]any{"lastName": lastName},
+		"sort":   map[string]any{"field": "FIRST_NAME"},
+		"first":  2,
+	}
+	page := fetchConnection(t, token, queryVolunteersConnection, "volunteersConnection", vars)
+	if page.TotalCount != 3 {
+		t.Errorf("totalCount = %d, want 3", page.TotalCount)
+	}
+	if got := page.ids(); len(got) != 2 || got[0] != strconv.Itoa(a) || got[1] != strconv.Itoa(b) {
+		t.Fatalf("first page = %v, want [%d %d]", got, a, b)
+	}
+	if !page.PageInfo.HasNextPage || page.PageInfo.HasPreviousPage {
+		t.Errorf("first page info = %+v", page.PageInfo)
+	}
+
+	vars["after"] = *page.PageInfo.EndCursor
+	page = fetchConnection(t, token, queryVolunteersConnection, "volunteersConnection", vars)
+	if got := page.ids(); len(got) != 1 || got[0] != strconv.Itoa(c) {
+		t.Fatalf("second page = %v, want [%d]", got, c)
+	}
+	if page.PageInfo.HasNextPage || !page.PageInfo.HasPreviousPage {
+		t.Errorf("second page info = %+v", page.PageInfo)
+	}
+
+	vars["sort"] = map[string]any{"field": "FIRST_NAME", "direction": "DESC"}
+	delete(vars, "after")
+	page = fetchConnection(t, token, queryVolunteersConnection, "volunteersConnection", vars)
+	if got := page.ids(); len(got) != 2 || got[0] != strconv.Itoa(c) {
+		t.Errorf("descending page = %v, want %d first", got, c)
+	}
+}
+
+// TestVolunteersConnection_CoordinatorScope verifies the coordinator's roster
+// restriction is part of the query, so totalCount only counts their volunteers.
+func TestVolunteersConnection_CoordinatorScope(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	eventID := seedEventInRegion(t, "Connection Roster Event", own)
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	shiftID := seedShift(t, oppID, "2028-08-12T09:00:00Z", "2028-08-12T12:00:00Z", 5)
+
+	_, rostered := makeVolunteer(t)
+	makeVolunteer(t)
+	seedVolunteerShift(t, shiftID, rostered)
+
+	page := fetchConnection(t, token, queryVolunteersConnection, "volunteersConnection", nil)
+	if page.TotalCount != 1 {
+		t.Errorf("totalCount = %d, want 1", page.TotalCount)
+	}
+	if got := page.ids(); len(got) != 1 || got[0] != strconv.Itoa(rostered) {
+		t.Errorf("volunteers = %v, want only %d", got, rostered)
+	}
+}
+
+// TestEventsConnection_SortAndScope verifies events are sorted by name and
+// limited to the coordinator's region across pages.
+func TestEventsConnection_SortAndScope(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	other := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	prefix := uniqueCode(t, "Paged ")
+	for _, name := range []string{"A", "B", "C"} {
+		seedEventDate(t, seedEventInRegion(t, prefix+name, own), "2028-05-01T09:00:00Z", "2028-05-01T17:00:00Z")
+	}
+	seedEventDate(t, seedEventInRegion(t, prefix+"Other", other), "2028-05-01T09:00:00Z", "2028-05-01T17:00:00Z")
+
+	vars := map[string]any{
+		"filter": map[string]any{"timeFrame": "ALL"},
+		"sort":   map[string]any{"field": "NAME", "direction": "DESC"},
+		"first":  2,
+	}
+	page := fetchConnection(t, token, queryEventsConnection, "eventsConnection", vars)
+	if page.TotalCount != 3 {
+		t.Errorf("totalCount = %d, want 3", page.TotalCount)
+	}
+	var names []string
+	for {
+		for _, e := range page.Edges {
+			names = append(names, e.Node.Name)
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = *page.PageInfo.EndCursor
+		page = fetchConnection(t, token, queryEventsConnection, "eventsConnection", vars)
+	}
+	want := []string{prefix + "C", prefix + "B", prefix + "A"}
+	if len(names) != len(want) {
+		t.Fatalf("events = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("events = %v, want %v", names, want)
+			break
+		}
+	}
+}
+
+// TestFeedbackConnection_WalksAllPages verifies that following endCursor
+// visits every feedback item exactly once and totalCount matches the table.
+func TestFeedbackConnection_WalksAllPages(t *testing.T) {
+	token, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	seeded := map[string]bool{}
+	for i := 0; i < 3; i++ {
+		seeded[strconv.Itoa(seedFeedback(t, volID))] = true
+	}
+
+	var total int
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM feedback").Scan(&total); err != nil {
+		t.Fatalf("count feedback: %v", err)
+	}
+
+	vars := map[string]any{"first": 2}
+	seen := map[string]bool{}
+	for {
+		page := fetchConnection(t, token, queryFeedbackConnection, "feedbackConnection", vars)
+		if page.TotalCount != total {
+			t.Fatalf("totalCount = %d, want %d", page.TotalCount, total)
+		}
+		for _, id := range page.ids() {
+			if seen[id] {
+				t.Fatalf("feedback %s returned twice", id)
+			}
+			seen[id] = true
+		}
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		vars["after"] = *page.PageInfo.EndCursor
+	}
+	if len(seen) != total {
+		t.Errorf("visited %d feedback items, want %d", len(seen), total)
+	}
+	for id := range seeded {
+		if !seen[id] {
+			t.Errorf("feedback %s was never returned", id)
+		}
+	}
+}
+
+// TestVolunteerShiftsConnection_Sort verifies a volunteer's shifts page in
+// the requested order.
+func TestVolunteerShiftsConnection_Sort(t *testing.T) {
+	token, _ := makeAdmin(t)
+	_, volID := makeVolunteer(t)
+	eventID := seedEvent(t, "Paged Shifts Event", true, nil)
+	oppID := seedOpportunity(t, eventID, getJobTypeID(t, "event_support"), true)
+	first := seedShift(t, oppID, "2028-08-12T09:00:00Z", "2028-08-12T10:00:00Z", 5)
+	second := seedShift(t, oppID, "2028-08-13T09:00:00Z", "2028-08-13T10:00:00Z", 5)
+	third := seedShift(t, oppID, "2028-08-14T09:00:00Z", "2028-08-14T10:00:00Z", 5)
+	for _, sh := range []int{second, third, first} {
+		seedVolunteerShift(t, sh, volID)
+	}
+
+	resp := gqlPost(t, "/graphql/admin", token, queryVolunteerShiftsConnection, map[string]any{
+		"volunteerId": strconv.Itoa(volID),
+		"sort":        map[string]any{"field": "START", "direction": "DESC"},
+		"first":       2,
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+	var page struct {
+		Edges []struct {
+			Node struct {
+				ShiftID string `json:"shiftId"`
+			} `json:"node"`
+		} `json:"edges"`
+		PageInfo   pageInfo `json:"pageInfo"`
+		TotalCount int      `json:"totalCount"`
+	}
+	unmarshalField(t, resp, "volunteerShiftsConnection", &page)
+	if page.TotalCount != 3 || !page.PageInfo.HasNextPage {
+		t.Errorf("totalCount = %d, info = %+v", page.TotalCount, page.PageInfo)
+	}
+	if len(page.Edges) != 2 ||
+		page.Edges[0].Node.ShiftID != strconv.Itoa(third) ||
+		page.Edges[1].Node.ShiftID != strconv.Itoa(second) {
+		t.Errorf("shifts = %+v, want [%d %d]", page.Edges, third, second)
+	}
+}
+
+// TestConnection_RejectsBadPaging verifies out-of-range page sizes and
+// malformed cursors are errors rather than silently ignored.
+func TestConnection_RejectsBadPaging(t *testing.T) {
+	token, _ := makeAdmin(t)
+	for _, vars := range []map[string]any{
+		{"first": 201},
+		{"first": -1},
+		{"after": "not-a-cursor"},
+	} {
+		resp := gqlPost(t, "/graphql/admin", token, queryVolunteersConnection, vars)
+		if !hasGQLErrors(resp) {
+			t.Errorf("expected an error for %v", vars)
+		}
+	}
+}
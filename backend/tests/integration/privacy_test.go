@@ -0,0 +1,356 @@
+package integration
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+// ============================================================================
+// GraphQL operation strings
+// ============================================================================
+
+const (
+	qryOwnDataExport = `query { ownDataExport }`
+
+	mutRequestErasure = `
+		mutation RequestErasure($reason: String) {
+			requestErasure(reason: $reason) { success message }
+		}`
+
+	qryErasureRequests = `
+		query ErasureRequests($status: ErasureRequestStatus) {
+			erasureRequests(status: $status) {
+				id volunteerId volunteerName reason status decidedById decisionMessage
+			}
+		}`
+
+	mutApproveErasureRequest = `
+		mutation Approve($id: ID!) {
+			approveErasureRequest(requestId: $id) { success message id }
+		}`
+
+	mutDenyErasureRequest = `
+		mutation Deny($id: ID!, $message: String) {
+			denyErasureRequest(requestId: $id, message: $message) { success message }
+		}`
+)
+
+type erasureRequest struct {
+	ID              string  `json:"id"`
+	VolunteerID     string  `json:"volunteerId"`
+	VolunteerName   string  `json:"volunteerName"`
+	Reason          *string `json:"reason"`
+	Status          string  `json:"status"`
+	DecidedByID     *string `json:"decidedById"`
+	DecisionMessage *string `json:"decisionMessage"`
+}
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// requestErasure files an erasure request as the volunteer behind token and
+// returns its ID.
+func requestErasure(t *testing.T, token string, volID int, reason string) string {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/volunteer", token, mutRequestErasure, map[string]any{"reason": reason})
+	if hasGQLErrors(resp) {
+		t.Fatalf("requestErasure: unexpected errors: %v", resp.Errors)
+	}
+	var id int
+	if err := testDB.QueryRow(
+		"SELECT id FROM erasure_requests WHERE volunteer_id = $1 AND status = 'PENDING'", volID).Scan(&id); err != nil {
+		t.Fatalf("requestErasure: no pending request stored: %v", err)
+	}
+	return strconv.Itoa(id)
+}
+
+// seedAttachment stores data as an attachment on feedbackID. seedFeedback's
+// cleanup removes it.
+func seedAttachment(t *testing.T, feedbackID int, filename string, data []byte) {
+	t.Helper()
+	if _, err := testDB.Exec(`
+		INSERT INTO feedback_attachments (feedback_id, filename, mime_type, file_data, file_size)
+		VALUES ($1, $2, 'text/plain', $3, $4)
+	`, feedbackID, filename, data, len(data)); err != nil {
+		t.Fatalf("seedAttachment: %v", err)
+	}
+}
+
+// ============================================================================
+// Export
+// ============================================================================
+
+// TestDataExport_Contents verifies the export holds the caller's profile,
+// shifts, feedback with notes and attachments, and leaves out admin-only
+// notes.
+func TestDataExport_Contents(t *testing.T) {
+	token, volID := makeVolunteer(t)
+	_, adminID := makeAdmin(t)
+	_, shiftID := seedEventWithShift(t, 5)
+	seedVolunteerShift(t, shiftID, volID)
+
+	feedbackID := seedFeedback(t, volID)
+	seedFeedbackNote(t, feedbackID, volID, "VOLUNTEER_NOTE", "More detail from me")
+	seedFeedbackNote(t, feedbackID, adminID, "ADMIN_NOTE", "Internal triage")
+	seedAttachment(t, feedbackID, "steps.txt", []byte("1. click\n2. crash"))
+
+	resp := gqlPost(t, "/graphql/volunteer", token, qryOwnDataExport, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("ownDataExport: unexpected errors: %v", resp.Errors)
+	}
+	var raw string
+	unmarshalField(t, resp, "ownDataExport", &raw)
+
+	var export struct {
+		Version int `json:"version"`
+		Profile struct {
+			ID    int      `json:"id"`
+			Email string   `json:"email"`
+			Roles []string `json:"roles"`
+		} `json:"profile"`
+		Shifts []struct {
+			ShiftID int `json:"shiftId"`
+		} `json:"shifts"`
+		Feedback []struct {
+			ID    int `json:"id"`
+			Notes []struct {
+				Type string `json:"type"`
+				Note string `json:"note"`
+			} `json:"notes"`
+			Attachments []struct {
+				Filename string `json:"filename"`
+				Data     string `json:"data"`
+			} `json:"attachments"`
+		} `json:"feedback"`
+		NotesWritten []struct {
+			FeedbackID int `json:"feedbackId"`
+		} `json:"notesWritten"`
+		Sessions []json.RawMessage `json:"sessions"`
+	}
+	if err := json.Unmarshal([]byte(raw), &export); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, raw)
+	}
+
+	if export.Version != 1 || export.Profile.ID != volID || export.Profile.Email == "" {
+		t.Errorf("unexpected profile: version=%d %+v", export.Version, export.Profile)
+	}
+	if len(export.Profile.Roles) != 1 || export.Profile.Roles[0] != "VOLUNTEER" {
+		t.Errorf("expected roles [VOLUNTEER], got %v", export.Profile.Roles)
+	}
+	if len(export.Shifts) != 1 || export.Shifts[0].ShiftID != shiftID {
+		t.Errorf("expected shift %d, got %+v", shiftID, export.Shifts)
+	}
+	if len(export.Sessions) != 1 {
+		t.Errorf("expected 1 session, got %d", len(export.Sessions))
+	}
+	if len(export.Feedback) != 1 || export.Feedback[0].ID != feedbackID {
+		t.Fatalf("expected feedback %d, got %+v", feedbackID, export.Feedback)
+	}
+	fb := export.Feedback[0]
+	if len(fb.Notes) != 1 || fb.Notes[0].Type != "VOLUNTEER_NOTE" {
+		t.Errorf("expected only the volunteer's note, got %+v", fb.Notes)
+	}
+	if len(fb.Attachments) != 1 {
+		t.Fatalf("expected 1 attachment, got %d", len(fb.Attachments))
+	}
+	data, err := base64.StdEncoding.DecodeString(fb.Attachments[0].Data)
+	if err != nil || string(data) != "1. click\n2. crash" {
+		t.Errorf("attachment data did not round-trip: %q, %v", data, err)
+	}
+	if len(export.NotesWritten) != 1 || export.NotesWritten[0].FeedbackID != feedbackID {
+		t.Errorf("expected 1 note written, got %+v", export.NotesWritten)
+	}
+}
+
+// ============================================================================
+// Erasure requests
+// ============================================================================
+
+// TestErasure_RequestQueued verifies a request reaches the admin queue once,
+// however often the volunteer asks.
+func TestErasure_RequestQueued(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	token, volID := makeVolunteer(t)
+
+	requestErasure(t, token, volID, "Moving away")
+	requestErasure(t, token, volID, "Asking again")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, qryErasureRequests, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("erasureRequests: unexpected errors: %v", resp.Errors)
+	}
+	var requests []erasureRequest
+	unmarshalField(t, resp, "erasureRequests", &requests)
+
+	var found []erasureRequest
+	for _, r := range requests {
+		if r.VolunteerID == strconv.Itoa(volID) {
+			found = append(found, r)
+		}
+	}
+	if len(found) != 1 {
+		t.Fatalf("expected one pending request, got %+v", found)
+	}
+	if found[0].Reason == nil || *found[0].Reason != "Moving away" || found[0].VolunteerName != "Vol Test" {
+		t.Errorf("unexpected request: %+v", found[0])
+	}
+}
+
+// TestErasure_ApproveAnonymizes verifies approval erases personal data but
+// keeps the shift history and the feedback rows that reference the
+// volunteer, and redacts the volunteer from the audit log.
+func TestErasure_ApproveAnonymizes(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	token, volID := makeVolunteer(t)
+	var email string
+	testDB.QueryRow("SELECT email FROM volunteers WHERE volunteer_id = $1", volID).Scan(&email)
+	testDB.Exec("UPDATE volunteers SET phone = '555-0100', zip_code = '12345' WHERE volunteer_id = $1", volID)
+
+	_, shiftID := seedEventWithShift(t, 5)
+	seedVolunteerShift(t, shiftID, volID)
+	feedbackID := seedFeedback(t, volID)
+	seedFeedbackNote(t, feedbackID, volID, "VOLUNTEER_NOTE", "My phone is 555-0100")
+	seedFeedbackNote(t, feedbackID, adminID, "ADMIN_NOTE", "Called them back")
+	seedAttachment(t, feedbackID, "me.txt", []byte("personal"))
+
+	// An entry written before personal values were redacted, about and by
+	// the volunteer.
+	testDB.Exec(`
+		INSERT INTO audit_log (actor_id, actor_name, operation, entity, entity_id, changes, success, ip_address)
+		VALUES ($1, 'Vol Test', 'updateVolunteer', 'Volunteer', $1::text,
+			jsonb_build_array(jsonb_build_object('field', 'email', 'before', $2::text, 'after', $2::text)),
+			TRUE, '203.0.113.50')`, volID, email)
+
+	requestID := requestErasure(t, token, volID, "")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutApproveErasureRequest, map[string]any{"id": requestID})
+	if hasGQLErrors(resp) {
+		t.Fatalf("approveErasureRequest: unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "approveErasureRequest", &result)
+	if !result.Success {
+		t.Fatalf("expected success, got %+v", result)
+	}
+
+	if !rowExists(t, `
+		SELECT COUNT(*) FROM volunteers
+		WHERE volunteer_id = $1 AND first_name = 'Erased' AND last_name = 'Volunteer'
+		  AND email = 'erased-' || volunteer_id || '@erased.invalid'
+		  AND phone IS NULL AND zip_code IS NULL AND is_active = FALSE AND erased_at IS NOT NULL`, volID) {
+		t.Error("expected the volunteer's personal fields to be anonymized")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM volunteer_shifts WHERE volunteer_id = $1 AND shift_id = $2", volID, shiftID) {
+		t.Error("shift history must be kept for reporting")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM feedback WHERE feedback_id = $1 AND subject = '[erased]' AND text = '[erased]'", feedbackID) {
+		t.Error("expected the feedback row to be kept with its text redacted")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM feedback_notes WHERE feedback_id = $1 AND note != '[erased]'", feedbackID) {
+		t.Error("expected every note on the feedback to be redacted")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM feedback_attachments WHERE feedback_id = $1", feedbackID) {
+		t.Error("expected attachments to be deleted")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM volunteer_roles WHERE volunteer_id = $1", volID) {
+		t.Error("expected roles to be removed")
+	}
+	if sessionExists(t, token) {
+		t.Error("expected the volunteer's sessions to be deleted")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM erasure_requests WHERE id = $1 AND status = 'APPROVED' AND decided_by = $2", requestID, adminID) {
+		t.Error("expected the request to be marked approved")
+	}
+	if rowExists(t, "SELECT COUNT(*) FROM audit_log WHERE changes::text ILIKE '%' || $1 || '%'", email) {
+		t.Error("the audit log must not record the erased email address")
+	}
+	if rowExists(t, `
+		SELECT COUNT(*) FROM audit_log
+		WHERE actor_id = $1 AND (actor_name <> 'Erased Volunteer' OR ip_address IS NOT NULL)`, volID) {
+		t.Error("expected the volunteer's name and address to be redacted from entries they made")
+	}
+	if _, err := testDB.Exec("UPDATE audit_log SET actor_name = 'x' WHERE actor_id = $1", volID); err == nil {
+		t.Error("audit_log must stay append-only outside an erasure")
+	}
+	if _, err := testDB.Exec(`
+		SELECT set_config('app.audit_redaction', 'on', true);
+		UPDATE audit_log SET actor_name = 'x' WHERE actor_id = ` + strconv.Itoa(volID)); err == nil {
+		t.Error("a session setting must not open audit_log for redaction")
+	}
+
+	resp = gqlPost(t, "/graphql/admin", adminToken, mutApproveErasureRequest, map[string]any{"id": requestID})
+	if !hasGQLErrors(resp) {
+		t.Error("expected a second approval to be refused")
+	}
+}
+
+// TestErasure_NotOwnRequest verifies an admin cannot approve their own
+// erasure.
+func TestErasure_NotOwnRequest(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	requestID := requestErasure(t, adminToken, adminID, "")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutApproveErasureRequest, map[string]any{"id": requestID})
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected approving one's own erasure to be refused")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE volunteer_id = $1 AND erased_at IS NULL AND is_active", adminID) {
+		t.Error("the admin must not have been erased")
+	}
+}
+
+// TestErasure_Deny verifies denial closes the request and leaves the
+// volunteer untouched.
+func TestErasure_Deny(t *testing.T) {
+	adminToken, adminID := makeAdmin(t)
+	token, volID := makeVolunteer(t)
+	requestID := requestErasure(t, token, volID, "")
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutDenyErasureRequest, map[string]any{
+		"id": requestID, "message": "You have an open shift next week.",
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("denyErasureRequest: unexpected errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "denyErasureRequest", &result)
+	if !result.Success {
+		t.Fatalf("expected success, got %+v", result)
+	}
+
+	if !rowExists(t, `
+		SELECT COUNT(*) FROM erasure_requests
+		WHERE id = $1 AND status = 'DENIED' AND decided_by = $2 AND decision_message = 'You have an open shift next week.'`,
+		requestID, adminID) {
+		t.Error("expected the request to be marked denied with the message")
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM volunteers WHERE volunteer_id = $1 AND first_name = 'Vol' AND erased_at IS NULL", volID) {
+		t.Error("a denied request must not change the volunteer")
+	}
+
+	// A new request can be made once the old one is decided.
+	requestErasure(t, token, volID, "")
+}
+
+// TestErasure_AdminOnly verifies volunteers and coordinators cannot see or
+// decide erasure requests.
+func TestErasure_AdminOnly(t *testing.T) {
+	token, volID := makeVolunteer(t)
+	requestID := requestErasure(t, token, volID, "")
+	coordToken, _ := makeCoordinator(t, seedFundingEntity(t, uniqueCode(t, "fe")))
+
+	for _, tok := range []string{token, coordToken} {
+		if resp := gqlPost(t, "/graphql/admin", tok, qryErasureRequests, nil); !hasGQLErrors(resp) {
+			t.Error("expected erasureRequests to be refused")
+		}
+		if resp := gqlPost(t, "/graphql/admin", tok, mutApproveErasureRequest, map[string]any{"id": requestID}); !hasGQLErrors(resp) {
+			t.Error("expected approveErasureRequest to be refused")
+		}
+	}
+	if !rowExists(t, "SELECT COUNT(*) FROM erasure_requests WHERE id = $1 AND status = 'PENDING'", requestID) {
+		t.Error("the request must still be pending")
+	}
+}
@@ -0,0 +1,238 @@
+package integration
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/publicfeed"
+	"volunteer-scheduler/services"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// seedPublicEvent creates an upcoming in-person event in city with one
+// shift of jobTypeID and returns the event and shift IDs.
+func seedPublicEvent(t *testing.T, name, city string, jobTypeID, maxVolunteers int) (int, int) {
+	t.Helper()
+	venueID := seedVenue(t, name+" Hall", "1 Main St", city, "CA")
+	eventID := seedEvent(t, name, false, &venueID)
+	seedEventDate(t, eventID, "2027-06-01T09:00:00Z", "2027-06-01T17:00:00Z")
+	oppID := seedOpportunity(t, eventID, jobTypeID, false)
+	shiftID := seedShift(t, oppID, "2027-06-01T09:00:00Z", "2027-06-01T12:00:00Z", maxVolunteers)
+	return eventID, shiftID
+}
+
+// getPublic fetches path from srv (the shared test server if nil).
+func getPublic(t *testing.T, srv *httptest.Server, path string) (*http.Response, []byte) {
+	t.Helper()
+	if srv == nil {
+		srv = testServer
+	}
+	resp, err := http.Get(srv.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return resp, body
+}
+
+func getPublicFeed(t *testing.T, query string) publicfeed.Feed {
+	t.Helper()
+	resp, body := getPublic(t, nil, "/public/events.json?"+query)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("events.json?%s: status %d: %s", query, resp.StatusCode, body)
+	}
+	var feed publicfeed.Feed
+	if err := json.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("unmarshal feed: %v", err)
+	}
+	return feed
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestPublicFeed_PublicFieldsOnly verifies an upcoming event is listed with
+// its open seats, and nothing about the volunteer who signed up.
+func TestPublicFeed_PublicFieldsOnly(t *testing.T) {
+	city := uniqueCode(t, "Feedville")
+	jobTypeID := seedJobType(t, uniqueCode(t, "jt"), "Greeter")
+	eventID, shiftID := seedPublicEvent(t, "Public Feed Fair", city, jobTypeID, 3)
+	volID := seedVolunteer(t, uniqueEmail(t), "Private", "Person", "VOLUNTEER")
+	seedVolunteerShift(t, shiftID, volID)
+
+	resp, body := getPublic(t, nil, "/public/events.json?cities="+city)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+	if strings.Contains(string(body), "Private") || strings.Contains(string(body), "@") {
+		t.Errorf("feed leaks volunteer data: %s", body)
+	}
+
+	var feed publicfeed.Feed
+	if err := json.Unmarshal(body, &feed); err != nil {
+		t.Fatalf("unmarshal feed: %v", err)
+	}
+	if len(feed.Events) != 1 {
+		t.Fatalf("got %d events, want 1: %s", len(feed.Events), body)
+	}
+	ev := feed.Events[0]
+	if ev.ID != strconv.Itoa(eventID) || ev.Name != "Public Feed Fair" || ev.City == nil || *ev.City != city {
+		t.Errorf("event = %+v", ev)
+	}
+	if len(ev.Dates) != 1 {
+		t.Errorf("dates = %+v, want 1", ev.Dates)
+	}
+	if len(ev.Jobs) != 1 || ev.Jobs[0].JobName != "Greeter" || ev.Jobs[0].OpenSeats != 2 || ev.Jobs[0].TotalSeats != 3 {
+		t.Errorf("jobs = %+v, want Greeter with 2 of 3 seats open", ev.Jobs)
+	}
+	if want := testAllowedOrigin + "/events/" + ev.ID; ev.URL != want {
+		t.Errorf("url = %q, want %q", ev.URL, want)
+	}
+}
+
+// TestPublicFeed_Filters verifies the city, job and event type filters.
+func TestPublicFeed_Filters(t *testing.T) {
+	city := uniqueCode(t, "Filterton")
+	greeter := seedJobType(t, uniqueCode(t, "jt"), "Greeter")
+	cook := seedJobType(t, uniqueCode(t, "jt"), "Cook")
+	greeterEvent, _ := seedPublicEvent(t, "Greeter Event", city, greeter, 2)
+	cookEvent, _ := seedPublicEvent(t, "Cook Event", city, cook, 2)
+
+	ids := func(feed publicfeed.Feed) []string {
+		var ids []string
+		for _, e := range feed.Events {
+			ids = append(ids, e.ID)
+		}
+		return ids
+	}
+
+	if got := ids(getPublicFeed(t, "cities="+city)); len(got) != 2 {
+		t.Errorf("city filter: got %v, want both events", got)
+	}
+	got := ids(getPublicFeed(t, fmt.Sprintf("cities=%s&jobs=%d", city, cook)))
+	if len(got) != 1 || got[0] != strconv.Itoa(cookEvent) {
+		t.Errorf("job filter: got %v, want only %d", got, cookEvent)
+	}
+	if got := ids(getPublicFeed(t, "cities="+city+"&eventType=VIRTUAL")); len(got) != 0 {
+		t.Errorf("eventType=VIRTUAL: got %v, want none", got)
+	}
+	got = ids(getPublicFeed(t, "cities="+city+"&eventType=in_person&limit=1"))
+	if len(got) != 1 || (got[0] != strconv.Itoa(greeterEvent) && got[0] != strconv.Itoa(cookEvent)) {
+		t.Errorf("limit=1: got %v, want one event", got)
+	}
+}
+
+// TestPublicFeed_InvalidQuery verifies bad parameters are rejected.
+func TestPublicFeed_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"eventType=PARTY", "jobs=abc", "limit=0", "limit=1000"} {
+		if resp, body := getPublic(t, nil, "/public/events.json?"+query); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400: %s", query, resp.StatusCode, body)
+		}
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, testServer.URL+"/public/events.json", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("POST: status %d, want 405", resp.StatusCode)
+	}
+}
+
+// TestPublicFeed_Widget verifies the widget renders the event, escaped, under
+// a restrictive content security policy.
+func TestPublicFeed_Widget(t *testing.T) {
+	city := uniqueCode(t, "Widgetburg")
+	jobTypeID := seedJobType(t, uniqueCode(t, "jt"), "Usher")
+	eventID, _ := seedPublicEvent(t, "<b>Widget</b> Gala", city, jobTypeID, 4)
+
+	resp, body := getPublic(t, nil, "/public/events/widget?cities="+city)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if csp := resp.Header.Get("Content-Security-Policy"); !strings.Contains(csp, "default-src 'none'") {
+		t.Errorf("Content-Security-Policy = %q", csp)
+	}
+	html := string(body)
+	if strings.Contains(html, "<b>Widget</b>") || !strings.Contains(html, "&lt;b&gt;Widget&lt;/b&gt; Gala") {
+		t.Errorf("event name not escaped: %s", html)
+	}
+	if !strings.Contains(html, fmt.Sprintf("/events/%d", eventID)) || !strings.Contains(html, "Usher: 4 open") {
+		t.Errorf("widget missing link or seats: %s", html)
+	}
+}
+
+// TestPublicFeed_CachedAndRateLimited verifies responses are reused until
+// they expire, revalidate with ETags, and are limited per client IP.
+func TestPublicFeed_CachedAndRateLimited(t *testing.T) {
+	city := uniqueCode(t, "Cachetown")
+	jobTypeID := seedJobType(t, uniqueCode(t, "jt"), "Runner")
+	seedPublicEvent(t, "Cached Event", city, jobTypeID, 1)
+
+	rule, err := middleware.ParseRateLimit("3/1m")
+	if err != nil {
+		t.Fatalf("ParseRateLimit: %v", err)
+	}
+	scope := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	eventService, err := services.NewEventService(testDB, nil, nil)
+	if err != nil {
+		t.Fatalf("NewEventService: %v", err)
+	}
+	feed := &publicfeed.Handlers{Events: eventService, CacheTTL: time.Minute}
+	srv := httptest.NewServer(middleware.RateLimitRequests(testRateLimiter, scope, rule, feed.JSON()))
+	t.Cleanup(func() {
+		srv.Close()
+		testDB.Exec("DELETE FROM rate_limits WHERE key LIKE $1", scope+":%")
+	})
+
+	resp, first := getPublic(t, srv, "/?cities="+city)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d: %s", resp.StatusCode, first)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc != "public, max-age=60" {
+		t.Errorf("Cache-Control = %q", cc)
+	}
+	etag := resp.Header.Get("ETag")
+
+	// A second event in the city isn't seen until the cache expires.
+	seedPublicEvent(t, "Later Event", city, jobTypeID, 1)
+	if _, second := getPublic(t, srv, "/?cities="+city); string(second) != string(first) {
+		t.Errorf("second response differs; want it served from cache")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/?cities="+city, nil)
+	req.Header.Set("If-None-Match", etag)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("conditional GET: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match: status %d, want 304", resp.StatusCode)
+	}
+
+	if resp, _ := getPublic(t, srv, "/?cities="+city); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("fourth request: status %d, want 429", resp.StatusCode)
+	}
+}
@@ -0,0 +1,162 @@
+package integration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+
+	adminGen "volunteer-scheduler/graph/admin/generated"
+	"volunteer-scheduler/middleware"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+// postTo sends a GraphQL request body straight to srv, without the HTTP
+// middleware in front of the real endpoints.
+func postTo(t *testing.T, srv http.Handler, body map[string]any) gqlResponse {
+	t.Helper()
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/graphql/admin", strings.NewReader(string(b)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var resp gqlResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v\nbody: %s", err, rec.Body.String())
+	}
+	return resp
+}
+
+// errorCode returns the extensions.code of the response's first error.
+func errorCode(r gqlResponse) string {
+	if len(r.Errors) == 0 {
+		return ""
+	}
+	code, _ := r.Errors[0].Extensions["code"].(string)
+	return code
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestComplexityLimit_AliasedLists verifies a query that repeats an expensive
+// list field under many aliases is refused before it runs.
+func TestComplexityLimit_AliasedLists(t *testing.T) {
+	token, _ := makeVolunteer(t)
+
+	var b strings.Builder
+	b.WriteString("query {")
+	for i := 0; i < 60; i++ {
+		fmt.Fprintf(&b, " e%d: eventViews { id name }", i)
+	}
+	b.WriteString(" }")
+
+	resp := gqlPost(t, "/graphql/volunteer", token, b.String(), nil)
+	if code := errorCode(resp); code != "COMPLEXITY_LIMIT_EXCEEDED" {
+		t.Fatalf("error code = %q, want COMPLEXITY_LIMIT_EXCEEDED (errors: %v)", code, resp.Errors)
+	}
+
+	// One copy is well within the limit.
+	resp = gqlPost(t, "/graphql/volunteer", token, "query { eventViews { id name } }", nil)
+	if hasGQLErrors(resp) {
+		t.Errorf("unexpected errors: %v", resp.Errors)
+	}
+}
+
+// TestComplexityLimit_ConnectionPageSize verifies a connection costs its
+// `first` argument, so asking for bigger pages costs more.
+func TestComplexityLimit_ConnectionPageSize(t *testing.T) {
+	token, _ := makeAdmin(t)
+	var b strings.Builder
+	b.WriteString("query($first: Int) {")
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&b, " v%d: volunteersConnection(first: $first) { edges { node { id firstName lastName email } } }", i)
+	}
+	b.WriteString(" }")
+	query := b.String()
+
+	if resp := gqlPost(t, "/graphql/admin", token, query, map[string]any{"first": 10}); hasGQLErrors(resp) {
+		t.Fatalf("small pages: unexpected errors: %v", resp.Errors)
+	}
+	resp := gqlPost(t, "/graphql/admin", token, query, map[string]any{"first": 200})
+	if code := errorCode(resp); code != "COMPLEXITY_LIMIT_EXCEEDED" {
+		t.Errorf("large pages: error code = %q, want COMPLEXITY_LIMIT_EXCEEDED", code)
+	}
+}
+
+// TestDepthLimit verifies operations nested deeper than the limit are
+// refused, while introspection fields do not count.
+func TestDepthLimit(t *testing.T) {
+	srv := handler.New(adminGen.NewExecutableSchema(adminGen.Config{}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(middleware.DepthLimit{Max: 3})
+
+	resp := postTo(t, srv, map[string]any{
+		"query": "{ volunteersConnection { edges { node { id } } } }",
+	})
+	if code := errorCode(resp); code != "DEPTH_LIMIT_EXCEEDED" {
+		t.Errorf("error code = %q, want DEPTH_LIMIT_EXCEEDED", code)
+	}
+
+	resp = postTo(t, srv, map[string]any{"query": "{ __typename }"})
+	if hasGQLErrors(resp) {
+		t.Errorf("unexpected errors: %v", resp.Errors)
+	}
+}
+
+// TestPersistedQueryAllowlist verifies only listed queries run, and a client
+// may send just the hash of one.
+func TestPersistedQueryAllowlist(t *testing.T) {
+	const listed = "query Listed { __typename }"
+	allowlist, err := middleware.NewPersistedQueryAllowlist(map[string]string{sha256Hex(listed): listed})
+	if err != nil {
+		t.Fatalf("NewPersistedQueryAllowlist: %v", err)
+	}
+	srv := handler.New(adminGen.NewExecutableSchema(adminGen.Config{}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(allowlist)
+
+	if resp := postTo(t, srv, map[string]any{"query": listed}); hasGQLErrors(resp) {
+		t.Errorf("listed query: unexpected errors: %v", resp.Errors)
+	}
+
+	resp := postTo(t, srv, map[string]any{"query": "query Other { __typename }"})
+	if code := errorCode(resp); code != "PERSISTED_QUERY_NOT_ALLOWED" {
+		t.Errorf("unlisted query: error code = %q, want PERSISTED_QUERY_NOT_ALLOWED", code)
+	}
+
+	resp = postTo(t, srv, map[string]any{
+		"extensions": map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": sha256Hex(listed)}},
+	})
+	if hasGQLErrors(resp) || string(resp.Data["__typename"]) != `"Query"` {
+		t.Errorf("hash only: data = %v, errors = %v", resp.Data, resp.Errors)
+	}
+
+	resp = postTo(t, srv, map[string]any{
+		"extensions": map[string]any{"persistedQuery": map[string]any{"version": 1, "sha256Hash": sha256Hex("nope")}},
+	})
+	if code := errorCode(resp); code != "PERSISTED_QUERY_NOT_FOUND" {
+		t.Errorf("unknown hash: error code = %q, want PERSISTED_QUERY_NOT_FOUND", code)
+	}
+
+	if _, err := middleware.NewPersistedQueryAllowlist(map[string]string{sha256Hex("a"): "b"}); err == nil {
+		t.Error("expected a manifest entry that does not match its hash to be refused")
+	}
+}
@@ -0,0 +1,247 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/middleware"
+)
+
+// ============================================================================
+// Rate limiting — middleware.RateLimitOperations
+//
+// Each test builds its own server with tight limits in front of a stub
+// handler, and poses as a fresh client via X-Forwarded-For (loopback is a
+// trusted proxy in setup_test.go).
+// ============================================================================
+
+// rateLimitedServer wraps a handler that always succeeds with the given rules.
+func rateLimitedServer(t *testing.T, spec string) *httptest.Server {
+	t.Helper()
+	rules, err := middleware.ParseRateLimits(spec)
+	if err != nil {
+		t.Fatalf("ParseRateLimits: %v", err)
+	}
+	scope := fmt.Sprintf("test-%d", time.Now().UnixNano())
+	stub := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":{}}`))
+	})
+	srv := httptest.NewServer(middleware.RateLimitOperations(testRateLimiter, scope, rules, stub))
+	t.Cleanup(func() {
+		srv.Close()
+		testDB.Exec("DELETE FROM rate_limits WHERE key LIKE $1", scope+":%")
+	})
+	return srv
+}
+
+// postAs sends a GraphQL request as the client at ip and returns the response.
+func postAs(t *testing.T, srv *httptest.Server, ip, query string) *http.Response {
+	t.Helper()
+	b, _ := json.Marshal(map[string]any{"query": query})
+	req, _ := http.NewRequest(http.MethodPost, srv.URL, bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-For", ip)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("postAs: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+const qryRequestAccountOp = `mutation { requestAccount(email: "a@example.com", firstName: "A", lastName: "B") { success } }`
+const qryRequestMagicLinkOp = `mutation { requestMagicLink(email: "a@example.com") { success } }`
+
+// TestRateLimit_BlocksAfterLimit verifies the request over the limit gets a
+// GraphQL-shaped 429 with Retry-After.
+func TestRateLimit_BlocksAfterLimit(t *testing.T) {
+	srv := rateLimitedServer(t, "requestAccount=2/1h")
+
+	for i := 0; i < 2; i++ {
+		if resp := postAs(t, srv, "203.0.113.10", qryRequestAccountOp); resp.StatusCode != http.StatusOK {
+			t.Fatalf("request %d: expected 200, got %d", i+1, resp.StatusCode)
+		}
+	}
+
+	resp := postAs(t, srv, "203.0.113.10", qryRequestAccountOp)
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", resp.StatusCode)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+
+	var body struct {
+		Errors []struct {
+			Message    string         `json:"message"`
+			Extensions map[string]any `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode 429 body: %v", err)
+	}
+	if len(body.Errors) != 1 || body.Errors[0].Extensions["code"] != "RATE_LIMITED" {
+		t.Errorf("expected a RATE_LIMITED GraphQL error, got %+v", body)
+	}
+}
+
+// TestRateLimit_PerClientIP verifies clients are counted separately.
+func TestRateLimit_PerClientIP(t *testing.T) {
+	srv := rateLimitedServer(t, "requestAccount=1/1h")
+
+	postAs(t, srv, "203.0.113.20", qryRequestAccountOp)
+	if resp := postAs(t, srv, "203.0.113.20", qryRequestAccountOp); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected first client to be limited, got %d", resp.StatusCode)
+	}
+	if resp := postAs(t, srv, "203.0.113.21", qryRequestAccountOp); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected second client to be allowed, got %d", resp.StatusCode)
+	}
+}
+
+// TestRateLimit_PerOperation verifies each operation has its own budget and
+// unlisted operations fall back to "*".
+func TestRateLimit_PerOperation(t *testing.T) {
+	srv := rateLimitedServer(t, "requestAccount=1/1h,*=2/1h")
+	ip := "203.0.113.30"
+
+	postAs(t, srv, ip, qryRequestAccountOp)
+	if resp := postAs(t, srv, ip, qryRequestMagicLinkOp); resp.StatusCode != http.StatusOK {
+		t.Errorf("requestMagicLink should not share requestAccount's budget, got %d", resp.StatusCode)
+	}
+	postAs(t, srv, ip, qryRequestMagicLinkOp)
+	if resp := postAs(t, srv, ip, qryRequestMagicLinkOp); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the \"*\" limit to apply to requestMagicLink, got %d", resp.StatusCode)
+	}
+}
+
+// TestRateLimit_AliasesCountSeparately verifies one request cannot run an
+// operation many times under aliases for the price of one hit.
+func TestRateLimit_AliasesCountSeparately(t *testing.T) {
+	srv := rateLimitedServer(t, "requestMagicLink=3/1h")
+	ip := "203.0.113.35"
+
+	var b strings.Builder
+	b.WriteString("mutation {")
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&b, ` m%d: requestMagicLink(email: "a@example.com") { success }`, i)
+	}
+	b.WriteString(" }")
+
+	if resp := postAs(t, srv, ip, b.String()); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected 5 aliased calls to exceed a limit of 3, got %d", resp.StatusCode)
+	}
+
+	// Repeating a field under the same key runs it once.
+	srv = rateLimitedServer(t, "requestMagicLink=1/1h")
+	same := `mutation { requestMagicLink(email: "a@example.com") { success } requestMagicLink(email: "a@example.com") { success } }`
+	if resp := postAs(t, srv, "203.0.113.36", same); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected a merged duplicate field to count once, got %d", resp.StatusCode)
+	}
+}
+
+// TestRateLimit_RequiresQueryText verifies requests the limiter cannot
+// classify — multipart forms and persisted-query hashes — are refused
+// rather than falling through to the "*" budget.
+func TestRateLimit_RequiresQueryText(t *testing.T) {
+	srv := rateLimitedServer(t, "requestMagicLink=1/1h")
+
+	send := func(contentType, body string) int {
+		req, _ := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Forwarded-For", "203.0.113.37")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("send: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	operations, _ := json.Marshal(map[string]any{"query": qryRequestMagicLinkOp})
+	multipart := "--x\r\nContent-Disposition: form-data; name=\"operations\"\r\n\r\n" + string(operations) +
+		"\r\n--x\r\nContent-Disposition: form-data; name=\"map\"\r\n\r\n{}\r\n--x--\r\n"
+	if got := send("multipart/form-data; boundary=x", multipart); got != http.StatusBadRequest {
+		t.Errorf("multipart request: expected 400, got %d", got)
+	}
+
+	hashOnly := `{"extensions":{"persistedQuery":{"version":1,"sha256Hash":"abc"}}}`
+	if got := send("application/json", hashOnly); got != http.StatusBadRequest {
+		t.Errorf("hash-only request: expected 400, got %d", got)
+	}
+}
+
+// TestRateLimit_SharedAcrossReplicas verifies that two servers backed by the
+// same database enforce one limit.
+func TestRateLimit_SharedAcrossReplicas(t *testing.T) {
+	rules, _ := middleware.ParseRateLimits("requestAccount=1/1h")
+	scope := fmt.Sprintf("replica-%d", time.Now().UnixNano())
+	stub := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"data":{}}`)) })
+	a := httptest.NewServer(middleware.RateLimitOperations(testRateLimiter, scope, rules, stub))
+	b := httptest.NewServer(middleware.RateLimitOperations(testRateLimiter, scope, rules, stub))
+	t.Cleanup(func() {
+		a.Close()
+		b.Close()
+		testDB.Exec("DELETE FROM rate_limits WHERE key LIKE $1", scope+":%")
+	})
+
+	postAs(t, a, "203.0.113.40", qryRequestAccountOp)
+	if resp := postAs(t, b, "203.0.113.40", qryRequestAccountOp); resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected the second replica to see the first replica's hit, got %d", resp.StatusCode)
+	}
+}
+
+// TestClientIP_TrustedProxies verifies X-Forwarded-For is only honoured from
+// trusted proxies, and spoofed leading entries are ignored.
+func TestClientIP_TrustedProxies(t *testing.T) {
+	tests := []struct {
+		name   string
+		remote string
+		xff    string
+		want   string
+	}{
+		{"untrusted peer ignores header", "198.51.100.7:5000", "203.0.113.1", "198.51.100.7"},
+		{"trusted peer uses header", "127.0.0.1:5000", "203.0.113.1", "203.0.113.1"},
+		{"rightmost untrusted hop wins", "127.0.0.1:5000", "6.6.6.6, 203.0.113.1", "203.0.113.1"},
+		{"trusted hops are skipped", "127.0.0.1:5000", "203.0.113.1, 127.0.0.1", "203.0.113.1"},
+		{"no header falls back to peer", "127.0.0.1:5000", "", "127.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/graphql/auth", nil)
+			r.RemoteAddr = tt.remote
+			if tt.xff != "" {
+				r.Header.Set("X-Forwarded-For", tt.xff)
+			}
+			if got := middleware.ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseRateLimits verifies the AUTH_RATE_LIMITS format.
+func TestParseRateLimits(t *testing.T) {
+	rules, err := middleware.ParseRateLimits(middleware.DefaultAuthRateLimits)
+	if err != nil {
+		t.Fatalf("default spec must parse: %v", err)
+	}
+	if got := rules["requestAccount"]; got.Limit != 3 || got.Window != time.Hour {
+		t.Errorf("requestAccount = %+v, want 3/1h", got)
+	}
+
+	if rules, _ := middleware.ParseRateLimits("off"); len(rules) != 0 {
+		t.Error("\"off\" should disable limits")
+	}
+	for _, bad := range []string{"requestAccount", "requestAccount=3", "requestAccount=0/1h", "requestAccount=3/soon"} {
+		if _, err := middleware.ParseRateLimits(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
@@ -0,0 +1,366 @@
+package integration
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"volunteer-scheduler/services"
+)
+
+// ============================================================================
+// Sessions — ownSessions / revokeOwnSession / revokeOtherSessions (volunteer)
+// and revokeAllSessions (admin)
+// ============================================================================
+
+const qryOwnSessions = `query {
+	ownSessions { id userAgent ipAddress createdAt lastActivityAt expiresAt current }
+}`
+
+const mutRevokeOwnSession = `mutation Revoke($id: ID!) {
+	revokeOwnSession(sessionId: $id) { success message id }
+}`
+
+const mutRevokeOtherSessions = `mutation {
+	revokeOtherSessions { success message }
+}`
+
+const mutRevokeAllSessions = `mutation RevokeAll($id: ID!) {
+	revokeAllSessions(volunteerId: $id) { success message id }
+}`
+
+type sessionView struct {
+	ID      string `json:"id"`
+	Current bool   `json:"current"`
+}
+
+// volunteerSessions signs a volunteer in on n devices and returns the tokens.
+func volunteerSessions(t *testing.T, n int) ([]string, int) {
+	t.Helper()
+	email := uniqueEmail(t)
+	id := seedVolunteer(t, email, "Vol", "Test", "VOLUNTEER")
+	tokens := make([]string, n)
+	for i := range tokens {
+		tokens[i] = seedSession(t, email, id, "VOLUNTEER", "dev"+strconv.Itoa(i)+"-"+email)
+	}
+	return tokens, id
+}
+
+func fetchOwnSessions(t *testing.T, token string) []sessionView {
+	t.Helper()
+	resp := gqlPost(t, "/graphql/volunteer", token, qryOwnSessions, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var sessions []sessionView
+	unmarshalField(t, resp, "ownSessions", &sessions)
+	return sessions
+}
+
+// TestOwnSessions_ListsAllDevices verifies that concurrent sessions coexist
+// and the requesting one is flagged as current.
+func TestOwnSessions_ListsAllDevices(t *testing.T) {
+	tokens, _ := volunteerSessions(t, 2)
+
+	sessions := fetchOwnSessions(t, tokens[0])
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	current := 0
+	for _, s := range sessions {
+		if s.Current {
+			current++
+		}
+	}
+	if current != 1 {
+		t.Errorf("expected exactly one current session, got %d", current)
+	}
+
+	// Both tokens must still authenticate.
+	for _, tok := range tokens {
+		resp := gqlPost(t, "/graphql/volunteer", tok, qryOwnSessions, nil)
+		if hasGQLErrors(resp) {
+			t.Errorf("token %q rejected: %v", tok, resp.Errors)
+		}
+	}
+}
+
+// TestRevokeOwnSession verifies that a volunteer can sign out another device.
+func TestRevokeOwnSession(t *testing.T) {
+	tokens, _ := volunteerSessions(t, 2)
+
+	var other string
+	for _, s := range fetchOwnSessions(t, tokens[0]) {
+		if !s.Current {
+			other = s.ID
+		}
+	}
+
+	resp := gqlPost(t, "/graphql/volunteer", tokens[0], mutRevokeOwnSession, map[string]any{"id": other})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "revokeOwnSession", &result)
+	if !result.Success {
+		t.Fatalf("expected success=true (message: %v)", result.Message)
+	}
+
+	resp = gqlPost(t, "/graphql/volunteer", tokens[1], qryOwnSessions, nil)
+	if !hasGQLErrors(resp) {
+		t.Error("expected revoked session to be rejected")
+	}
+}
+
+// TestRevokeOwnSession_NotOwner verifies that a volunteer cannot revoke
+// someone else's session.
+func TestRevokeOwnSession_NotOwner(t *testing.T) {
+	victim, _ := volunteerSessions(t, 1)
+	attacker, _ := makeVolunteer(t)
+
+	victimSession := fetchOwnSessions(t, victim[0])[0].ID
+
+	resp := gqlPost(t, "/graphql/volunteer", attacker, mutRevokeOwnSession, map[string]any{"id": victimSession})
+	if code := errorCode(resp); code != "NOT_FOUND" {
+		t.Errorf("revoking another volunteer's session: error code = %q, want NOT_FOUND (errors: %v)", code, resp.Errors)
+	}
+	if len(fetchOwnSessions(t, victim[0])) != 1 {
+		t.Error("victim's session should be untouched")
+	}
+}
+
+// TestRevokeOtherSessions verifies "sign out everywhere else" keeps only the
+// requesting session.
+func TestRevokeOtherSessions(t *testing.T) {
+	tokens, _ := volunteerSessions(t, 3)
+
+	resp := gqlPost(t, "/graphql/volunteer", tokens[0], mutRevokeOtherSessions, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "revokeOtherSessions", &result)
+	if !result.Success {
+		t.Fatalf("expected success=true (message: %v)", result.Message)
+	}
+
+	sessions := fetchOwnSessions(t, tokens[0])
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Errorf("expected only the current session to remain, got %+v", sessions)
+	}
+}
+
+// TestRevokeAllSessions_Admin verifies that an admin can sign a volunteer out
+// on every device.
+func TestRevokeAllSessions_Admin(t *testing.T) {
+	adminToken, _ := makeAdmin(t)
+	tokens, volID := volunteerSessions(t, 2)
+
+	resp := gqlPost(t, "/graphql/admin", adminToken, mutRevokeAllSessions, map[string]any{"id": strconv.Itoa(volID)})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+	var result mutationResult
+	unmarshalField(t, resp, "revokeAllSessions", &result)
+	if !result.Success {
+		t.Fatalf("expected success=true (message: %v)", result.Message)
+	}
+
+	if rowExists(t, "SELECT COUNT(*) FROM sessions WHERE volunteer_id = $1", volID) {
+		t.Error("expected all sessions to be deleted")
+	}
+	for _, tok := range tokens {
+		if resp := gqlPost(t, "/graphql/volunteer", tok, qryOwnSessions, nil); !hasGQLErrors(resp) {
+			t.Error("expected revoked token to be rejected")
+		}
+	}
+}
+
+// TestRevokeAllSessions_RequiresAdmin verifies volunteers cannot call the
+// admin mutation.
+func TestRevokeAllSessions_RequiresAdmin(t *testing.T) {
+	volToken, volID := makeVolunteer(t)
+
+	resp := gqlPost(t, "/graphql/admin", volToken, mutRevokeAllSessions, map[string]any{"id": strconv.Itoa(volID)})
+	if !hasGQLErrors(resp) {
+		t.Error("expected an error for non-admin caller")
+	}
+}
+
+// ============================================================================
+// Session policy — idle timeout, sliding renewal, remember-device
+// ============================================================================
+
+// TestSession_IdleTimeout verifies a session unused for longer than its idle
+// timeout is rejected and removed, even though it has not expired.
+func TestSession_IdleTimeout(t *testing.T) {
+	t.Setenv("SESSION_IDLE_TIMEOUT", "3600")
+	token, _ := makeVolunteer(t)
+
+	testDB.Exec("UPDATE sessions SET last_activity_at = NOW() - INTERVAL '2 hours' WHERE token = $1", hashSessionToken(token))
+
+	resp := gqlPost(t, "/graphql/volunteer", token, qryOwnSessions, nil)
+	if !hasGQLErrors(resp) {
+		t.Fatal("expected idle session to be rejected")
+	}
+	if sessionExists(t, token) {
+		t.Error("expected idle session to be deleted")
+	}
+}
+
+// TestSession_AdminIdleTimeoutStricter verifies admins time out sooner than
+// volunteers after the same period of inactivity.
+func TestSession_AdminIdleTimeoutStricter(t *testing.T) {
+	t.Setenv("SESSION_IDLE_TIMEOUT", "86400")
+	t.Setenv("ADMIN_SESSION_IDLE_TIMEOUT", "1800")
+	volToken, _ := makeVolunteer(t)
+	adminToken, _ := makeAdmin(t)
+
+	for _, tok := range []string{volToken, adminToken} {
+		testDB.Exec("UPDATE sessions SET last_activity_at = NOW() - INTERVAL '1 hour' WHERE token = $1", hashSessionToken(tok))
+	}
+
+	if resp := gqlPost(t, "/graphql/volunteer", volToken, qryOwnSessions, nil); hasGQLErrors(resp) {
+		t.Errorf("volunteer idle for 1h should still be signed in: %v", resp.Errors)
+	}
+	if resp := gqlPost(t, "/graphql/admin", adminToken, queryLookupValues, nil); !hasGQLErrors(resp) {
+		t.Error("admin idle for 1h should be signed out")
+	}
+}
+
+// TestSession_SlidingRenewal verifies an active session past half its
+// lifetime is extended and its cookie refreshed.
+func TestSession_SlidingRenewal(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	testDB.Exec("UPDATE sessions SET expires_at = NOW() + INTERVAL '1 hour' WHERE token = $1", hashSessionToken(token))
+
+	resp, cookies := gqlPostFullCookie(t, "/graphql/volunteer", token, qryOwnSessions, nil)
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+
+	const sessionMaxAge = 86400 // matches SESSION_MAX_AGE env var in setup_test.go
+	var secondsRemaining float64
+	testDB.QueryRow(`SELECT EXTRACT(EPOCH FROM (expires_at - NOW())) FROM sessions WHERE token = $1`,
+		hashSessionToken(token)).Scan(&secondsRemaining)
+	if secondsRemaining < sessionMaxAge-60 {
+		t.Errorf("expected expiry to slide to ~%d seconds, got %.0f", sessionMaxAge, secondsRemaining)
+	}
+
+	refreshed := findCookie(cookies, "session")
+	if refreshed == nil || refreshed.Value != token {
+		t.Fatal("expected the session cookie to be refreshed with the same token")
+	}
+	if time.Until(refreshed.Expires) < (sessionMaxAge-60)*time.Second {
+		t.Errorf("expected refreshed cookie to expire in ~%ds, got %v", sessionMaxAge, time.Until(refreshed.Expires))
+	}
+}
+
+// TestSession_AbsoluteLifetime verifies sliding renewal stops at the
+// policy's absolute deadline, however active the session is.
+func TestSession_AbsoluteLifetime(t *testing.T) {
+	t.Setenv("ADMIN_SESSION_ABSOLUTE_MAX_AGE", "43200") // 12 hours
+	token, _ := makeAdmin(t)
+	testDB.Exec(`
+		UPDATE sessions
+		SET    created_at = NOW() - INTERVAL '690 minutes', last_activity_at = NOW(), expires_at = NOW() + INTERVAL '1 hour'
+		WHERE  token = $1`, hashSessionToken(token))
+
+	if resp, _ := gqlPostFullCookie(t, "/graphql/volunteer", token, qryOwnSessions, nil); hasGQLErrors(resp) {
+		t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+	}
+
+	var secondsRemaining float64
+	testDB.QueryRow(`SELECT EXTRACT(EPOCH FROM (expires_at - NOW())) FROM sessions WHERE token = $1`,
+		hashSessionToken(token)).Scan(&secondsRemaining)
+	if secondsRemaining < 30*60-60 || secondsRemaining > 30*60+60 {
+		t.Errorf("expected renewal to stop at the 12 hour deadline (~1800s left), got %.0f", secondsRemaining)
+	}
+}
+
+// TestSession_CheckDoesNotTouch verifies the read-only check used by open
+// websockets neither counts as activity nor renews the session.
+func TestSession_CheckDoesNotTouch(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	testDB.Exec(`
+		UPDATE sessions
+		SET    last_activity_at = NOW() - INTERVAL '10 minutes', expires_at = NOW() + INTERVAL '1 hour'
+		WHERE  token = $1`, hashSessionToken(token))
+
+	var activityBefore, expiresBefore time.Time
+	testDB.QueryRow(`SELECT last_activity_at, expires_at FROM sessions WHERE token = $1`,
+		hashSessionToken(token)).Scan(&activityBefore, &expiresBefore)
+
+	svc := services.NewMagicLinkService(testDB, services.NewTestMailer())
+	if _, err := svc.CheckSessionToken(context.Background(), token); err != nil {
+		t.Fatalf("expected live session to pass the check: %v", err)
+	}
+
+	var activityAfter, expiresAfter time.Time
+	testDB.QueryRow(`SELECT last_activity_at, expires_at FROM sessions WHERE token = $1`,
+		hashSessionToken(token)).Scan(&activityAfter, &expiresAfter)
+	if !activityAfter.Equal(activityBefore) {
+		t.Errorf("expected last_activity_at to stay %v, got %v", activityBefore, activityAfter)
+	}
+	if !expiresAfter.Equal(expiresBefore) {
+		t.Errorf("expected expires_at to stay %v, got %v", expiresBefore, expiresAfter)
+	}
+}
+
+// TestSession_NoRenewalEarly verifies a fresh session is not rewritten on
+// every request.
+func TestSession_NoRenewalEarly(t *testing.T) {
+	token, _ := makeVolunteer(t) // seeded with a full day to go
+
+	_, cookies := gqlPostFullCookie(t, "/graphql/volunteer", token, qryOwnSessions, nil)
+	if findCookie(cookies, "session") != nil {
+		t.Error("did not expect a cookie refresh for a fresh session")
+	}
+}
+
+// TestConsumeMagicLink_RememberDevice verifies remember-device sessions get
+// the longer lifetime, and that admins do not.
+func TestConsumeMagicLink_RememberDevice(t *testing.T) {
+	t.Setenv("SESSION_REMEMBER_MAX_AGE", "2592000")
+	t.Setenv("ADMIN_SESSION_MAX_AGE", "3600")
+
+	tests := []struct {
+		role    string
+		wantAge float64
+	}{
+		{"VOLUNTEER", 2592000},
+		{"ADMINISTRATOR", 3600},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			email := uniqueEmail(t)
+			seedVolunteer(t, email, "Remember", "Test", tt.role)
+			token := "remember-token-" + email
+			seedMagicLink(t, email, token, time.Now().Add(15*time.Minute))
+
+			resp, cookies := gqlPostFull(t, "/graphql/auth", "", mutConsumeMagicLinkRemember, map[string]any{"token": token})
+			if hasGQLErrors(resp) {
+				t.Fatalf("unexpected GQL errors: %v", resp.Errors)
+			}
+			sessionCookie := findCookie(cookies, "session")
+			if sessionCookie == nil {
+				t.Fatal("expected a session cookie")
+			}
+			t.Cleanup(func() {
+				testDB.Exec("DELETE FROM sessions WHERE token = $1", hashSessionToken(sessionCookie.Value))
+			})
+
+			var secondsRemaining float64
+			testDB.QueryRow(`SELECT EXTRACT(EPOCH FROM (expires_at - NOW())) FROM sessions WHERE token = $1`,
+				hashSessionToken(sessionCookie.Value)).Scan(&secondsRemaining)
+			if secondsRemaining < tt.wantAge-60 || secondsRemaining > tt.wantAge+60 {
+				t.Errorf("expected session to expire in ~%.0f seconds, got %.0f", tt.wantAge, secondsRemaining)
+			}
+		})
+	}
+}
+
+const mutConsumeMagicLinkRemember = `mutation Consume($token: String!) {
+	consumeMagicLink(token: $token, rememberDevice: true) { success message }
+}`
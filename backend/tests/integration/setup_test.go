@@ -10,7 +10,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/gorilla/websocket"
 	_ "github.com/lib/pq"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -24,7 +28,9 @@ import (
 	"volunteer-scheduler/graph/volunteer"
 	volGen "volunteer-scheduler/graph/volunteer/generated"
 	"volunteer-scheduler/middleware"
+	"volunteer-scheduler/publicfeed"
 	"volunteer-scheduler/services"
+	"volunteer-scheduler/webhooks"
 )
 
 // testServer is the shared httptest.Server used by all tests in this package.
@@ -33,8 +39,43 @@ var (
 	testServer           *httptest.Server
 	testDB               *sql.DB
 	testMagicLinkService *services.MagicLinkService
+	testRateLimiter      *services.RateLimiter
 )
 
+// testWebhookSecret signs requests to the /webhooks/* endpoints.
+const testWebhookSecret = "test-webhook-secret"
+
+// testSvixSecret signs delivery events the way Resend (via Svix) does.
+const testSvixSecret = "whsec_dGVzdC1zdml4LXNlY3JldA=="
+
+// testAllowedOrigin stands in for ALLOWED_ORIGIN, the frontend's origin.
+const testAllowedOrigin = "http://localhost:3000"
+
+// Query limits used by the test servers; the same defaults as main.go.
+var (
+	testAuthLimits      = middleware.QueryLimits{MaxComplexity: 100, MaxDepth: 6}
+	testVolunteerLimits = middleware.QueryLimits{MaxComplexity: 5000, MaxDepth: 8}
+	testAdminLimits     = middleware.QueryLimits{MaxComplexity: 20000, MaxDepth: 10}
+)
+
+// newTestServer mirrors newServer in main.go, without a persisted query
+// allowlist.
+func newTestServer(es graphql.ExecutableSchema, listSizes map[string]int, limits middleware.QueryLimits, ws *transport.Websocket) *handler.Server {
+	srv := handler.New(middleware.WithListCosts(es, listSizes))
+	if ws != nil {
+		srv.AddTransport(*ws)
+	}
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.AddTransport(transport.MultipartForm{})
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	srv.Use(middleware.DepthLimit{Max: limits.MaxDepth})
+	srv.SetErrorPresenter(middleware.ErrorPresenter)
+	return srv
+}
+
 func TestMain(m *testing.M) {
 	ctx := context.Background()
 
@@ -84,11 +125,16 @@ func TestMain(m *testing.M) {
 	os.Setenv("APP_URL", "http://localhost:3000")
 	os.Setenv("EMAIL_FROM", "test@example.com")
 	os.Setenv("SESSION_MAX_AGE", "86400")
+	os.Setenv("INBOUND_REPLY_DOMAIN", "reply.example.com")
+	os.Setenv("INBOUND_EMAIL_SECRET", "test-inbound-email-secret")
+	os.Setenv("SIGN_IN_CODE_SECRET", "test-sign-in-code-secret")
 
 	// -------------------------------------------------------------------------
 	// Wire up services — same order as main.go.
 	// -------------------------------------------------------------------------
 	mailer := services.NewTestMailer()
+	suppressionList := services.NewSuppressionList(db)
+	mailer.UseSuppressionList(suppressionList)
 	magicLinkService := services.NewMagicLinkService(db, mailer)
 	volunteerService := services.NewVolunteerService(db, mailer)
 	shiftService := services.NewShiftService(db, mailer)
@@ -96,6 +142,18 @@ func TestMain(m *testing.M) {
 	feedbackService := services.NewFeedbackService(db, mailer)
 	staffService := services.NewStaffService(db)
 	fundingEntityService := services.NewFundingEntityService(db)
+	sessionService := services.NewSessionService(db)
+	scopeService := services.NewScopeService(db)
+	impersonationService := services.NewImpersonationService(db, mailer)
+	apiTokenService := services.NewAPITokenService(db)
+	auditService := services.NewAuditService(db)
+	accountRequestService := services.NewAccountRequestService(db, mailer, volunteerService)
+	privacyService := services.NewPrivacyService(db, mailer)
+	webhookService := services.NewWebhookService(db)
+	testRateLimiter = services.NewRateLimiter(db)
+	shiftCapacityFeed := services.NewShiftCapacityFeed(connStr)
+	feedCtx, stopFeed := context.WithCancel(ctx)
+	go shiftCapacityFeed.RunShiftCapacityFeed(feedCtx)
 
 	eventService, err := services.NewEventService(db, mailer, shiftService)
 	if err != nil {
@@ -108,47 +166,101 @@ func TestMain(m *testing.M) {
 	// Wire up resolvers.
 	// -------------------------------------------------------------------------
 	authResolver := &auth.Resolver{
-		MagicLinkService: magicLinkService,
-		IsProd:           false, // tests run over plain HTTP; no Secure flag on cookies
+		MagicLinkService:      magicLinkService,
+		AccountRequestService: accountRequestService,
+		IsProd:                false, // tests run over plain HTTP; no Secure flag on cookies
 	}
 	volunteerResolver := &volunteer.Resolver{
-		DB:               db,
-		EventService:     eventService,
-		VolunteerService: volunteerService,
-		ShiftService:     shiftService,
-		VenueService:     venueService,
-		FeedbackService:  feedbackService,
+		DB:                db,
+		EventService:      eventService,
+		VolunteerService:  volunteerService,
+		ShiftService:      shiftService,
+		VenueService:      venueService,
+		FeedbackService:   feedbackService,
+		SessionService:    sessionService,
+		PrivacyService:    privacyService,
+		ShiftCapacityFeed: shiftCapacityFeed,
 	}
 	adminResolver := &admin.Resolver{
-		DB:                   db,
-		EventService:         eventService,
-		VolunteerService:     volunteerService,
-		ShiftService:         shiftService,
-		VenueService:         venueService,
-		FeedbackService:      feedbackService,
-		StaffService:         staffService,
-		FundingEntityService: fundingEntityService,
+		DB:                    db,
+		EventService:          eventService,
+		VolunteerService:      volunteerService,
+		ShiftService:          shiftService,
+		VenueService:          venueService,
+		FeedbackService:       feedbackService,
+		StaffService:          staffService,
+		FundingEntityService:  fundingEntityService,
+		SessionService:        sessionService,
+		ScopeService:          scopeService,
+		ImpersonationService:  impersonationService,
+		APITokenService:       apiTokenService,
+		AuditService:          auditService,
+		AccountRequestService: accountRequestService,
+		PrivacyService:        privacyService,
+		WebhookService:        webhookService,
+		ShiftCapacityFeed:     shiftCapacityFeed,
 	}
 
 	// -------------------------------------------------------------------------
 	// Build the HTTP mux — same routes as main.go, no CORS needed for tests.
 	// -------------------------------------------------------------------------
-	authSrv := handler.NewDefaultServer(authGen.NewExecutableSchema(authGen.Config{
+	ws := &transport.Websocket{
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: middleware.WebsocketOriginAllowed(testAllowedOrigin),
+		},
+		InitFunc:              middleware.WebsocketAuth(magicLinkService, apiTokenService),
+		KeepAlivePingInterval: 10 * time.Second,
+	}
+	authSrv := newTestServer(authGen.NewExecutableSchema(authGen.Config{
 		Resolvers: authResolver,
-	}))
-	volunteerSrv := handler.NewDefaultServer(volGen.NewExecutableSchema(volGen.Config{
+	}), nil, testAuthLimits, nil)
+	volunteerSrv := newTestServer(volGen.NewExecutableSchema(volGen.Config{
 		Resolvers: volunteerResolver,
-	}))
-	adminSrv := handler.NewDefaultServer(adminGen.NewExecutableSchema(adminGen.Config{
+	}), volunteer.ListSizes, testVolunteerLimits, ws)
+	volunteerSrv.AroundOperations(volunteer.ReadOnlyWhenImpersonating)
+	volunteerSrv.AroundRootFields(middleware.AuthorizeTokenScope)
+	volunteerSrv.AroundFields(middleware.SubscriptionRootFields(middleware.AuthorizeTokenScope))
+	adminSrv := newTestServer(adminGen.NewExecutableSchema(adminGen.Config{
 		Resolvers: adminResolver,
-	}))
+	}), admin.ListSizes, testAdminLimits, ws)
+	adminSrv.AroundRootFields(admin.AuthorizeRootField)
+	adminSrv.AroundRootFields(middleware.AuthorizeTokenScope)
+	adminSrv.AroundFields(middleware.SubscriptionRootFields(admin.AuthorizeRootField))
+	adminSrv.AroundFields(middleware.SubscriptionRootFields(middleware.AuthorizeTokenScope))
+	adminSrv.AroundFields(admin.AuditMutations(auditService))
+
+	// The test client connects from loopback; trusting it lets tests pose as
+	// distinct clients via X-Forwarded-For.
+	if err := middleware.SetTrustedProxies("127.0.0.1,::1"); err != nil {
+		log.Fatalf("Failed to set trusted proxies: %v", err)
+	}
+	// Every test talks to /graphql/auth from the same address, so the shared
+	// server's limit is high; ratelimit_test.go exercises real limits.
+	authRateLimits, err := middleware.ParseRateLimits("*=100000/1m")
+	if err != nil {
+		log.Fatalf("Failed to parse rate limits: %v", err)
+	}
 
 	mux := http.NewServeMux()
 	// Auth endpoint: inject ResponseWriter+Request so login/logout can set/clear cookies.
-	mux.Handle("/graphql/auth", middleware.WithHTTPContext(authSrv))
+	// All three refuse cross-site requests from anywhere but testAllowedOrigin.
+	mux.Handle("/graphql/auth", middleware.CSRFProtect(testAllowedOrigin,
+		middleware.RateLimitOperations(testRateLimiter, "auth", authRateLimits,
+			middleware.WithHTTPContext(authSrv))))
 	// Authenticated endpoints: RequireAuth already injects ResponseWriter+Request.
-	mux.Handle("/graphql/volunteer", middleware.RequireAuth(magicLinkService, volunteerSrv))
-	mux.Handle("/graphql/admin", middleware.RequireAdmin(magicLinkService, adminSrv))
+	mux.Handle("/graphql/volunteer", middleware.CSRFProtect(testAllowedOrigin,
+		middleware.RequireAuth(magicLinkService, apiTokenService,
+			middleware.Impersonate(impersonationService, volunteerSrv))))
+	mux.Handle("/graphql/admin", middleware.CSRFProtect(testAllowedOrigin,
+		middleware.RequireAdmin(magicLinkService, apiTokenService, adminSrv)))
+	mux.Handle("/webhooks/inbound-email", webhooks.InboundEmail(feedbackService, testWebhookSecret))
+	mux.Handle("/webhooks/email-events", webhooks.EmailEvents(suppressionList, testSvixSecret))
+
+	// Uncached, so each test sees the rows it just wrote; public_feed_test.go
+	// builds its own handler to exercise the cache and the rate limit.
+	publicFeed := &publicfeed.Handlers{Events: eventService, FrontendURL: testAllowedOrigin}
+	mux.Handle("/public/events.json", publicFeed.JSON())
+	mux.Handle("/public/events/widget", publicFeed.Widget())
 
 	testServer = httptest.NewServer(mux)
 
@@ -158,6 +270,7 @@ func TestMain(m *testing.M) {
 	code := m.Run()
 
 	testServer.Close()
+	stopFeed()
 	db.Close()
 	pgContainer.Terminate(ctx)
 
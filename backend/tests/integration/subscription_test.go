@@ -0,0 +1,189 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ============================================================================
+// Helpers
+// ============================================================================
+
+const subShiftCapacityChanged = `subscription($eventId: ID!) {
+	shiftCapacityChanged(eventId: $eventId) { eventId shiftId assignedVolunteers maxVolunteers }
+}`
+
+// wsMessage is one graphql-transport-ws protocol message.
+type wsMessage struct {
+	ID      string          `json:"id,omitempty"`
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// dialGraphQLWS opens a graphql-transport-ws connection to path, completes
+// the connection_init handshake and returns the connection.
+func dialGraphQLWS(t *testing.T, path, token string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	if header == nil {
+		header = http.Header{}
+	}
+	if token != "" {
+		header.Set("Authorization", "Bearer "+token)
+	}
+	dialer := websocket.Dialer{Subprotocols: []string{"graphql-transport-ws"}}
+	conn, resp, err := dialer.Dial("ws"+strings.TrimPrefix(testServer.URL, "http")+path, header)
+	if err != nil {
+		return nil, resp, err
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.WriteJSON(wsMessage{Type: "connection_init"}); err != nil {
+		t.Fatalf("connection_init: %v", err)
+	}
+	if msg := readWS(t, conn); msg.Type != "connection_ack" {
+		t.Fatalf("expected connection_ack, got %+v", msg)
+	}
+	return conn, resp, nil
+}
+
+// subscribeWS starts a subscription with id "1".
+func subscribeWS(t *testing.T, conn *websocket.Conn, query string, variables map[string]any) {
+	t.Helper()
+	payload, _ := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err := conn.WriteJSON(wsMessage{ID: "1", Type: "subscribe", Payload: payload}); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+}
+
+// readWS returns the next message other than a keep-alive ping.
+func readWS(t *testing.T, conn *websocket.Conn) wsMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var msg wsMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			t.Fatalf("read websocket: %v", err)
+		}
+		if msg.Type != "ping" && msg.Type != "pong" {
+			return msg
+		}
+	}
+}
+
+// ============================================================================
+// Tests
+// ============================================================================
+
+// TestShiftCapacityChanged_PushesSignups verifies a subscriber is told the new
+// count when a volunteer signs up for one of the event's shifts.
+func TestShiftCapacityChanged_PushesSignups(t *testing.T) {
+	watcher, _ := makeVolunteer(t)
+	token, volID := makeVolunteer(t)
+	eventID, shiftID := seedEventWithShift(t, 2)
+
+	conn, _, err := dialGraphQLWS(t, "/graphql/volunteer", watcher, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	subscribeWS(t, conn, subShiftCapacityChanged, map[string]any{"eventId": strconv.Itoa(eventID)})
+	// Give the subscription a moment to register before the change is made.
+	time.Sleep(200 * time.Millisecond)
+
+	resp := gqlPost(t, "/graphql/volunteer", token, mutAssignSelfToShift, map[string]any{
+		"shiftId": strconv.Itoa(shiftID),
+	})
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM volunteer_shifts WHERE volunteer_id = $1 AND shift_id = $2", volID, shiftID)
+	})
+	if hasGQLErrors(resp) {
+		t.Fatalf("unexpected errors: %v", resp.Errors)
+	}
+
+	msg := readWS(t, conn)
+	if msg.Type != "next" {
+		t.Fatalf("expected next, got %+v", msg)
+	}
+	var next struct {
+		Data struct {
+			ShiftCapacityChanged struct {
+				EventID            string `json:"eventId"`
+				ShiftID            string `json:"shiftId"`
+				AssignedVolunteers int    `json:"assignedVolunteers"`
+				MaxVolunteers      *int   `json:"maxVolunteers"`
+			} `json:"shiftCapacityChanged"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(msg.Payload, &next); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	got := next.Data.ShiftCapacityChanged
+	if got.EventID != strconv.Itoa(eventID) || got.ShiftID != strconv.Itoa(shiftID) ||
+		got.AssignedVolunteers != 1 || got.MaxVolunteers == nil || *got.MaxVolunteers != 2 {
+		t.Errorf("change = %+v", got)
+	}
+}
+
+// TestShiftCapacityChanged_RequiresAuth verifies the websocket handshake is
+// authenticated like any other GraphQL request.
+func TestShiftCapacityChanged_RequiresAuth(t *testing.T) {
+	_, resp, err := dialGraphQLWS(t, "/graphql/volunteer", "", nil)
+	if err == nil {
+		t.Fatal("expected the handshake to be rejected")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("handshake response = %v, want 401", resp)
+	}
+}
+
+// TestShiftCapacityChanged_RejectsForeignOrigin verifies a page on another
+// site cannot open a websocket with the user's credentials.
+func TestShiftCapacityChanged_RejectsForeignOrigin(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	_, resp, err := dialGraphQLWS(t, "/graphql/volunteer", token, http.Header{"Origin": {"https://evil.example"}})
+	if err == nil {
+		t.Fatal("expected the handshake to be rejected")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("handshake response = %v, want 403", resp)
+	}
+}
+
+// TestShiftCapacityChanged_CoordinatorScope verifies a coordinator cannot
+// watch an event outside their regions.
+func TestShiftCapacityChanged_CoordinatorScope(t *testing.T) {
+	own := seedFundingEntity(t, uniqueCode(t, "Region "))
+	other := seedFundingEntity(t, uniqueCode(t, "Region "))
+	token, _ := makeCoordinator(t, own)
+	eventID := seedEventInRegion(t, "Watched Elsewhere", other)
+
+	conn, _, err := dialGraphQLWS(t, "/graphql/admin", token, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	subscribeWS(t, conn, subShiftCapacityChanged, map[string]any{"eventId": strconv.Itoa(eventID)})
+	if msg := readWS(t, conn); msg.Type != "error" && !strings.Contains(string(msg.Payload), "errors") {
+		t.Errorf("expected an error, got %+v", msg)
+	}
+}
+
+// TestShiftCapacityChanged_DraftHidden verifies a volunteer cannot watch an
+// event they could not open in eventView.
+func TestShiftCapacityChanged_DraftHidden(t *testing.T) {
+	token, _ := makeVolunteer(t)
+	eventID, _, _ := seedStatusEvent(t, "DRAFT")
+
+	conn, _, err := dialGraphQLWS(t, "/graphql/volunteer", token, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	subscribeWS(t, conn, subShiftCapacityChanged, map[string]any{"eventId": strconv.Itoa(eventID)})
+	if msg := readWS(t, conn); msg.Type != "error" || !strings.Contains(string(msg.Payload), "NOT_FOUND") {
+		t.Errorf("expected a NOT_FOUND error, got %+v", msg)
+	}
+}
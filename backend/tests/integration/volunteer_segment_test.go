@@ -0,0 +1,291 @@
+package integration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ============================================================================
+// Volunteer list filters (service history, roles, distance) and saved
+// segments
+// ============================================================================
+
+const (
+	qryFilteredVolunteers = `
+		query Volunteers($filter: VolunteerFilterInput) {
+			volunteers(filter: $filter) { id hoursServed signupCount lastServedAt }
+		}`
+
+	qryVolunteerSegments = `
+		query { volunteerSegments { id name createdById filter { lastName roles minHours near { venueId miles } } } }`
+
+	mutCreateVolunteerSegment = `
+		mutation Create($input: NewVolunteerSegmentInput!) {
+			createVolunteerSegment(input: $input) { success message code id }
+		}`
+
+	mutUpdateVolunteerSegment = `
+		mutation Update($input: UpdateVolunteerSegmentInput!) {
+			updateVolunteerSegment(input: $input) { success message code id }
+		}`
+
+	mutDeleteVolunteerSegment = `
+		mutation Delete($id: ID!) {
+			deleteVolunteerSegment(segmentId: $id) { success message code }
+		}`
+)
+
+type filteredVolunteer struct {
+	ID           string   `json:"id"`
+	HoursServed  *float64 `json:"hoursServed"`
+	SignupCount  *int     `json:"signupCount"`
+	LastServedAt *string  `json:"lastServedAt"`
+}
+
+type segmentResult struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+	Code    *string `json:"code"`
+	ID      *string `json:"id"`
+}
+
+// segmentFixture is three volunteers sharing a made-up last name:
+//
+//	veteran   COORDINATOR in Seattle, served a 3-hour shift of jobTypeID
+//	newcomer  VOLUNTEER in Spokane, signed up for a shift not yet worked
+//	retired   inactive
+//
+// and a venue in Tacoma, ~27 miles from Seattle.
+type segmentFixture struct {
+	lastName  string
+	veteran   string
+	newcomer  string
+	retired   string
+	jobTypeID int
+	venueID   string
+}
+
+func setupSegmentFixture(t *testing.T) segmentFixture {
+	t.Helper()
+	f := segmentFixture{lastName: fmt.Sprintf("Segmentson%d", time.Now().UnixNano())}
+
+	veteranID := seedVolunteer(t, uniqueEmail(t), "Vera", f.lastName, "COORDINATOR")
+	newcomerID := seedVolunteer(t, uniqueEmail(t), "Ned", f.lastName, "VOLUNTEER")
+	retiredID := seedInactiveVolunteer(t, uniqueEmail(t), "Rita", f.lastName, "VOLUNTEER")
+	f.veteran, f.newcomer, f.retired = strconv.Itoa(veteranID), strconv.Itoa(newcomerID), strconv.Itoa(retiredID)
+	mustExec(t, "UPDATE volunteers SET latitude = $1, longitude = $2 WHERE volunteer_id = $3", seattleLat, seattleLng, veteranID)
+	mustExec(t, "UPDATE volunteers SET latitude = $1, longitude = $2 WHERE volunteer_id = $3", spokaneLat, spokaneLng, newcomerID)
+
+	venueID := seedVenue(t, "Segment Venue "+f.lastName, "1 Commerce St", "Tacoma", "WA")
+	mustExec(t, "UPDATE venues SET latitude = $1, longitude = $2 WHERE venue_id = $3", tacomaLat, tacomaLng, venueID)
+	f.venueID = strconv.Itoa(venueID)
+
+	f.jobTypeID = seedJobType(t, uniqueCode(t, "seg"), "Sorter")
+	eventID := seedEvent(t, "Segment Event "+f.lastName, false, &venueID)
+	oppID := seedOpportunity(t, eventID, f.jobTypeID, false)
+	past := seedShift(t, oppID, "2025-06-01T16:00:00Z", "2025-06-01T19:00:00Z", 5)
+	future := seedShift(t, oppID, time.Now().Add(14*24*time.Hour).UTC().Format(time.RFC3339),
+		time.Now().Add(14*24*time.Hour+2*time.Hour).UTC().Format(time.RFC3339), 5)
+	seedVolunteerShift(t, past, veteranID)
+	seedVolunteerShift(t, future, newcomerID)
+	return f
+}
+
+// filterVolunteers runs the volunteers query restricted to the fixture's
+// last name plus filter, and returns the volunteers by ID.
+func (f segmentFixture) filterVolunteers(t *testing.T, token string, filter map[string]any) map[string]filteredVolunteer {
+	t.Helper()
+	filter["lastName"] = f.lastName
+	resp := gqlPost(t, "/graphql/admin", token, qryFilteredVolunteers, map[string]any{"filter": filter})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("volunteers(%v): unexpected errors: %v", filter, resp.Errors)
+	}
+	var vols []filteredVolunteer
+	unmarshalField(t, resp, "volunteers", &vols)
+	byID := map[string]filteredVolunteer{}
+	for _, v := range vols {
+		byID[v.ID] = v
+	}
+	return byID
+}
+
+// TestVolunteerFilter_ServiceHistory verifies the service record on each
+// volunteer and each filter over it.
+func TestVolunteerFilter_ServiceHistory(t *testing.T) {
+	token := makeAdminToken(t)
+	f := setupSegmentFixture(t)
+
+	all := f.filterVolunteers(t, token, map[string]any{})
+	if len(all) != 2 {
+		t.Fatalf("got %d volunteers, want the 2 active ones: %+v", len(all), all)
+	}
+	vet := all[f.veteran]
+	if vet.HoursServed == nil || *vet.HoursServed != 3 || vet.SignupCount == nil || *vet.SignupCount != 1 || vet.LastServedAt == nil {
+		t.Errorf("veteran = %+v, want 3 hours, 1 signup and a last served date", vet)
+	}
+	newc := all[f.newcomer]
+	if newc.HoursServed == nil || *newc.HoursServed != 0 || newc.SignupCount == nil || *newc.SignupCount != 1 || newc.LastServedAt != nil {
+		t.Errorf("newcomer = %+v, want 0 hours, 1 signup and never served", newc)
+	}
+
+	cases := []struct {
+		name   string
+		filter map[string]any
+		want   []string
+	}{
+		{"inactive", map[string]any{"isActive": false}, []string{f.retired}},
+		{"roles", map[string]any{"roles": []string{"COORDINATOR"}}, []string{f.veteran}},
+		{"job types", map[string]any{"jobTypeIds": []int{f.jobTypeID}}, []string{f.veteran}},
+		{"min hours", map[string]any{"minHours": 1}, []string{f.veteran}},
+		{"max hours", map[string]any{"maxHours": 0}, []string{f.newcomer}},
+		{"min signups", map[string]any{"minSignups": 1}, []string{f.veteran, f.newcomer}},
+		{"max signups", map[string]any{"maxSignups": 0}, nil},
+		{"served since", map[string]any{"lastServedFrom": "2025-01-01"}, []string{f.veteran}},
+		{"served before", map[string]any{"lastServedTo": "2024-12-31"}, nil},
+		{"near venue", map[string]any{"near": map[string]any{"venueId": f.venueID, "miles": 50}}, []string{f.veteran}},
+	}
+	for _, c := range cases {
+		got := f.filterVolunteers(t, token, c.filter)
+		if len(got) != len(c.want) {
+			t.Errorf("%s: got %d volunteers, want %v: %+v", c.name, len(got), c.want, got)
+			continue
+		}
+		for _, id := range c.want {
+			if _, ok := got[id]; !ok {
+				t.Errorf("%s: volunteer %s missing: %+v", c.name, id, got)
+			}
+		}
+	}
+}
+
+// TestVolunteerFilter_Invalid verifies a distance without an origin is
+// rejected.
+func TestVolunteerFilter_Invalid(t *testing.T) {
+	token := makeAdminToken(t)
+	resp := gqlPost(t, "/graphql/admin", token, qryFilteredVolunteers, map[string]any{
+		"filter": map[string]any{"near": map[string]any{"miles": 10}},
+	})
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
+	}
+}
+
+// TestVolunteerSegment_CRUD verifies a segment is saved with its filter,
+// names are unique ignoring case, and it can be renamed and deleted.
+func TestVolunteerSegment_CRUD(t *testing.T) {
+	token, adminID := makeAdmin(t)
+	name := uniqueCode(t, "Coordinators near Tacoma ")
+	t.Cleanup(func() {
+		testDB.Exec("DELETE FROM volunteer_segments WHERE LOWER(name) LIKE LOWER($1) || '%'", name)
+	})
+
+	filter := map[string]any{
+		"lastName": "Smith",
+		"roles":    []string{"COORDINATOR"},
+		"minHours": 2.5,
+		"near":     map[string]any{"venueId": "1", "miles": 25},
+	}
+	resp := gqlPost(t, "/graphql/admin", token, mutCreateVolunteerSegment, map[string]any{
+		"input": map[string]any{"name": name, "filter": filter},
+	})
+	var created segmentResult
+	unmarshalField(t, resp, "createVolunteerSegment", &created)
+	if !created.Success || created.ID == nil {
+		t.Fatalf("createVolunteerSegment = %+v, errors %v", created, resp.Errors)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, qryVolunteerSegments, nil)
+	var segments []struct {
+		ID          string  `json:"id"`
+		Name        string  `json:"name"`
+		CreatedByID *string `json:"createdById"`
+		Filter      struct {
+			LastName *string  `json:"lastName"`
+			Roles    []string `json:"roles"`
+			MinHours *float64 `json:"minHours"`
+			Near     *struct {
+				VenueID *string `json:"venueId"`
+				Miles   int     `json:"miles"`
+			} `json:"near"`
+		} `json:"filter"`
+	}
+	unmarshalField(t, resp, "volunteerSegments", &segments)
+	found := false
+	for _, s := range segments {
+		if s.ID != *created.ID {
+			continue
+		}
+		found = true
+		if s.Name != name || s.CreatedByID == nil || *s.CreatedByID != strconv.Itoa(adminID) {
+			t.Errorf("segment = %+v, want name %q created by %d", s, name, adminID)
+		}
+		fl := s.Filter
+		if fl.LastName == nil || *fl.LastName != "Smith" || len(fl.Roles) != 1 || fl.Roles[0] != "COORDINATOR" ||
+			fl.MinHours == nil || *fl.MinHours != 2.5 || fl.Near == nil || fl.Near.Miles != 25 {
+			t.Errorf("filter = %+v, want the filter saved", fl)
+		}
+	}
+	if !found {
+		t.Fatalf("segment %s not listed: %+v", *created.ID, segments)
+	}
+
+	// Same name in another case.
+	resp = gqlPost(t, "/graphql/admin", token, mutCreateVolunteerSegment, map[string]any{
+		"input": map[string]any{"name": "  " + strings.ToUpper(name) + "  ", "filter": map[string]any{}},
+	})
+	if code := errorCode(resp); code != "CONFLICT" {
+		t.Errorf("duplicate name: error code = %q, want CONFLICT (errors: %v)", code, resp.Errors)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, mutUpdateVolunteerSegment, map[string]any{
+		"input": map[string]any{"id": *created.ID, "name": name + " renamed"},
+	})
+	var updated segmentResult
+	unmarshalField(t, resp, "updateVolunteerSegment", &updated)
+	if !updated.Success {
+		t.Fatalf("updateVolunteerSegment = %+v, errors %v", updated, resp.Errors)
+	}
+	if !rowExists(t, "SELECT 1 FROM volunteer_segments WHERE id = $1 AND name = $2", *created.ID, name+" renamed") {
+		t.Errorf("segment was not renamed")
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, mutUpdateVolunteerSegment, map[string]any{
+		"input": map[string]any{"id": *created.ID, "filter": map[string]any{"minHours": 5, "maxHours": 1}},
+	})
+	if code := errorCode(resp); code != "VALIDATION" {
+		t.Errorf("invalid filter: error code = %q, want VALIDATION (errors: %v)", code, resp.Errors)
+	}
+
+	resp = gqlPost(t, "/graphql/admin", token, mutDeleteVolunteerSegment, map[string]any{"id": *created.ID})
+	var deleted segmentResult
+	unmarshalField(t, resp, "deleteVolunteerSegment", &deleted)
+	if !deleted.Success {
+		t.Fatalf("deleteVolunteerSegment = %+v, errors %v", deleted, resp.Errors)
+	}
+	resp = gqlPost(t, "/graphql/admin", token, mutDeleteVolunteerSegment, map[string]any{"id": *created.ID})
+	if code := errorCode(resp); code != "NOT_FOUND" {
+		t.Errorf("deleting again: error code = %q, want NOT_FOUND (errors: %v)", code, resp.Errors)
+	}
+}
+
+// TestVolunteerSegment_CoordinatorReadOnly verifies coordinators can list
+// segments but not save them.
+func TestVolunteerSegment_CoordinatorReadOnly(t *testing.T) {
+	email := uniqueEmail(t)
+	id := seedVolunteer(t, email, "Coord", "Test", "COORDINATOR")
+	token := seedSession(t, email, id, "COORDINATOR", "coord-"+email)
+
+	resp := gqlPost(t, "/graphql/admin", token, qryVolunteerSegments, nil)
+	if len(resp.Errors) > 0 {
+		t.Errorf("volunteerSegments: unexpected errors: %v", resp.Errors)
+	}
+	resp = gqlPost(t, "/graphql/admin", token, mutCreateVolunteerSegment, map[string]any{
+		"input": map[string]any{"name": uniqueCode(t, "Coordinator segment "), "filter": map[string]any{}},
+	})
+	if len(resp.Errors) == 0 {
+		t.Errorf("createVolunteerSegment as coordinator: want an error")
+	}
+}
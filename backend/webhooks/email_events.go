@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
+)
+
+// resendEvent is the subset of a Resend webhook event we act on.
+// See https://resend.com/docs/dashboard/webhooks/event-types.
+type resendEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		To     []string `json:"to"`
+		Bounce *struct {
+			Type    string `json:"type"` // "Permanent" or "Transient"
+			SubType string `json:"subType"`
+			Message string `json:"message"`
+		} `json:"bounce"`
+	} `json:"data"`
+}
+
+// EmailEvents handles POST /webhooks/email-events: delivery events from the
+// email provider, signed with RESEND_WEBHOOK_SECRET.
+//
+// Hard bounces and spam complaints put the recipient on the suppression list.
+// Soft (transient) bounces and every other event type are acknowledged and
+// ignored.
+func EmailEvents(suppressions *services.SuppressionList, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := readSvixSignedBody(r, secret)
+		if err != nil {
+			writeJSONError(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var event resendEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			writeJSONError(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		var reason models.EmailSuppressionReason
+		var detail string
+		switch event.Type {
+		case "email.bounced":
+			if event.Data.Bounce != nil {
+				if strings.EqualFold(event.Data.Bounce.Type, "Transient") {
+					break
+				}
+				detail = strings.TrimSpace(event.Data.Bounce.SubType + ": " + event.Data.Bounce.Message)
+			}
+			reason = models.EmailSuppressionBounce
+		case "email.complained":
+			reason = models.EmailSuppressionComplaint
+		}
+
+		if reason != "" {
+			for _, to := range event.Data.To {
+				if err := suppressions.Add(r.Context(), to, reason, detail); err != nil {
+					// 500 so the provider retries the event later.
+					log.Printf("email event %s for %s: %v", event.Type, to, err)
+					writeJSONError(w, "could not record event", http.StatusInternalServerError)
+					return
+				}
+				log.Printf("email event %s: suppressed %s", event.Type, to)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"received": true})
+	})
+}
@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"volunteer-scheduler/models"
+	"volunteer-scheduler/services"
+)
+
+// inboundEmailPayload is the provider-neutral shape of a parsed inbound
+// message. Most providers can be configured (or fronted by a small relay) to
+// post this.
+type inboundEmailPayload struct {
+	MessageID string   `json:"messageId"`
+	From      string   `json:"from"`
+	To        []string `json:"to"`
+	Subject   string   `json:"subject"`
+	Text      string   `json:"text"`
+}
+
+// InboundEmail handles POST /webhooks/inbound-email. Requests must be signed
+// with INBOUND_WEBHOOK_SECRET (see Sign).
+//
+// Messages that can't be matched to a feedback thread are acknowledged with
+// 200 and accepted=false so the provider does not keep retrying them.
+func InboundEmail(feedbackService *services.FeedbackService, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeJSONError(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := readSignedBody(r, secret)
+		if err != nil {
+			writeJSONError(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		var payload inboundEmailPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeJSONError(w, "invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		result, err := feedbackService.AddEmailReplyNote(r.Context(), services.InboundEmail{
+			MessageID: payload.MessageID,
+			From:      payload.From,
+			To:        payload.To,
+			Subject:   payload.Subject,
+			Text:      payload.Text,
+		})
+		var rejected *models.Error
+		if errors.As(err, &rejected) && rejected.Code != models.ErrorCodeInternal {
+			log.Printf("inbound email from %s ignored: %s", payload.From, rejected.Message)
+			result = &models.MutationResult{Success: false, Message: &rejected.Message}
+		} else if err != nil {
+			log.Printf("inbound email from %s: %v", payload.From, err)
+			writeJSONError(w, "could not store reply", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"accepted": result.Success,
+			"message":  result.Message,
+		})
+	})
+}
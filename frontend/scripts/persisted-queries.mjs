@@ -0,0 +1,85 @@
+// Builds the persisted query manifest the backend loads from
+// PERSISTED_QUERIES_PATH: a JSON object mapping the hex SHA-256 hash of each
+// GraphQL operation the frontend sends to its text.
+//
+// Operations are found as template literals in src/ that start with query,
+// mutation or subscription. The text is hashed exactly as it will be sent,
+// so rebuild the manifest whenever a query changes.
+//
+// The build fails if src/ holds a GraphQL document the manifest would miss,
+// since the backend would refuse it: a literal with a ${...} substitution
+// (write one literal per variant), a quoted string, or a shorthand "{ ... }"
+// query (name it "query Name { ... }").
+//
+// Usage: node scripts/persisted-queries.mjs [output]   (default: stdout)
+//        node scripts/persisted-queries.mjs --check    (run before next build)
+
+import { createHash } from "node:crypto";
+import { readdirSync, readFileSync, writeFileSync } from "node:fs";
+import { join } from "node:path";
+
+const SRC = new URL("../src/", import.meta.url).pathname;
+const SOURCE_FILE = /\.(js|jsx|ts|tsx|mjs)$/;
+const TEMPLATE_LITERAL = /`((?:[^`\\]|\\.)*)`/gs;
+const OPERATION = /^\s*(query|mutation|subscription)\b/;
+// A shorthand query: "{ field {" or "{ field(".
+const SHORTHAND = /^\s*\{\s*[A-Za-z_]\w*\s*[{(]/;
+// A GraphQL document in a single- or double-quoted string.
+const QUOTED_DOCUMENT =
+  /(["'])(\s*(?:(?:query|mutation|subscription)\b[^"'\n]*\{|\{\s*[A-Za-z_]\w*\s*[{(])[^"'\n]*)\1/g;
+
+function sourceFiles(dir) {
+  return readdirSync(dir, { withFileTypes: true }).flatMap((entry) => {
+    const path = join(dir, entry.name);
+    if (entry.isDirectory()) return sourceFiles(path);
+    return SOURCE_FILE.test(entry.name) ? [path] : [];
+  });
+}
+
+const manifest = {};
+const missed = [];
+
+for (const file of sourceFiles(SRC)) {
+  const source = readFileSync(file, "utf8");
+  for (const [, raw] of source.matchAll(TEMPLATE_LITERAL)) {
+    if (SHORTHAND.test(raw)) {
+      missed.push(`${file}: shorthand query ${firstLine(raw)}`);
+      continue;
+    }
+    if (!OPERATION.test(raw)) continue;
+    if (raw.includes("${")) {
+      missed.push(`${file}: substitution in ${firstLine(raw)}`);
+      continue;
+    }
+    // Evaluate the literal so escape sequences match the string sent.
+    const query = new Function(`return \`${raw}\`;`)();
+    manifest[createHash("sha256").update(query).digest("hex")] = query;
+  }
+  // Template literals were matched first, so strip them before looking for
+  // quoted documents (a quote inside a query is not a string).
+  for (const [, , doc] of source.replace(TEMPLATE_LITERAL, "``").matchAll(QUOTED_DOCUMENT)) {
+    missed.push(`${file}: quoted string ${firstLine(doc)}`);
+  }
+}
+
+if (missed.length > 0) {
+  console.error("GraphQL documents that cannot be persisted:");
+  for (const m of missed) console.error(`  ${m}`);
+  process.exit(1);
+}
+
+function firstLine(text) {
+  return text.trim().split("\n")[0];
+}
+
+const sorted = Object.fromEntries(Object.entries(manifest).sort());
+const json = JSON.stringify(sorted, null, 2) + "\n";
+const output = process.argv[2];
+if (output === "--check") {
+  console.error(`All ${Object.keys(sorted).length} operations can be persisted.`);
+} else if (output) {
+  writeFileSync(output, json);
+  console.error(`Wrote ${Object.keys(sorted).length} operations to ${output}`);
+} else {
+  process.stdout.write(json);
+}
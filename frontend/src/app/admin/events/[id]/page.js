@@ -27,7 +27,7 @@ const ADMIN_EVENT_DETAIL = `
       fundingEntity { id name }
       eventDates { id startDateTime endDateTime }
       serviceTypes
-      recurrenceOrder
+      recurrenceOrder status publishAt
       recurrenceGroup { groupId pattern maxOccurrences weekdayOrdinal }
     }
     opportunitiesForEvent(eventId: $eventId) {
@@ -50,6 +50,16 @@ const ALL_VOLUNTEERS_FOR_ROSTER = `
   }
 `;
 
+// Everyone who can be assigned, for the assign dropdown. Coordinators only see
+// their own rosters through volunteers, so this is how they find anyone new.
+const ASSIGNABLE_VOLUNTEERS = `
+  query AssignableVolunteers {
+    assignableVolunteers {
+      id firstName lastName
+    }
+  }
+`;
+
 const VOLUNTEER_SHIFTS_FOR_ROSTER = `
   query VolShifts($volunteerId: ID!, $filter: ShiftTimeFilter!) {
     volunteerShifts(volunteerId: $volunteerId, filter: $filter) {
@@ -73,6 +83,8 @@ const UPCOMING_SHIFTS_FOR_VOL = `
 
 const UPDATE_EVENT      = `mutation UpdateEvent($event: UpdateEventInput!) { updateEvent(event: $event) { success message } }`;
 const DELETE_EVENT      = `mutation DeleteEvent($eventId: ID!, $scope: RecurrenceUpdateScope) { deleteEvent(eventId: $eventId, scope: $scope) { success message } }`;
+const PUBLISH_EVENT     = `mutation PublishEvent($eventId: ID!) { publishEvent(eventId: $eventId) { success message } }`;
+const CANCEL_EVENT      = `mutation CancelEvent($eventId: ID!) { cancelEvent(eventId: $eventId) { success message } }`;
 const CREATE_EVENT_DATE = `mutation CreateEventDate($newDate: AddEventDateInput!) { createEventDate(newDate: $newDate) { success message id } }`;
 const UPDATE_EVENT_DATE = `mutation UpdateEventDate($date: UpdateEventDateInput!) { updateEventDate(date: $date) { success message } }`;
 const DELETE_EVENT_DATE = `mutation DeleteEventDate($eventDateId: ID!) { deleteEventDate(eventDateId: $eventDateId) { success message } }`;
@@ -243,6 +255,7 @@ function formatDisplay(utcString, ianaZone) {
 }
 
 const FORMAT_LABEL = { VIRTUAL: "Virtual", IN_PERSON: "In Person", HYBRID: "Hybrid" };
+const STATUS_LABEL = { DRAFT: "Draft", PUBLISHED: "Published", CANCELLED: "Cancelled", COMPLETED: "Completed" };
 
 /**
  * Return the earliest event date as { date: "YYYY-MM-DD", time: "HH:MM" }
@@ -526,10 +539,12 @@ export default function AdminEventDetailPage() {
   const loadRoster = useCallback((bound, shiftIdSet) => {
     setRosterLoading(true);
     setRosterMap(null);
+    bound(ASSIGNABLE_VOLUNTEERS, null)
+      .then((res) => setAllVolunteers(res.data?.assignableVolunteers ?? []))
+      .catch(() => setAllVolunteers([]));
     bound(ALL_VOLUNTEERS_FOR_ROSTER, null)
       .then(async (res) => {
         const vols = res.data?.volunteers ?? [];
-        setAllVolunteers(vols);
         // Fetch shifts for every volunteer in parallel, then build shiftId → vol[] map.
         const entries = await Promise.all(
           vols.map((v) =>
@@ -617,7 +632,7 @@ export default function AdminEventDetailPage() {
 
   useEffect(() => {
     if (!isAuthenticated()) { router.replace("/login"); return; }
-    if (!hasAuthRole(Roles.ADMINISTRATOR)) { router.replace("/events"); return; }
+    if (!hasAuthRole(Roles.ADMINISTRATOR) && !hasAuthRole(Roles.COORDINATOR)) { router.replace("/events"); return; }
     const bound = adminGql;
     setGql(() => bound);
     setUserName(getAuthName() ?? "");
@@ -634,14 +649,15 @@ export default function AdminEventDetailPage() {
     window.scrollTo({ top: 0, behavior: "smooth" });
   };
 
-  /** Translate raw DB/server error messages into user-friendly text. */
-  const friendlyError = (msg) => {
-    if (!msg) return "Operation failed.";
-    if (msg.includes("check constraint") && msg.includes("shifts"))
-      return "Shift end time must be after its start time.";
-    if (msg.includes("null value") && msg.includes("max_volunteers"))
-      return "Max Volunteers is required.";
-    return msg;
+  /**
+   * The text to show for a failed mutation. Server messages are safe to show
+   * as they are; an internal error comes with a reference to quote instead.
+   */
+  const friendlyError = (result, errors) => {
+    const err = errors?.[0];
+    if (err?.extensions?.code === "INTERNAL")
+      return `Something went wrong (reference ${err.extensions.correlationId}).`;
+    return result?.message ?? err?.message ?? "Operation failed.";
   };
 
   /**
@@ -658,7 +674,7 @@ export default function AdminEventDetailPage() {
       const key = Object.keys(res.data ?? {})[0];
       const result = res.data?.[key];
       if (res.errors || !result?.success) {
-        const msg = friendlyError(result?.message ?? res.errors?.[0]?.message);
+        const msg = friendlyError(result, res.errors);
         if (onError) onError(msg); else showMsg("error", msg);
         return null;
       }
@@ -742,6 +758,16 @@ export default function AdminEventDetailPage() {
     );
   };
 
+  /* --- Event status --- */
+  const handlePublishEvent = () => {
+    mutate(PUBLISH_EVENT, { eventId }, "Event published.");
+  };
+
+  const handleCancelEvent = () => {
+    if (!window.confirm(`Cancel "${event?.name}"? Signed-up volunteers will be notified and no new signups will be taken.`)) return;
+    mutate(CANCEL_EVENT, { eventId }, "Event cancelled.");
+  };
+
   /* --- Event Dates --- */
   const openEditDate = (date) => {
     setEditingDateId(date.id);
@@ -1054,6 +1080,24 @@ export default function AdminEventDetailPage() {
           <div className={styles.sectionHeader}>
             <div className={styles.sectionTitle}>Event Details</div>
             <div className={styles.oppActions}>
+              {event.status === "DRAFT" && (
+                <button
+                  className={`${styles.iconBtn} ${styles.iconBtnEdit}`}
+                  aria-label="Publish event"
+                  title="Publish event"
+                  onClick={handlePublishEvent}
+                  disabled={busy}
+                >✔</button>
+              )}
+              {(event.status === "DRAFT" || event.status === "PUBLISHED") && (
+                <button
+                  className={`${styles.iconBtn} ${styles.iconBtnDelete}`}
+                  aria-label="Cancel event"
+                  title="Cancel event"
+                  onClick={handleCancelEvent}
+                  disabled={busy}
+                >⊘</button>
+              )}
               <button
                 className={`${styles.iconBtn} ${styles.iconBtnEdit}`}
                 aria-label="Edit event"
@@ -1083,6 +1127,12 @@ export default function AdminEventDetailPage() {
                 </>
               )}
 
+              <span className={styles.metaLabel}>Status</span>
+              <span className={styles.metaValue}>
+                {STATUS_LABEL[event.status] ?? event.status}
+                {event.status === "DRAFT" && event.publishAt && ` — publishes ${formatDisplay(event.publishAt, tz)}`}
+              </span>
+
               <span className={styles.metaLabel}>Format</span>
               <span className={styles.metaValue}>{FORMAT_LABEL[event.eventType] ?? event.eventType}</span>
 
@@ -515,7 +515,7 @@ export default function AddEventPage() {
   /* Auth check + load data */
   useEffect(() => {
     if (!isAuthenticated()) { router.replace("/login"); return; }
-    if (!hasAuthRole(Roles.ADMINISTRATOR)) { router.replace("/events"); return; }
+    if (!hasAuthRole(Roles.ADMINISTRATOR) && !hasAuthRole(Roles.COORDINATOR)) { router.replace("/events"); return; }
 
     const boundGql = adminGql;
     setGql(() => boundGql);
@@ -223,7 +223,7 @@ export default function AdminVolunteersPage() {
 
   useEffect(() => {
     if (!isAuthenticated()) { router.replace("/login"); return; }
-    if (!hasAuthRole(Roles.ADMINISTRATOR)) { router.replace("/events"); return; }
+    if (!hasAuthRole(Roles.ADMINISTRATOR) && !hasAuthRole(Roles.COORDINATOR)) { router.replace("/events"); return; }
     const bound = adminGql;
     setGql(() => bound);
     setUserName(getAuthName() ?? "");
@@ -3,27 +3,18 @@
 import { useEffect, useState, Suspense } from "react";
 import { useRouter, useSearchParams } from "next/navigation";
 import Link from "next/link";
-import { authGql, volunteerGql, setAuthInfo } from "../../lib/api";
+import { authGql, completeSignIn } from "../../lib/api";
 import styles from "./magic-link.module.css";
 
 /* ----- GraphQL operations ----- */
 
 const CONSUME_MAGIC_LINK = `
-  mutation ConsumeMagicLink($token: String!) {
-    consumeMagicLink(token: $token) {
+  mutation ConsumeMagicLink($token: String!, $confirmDevice: Boolean) {
+    consumeMagicLink(token: $token, confirmDevice: $confirmDevice) {
       success
       message
       email
-    }
-  }
-`;
-
-const VOLUNTEER_PROFILE = `
-  query {
-    ownProfile {
-      firstName
-      lastName
-      roles
+      confirmationRequired
     }
   }
 `;
@@ -33,8 +24,9 @@ const VOLUNTEER_PROFILE = `
 function MagicLinkContent() {
   const router = useRouter();
   const searchParams = useSearchParams();
-  const [status, setStatus] = useState("processing"); // processing | success | error
+  const [status, setStatus] = useState("processing"); // processing | confirm | success | error
   const [errorMsg, setErrorMsg] = useState("");
+  const [confirmMsg, setConfirmMsg] = useState("");
 
   useEffect(() => {
     const token = searchParams.get("token");
@@ -48,10 +40,19 @@ function MagicLinkContent() {
     consumeToken(token);
   }, [searchParams]);
 
-  const consumeToken = async (token) => {
+  const consumeToken = async (token, confirmDevice = false) => {
     try {
-      const result = await authGql(CONSUME_MAGIC_LINK, { token });
-      const { success, message, email } = result.data.consumeMagicLink;
+      const result = await authGql(CONSUME_MAGIC_LINK, { token, confirmDevice });
+      const { success, message, email, confirmationRequired } =
+        result.data.consumeMagicLink;
+
+      // The link was requested from another browser; the volunteer has to
+      // say this device is theirs before it is used.
+      if (confirmationRequired) {
+        setStatus("confirm");
+        setConfirmMsg(message);
+        return;
+      }
 
       if (!success) {
         setStatus("error");
@@ -59,23 +60,8 @@ function MagicLinkContent() {
         return;
       }
 
-      // The server has set an HttpOnly session cookie. Now fetch the volunteer's
-      // profile — the cookie is sent automatically via credentials: 'include'.
-      let roles = null;
-      let name = null;
-      try {
-        const profileResult = await volunteerGql(VOLUNTEER_PROFILE);
-        const profile = profileResult.data?.ownProfile;
-        roles = profile?.roles ?? null;
-        if (profile?.firstName || profile?.lastName) {
-          name = `${profile.firstName ?? ""} ${profile.lastName ?? ""}`.trim();
-        }
-      } catch {
-        // Non-fatal — proceed without profile; the events page will still load.
-      }
-
-      // Save only display values — the session token lives in the HttpOnly cookie.
-      setAuthInfo(email, roles, name);
+      // The server has set an HttpOnly session cookie; save the display values.
+      await completeSignIn(email);
       setStatus("success");
       setTimeout(() => router.push("/"), 2000);
     } catch {
@@ -96,6 +82,35 @@ function MagicLinkContent() {
     );
   }
 
+  if (status === "confirm") {
+    return (
+      <div className={styles.card}>
+        <div className={`${styles.iconWrapper} ${styles.iconLoading}`}>
+          <span className={styles.questionmark}>?</span>
+        </div>
+        <h1 className={styles.title}>Sign in on this device?</h1>
+        <p className={styles.message}>
+          {confirmMsg ||
+            "This link was requested from a different browser."}{" "}
+          Only continue if you asked for this link yourself.
+        </p>
+        <button
+          type="button"
+          className={styles.confirmButton}
+          onClick={() => {
+            setStatus("processing");
+            consumeToken(searchParams.get("token"), true);
+          }}
+        >
+          Yes, sign me in here
+        </button>
+        <Link href="/login" className={styles.backLink}>
+          Request a new sign-in link instead
+        </Link>
+      </div>
+    );
+  }
+
   if (status === "success") {
     return (
       <div className={styles.card}>
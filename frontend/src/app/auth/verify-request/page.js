@@ -0,0 +1,110 @@
+"use client";
+
+import { useEffect, useState, Suspense } from "react";
+import { useSearchParams } from "next/navigation";
+import Link from "next/link";
+import { authGql } from "../../lib/api";
+import styles from "../magic-link/magic-link.module.css";
+
+/* ----- GraphQL operations ----- */
+
+const VERIFY_ACCOUNT_REQUEST = `
+  mutation VerifyAccountRequest($token: String!) {
+    verifyAccountRequest(token: $token) {
+      success
+      message
+    }
+  }
+`;
+
+/* ----- Inner component — uses useSearchParams, must be inside Suspense ----- */
+
+function VerifyRequestContent() {
+  const searchParams = useSearchParams();
+  const [status, setStatus] = useState("processing"); // processing | success | error
+  const [message, setMessage] = useState("");
+
+  useEffect(() => {
+    const token = searchParams.get("token");
+    if (!token) {
+      setStatus("error");
+      setMessage("No token was found in this link. Please request an account again.");
+      return;
+    }
+    verify(token);
+  }, [searchParams]);
+
+  const verify = async (token) => {
+    try {
+      const result = await authGql(VERIFY_ACCOUNT_REQUEST, { token });
+      const { success, message } = result.data.verifyAccountRequest;
+      setStatus(success ? "success" : "error");
+      setMessage(message);
+    } catch {
+      setStatus("error");
+      setMessage("Unable to reach the server. Please try again.");
+    }
+  };
+
+  if (status === "processing") {
+    return (
+      <div className={styles.card}>
+        <div className={`${styles.iconWrapper} ${styles.iconLoading}`}>
+          <div className={styles.spinner} />
+        </div>
+        <h1 className={styles.title}>Confirming your email&hellip;</h1>
+        <p className={styles.message}>Please wait a moment.</p>
+      </div>
+    );
+  }
+
+  if (status === "success") {
+    return (
+      <div className={styles.card}>
+        <div className={`${styles.iconWrapper} ${styles.iconSuccess}`}>
+          <span className={styles.checkmark}>✓</span>
+        </div>
+        <h1 className={styles.title}>Email confirmed</h1>
+        <p className={styles.message}>{message}</p>
+      </div>
+    );
+  }
+
+  return (
+    <div className={styles.card}>
+      <div className={`${styles.iconWrapper} ${styles.iconError}`}>
+        <span className={styles.crossmark}>✕</span>
+      </div>
+      <h1 className={styles.title}>Confirmation failed</h1>
+      <p className={styles.message}>{message}</p>
+      <Link href="/login" className={styles.backLink}>
+        Back to sign in
+      </Link>
+    </div>
+  );
+}
+
+/* ----- Loading fallback while searchParams resolves ----- */
+
+function LoadingCard() {
+  return (
+    <div className={styles.card}>
+      <div className={`${styles.iconWrapper} ${styles.iconLoading}`}>
+        <div className={styles.spinner} />
+      </div>
+      <h1 className={styles.title}>Loading&hellip;</h1>
+    </div>
+  );
+}
+
+/* ----- Page export ----- */
+
+export default function VerifyRequestPage() {
+  return (
+    <div className={styles.page}>
+      <Suspense fallback={<LoadingCard />}>
+        <VerifyRequestContent />
+      </Suspense>
+    </div>
+  );
+}
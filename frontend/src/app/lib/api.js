@@ -21,6 +21,7 @@
 export const Roles = Object.freeze({
   VOLUNTEER:     "VOLUNTEER",
   ADMINISTRATOR: "ADMINISTRATOR",
+  COORDINATOR:   "COORDINATOR",
 });
 
 // All GraphQL endpoints are proxied through the Next.js server via rewrites
@@ -44,7 +45,11 @@ async function gqlFetch(url, query, variables) {
   const response = await fetch(url, {
     method: "POST",
     credentials: "include", // send the HttpOnly session cookie on every request
-    headers: { "Content-Type": "application/json" },
+    headers: {
+      "Content-Type": "application/json",
+      // Required by the backend's CSRF check on cookie-authenticated requests.
+      "X-Requested-With": "fetch",
+    },
     body: JSON.stringify({ query, variables }),
   });
 
@@ -99,6 +104,7 @@ export async function volunteerGqlUpload(query, variables, file) {
     method: "POST",
     credentials: "include", // send the HttpOnly session cookie
     // Do NOT set Content-Type — the browser sets it with the correct boundary.
+    headers: { "X-Requested-With": "fetch" },
     body: form,
   });
 
@@ -108,6 +114,23 @@ export async function volunteerGqlUpload(query, variables, file) {
   return response.json();
 }
 
+// One literal per endpoint so both appear in the persisted query manifest.
+const GET_ATTACHMENT = `query GetAttachment($id: Int!) {
+      attachment(attachmentId: $id) {
+        filename
+        mimeType
+        data
+      }
+    }`;
+
+const GET_OWN_ATTACHMENT = `query GetAttachment($id: Int!) {
+      ownAttachment(attachmentId: $id) {
+        filename
+        mimeType
+        data
+      }
+    }`;
+
 /**
  * Fetch one attachment's binary data (returned as Base64 by the server) and
  * trigger a browser file-download. Pass useAdminEndpoint=true on admin pages.
@@ -117,13 +140,7 @@ export async function downloadAttachment(attachmentId, useAdminEndpoint = false)
   const queryName = useAdminEndpoint ? "attachment" : "ownAttachment";
   const res = await gqlFetch(
     url,
-    `query GetAttachment($id: Int!) {
-      ${queryName}(attachmentId: $id) {
-        filename
-        mimeType
-        data
-      }
-    }`,
+    useAdminEndpoint ? GET_ATTACHMENT : GET_OWN_ATTACHMENT,
     { id: attachmentId }
   );
 
@@ -158,6 +175,38 @@ export function setAuthInfo(email, roles, name) {
   if (name)  localStorage.setItem("authName", name);
 }
 
+const OWN_PROFILE_FOR_SIGN_IN = `
+  query {
+    ownProfile {
+      firstName
+      lastName
+      roles
+    }
+  }
+`;
+
+/**
+ * Finish signing in once the server has set the session cookie (magic link
+ * or sign-in code): look up the volunteer's display values and save them
+ * with setAuthInfo. A failed profile lookup is not fatal — the pages still
+ * load, just without the name and roles until the next sign-in.
+ */
+export async function completeSignIn(email) {
+  let roles = null;
+  let name = null;
+  try {
+    const profileResult = await volunteerGql(OWN_PROFILE_FOR_SIGN_IN);
+    const profile = profileResult.data?.ownProfile;
+    roles = profile?.roles ?? null;
+    if (profile?.firstName || profile?.lastName) {
+      name = `${profile.firstName ?? ""} ${profile.lastName ?? ""}`.trim();
+    }
+  } catch {
+    // Non-fatal — proceed without profile.
+  }
+  setAuthInfo(email, roles, name);
+}
+
 /**
  * Returns true when the user has an active session.
  * Checks the sessionActive flag — the session token lives in an HttpOnly cookie
@@ -1,8 +1,8 @@
 "use client";
 
 import { useState, Suspense } from "react";
-import { useSearchParams } from "next/navigation";
-import { authGql } from "../lib/api";
+import { useRouter, useSearchParams } from "next/navigation";
+import { authGql, completeSignIn } from "../lib/api";
 import styles from "./login.module.css";
 
 /* ----- GraphQL mutations ----- */
@@ -16,6 +16,17 @@ const REQUEST_MAGIC_LINK = `
   }
 `;
 
+const CONSUME_SIGN_IN_CODE = `
+  mutation ConsumeSignInCode($email: String!, $code: String!) {
+    consumeSignInCode(email: $email, code: $code) {
+      success
+      message
+      email
+      code
+    }
+  }
+`;
+
 const REQUEST_ACCOUNT = `
   mutation RequestAccount($email: String!, $firstName: String!, $lastName: String!) {
     requestAccount(email: $email, firstName: $firstName, lastName: $lastName) {
@@ -28,10 +39,13 @@ const REQUEST_ACCOUNT = `
 /* ----- Stages ----- */
 // enterEmail → linkSent (always, regardless of whether email exists)
 //            → (server error) enterEmail with errorMsg
+// linkSent   → signed in with the 6-digit code from the email, for mail apps
+//              whose built-in browser cannot keep the session
 // enterEmail → requestForm → requestSent
 
 // Inner component — reads search params (requires Suspense boundary below).
 function LoginPageContent() {
+  const router = useRouter();
   const searchParams = useSearchParams();
   const sessionExpired = searchParams.get("expired") === "1";
 
@@ -41,6 +55,7 @@ function LoginPageContent() {
   const [email, setEmail] = useState("");
   const [firstName, setFirstName] = useState("");
   const [lastName, setLastName] = useState("");
+  const [code, setCode] = useState("");
 
   // UI state
   const [loading, setLoading] = useState(false);
@@ -52,6 +67,7 @@ function LoginPageContent() {
     setEmail("");
     setFirstName("");
     setLastName("");
+    setCode("");
     setErrorMsg("");
   };
 
@@ -71,6 +87,31 @@ function LoginPageContent() {
     }
   };
 
+  /* Sign in with the code from the email instead of the link */
+  const handleCodeSubmit = async (e) => {
+    e.preventDefault();
+    setErrorMsg("");
+    setLoading(true);
+    try {
+      const result = await authGql(CONSUME_SIGN_IN_CODE, { email, code: code.trim() });
+      const res = result.data?.consumeSignInCode;
+      if (!res?.success) {
+        setErrorMsg(
+          res?.code === "UNAUTHENTICATED"
+            ? "That code is incorrect or has expired."
+            : res?.message || "Sign-in failed. Please try again."
+        );
+        return;
+      }
+      await completeSignIn(res.email ?? email);
+      router.push("/");
+    } catch {
+      setErrorMsg("Unable to reach the server. Please try again.");
+    } finally {
+      setLoading(false);
+    }
+  };
+
   /* Submit the account request form */
   const handleRequestAccount = async (e) => {
     e.preventDefault();
@@ -122,7 +163,15 @@ function LoginPageContent() {
         )}
 
         {stage === "linkSent" && (
-          <LinkSentStage email={email} onReset={reset} />
+          <LinkSentStage
+            email={email}
+            code={code}
+            setCode={setCode}
+            loading={loading}
+            errorMsg={errorMsg}
+            onSubmit={handleCodeSubmit}
+            onReset={reset}
+          />
         )}
 
         {stage === "requestForm" && (
@@ -200,7 +249,7 @@ function EnterEmailStage({ email, setEmail, loading, errorMsg, onSubmit, onReque
   );
 }
 
-function LinkSentStage({ email, onReset }) {
+function LinkSentStage({ email, code, setCode, loading, errorMsg, onSubmit, onReset }) {
   return (
     <>
       <div className={`${styles.statusIcon} ${styles.statusIconSuccess}`}>
@@ -216,7 +265,30 @@ function LinkSentStage({ email, onReset }) {
         The link expires in 15 minutes. Check your spam folder if you
         don&apos;t see it.
       </p>
-      <button className={styles.linkButton} onClick={onReset}>
+      <form className={styles.form} onSubmit={onSubmit}>
+        <div className={styles.field}>
+          <label className={styles.label} htmlFor="code">
+            Or enter the 6-digit code from the email
+          </label>
+          <input
+            id="code"
+            className={styles.input}
+            type="text"
+            inputMode="numeric"
+            pattern="[0-9]{6}"
+            maxLength={6}
+            value={code}
+            onChange={(e) => setCode(e.target.value.replace(/\D/g, ""))}
+            autoComplete="one-time-code"
+            required
+          />
+        </div>
+        {errorMsg && <p className={styles.errorMessage}>{errorMsg}</p>}
+        <button className={styles.buttonPrimary} type="submit" disabled={loading}>
+          {loading ? "Signing in…" : "Sign In"}
+        </button>
+      </form>
+      <button className={styles.linkButton} onClick={onReset} style={{ marginTop: "0.75rem" }}>
         Use a different email
       </button>
     </>
@@ -309,10 +381,12 @@ function RequestSentStage({ email, onReset }) {
       <div className={`${styles.statusIcon} ${styles.statusIconSuccess}`}>
         ✓
       </div>
-      <h1 className={styles.cardTitle}>Request Submitted</h1>
+      <h1 className={styles.cardTitle}>Check Your Email</h1>
       <p className={styles.cardBody}>
-        Thank you! An administrator will review your request and be in touch
-        at <span className={styles.highlight}>{email}</span>.
+        Thank you! We sent a confirmation link to{" "}
+        <span className={styles.highlight}>{email}</span>. Once you confirm
+        your address, an administrator will review your request and be in
+        touch.
       </p>
       <button className={styles.linkButton} onClick={onReset}>
         Back to sign in
@@ -42,6 +42,21 @@ const UPDATE_PROFILE = `
   }
 `;
 
+const GET_DATA_EXPORT = `
+  query {
+    ownDataExport
+  }
+`;
+
+const REQUEST_ERASURE = `
+  mutation RequestErasure($reason: String) {
+    requestErasure(reason: $reason) {
+      success
+      message
+    }
+  }
+`;
+
 /* =========================================================
    Page
    ========================================================= */
@@ -61,6 +76,9 @@ export default function ProfilePage() {
   const [loading, setLoading]   = useState(true);
   const [saving, setSaving]     = useState(false);
   const [actionMsg, setActionMsg] = useState(null); // { type: "success"|"error", text }
+  const [exporting, setExporting] = useState(false);
+  const [erasureReason, setErasureReason] = useState("");
+  const [requestingErasure, setRequestingErasure] = useState(false);
 
   /* ----- Auth + load ----- */
   useEffect(() => {
@@ -140,6 +158,55 @@ export default function ProfilePage() {
     }
   };
 
+  /* ----- Personal data ----- */
+  const handleExport = async () => {
+    setExporting(true);
+    setActionMsg(null);
+    try {
+      const res = await gql(GET_DATA_EXPORT, null);
+      if (res.errors || !res.data?.ownDataExport) {
+        setActionMsg({ type: "error", text: res.errors?.[0]?.message ?? "Export failed." });
+        return;
+      }
+      const blob = new Blob([res.data.ownDataExport], { type: "application/json" });
+      const url = URL.createObjectURL(blob);
+      const a = document.createElement("a");
+      a.href = url;
+      a.download = "my-volunteer-data.json";
+      document.body.appendChild(a);
+      a.click();
+      a.remove();
+      URL.revokeObjectURL(url);
+    } catch {
+      setActionMsg({ type: "error", text: "Unable to reach the server. Please try again." });
+    } finally {
+      setExporting(false);
+    }
+  };
+
+  const handleRequestErasure = async () => {
+    if (!window.confirm(
+      "Ask an administrator to erase your personal data? Once approved, your account is closed and cannot be restored."
+    )) return;
+
+    setRequestingErasure(true);
+    setActionMsg(null);
+    try {
+      const res = await gql(REQUEST_ERASURE, { reason: erasureReason.trim() || null });
+      const result = res.data?.requestErasure;
+      if (res.errors || !result?.success) {
+        setActionMsg({ type: "error", text: result?.message ?? res.errors?.[0]?.message ?? "Request failed." });
+        return;
+      }
+      setErasureReason("");
+      setActionMsg({ type: "success", text: result.message });
+    } catch {
+      setActionMsg({ type: "error", text: "Unable to reach the server. Please try again." });
+    } finally {
+      setRequestingErasure(false);
+    }
+  };
+
   const handleSignOut = async () => { await signOut(); router.replace("/login"); };
 
   if (!gql) return null;
@@ -274,6 +341,43 @@ export default function ProfilePage() {
             </div>
           </form>
         )}
+
+        {!loading && (
+          <div className={`${styles.card} ${styles.dataCard}`}>
+            <h2 className={styles.sectionTitle}>Your Data</h2>
+            <p className={styles.fieldHint}>
+              Download everything we hold about you: your profile, shift history, feedback, notes and attachments.
+            </p>
+            <div className={styles.formActions}>
+              <button className={styles.btnSecondary} type="button" onClick={handleExport} disabled={exporting}>
+                {exporting ? "Preparing..." : "Download My Data"}
+              </button>
+            </div>
+
+            <div className={styles.field}>
+              <label className={styles.label} htmlFor="erasureReason">
+                Ask to be forgotten
+              </label>
+              <textarea
+                id="erasureReason"
+                className={styles.input}
+                rows={3}
+                placeholder="Reason (optional)"
+                value={erasureReason}
+                onChange={(e) => setErasureReason(e.target.value)}
+              />
+              <span className={styles.fieldHint}>
+                An administrator reviews each request. Your name and contact details are then erased and your
+                account closed; the hours you volunteered stay in our records anonymously.
+              </span>
+            </div>
+            <div className={styles.formActions}>
+              <button className={styles.btnDanger} type="button" onClick={handleRequestErasure} disabled={requestingErasure}>
+                {requestingErasure ? "Sending..." : "Request Erasure"}
+              </button>
+            </div>
+          </div>
+        )}
       </div>
 
       <FeedbackButton open={feedbackOpen} onClose={() => setFeedbackOpen(false)} />
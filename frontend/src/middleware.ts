@@ -5,7 +5,9 @@
  * cookie, asks the backend for the volunteer's role, and redirects if:
  *   - No session cookie present  → /login
  *   - Session invalid/expired    → /login
- *   - Role is not ADMINISTRATOR  → /events
+ *   - Role is not ADMINISTRATOR  → /events (COORDINATOR may use the
+ *     event and volunteer sections; the backend limits them to their
+ *     funding entities)
  *   - Backend unreachable        → /login  (fail secure)
  *
  * Because this runs before the page renders, a volunteer who manually sets
@@ -23,6 +25,17 @@ import { NextResponse } from "next/server";
 import type { NextRequest } from "next/server";
 
 const ROLE_ADMINISTRATOR = "ADMINISTRATOR";
+const ROLE_COORDINATOR = "COORDINATOR";
+
+// Admin sections open to coordinators as well as administrators.
+const COORDINATOR_PATHS = ["/admin/events", "/admin/volunteers"];
+
+function coordinatorMayOpen(pathname: string): boolean {
+  return COORDINATOR_PATHS.some((p) => pathname === p || pathname.startsWith(p + "/"));
+}
+
+// Named so scripts/persisted-queries.mjs lists it in the manifest.
+const ADMIN_GUARD = `query AdminGuard { ownProfile { roles } }`;
 
 const VOLUNTEER_API_URL =
   process.env.GRAPHQL_VOLUNTEER_URL ||
@@ -42,8 +55,9 @@ export async function middleware(request: NextRequest) {
       headers: {
         "Content-Type": "application/json",
         Cookie: `session=${sessionCookie.value}`,
+        "X-Requested-With": "fetch",
       },
-      body: JSON.stringify({ query: "{ ownProfile { roles } }" }),
+      body: JSON.stringify({ query: ADMIN_GUARD }),
     });
 
     if (!res.ok) {
@@ -55,7 +69,11 @@ export async function middleware(request: NextRequest) {
     };
     const roles = json?.data?.ownProfile?.roles;
 
-    if (!Array.isArray(roles) || !roles.includes(ROLE_ADMINISTRATOR)) {
+    const allowed =
+      Array.isArray(roles) &&
+      (roles.includes(ROLE_ADMINISTRATOR) ||
+        (roles.includes(ROLE_COORDINATOR) && coordinatorMayOpen(request.nextUrl.pathname)));
+    if (!allowed) {
       return NextResponse.redirect(new URL("/events", request.url));
     }
   } catch {
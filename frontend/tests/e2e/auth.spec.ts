@@ -144,6 +144,7 @@ test.describe("Logout", () => {
       headers: {
         "Content-Type": "application/json",
         Cookie: `session=${volunteerToken}`,
+        "X-Requested-With": "fetch",
       },
       body: JSON.stringify({ query: "mutation { logout { success } }" }),
     });
@@ -253,7 +254,7 @@ test.describe("Magic-link login — error cases", () => {
     await page.getByRole("button", { name: "Submit Request" }).click();
 
     await expect(
-      page.getByRole("heading", { name: "Request Submitted" })
+      page.getByRole("heading", { name: "Check Your Email" })
     ).toBeVisible();
   });
 });